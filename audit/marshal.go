@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	auditv1 "github.com/annexsh/annex/gen/annex/audit/v1"
+	"github.com/annexsh/annex/internal/ptr"
+)
+
+func (e *Entry) Proto() *auditv1.AuditEntry {
+	entry := &auditv1.AuditEntry{
+		Id:         e.ID.String(),
+		Context:    e.Context,
+		Action:     string(e.Action),
+		Subject:    e.Subject,
+		AuthMethod: string(e.AuthMethod),
+		Summary:    e.Summary,
+		CreateTime: timestamppb.New(e.CreateTime),
+	}
+	if e.TestSuiteID != nil {
+		entry.TestSuiteId = ptr.Get(e.TestSuiteID.String())
+	}
+	if e.TestID != nil {
+		entry.TestId = ptr.Get(e.TestID.String())
+	}
+	if e.TestExecutionID != nil {
+		entry.TestExecutionId = ptr.Get(e.TestExecutionID.String())
+	}
+	return entry
+}
+
+func (e EntryList) Proto() []*auditv1.AuditEntry {
+	entries := make([]*auditv1.AuditEntry, len(e))
+	for i, entry := range e {
+		entries[i] = entry.Proto()
+	}
+	return entries
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/internal/metrics"
+	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/uuid"
+)
+
+var entryFailures = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "audit_entry_failures_total",
+	Help:      "Total number of audit entries that failed to be recorded by action.",
+}, []string{"action"})
+
+// Record records a completed action in the audit log on behalf of the caller.
+// The action has already taken effect, so the entry is recorded even if the
+// request is cancelled and failures are logged and counted by the
+// annex_audit_entry_failures_total metric rather than returned to the caller.
+func Record(ctx context.Context, repo Repository, logger log.Logger, entry *Entry) {
+	entry.ID = uuid.New()
+	entry.CreateTime = time.Now().UTC()
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		entry.Subject = principal.Subject
+		entry.AuthMethod = principal.Method
+	}
+
+	if err := repo.CreateEntry(context.WithoutCancel(ctx), entry); err != nil {
+		entryFailures.WithLabelValues(string(entry.Action)).Inc()
+		logger.Error("failed to record audit entry", "action", entry.Action, "context", entry.Context, "error", err)
+	}
+}
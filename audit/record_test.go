@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/log"
+)
+
+func TestRecord(t *testing.T) {
+	principal := &auth.Principal{Subject: "alice", Method: auth.MethodAPIKey}
+	ctx := auth.ContextWithPrincipal(context.Background(), principal)
+
+	repo := &entryRepository{}
+	Record(ctx, repo, log.NewNopLogger(), &Entry{Context: "foo", Action: ActionGrantRole})
+
+	require.Len(t, repo.entries, 1)
+	got := repo.entries[0]
+	assert.NotEmpty(t, got.ID)
+	assert.NotZero(t, got.CreateTime)
+	assert.Equal(t, "alice", got.Subject)
+	assert.Equal(t, auth.MethodAPIKey, got.AuthMethod)
+}
+
+func TestRecord_failure(t *testing.T) {
+	before := entryFailureCount(t, ActionRevokeApiKey)
+
+	repo := &entryRepository{err: errors.New("bang")}
+	Record(context.Background(), repo, log.NewNopLogger(), &Entry{Context: auth.AllContexts, Action: ActionRevokeApiKey})
+
+	assert.Equal(t, before+1, entryFailureCount(t, ActionRevokeApiKey))
+}
+
+func entryFailureCount(t *testing.T, action Action) float64 {
+	var m dto.Metric
+	require.NoError(t, entryFailures.WithLabelValues(string(action)).Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+type entryRepository struct {
+	Repository
+	entries EntryList
+	err     error
+}
+
+func (r *entryRepository) CreateEntry(_ context.Context, entry *Entry) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+)
+
+type Repository interface {
+	Reader
+	Writer
+}
+
+type Reader interface {
+	ListEntries(ctx context.Context, contextID string, filter Filter) (EntryList, error)
+}
+
+type Writer interface {
+	CreateEntry(ctx context.Context, entry *Entry) error
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+// Action is a user-initiated action that is recorded in the audit log. It is
+// named after the RPC that performs the action.
+type Action string
+
+const (
+	ActionRegisterContext    Action = "RegisterContext"
+	ActionRegisterTestSuite  Action = "RegisterTestSuite"
+	ActionRegisterTests      Action = "RegisterTests"
+	ActionExecuteTest        Action = "ExecuteTest"
+	ActionRetryTestExecution Action = "RetryTestExecution"
+	ActionGrantRole          Action = "GrantRole"
+	ActionRevokeRole         Action = "RevokeRole"
+	ActionCreateApiKey       Action = "CreateApiKey"
+	ActionRevokeApiKey       Action = "RevokeApiKey"
+)
+
+var Actions = []Action{
+	ActionRegisterContext,
+	ActionRegisterTestSuite,
+	ActionRegisterTests,
+	ActionExecuteTest,
+	ActionRetryTestExecution,
+	ActionGrantRole,
+	ActionRevokeRole,
+	ActionCreateApiKey,
+	ActionRevokeApiKey,
+}
+
+// Entry is an append-only record of an action taken by a caller.
+type Entry struct {
+	ID      uuid.V7
+	Context string
+	Action  Action
+	// Subject identifies the caller. It is empty if the caller was not
+	// authenticated.
+	Subject    string
+	AuthMethod auth.Method
+	// The IDs of the resources targeted by the action, if any.
+	TestSuiteID     *uuid.V7
+	TestID          *uuid.V7
+	TestExecutionID *test.TestExecutionID
+	// Summary describes the request in a human-readable form.
+	Summary    string
+	CreateTime time.Time
+}
+
+type EntryList []*Entry
+
+// Filter selects audit entries. Unset fields match all entries.
+type Filter struct {
+	test.PageFilter[uuid.V7]
+	Subject         *string
+	Action          *Action
+	TestID          *uuid.V7
+	TestExecutionID *test.TestExecutionID
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix prefixes all API key secrets to distinguish them from other
+// bearer tokens.
+const APIKeyPrefix = "annex_"
+
+const apiKeySecretBytes = 32
+
+// NewAPIKeySecret generates a random API key secret and its hash for storage.
+func NewAPIKeySecret() (string, []byte, error) {
+	b := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	secret := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return secret, HashAPIKey(secret), nil
+}
+
+// HashAPIKey returns the hash of an API key secret. API key secrets have
+// enough entropy that a fast hash is sufficient.
+func HashAPIKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+var _ Authenticator = (*APIKeyAuthenticator)(nil)
+
+// APIKeyAuthenticator authenticates API key secrets against the hashed keys
+// in the repository.
+type APIKeyAuthenticator struct {
+	repo APIKeyReader
+}
+
+func NewAPIKeyAuthenticator(repo APIKeyReader) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{repo: repo}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if !strings.HasPrefix(token, APIKeyPrefix) {
+		return nil, ErrorUnauthenticated
+	}
+
+	key, err := a.repo.GetAPIKeyByHash(ctx, HashAPIKey(token))
+	if err != nil {
+		if errors.Is(err, ErrorAPIKeyNotFound) {
+			return nil, ErrorUnauthenticated
+		}
+		return nil, err
+	}
+	if key.Revoked() {
+		return nil, ErrorUnauthenticated
+	}
+
+	return &Principal{
+		Subject: key.ID.String(),
+		Method:  MethodAPIKey,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestNewAPIKeySecret(t *testing.T) {
+	secret, hash, err := NewAPIKeySecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, APIKeyPrefix))
+	assert.Equal(t, HashAPIKey(secret), hash)
+
+	other, _, err := NewAPIKeySecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	secret, hash, err := NewAPIKeySecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	key := &APIKey{ID: uuid.New(), Name: "ci", CreateTime: now}
+	revokedKey := &APIKey{ID: uuid.New(), Name: "old", CreateTime: now, RevokeTime: &now}
+
+	tests := []struct {
+		name      string
+		token     string
+		repoKey   *APIKey
+		repoErr   error
+		wantErr   error
+		wantCalls int
+		wantPrinc *Principal
+	}{
+		{
+			name:      "valid key",
+			token:     secret,
+			repoKey:   key,
+			wantCalls: 1,
+			wantPrinc: &Principal{Subject: key.ID.String(), Method: MethodAPIKey},
+		},
+		{
+			name:    "not an api key",
+			token:   "eyJhbGciOi",
+			wantErr: ErrorUnauthenticated,
+		},
+		{
+			name:      "unknown key",
+			token:     secret,
+			repoErr:   ErrorAPIKeyNotFound,
+			wantCalls: 1,
+			wantErr:   ErrorUnauthenticated,
+		},
+		{
+			name:      "revoked key",
+			token:     secret,
+			repoKey:   revokedKey,
+			wantCalls: 1,
+			wantErr:   ErrorUnauthenticated,
+		},
+		{
+			name:      "repository error",
+			token:     secret,
+			repoErr:   errors.New("bang"),
+			wantCalls: 1,
+			wantErr:   errors.New("bang"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &APIKeyReaderMock{
+				GetAPIKeyByHashFunc: func(ctx context.Context, gotHash []byte) (*APIKey, error) {
+					assert.Equal(t, hash, gotHash)
+					return tt.repoKey, tt.repoErr
+				},
+			}
+
+			got, err := NewAPIKeyAuthenticator(repo).Authenticate(context.Background(), tt.token)
+			assert.Len(t, repo.GetAPIKeyByHashCalls(), tt.wantCalls)
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPrinc, got)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	bootstrap := NewStaticAuthenticator("annex_bootstrap", "bootstrap")
+	repo := &APIKeyReaderMock{
+		GetAPIKeyByHashFunc: func(ctx context.Context, hash []byte) (*APIKey, error) {
+			return nil, ErrorAPIKeyNotFound
+		},
+	}
+
+	a := Chain(NewAPIKeyAuthenticator(repo), bootstrap)
+
+	got, err := a.Authenticate(ctx, "annex_bootstrap")
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "bootstrap", Method: MethodAPIKey, Superuser: true}, got)
+
+	_, err = a.Authenticate(ctx, "annex_unknown")
+	assert.ErrorIs(t, err, ErrorUnauthenticated)
+
+	// Errors other than unauthenticated stop the chain
+	repo.GetAPIKeyByHashFunc = func(ctx context.Context, hash []byte) (*APIKey, error) {
+		return nil, errors.New("bang")
+	}
+	_, err = a.Authenticate(ctx, "annex_bootstrap")
+	assert.EqualError(t, err, "bang")
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// Authenticator authenticates a bearer token. ErrorUnauthenticated is
+// returned when the token is not valid.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// Chain returns an Authenticator that tries each authenticator in order and
+// returns the first authenticated principal.
+func Chain(authenticators ...Authenticator) Authenticator {
+	return chain(authenticators)
+}
+
+type chain []Authenticator
+
+func (c chain) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(ctx, token)
+		if err == nil {
+			return p, nil
+		}
+		if !errors.Is(err, ErrorUnauthenticated) {
+			return nil, err
+		}
+	}
+	return nil, ErrorUnauthenticated
+}
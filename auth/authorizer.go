@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// Authorizer authorizes the principal of a context. ErrorPermissionDenied is
+// returned when the principal does not have the required role.
+type Authorizer interface {
+	Authorize(ctx context.Context, contextID string, required Role) error
+}
+
+var _ Authorizer = (*RoleAuthorizer)(nil)
+
+// RoleAuthorizer authorizes principals using their role bindings.
+type RoleAuthorizer struct {
+	repo RoleBindingReader
+}
+
+func NewRoleAuthorizer(repo RoleBindingReader) *RoleAuthorizer {
+	return &RoleAuthorizer{repo: repo}
+}
+
+// Authorize returns ErrorPermissionDenied unless the principal of the
+// context is bound to a role in the context that includes the required role.
+// Everything is authorized when authentication is disabled.
+func (a *RoleAuthorizer) Authorize(ctx context.Context, contextID string, required Role) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.Superuser {
+		return nil
+	}
+
+	roles, err := a.repo.ListSubjectRoles(ctx, p.Subject, contextID)
+	if err != nil {
+		return fmt.Errorf("failed to list subject roles: %w", err)
+	}
+
+	for _, role := range roles {
+		if role.Includes(required) {
+			return nil
+		}
+	}
+
+	return ErrorPermissionDenied
+}
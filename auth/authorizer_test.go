@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole_Includes(t *testing.T) {
+	assert.True(t, RoleAdmin.Includes(RoleRunner))
+	assert.True(t, RoleAdmin.Includes(RoleExecutor))
+	assert.True(t, RoleExecutor.Includes(RoleViewer))
+	assert.True(t, RoleRunner.Includes(RoleViewer))
+	assert.True(t, RoleRunner.Includes(RoleRunner))
+	assert.False(t, RoleRunner.Includes(RoleExecutor))
+	assert.False(t, RoleExecutor.Includes(RoleRunner))
+	assert.False(t, RoleViewer.Includes(RoleExecutor))
+	assert.False(t, RoleExecutor.Includes(RoleAdmin))
+	assert.False(t, Role("unknown").Includes(RoleViewer))
+}
+
+func TestRoleAuthorizer_Authorize(t *testing.T) {
+	contextID := "foo"
+	principal := &Principal{Subject: "alice", Method: MethodJWT}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		roles     []Role
+		required  Role
+		wantErr   error
+	}{
+		{
+			name:      "role granted",
+			principal: principal,
+			roles:     []Role{RoleViewer, RoleExecutor},
+			required:  RoleExecutor,
+		},
+		{
+			name:      "role included",
+			principal: principal,
+			roles:     []Role{RoleAdmin},
+			required:  RoleRunner,
+		},
+		{
+			name:      "role missing",
+			principal: principal,
+			roles:     []Role{RoleExecutor},
+			required:  RoleRunner,
+			wantErr:   ErrorPermissionDenied,
+		},
+		{
+			name:      "no roles",
+			principal: principal,
+			required:  RoleViewer,
+			wantErr:   ErrorPermissionDenied,
+		},
+		{
+			name:      "superuser",
+			principal: &Principal{Subject: "bootstrap", Method: MethodAPIKey, Superuser: true},
+			required:  RoleAdmin,
+		},
+		{
+			name:     "authentication disabled",
+			required: RoleAdmin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &RoleBindingReaderMock{
+				ListSubjectRolesFunc: func(ctx context.Context, subject string, gotContextID string) ([]Role, error) {
+					assert.Equal(t, tt.principal.Subject, subject)
+					assert.Equal(t, contextID, gotContextID)
+					return tt.roles, nil
+				},
+			}
+
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = ContextWithPrincipal(ctx, tt.principal)
+			}
+
+			err := NewRoleAuthorizer(repo).Authorize(ctx, contextID, tt.required)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package auth
+
+import "context"
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of the context carrying the
+// authenticated principal.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the authenticated principal of the context.
+// It returns false when authentication is disabled or the context does not
+// belong to an authenticated request.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,14 @@
+package auth
+
+const (
+	ErrorUnauthenticated     = authErr("unauthenticated")
+	ErrorPermissionDenied    = authErr("permission denied")
+	ErrorAPIKeyNotFound      = authErr("api key not found")
+	ErrorRoleBindingNotFound = authErr("role binding not found")
+)
+
+type authErr string
+
+func (e authErr) Error() string {
+	return string(e)
+}
@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/annexsh/annex/log"
+)
+
+const (
+	defaultJWKSRefreshInterval = 15 * time.Minute
+	// minJWKSRefreshInterval limits how often the key set is refreshed when a
+	// token is signed by an unknown key.
+	minJWKSRefreshInterval = time.Minute
+	maxJWKSBytes           = 1 << 20
+)
+
+var jwtSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type JWTOption func(a *JWTAuthenticator)
+
+// WithIssuer requires tokens to be issued by the issuer.
+func WithIssuer(issuer string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.issuer = issuer
+	}
+}
+
+// WithAudience requires tokens to be issued for the audience.
+func WithAudience(audience string) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.audience = audience
+	}
+}
+
+// WithJWKSRefreshInterval sets how often the key set is reloaded to pick up
+// rotated keys. Defaults to 15 minutes.
+func WithJWKSRefreshInterval(interval time.Duration) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.refreshInterval = interval
+	}
+}
+
+// WithHTTPClient sets the client used to fetch a key set from a URL.
+func WithHTTPClient(client *http.Client) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.httpClient = client
+	}
+}
+
+func WithLogger(logger log.Logger) JWTOption {
+	return func(a *JWTAuthenticator) {
+		a.logger = logger
+	}
+}
+
+var _ Authenticator = (*JWTAuthenticator)(nil)
+
+// JWTAuthenticator authenticates JWT bearer tokens, such as OIDC ID and access
+// tokens, by verifying their signature against a JSON Web Key Set (JWKS).
+type JWTAuthenticator struct {
+	jwksSource      string
+	issuer          string
+	audience        string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+	logger          log.Logger
+	parser          *jwt.Parser
+	keys            atomic.Pointer[jose.JSONWebKeySet]
+	refreshMu       sync.Mutex
+	lastRefresh     time.Time
+}
+
+// NewJWTAuthenticator returns a JWTAuthenticator that verifies tokens against
+// the key set at the JWKS source, which is either an HTTP(S) URL or a file
+// path. The key set is loaded on creation and reloaded in the background
+// until the context is cancelled.
+func NewJWTAuthenticator(ctx context.Context, jwksSource string, opts ...JWTOption) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		jwksSource:      jwksSource,
+		refreshInterval: defaultJWKSRefreshInterval,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+		logger:          log.NewNopLogger(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtSigningMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	}
+	if a.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(a.issuer))
+	}
+	if a.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(a.audience))
+	}
+	a.parser = jwt.NewParser(parserOpts...)
+
+	if err := a.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if a.refreshInterval > 0 {
+		go a.refreshPeriodically(ctx)
+	}
+
+	return a, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if strings.HasPrefix(token, APIKeyPrefix) {
+		return nil, ErrorUnauthenticated
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, err := a.parser.ParseWithClaims(token, &claims, a.keyFunc(ctx)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrorUnauthenticated, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrorUnauthenticated)
+	}
+
+	return &Principal{
+		Subject: claims.Subject,
+		Method:  MethodJWT,
+	}, nil
+}
+
+func (a *JWTAuthenticator) keyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		key, ok := findVerificationKey(a.keys.Load(), kid)
+		if !ok && kid != "" {
+			// The key may have been rotated since the key set was loaded
+			if err := a.refreshIfStale(ctx); err != nil {
+				a.logger.Error("failed to refresh jwks", "error", err)
+			}
+			key, ok = findVerificationKey(a.keys.Load(), kid)
+		}
+		if !ok {
+			return nil, fmt.Errorf("no verification key found for key id '%s'", kid)
+		}
+
+		return key.Key, nil
+	}
+}
+
+func findVerificationKey(keySet *jose.JSONWebKeySet, kid string) (jose.JSONWebKey, bool) {
+	candidates := keySet.Keys
+	if kid != "" {
+		candidates = keySet.Key(kid)
+	} else if len(candidates) != 1 {
+		return jose.JSONWebKey{}, false // key id required to choose between keys
+	}
+
+	for _, key := range candidates {
+		if key.Use == "enc" {
+			continue
+		}
+		if !key.IsPublic() {
+			key = key.Public()
+		}
+		if key.Valid() {
+			return key, true
+		}
+	}
+
+	return jose.JSONWebKey{}, false
+}
+
+func (a *JWTAuthenticator) refreshPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(a.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.refresh(ctx); err != nil {
+				a.logger.Error("failed to refresh jwks", "error", err)
+			}
+		}
+	}
+}
+
+func (a *JWTAuthenticator) refreshIfStale(ctx context.Context) error {
+	a.refreshMu.Lock()
+	stale := time.Since(a.lastRefresh) >= minJWKSRefreshInterval
+	a.refreshMu.Unlock()
+	if !stale {
+		return nil
+	}
+	return a.refresh(ctx)
+}
+
+func (a *JWTAuthenticator) refresh(ctx context.Context) error {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	data, err := a.readJWKS(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read jwks: %w", err)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err = json.Unmarshal(data, &keySet); err != nil {
+		return fmt.Errorf("failed to parse jwks: %w", err)
+	}
+	if len(keySet.Keys) == 0 {
+		return errors.New("jwks contains no keys")
+	}
+
+	a.keys.Store(&keySet)
+	a.lastRefresh = time.Now()
+	return nil
+}
+
+func (a *JWTAuthenticator) readJWKS(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(a.jwksSource, "http://") && !strings.HasPrefix(a.jwksSource, "https://") {
+		return os.ReadFile(a.jwksSource)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.jwksSource, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, maxJWKSBytes))
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "annex"
+)
+
+type testSigner struct {
+	key *rsa.PrivateKey
+	kid string
+}
+
+func newTestSigner(t *testing.T, kid string) *testSigner {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return &testSigner{key: key, kid: kid}
+}
+
+func (s *testSigner) jwks(t *testing.T) []byte {
+	b, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       s.key.Public(),
+		KeyID:     s.kid,
+		Algorithm: "RS256",
+		Use:       "sig",
+	}}})
+	require.NoError(t, err)
+	return b
+}
+
+func (s *testSigner) sign(t *testing.T, claims jwt.RegisteredClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.kid
+	signed, err := token.SignedString(s.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "user@example.com",
+		Issuer:    testIssuer,
+		Audience:  jwt.ClaimStrings{testAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signer := newTestSigner(t, "key-1")
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, signer.jwks(t), 0o600))
+
+	a, err := NewJWTAuthenticator(ctx, jwksPath, WithIssuer(testIssuer), WithAudience(testAudience))
+	require.NoError(t, err)
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = nil
+
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "https://other.example.com"
+
+	wrongAudience := validClaims()
+	wrongAudience.Audience = jwt.ClaimStrings{"other"}
+
+	noSubject := validClaims()
+	noSubject.Subject = ""
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "valid", token: signer.sign(t, validClaims())},
+		{name: "expired", token: signer.sign(t, expired), wantErr: true},
+		{name: "no expiry", token: signer.sign(t, noExpiry), wantErr: true},
+		{name: "wrong issuer", token: signer.sign(t, wrongIssuer), wantErr: true},
+		{name: "wrong audience", token: signer.sign(t, wrongAudience), wantErr: true},
+		{name: "no subject", token: signer.sign(t, noSubject), wantErr: true},
+		{name: "unknown key", token: newTestSigner(t, "key-2").sign(t, validClaims()), wantErr: true},
+		{name: "malformed", token: "not.a.jwt", wantErr: true},
+		{name: "api key", token: APIKeyPrefix + "secret", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.Authenticate(ctx, tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrorUnauthenticated)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &Principal{Subject: "user@example.com", Method: MethodJWT}, got)
+		})
+	}
+}
+
+func TestJWTAuthenticator_jwksURL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signer := newTestSigner(t, "key-1")
+	jwks := signer.jwks(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(jwks)
+	}))
+	defer srv.Close()
+
+	a, err := NewJWTAuthenticator(ctx, srv.URL)
+	require.NoError(t, err)
+
+	_, err = a.Authenticate(ctx, signer.sign(t, validClaims()))
+	require.NoError(t, err)
+
+	// Rotated keys are fetched when a token is signed by an unknown key
+	rotated := newTestSigner(t, "key-2")
+	jwks = rotated.jwks(t)
+	a.lastRefresh = time.Time{}
+
+	_, err = a.Authenticate(ctx, rotated.sign(t, validClaims()))
+	require.NoError(t, err)
+}
+
+func TestNewJWTAuthenticator_invalidJWKS(t *testing.T) {
+	_, err := NewJWTAuthenticator(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	emptyPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(emptyPath, []byte(`{"keys":[]}`), 0o600))
+	_, err = NewJWTAuthenticator(context.Background(), emptyPath)
+	assert.Error(t, err)
+}
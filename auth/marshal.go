@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+)
+
+func (k *APIKey) Proto() *authv1.ApiKey {
+	key := &authv1.ApiKey{
+		Id:         k.ID.String(),
+		Name:       k.Name,
+		CreateTime: timestamppb.New(k.CreateTime),
+	}
+	if k.RevokeTime != nil {
+		key.RevokeTime = timestamppb.New(*k.RevokeTime)
+	}
+	return key
+}
+
+func (k APIKeyList) Proto() []*authv1.ApiKey {
+	keys := make([]*authv1.ApiKey, len(k))
+	for i, key := range k {
+		keys[i] = key.Proto()
+	}
+	return keys
+}
+
+func (b *RoleBinding) Proto() *authv1.RoleBinding {
+	return &authv1.RoleBinding{
+		Id:         b.ID.String(),
+		Context:    b.Context,
+		Subject:    b.Subject,
+		Role:       string(b.Role),
+		CreateTime: timestamppb.New(b.CreateTime),
+	}
+}
+
+func (b RoleBindingList) Proto() []*authv1.RoleBinding {
+	bindings := make([]*authv1.RoleBinding, len(b))
+	for i, binding := range b {
+		bindings[i] = binding.Proto()
+	}
+	return bindings
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+)
+
+var _ Authenticator = (*RemoteAuthenticator)(nil)
+
+const (
+	defaultRemoteCacheTTL = 10 * time.Second
+	maxRemoteCacheEntries = 10000
+)
+
+type RemoteOption func(r *RemoteAuthenticator)
+
+// WithCacheTTL sets how long authenticated tokens are cached before the
+// remote auth service is called again. A revoked API key is accepted until its
+// cached principal expires. Zero disables caching. Defaults to 10 seconds.
+func WithCacheTTL(ttl time.Duration) RemoteOption {
+	return func(r *RemoteAuthenticator) {
+		r.cacheTTL = ttl
+	}
+}
+
+// RemoteAuthenticator authenticates bearer tokens by calling the auth service
+// of another Annex server. It allows servers without repository access to
+// accept API keys. Authenticated tokens are cached by their hash for a short
+// time so that the remote auth service isn't called for every request.
+type RemoteAuthenticator struct {
+	client   authv1connect.AuthServiceClient
+	cacheTTL time.Duration
+	cache    map[[sha256.Size]byte]cachedPrincipal
+	cacheMu  sync.Mutex
+	now      func() time.Time
+}
+
+type cachedPrincipal struct {
+	principal  *Principal
+	expireTime time.Time
+}
+
+func NewRemoteAuthenticator(client authv1connect.AuthServiceClient, opts ...RemoteOption) *RemoteAuthenticator {
+	r := &RemoteAuthenticator{
+		client:   client,
+		cacheTTL: defaultRemoteCacheTTL,
+		cache:    map[[sha256.Size]byte]cachedPrincipal{},
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *RemoteAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	key := sha256.Sum256([]byte(token))
+	if principal, ok := r.getCached(key); ok {
+		return principal, nil
+	}
+
+	principal, err := r.whoAmI(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	r.setCached(key, principal)
+	return principal, nil
+}
+
+func (r *RemoteAuthenticator) whoAmI(ctx context.Context, token string) (*Principal, error) {
+	req := connect.NewRequest(&authv1.WhoAmIRequest{})
+	req.Header().Set("Authorization", "Bearer "+token)
+
+	res, err := r.client.WhoAmI(ctx, req)
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeUnauthenticated {
+			return nil, ErrorUnauthenticated
+		}
+		return nil, err
+	}
+	if res.Msg.Subject == "" {
+		return nil, errors.New("remote server has authentication disabled")
+	}
+
+	return &Principal{
+		Subject: res.Msg.Subject,
+		Method:  Method(res.Msg.Method),
+	}, nil
+}
+
+func (r *RemoteAuthenticator) getCached(key [sha256.Size]byte) (*Principal, bool) {
+	if r.cacheTTL <= 0 {
+		return nil, false
+	}
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	cached, ok := r.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !r.now().Before(cached.expireTime) {
+		delete(r.cache, key)
+		return nil, false
+	}
+	return cached.principal, true
+}
+
+func (r *RemoteAuthenticator) setCached(key [sha256.Size]byte, principal *Principal) {
+	if r.cacheTTL <= 0 {
+		return
+	}
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	now := r.now()
+	if len(r.cache) >= maxRemoteCacheEntries {
+		// Evict expired principals to bound the cache size, and start over if
+		// all of them are still live
+		for k, cached := range r.cache {
+			if !now.Before(cached.expireTime) {
+				delete(r.cache, k)
+			}
+		}
+		if len(r.cache) >= maxRemoteCacheEntries {
+			clear(r.cache)
+		}
+	}
+
+	r.cache[key] = cachedPrincipal{
+		principal:  principal,
+		expireTime: now.Add(r.cacheTTL),
+	}
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+)
+
+func TestRemoteAuthenticator_cache(t *testing.T) {
+	ctx := context.Background()
+
+	client := &whoAmIClient{}
+	r := NewRemoteAuthenticator(client, WithCacheTTL(time.Minute))
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	want := &Principal{Subject: "foo", Method: MethodAPIKey}
+
+	got, err := r.Authenticate(ctx, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// Cached
+	got, err = r.Authenticate(ctx, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, 1, client.calls)
+
+	// Cached per token
+	_, err = r.Authenticate(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.calls)
+
+	// Expired
+	now = now.Add(time.Minute)
+	_, err = r.Authenticate(ctx, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.calls)
+
+	// Unauthenticated tokens aren't cached
+	client.unauthenticated = true
+	for range 2 {
+		_, err = r.Authenticate(ctx, "revoked")
+		assert.ErrorIs(t, err, ErrorUnauthenticated)
+	}
+	assert.Equal(t, 5, client.calls)
+}
+
+type whoAmIClient struct {
+	authv1connect.AuthServiceClient
+	calls           int
+	unauthenticated bool
+}
+
+func (c *whoAmIClient) WhoAmI(ctx context.Context, req *connect.Request[authv1.WhoAmIRequest]) (*connect.Response[authv1.WhoAmIResponse], error) {
+	c.calls++
+	if c.unauthenticated {
+		return nil, connect.NewError(connect.CodeUnauthenticated, nil)
+	}
+	return connect.NewResponse(&authv1.WhoAmIResponse{
+		Subject: "foo",
+		Method:  string(MethodAPIKey),
+	}), nil
+}
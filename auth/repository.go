@@ -0,0 +1,51 @@
+package auth
+
+//go:generate go run github.com/matryer/moq@latest -out api_key_reader_mock_test.go . APIKeyReader
+//go:generate go run github.com/matryer/moq@latest -out role_binding_reader_mock_test.go . RoleBindingReader
+
+import (
+	"context"
+	"time"
+
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+type Repository interface {
+	APIKeyReadWriter
+	RoleBindingReadWriter
+}
+
+type APIKeyReadWriter interface {
+	APIKeyReader
+	APIKeyWriter
+}
+
+type APIKeyReader interface {
+	GetAPIKeyByHash(ctx context.Context, hash []byte) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, filter test.PageFilter[uuid.V7]) (APIKeyList, error)
+}
+
+type APIKeyWriter interface {
+	CreateAPIKey(ctx context.Context, key *APIKey, hash []byte) error
+	RevokeAPIKey(ctx context.Context, id uuid.V7, revokeTime time.Time) error
+}
+
+type RoleBindingReadWriter interface {
+	RoleBindingReader
+	RoleBindingWriter
+}
+
+type RoleBindingReader interface {
+	ListRoleBindings(ctx context.Context, contextID string, filter test.PageFilter[uuid.V7]) (RoleBindingList, error)
+	// ListSubjectRoles lists the roles of the subject in the context,
+	// including roles bound to AllContexts.
+	ListSubjectRoles(ctx context.Context, subject string, contextID string) ([]Role, error)
+}
+
+type RoleBindingWriter interface {
+	// CreateRoleBinding creates the role binding if it doesn't exist and
+	// returns the stored role binding.
+	CreateRoleBinding(ctx context.Context, binding *RoleBinding) (*RoleBinding, error)
+	DeleteRoleBinding(ctx context.Context, contextID string, subject string, role Role) error
+}
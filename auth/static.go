@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+)
+
+var _ Authenticator = (*StaticAuthenticator)(nil)
+
+// StaticAuthenticator authenticates a single API key secret that is
+// configured rather than stored in the repository. It is used to bootstrap
+// the creation of API keys and role bindings, so its principal is a
+// superuser.
+type StaticAuthenticator struct {
+	hash    []byte
+	subject string
+}
+
+func NewStaticAuthenticator(secret string, subject string) *StaticAuthenticator {
+	return &StaticAuthenticator{
+		hash:    HashAPIKey(secret),
+		subject: subject,
+	}
+}
+
+func (s *StaticAuthenticator) Authenticate(_ context.Context, token string) (*Principal, error) {
+	if subtle.ConstantTimeCompare(HashAPIKey(token), s.hash) != 1 {
+		return nil, ErrorUnauthenticated
+	}
+	return &Principal{
+		Subject:   s.subject,
+		Method:    MethodAPIKey,
+		Superuser: true,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/annexsh/annex/uuid"
+)
+
+// Method is the method used to authenticate a principal.
+type Method string
+
+const (
+	MethodAPIKey Method = "apiKey"
+	MethodJWT    Method = "jwt"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Subject identifies the caller. It is the API key ID for API keys and
+	// the subject claim for JWTs.
+	Subject string
+	Method  Method
+	// Superuser principals are authorized for everything regardless of
+	// their role bindings.
+	Superuser bool
+}
+
+// APIKey is a static credential. Only a hash of the key secret is stored.
+type APIKey struct {
+	ID         uuid.V7
+	Name       string
+	CreateTime time.Time
+	RevokeTime *time.Time
+}
+
+type APIKeyList []*APIKey
+
+// Revoked reports whether the API key has been revoked.
+func (k *APIKey) Revoked() bool {
+	return k.RevokeTime != nil
+}
+
+// Role is a set of permissions granted to a subject in a context.
+type Role string
+
+const (
+	// RoleViewer can read tests, test executions and their logs and events.
+	RoleViewer Role = "viewer"
+	// RoleExecutor can execute and retry tests in addition to the viewer
+	// permissions.
+	RoleExecutor Role = "executor"
+	// RoleRunner can register contexts, test suites and tests and report test
+	// execution progress in addition to the viewer permissions.
+	RoleRunner Role = "runner"
+	// RoleAdmin has all permissions and can grant roles.
+	RoleAdmin Role = "admin"
+)
+
+var Roles = []Role{RoleViewer, RoleExecutor, RoleRunner, RoleAdmin}
+
+// Includes reports whether the role grants the permissions of the other
+// role.
+func (r Role) Includes(other Role) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleExecutor, RoleRunner:
+		return other == r || other == RoleViewer
+	case RoleViewer:
+		return other == RoleViewer
+	default:
+		return false
+	}
+}
+
+// AllContexts is the context of role bindings that apply to every context.
+const AllContexts = "*"
+
+// RoleBinding grants a role to a subject in a context.
+type RoleBinding struct {
+	ID         uuid.V7
+	Context    string
+	Subject    string
+	Role       Role
+	CreateTime time.Time
+}
+
+type RoleBindingList []*RoleBinding
@@ -0,0 +1,136 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/internal/pagination"
+	"github.com/annexsh/annex/uuid"
+)
+
+func (s *Service) CreateApiKey(
+	ctx context.Context,
+	req *connect.Request[authv1.CreateApiKeyRequest],
+) (*connect.Response[authv1.CreateApiKeyResponse], error) {
+	if err := validateCreateApiKeyRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, auth.AllContexts, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	secret, hash, err := auth.NewAPIKeySecret()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &auth.APIKey{
+		ID:         uuid.New(),
+		Name:       req.Msg.Name,
+		CreateTime: time.Now().UTC(),
+	}
+	if err = s.repo.CreateAPIKey(ctx, key, hash); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("created api key", "api_key.id", key.ID, "api_key.name", key.Name)
+	s.recordAudit(ctx, &audit.Entry{
+		Context: auth.AllContexts,
+		Action:  audit.ActionCreateApiKey,
+		Summary: fmt.Sprintf("created api key '%s' (%s)", key.Name, key.ID),
+	})
+
+	return connect.NewResponse(&authv1.CreateApiKeyResponse{
+		ApiKey: key.Proto(),
+		Secret: secret,
+	}), nil
+}
+
+func (s *Service) ListApiKeys(
+	ctx context.Context,
+	req *connect.Request[authv1.ListApiKeysRequest],
+) (*connect.Response[authv1.ListApiKeysResponse], error) {
+	if err := validateListApiKeysRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, auth.AllContexts, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.ListAPIKeys(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(filter.Size, keys, func(key *auth.APIKey) uuid.V7 {
+		return key.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&authv1.ListApiKeysResponse{
+		ApiKeys:       keys.Proto(),
+		NextPageToken: nextPageTkn,
+	}), nil
+}
+
+func (s *Service) RevokeApiKey(
+	ctx context.Context,
+	req *connect.Request[authv1.RevokeApiKeyRequest],
+) (*connect.Response[authv1.RevokeApiKeyResponse], error) {
+	if err := validateRevokeApiKeyRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, auth.AllContexts, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.RevokeAPIKey(ctx, id, time.Now().UTC()); err != nil {
+		if errors.Is(err, auth.ErrorAPIKeyNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("revoked api key", "api_key.id", id)
+	s.recordAudit(ctx, &audit.Entry{
+		Context: auth.AllContexts,
+		Action:  audit.ActionRevokeApiKey,
+		Summary: fmt.Sprintf("revoked api key %s", id),
+	})
+
+	return connect.NewResponse(&authv1.RevokeApiKeyResponse{}), nil
+}
+
+func (s *Service) WhoAmI(
+	ctx context.Context,
+	_ *connect.Request[authv1.WhoAmIRequest],
+) (*connect.Response[authv1.WhoAmIResponse], error) {
+	res := &authv1.WhoAmIResponse{}
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		res.Subject = p.Subject
+		res.Method = string(p.Method)
+	}
+	return connect.NewResponse(res), nil
+}
@@ -0,0 +1,136 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_CreateApiKey(t *testing.T) {
+	var gotKey *auth.APIKey
+	var gotHash []byte
+
+	r := &RepositoryMock{
+		CreateAPIKeyFunc: func(ctx context.Context, key *auth.APIKey, hash []byte) error {
+			gotKey = key
+			gotHash = hash
+			return nil
+		},
+	}
+	a := &AuditRepositoryMock{
+		CreateEntryFunc: func(ctx context.Context, entry *audit.Entry) error {
+			return nil
+		},
+	}
+
+	s := New(r, WithAuditRepository(a))
+
+	req := &authv1.CreateApiKeyRequest{Name: "ci"}
+	res, err := s.CreateApiKey(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+
+	require.NotNil(t, gotKey)
+	assert.Equal(t, req.Name, gotKey.Name)
+	assert.Equal(t, gotKey.Proto(), res.Msg.ApiKey)
+	assert.True(t, strings.HasPrefix(res.Msg.Secret, auth.APIKeyPrefix))
+	assert.Equal(t, auth.HashAPIKey(res.Msg.Secret), gotHash, "only the secret hash is stored")
+
+	require.Len(t, a.CreateEntryCalls(), 1)
+	entry := a.CreateEntryCalls()[0].Entry
+	assert.Equal(t, auth.AllContexts, entry.Context)
+	assert.Equal(t, audit.ActionCreateApiKey, entry.Action)
+	assert.NotContains(t, entry.Summary, res.Msg.Secret)
+}
+
+func TestService_CreateApiKey_invalid(t *testing.T) {
+	s := New(&RepositoryMock{})
+
+	_, err := s.CreateApiKey(context.Background(), connect.NewRequest(&authv1.CreateApiKeyRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestService_ListApiKeys(t *testing.T) {
+	now := time.Now().UTC()
+	want := auth.APIKeyList{
+		{ID: uuid.New(), Name: "a", CreateTime: now},
+		{ID: uuid.New(), Name: "b", CreateTime: now, RevokeTime: &now},
+	}
+
+	r := &RepositoryMock{
+		ListAPIKeysFunc: func(ctx context.Context, filter test.PageFilter[uuid.V7]) (auth.APIKeyList, error) {
+			assert.Equal(t, 2, filter.Size)
+			assert.Nil(t, filter.OffsetID)
+			return want, nil
+		},
+	}
+
+	s := New(r)
+
+	req := &authv1.ListApiKeysRequest{PageSize: 2}
+	res, err := s.ListApiKeys(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, want.Proto(), res.Msg.ApiKeys)
+	assert.NotEmpty(t, res.Msg.NextPageToken)
+}
+
+func TestService_RevokeApiKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoErr  error
+		wantCode connect.Code
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:     "not found",
+			repoErr:  auth.ErrorAPIKeyNotFound,
+			wantCode: connect.CodeNotFound,
+		},
+		{
+			name:     "error",
+			repoErr:  errors.New("bang"),
+			wantCode: connect.CodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := uuid.New()
+
+			r := &RepositoryMock{
+				RevokeAPIKeyFunc: func(ctx context.Context, gotID uuid.V7, revokeTime time.Time) error {
+					assert.Equal(t, id, gotID)
+					assert.False(t, revokeTime.IsZero())
+					return tt.repoErr
+				},
+			}
+
+			s := New(r)
+
+			req := &authv1.RevokeApiKeyRequest{Id: id.String()}
+			res, err := s.RevokeApiKey(context.Background(), connect.NewRequest(req))
+			if tt.repoErr != nil {
+				require.ErrorIs(t, err, tt.repoErr)
+				assert.Equal(t, tt.wantCode, connect.CodeOf(err))
+				assert.Nil(t, res)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, res)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/auth"
+)
+
+// authorize returns a permission denied error unless the caller has a role in
+// the context that includes the required role.
+func (s *Service) authorize(ctx context.Context, contextID string, required auth.Role) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	if err := s.authorizer.Authorize(ctx, contextID, required); err != nil {
+		if errors.Is(err, auth.ErrorPermissionDenied) {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s role required in context '%s'", required, contextID))
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/internal/pagination"
+	"github.com/annexsh/annex/uuid"
+)
+
+func (s *Service) GrantRole(
+	ctx context.Context,
+	req *connect.Request[authv1.GrantRoleRequest],
+) (*connect.Response[authv1.GrantRoleResponse], error) {
+	if err := validateGrantRoleRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	binding, err := s.repo.CreateRoleBinding(ctx, &auth.RoleBinding{
+		ID:         uuid.New(),
+		Context:    req.Msg.Context,
+		Subject:    req.Msg.Subject,
+		Role:       auth.Role(req.Msg.Role),
+		CreateTime: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("granted role", "context", binding.Context, "subject", binding.Subject, "role", binding.Role)
+	s.recordAudit(ctx, &audit.Entry{
+		Context: binding.Context,
+		Action:  audit.ActionGrantRole,
+		Summary: fmt.Sprintf("granted role '%s' to subject '%s'", binding.Role, binding.Subject),
+	})
+
+	return connect.NewResponse(&authv1.GrantRoleResponse{
+		RoleBinding: binding.Proto(),
+	}), nil
+}
+
+func (s *Service) RevokeRole(
+	ctx context.Context,
+	req *connect.Request[authv1.RevokeRoleRequest],
+) (*connect.Response[authv1.RevokeRoleResponse], error) {
+	if err := validateRevokeRoleRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.DeleteRoleBinding(ctx, req.Msg.Context, req.Msg.Subject, auth.Role(req.Msg.Role))
+	if err != nil {
+		if errors.Is(err, auth.ErrorRoleBindingNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("revoked role", "context", req.Msg.Context, "subject", req.Msg.Subject, "role", req.Msg.Role)
+	s.recordAudit(ctx, &audit.Entry{
+		Context: req.Msg.Context,
+		Action:  audit.ActionRevokeRole,
+		Summary: fmt.Sprintf("revoked role '%s' from subject '%s'", req.Msg.Role, req.Msg.Subject),
+	})
+
+	return connect.NewResponse(&authv1.RevokeRoleResponse{}), nil
+}
+
+func (s *Service) ListRoleBindings(
+	ctx context.Context,
+	req *connect.Request[authv1.ListRoleBindingsRequest],
+) (*connect.Response[authv1.ListRoleBindingsResponse], error) {
+	if err := validateListRoleBindingsRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := s.repo.ListRoleBindings(ctx, req.Msg.Context, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(filter.Size, bindings, func(binding *auth.RoleBinding) uuid.V7 {
+		return binding.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&authv1.ListRoleBindingsResponse{
+		RoleBindings:  bindings.Proto(),
+		NextPageToken: nextPageTkn,
+	}), nil
+}
@@ -0,0 +1,131 @@
+package authservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_GrantRole(t *testing.T) {
+	want := &auth.RoleBinding{
+		ID:         uuid.New(),
+		Context:    "foo",
+		Subject:    "alice",
+		Role:       auth.RoleExecutor,
+		CreateTime: time.Now().UTC(),
+	}
+
+	r := &RepositoryMock{
+		CreateRoleBindingFunc: func(ctx context.Context, binding *auth.RoleBinding) (*auth.RoleBinding, error) {
+			assert.Equal(t, want.Context, binding.Context)
+			assert.Equal(t, want.Subject, binding.Subject)
+			assert.Equal(t, want.Role, binding.Role)
+			return want, nil
+		},
+	}
+	a := &AuditRepositoryMock{
+		CreateEntryFunc: func(ctx context.Context, entry *audit.Entry) error {
+			return nil
+		},
+	}
+
+	s := New(r, WithAuditRepository(a))
+
+	req := &authv1.GrantRoleRequest{Context: "foo", Subject: "alice", Role: "executor"}
+	res, err := s.GrantRole(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, want.Proto(), res.Msg.RoleBinding)
+
+	require.Len(t, a.CreateEntryCalls(), 1)
+	entry := a.CreateEntryCalls()[0].Entry
+	assert.Equal(t, "foo", entry.Context)
+	assert.Equal(t, audit.ActionGrantRole, entry.Action)
+	assert.Equal(t, "granted role 'executor' to subject 'alice'", entry.Summary)
+}
+
+func TestService_GrantRole_invalidRole(t *testing.T) {
+	s := New(&RepositoryMock{})
+
+	req := &authv1.GrantRoleRequest{Context: "foo", Subject: "alice", Role: "owner"}
+	_, err := s.GrantRole(context.Background(), connect.NewRequest(req))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestService_RevokeRole(t *testing.T) {
+	r := &RepositoryMock{
+		DeleteRoleBindingFunc: func(ctx context.Context, contextID string, subject string, role auth.Role) error {
+			assert.Equal(t, "foo", contextID)
+			assert.Equal(t, "alice", subject)
+			assert.Equal(t, auth.RoleRunner, role)
+			return auth.ErrorRoleBindingNotFound
+		},
+	}
+
+	s := New(r)
+
+	req := &authv1.RevokeRoleRequest{Context: "foo", Subject: "alice", Role: "runner"}
+	_, err := s.RevokeRole(context.Background(), connect.NewRequest(req))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	assert.Len(t, r.DeleteRoleBindingCalls(), 1)
+}
+
+func TestService_ListRoleBindings(t *testing.T) {
+	want := auth.RoleBindingList{
+		{ID: uuid.New(), Context: "foo", Subject: "alice", Role: auth.RoleViewer, CreateTime: time.Now().UTC()},
+	}
+
+	r := &RepositoryMock{
+		ListRoleBindingsFunc: func(ctx context.Context, contextID string, filter test.PageFilter[uuid.V7]) (auth.RoleBindingList, error) {
+			assert.Equal(t, "foo", contextID)
+			return want, nil
+		},
+	}
+
+	s := New(r)
+
+	req := &authv1.ListRoleBindingsRequest{Context: "foo", PageSize: 10}
+	res, err := s.ListRoleBindings(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, want.Proto(), res.Msg.RoleBindings)
+	assert.Empty(t, res.Msg.NextPageToken)
+}
+
+func TestService_adminRequired(t *testing.T) {
+	principal := &auth.Principal{Subject: "alice", Method: auth.MethodJWT}
+	ctx := auth.ContextWithPrincipal(context.Background(), principal)
+
+	r := &RepositoryMock{
+		ListSubjectRolesFunc: func(ctx context.Context, subject string, contextID string) ([]auth.Role, error) {
+			if contextID == "foo" {
+				return []auth.Role{auth.RoleAdmin}, nil
+			}
+			return []auth.Role{auth.RoleExecutor}, nil
+		},
+		ListRoleBindingsFunc: func(ctx context.Context, contextID string, filter test.PageFilter[uuid.V7]) (auth.RoleBindingList, error) {
+			return nil, nil
+		},
+	}
+
+	s := New(r, WithAuthorizer(auth.NewRoleAuthorizer(r)))
+
+	// Context admins can manage the role bindings of their context
+	_, err := s.ListRoleBindings(ctx, connect.NewRequest(&authv1.ListRoleBindingsRequest{Context: "foo"}))
+	require.NoError(t, err)
+
+	_, err = s.ListRoleBindings(ctx, connect.NewRequest(&authv1.ListRoleBindingsRequest{Context: "bar"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// API keys can only be managed by admins of all contexts
+	_, err = s.CreateApiKey(ctx, connect.NewRequest(&authv1.CreateApiKeyRequest{Name: "ci"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
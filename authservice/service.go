@@ -0,0 +1,66 @@
+package authservice
+
+//go:generate go run github.com/matryer/moq@latest -out repository_mock_test.go -pkg authservice ../auth Repository
+//go:generate go run github.com/matryer/moq@latest -out audit_repository_mock_test.go -pkg authservice ../audit Repository:AuditRepositoryMock
+
+import (
+	"context"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/log"
+)
+
+var _ authv1connect.AuthServiceHandler = (*Service)(nil)
+
+type ServiceOption func(s *Service)
+
+func WithLogger(logger log.Logger) ServiceOption {
+	return func(s *Service) {
+		s.logger = logger
+	}
+}
+
+// WithAuditRepository records role and API key changes in the audit log of
+// the repository. Changes are not recorded by default.
+func WithAuditRepository(repo audit.Repository) ServiceOption {
+	return func(s *Service) {
+		s.auditRepo = repo
+	}
+}
+
+// WithAuthorizer authorizes requests using the authorizer. Requests are not
+// authorized by default.
+func WithAuthorizer(authorizer auth.Authorizer) ServiceOption {
+	return func(s *Service) {
+		s.authorizer = authorizer
+	}
+}
+
+type Service struct {
+	repo       auth.Repository
+	auditRepo  audit.Repository
+	authorizer auth.Authorizer
+	logger     log.Logger
+}
+
+func New(repo auth.Repository, opts ...ServiceOption) *Service {
+	s := &Service{
+		repo:   repo,
+		logger: log.NewNopLogger(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// recordAudit records a completed action in the audit log on behalf of the
+// caller, if the audit log is enabled.
+func (s *Service) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if s.auditRepo == nil {
+		return
+	}
+	audit.Record(ctx, s.auditRepo, s.logger, entry)
+}
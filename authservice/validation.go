@@ -0,0 +1,70 @@
+package authservice
+
+import (
+	"github.com/cohesivestack/valgo"
+
+	"github.com/annexsh/annex/auth"
+	authv1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	"github.com/annexsh/annex/internal/validator"
+)
+
+const (
+	reqValidationBaseErrMsg = "invalid request"
+	maxPageSize             = 1000
+	maxAPIKeyNameLength     = 100
+)
+
+func validateCreateApiKeyRequest(req *authv1.CreateApiKeyRequest) error {
+	v := newValidator()
+	v.Is(valgo.String(req.Name, "name").Not().Blank().MaxLength(maxAPIKeyNameLength))
+	return v.ConnectError()
+}
+
+func validateListApiKeysRequest(req *authv1.ListApiKeysRequest) error {
+	v := newValidator()
+	v.Is(validator.PageSize(req.PageSize, maxPageSize))
+	return v.ConnectError()
+}
+
+func validateRevokeApiKeyRequest(req *authv1.RevokeApiKeyRequest) error {
+	v := newValidator()
+	v.Is(validator.UUIDv7(req.Id, "id"))
+	return v.ConnectError()
+}
+
+func validateGrantRoleRequest(req *authv1.GrantRoleRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		valgo.String(req.Subject, "subject").Not().Blank(),
+		roleValidator(req.Role),
+	)
+	return v.ConnectError()
+}
+
+func validateRevokeRoleRequest(req *authv1.RevokeRoleRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		valgo.String(req.Subject, "subject").Not().Blank(),
+		roleValidator(req.Role),
+	)
+	return v.ConnectError()
+}
+
+func validateListRoleBindingsRequest(req *authv1.ListRoleBindingsRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.PageSize(req.PageSize, maxPageSize),
+	)
+	return v.ConnectError()
+}
+
+func roleValidator(role string) valgo.Validator {
+	return valgo.String(auth.Role(role), "role").InSlice(auth.Roles, "{{title}} must be one of 'viewer', 'executor', 'runner' or 'admin'")
+}
+
+func newValidator() *validator.Validator {
+	return validator.New(validator.WithBaseErrorMessage(reqValidationBaseErrMsg))
+}
@@ -0,0 +1,262 @@
+// Package backup exports the test history of a context to a portable archive
+// and imports it into the same or another Annex instance, regardless of the
+// database backend.
+//
+// An archive is a gzip compressed stream of newline-delimited JSON records.
+// The first record is a header, followed by the test suites and tests of the
+// context, then each test execution followed by its case executions, logs and
+// events. Records are written before the records that reference them so that
+// an archive can be imported as it's read. Inputs are archived decoded, so
+// archives contain them in plaintext.
+package backup
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/annexsh/annex/codec"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+const (
+	archiveFormat  = "annex-context"
+	archiveVersion = 1
+)
+
+// ErrPayloadEncrypted is returned when an input is encrypted with a key that
+// is not configured.
+var ErrPayloadEncrypted = errors.New("payload encrypted")
+
+const (
+	kindHeader        = "header"
+	kindTestSuite     = "test_suite"
+	kindTest          = "test"
+	kindTestExecution = "test_execution"
+	kindCaseExecution = "case_execution"
+	kindLog           = "log"
+	kindEvent         = "event"
+)
+
+// Result is the number of records exported or imported.
+type Result struct {
+	TestSuites     int
+	Tests          int
+	TestExecutions int
+	CaseExecutions int
+	Logs           int
+	Events         int
+	// Skipped is the number of test suites, tests and test executions that
+	// were not imported because they already exist.
+	Skipped int
+}
+
+type record struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+type header struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	Context    string    `json:"context"`
+	ExportTime time.Time `json:"exportTime"`
+}
+
+type testSuite struct {
+	ID          uuid.V7 `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+type testRecord struct {
+	ID           uuid.V7   `json:"id"`
+	TestSuiteID  uuid.V7   `json:"testSuiteId"`
+	Name         string    `json:"name"`
+	HasInput     bool      `json:"hasInput"`
+	CreateTime   time.Time `json:"createTime"`
+	DefaultInput *payload  `json:"defaultInput,omitempty"`
+}
+
+type payload struct {
+	Metadata map[string][]byte `json:"metadata,omitempty"`
+	Data     []byte            `json:"data"`
+}
+
+type testExecution struct {
+	ID           test.TestExecutionID `json:"id"`
+	TestID       uuid.V7              `json:"testId"`
+	HasInput     bool                 `json:"hasInput"`
+	ScheduleTime time.Time            `json:"scheduleTime"`
+	StartTime    *time.Time           `json:"startTime,omitempty"`
+	FinishTime   *time.Time           `json:"finishTime,omitempty"`
+	Error        *string              `json:"error,omitempty"`
+	Metadata     executionMetadata    `json:"metadata"`
+	Input        *payload             `json:"input,omitempty"`
+}
+
+type executionMetadata struct {
+	TriggeredBy *string           `json:"triggeredBy,omitempty"`
+	GitSHA      *string           `json:"gitSha,omitempty"`
+	GitBranch   *string           `json:"gitBranch,omitempty"`
+	CIJobURL    *string           `json:"ciJobUrl,omitempty"`
+	Environment *string           `json:"environment,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+type caseExecution struct {
+	ID              test.CaseExecutionID `json:"id"`
+	TestExecutionID test.TestExecutionID `json:"testExecutionId"`
+	CaseName        string               `json:"caseName"`
+	ScheduleTime    time.Time            `json:"scheduleTime"`
+	StartTime       *time.Time           `json:"startTime,omitempty"`
+	FinishTime      *time.Time           `json:"finishTime,omitempty"`
+	Error           *string              `json:"error,omitempty"`
+}
+
+type logRecord struct {
+	ID              uuid.V7               `json:"id"`
+	TestExecutionID test.TestExecutionID  `json:"testExecutionId"`
+	CaseExecutionID *test.CaseExecutionID `json:"caseExecutionId,omitempty"`
+	Level           string                `json:"level"`
+	Message         string                `json:"message"`
+	CreateTime      time.Time             `json:"createTime"`
+	Attributes      map[string]string     `json:"attributes,omitempty"`
+	RedactionCount  int                   `json:"redactionCount,omitempty"`
+}
+
+type eventRecord struct {
+	TestExecutionID test.TestExecutionID  `json:"testExecutionId"`
+	Sequence        uint64                `json:"sequence"`
+	CaseExecutionID *test.CaseExecutionID `json:"caseExecutionId,omitempty"`
+	LogID           *uuid.V7              `json:"logId,omitempty"`
+	// Event is the annex.events.v1.Event in the protobuf JSON format.
+	Event json.RawMessage `json:"event"`
+}
+
+// archiveWriter writes records to a compressed archive.
+type archiveWriter struct {
+	gz  *gzip.Writer
+	enc *json.Encoder
+}
+
+func newArchiveWriter(w io.Writer) *archiveWriter {
+	gz := gzip.NewWriter(w)
+	return &archiveWriter{
+		gz:  gz,
+		enc: json.NewEncoder(gz),
+	}
+}
+
+func (w *archiveWriter) write(kind string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", kind, err)
+	}
+	return w.enc.Encode(record{Kind: kind, Data: data})
+}
+
+// Close flushes the archive without closing the underlying writer.
+func (w *archiveWriter) Close() error {
+	return w.gz.Close()
+}
+
+// archiveReader reads records from a compressed archive.
+type archiveReader struct {
+	gz  *gzip.Reader
+	dec *json.Decoder
+}
+
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	return &archiveReader{
+		gz:  gz,
+		dec: json.NewDecoder(gz),
+	}, nil
+}
+
+// next returns the next record, or io.EOF at the end of the archive.
+func (r *archiveReader) next() (record, error) {
+	var rec record
+	if err := r.dec.Decode(&rec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return record{}, io.EOF
+		}
+		return record{}, fmt.Errorf("invalid archive: %w", err)
+	}
+	return rec, nil
+}
+
+func (r *archiveReader) Close() error {
+	return r.gz.Close()
+}
+
+func decodeRecord[T any](rec record) (T, error) {
+	var v T
+	if err := json.Unmarshal(rec.Data, &v); err != nil {
+		return v, fmt.Errorf("invalid %s record: %w", rec.Kind, err)
+	}
+	return v, nil
+}
+
+// newPayload returns the archived payload of a stored payload, decoded with
+// the codec so that it can be imported into an instance with another
+// encryption key.
+func newPayload(payloadCodec converter.PayloadCodec, p *test.Payload) (*payload, error) {
+	if p == nil {
+		return nil, nil
+	}
+	decoded, err := decodePayload(payloadCodec, p)
+	if err != nil {
+		return nil, err
+	}
+	return &payload{Metadata: decoded.Metadata, Data: decoded.Data}, nil
+}
+
+// toTest returns the payload to be stored, encoded with the codec. Archives
+// of earlier releases contain payloads as stored, so the payload is decoded
+// first in case it's encrypted.
+func (p *payload) toTest(payloadCodec converter.PayloadCodec) (*test.Payload, error) {
+	if p == nil {
+		return nil, nil
+	}
+	decoded, err := decodePayload(payloadCodec, &test.Payload{Metadata: p.Metadata, Data: p.Data})
+	if err != nil {
+		return nil, err
+	}
+	if payloadCodec == nil {
+		return decoded, nil
+	}
+	encoded, err := payloadCodec.Encode([]*commonpb.Payload{{Metadata: decoded.Metadata, Data: decoded.Data}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return &test.Payload{Metadata: encoded[0].Metadata, Data: encoded[0].Data}, nil
+}
+
+// decodePayload decodes a payload with the codec. An error is returned if
+// the payload is still encrypted, since the codec is nil or doesn't encode
+// payloads.
+func decodePayload(payloadCodec converter.PayloadCodec, p *test.Payload) (*test.Payload, error) {
+	if payloadCodec != nil {
+		decoded, err := payloadCodec.Decode([]*commonpb.Payload{{Metadata: p.Metadata, Data: p.Data}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode payload: %w", err)
+		}
+		p = &test.Payload{Metadata: decoded[0].Metadata, Data: decoded[0].Data}
+	}
+	if string(p.Metadata[converter.MetadataEncoding]) == codec.MetadataEncodingEncrypted {
+		return nil, fmt.Errorf("%w with key '%s' but no encryption key is configured", ErrPayloadEncrypted, p.Metadata[codec.MetadataEncryptionKeyID])
+	}
+	return p, nil
+}
@@ -0,0 +1,328 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/annexsh/annex/codec"
+	"github.com/annexsh/annex/event"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/sqlite"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	src := newRepository(t)
+	seedContext(ctx, t, src, "foo", nil)
+
+	var archive bytes.Buffer
+	exported, err := NewExporter(src).Export(ctx, &archive, "foo")
+	require.NoError(t, err)
+	want := Result{TestSuites: 1, Tests: 2, TestExecutions: 2, CaseExecutions: 1, Logs: 2, Events: 3}
+	assert.Equal(t, want, exported)
+
+	dst := newRepository(t)
+	imported, err := NewImporter(dst).Import(ctx, bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, want, imported)
+
+	// Exporting the imported context produces the same records
+	var reexported bytes.Buffer
+	_, err = NewExporter(dst).Export(ctx, &reexported, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, readRecords(t, archive.Bytes())[1:], readRecords(t, reexported.Bytes())[1:])
+}
+
+func TestImport_conflict(t *testing.T) {
+	ctx := context.Background()
+
+	src := newRepository(t)
+	seedContext(ctx, t, src, "foo", nil)
+
+	var archive bytes.Buffer
+	_, err := NewExporter(src).Export(ctx, &archive, "foo")
+	require.NoError(t, err)
+
+	t.Run("fail", func(t *testing.T) {
+		_, err = NewImporter(src).Import(ctx, bytes.NewReader(archive.Bytes()))
+		assert.ErrorIs(t, err, ErrConflict)
+
+		// Nothing is imported when the import fails
+		contexts, err := src.ListContexts(ctx, test.PageFilter[string]{Size: 10})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foo"}, contexts)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		dst := newRepository(t)
+		_, err = NewImporter(dst).Import(ctx, bytes.NewReader(archive.Bytes()))
+		require.NoError(t, err)
+
+		res, err := NewImporter(dst, WithConflictPolicy(ConflictSkip)).Import(ctx, bytes.NewReader(archive.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, Result{Skipped: 5}, res)
+	})
+
+	t.Run("different id", func(t *testing.T) {
+		dst := newRepository(t)
+		// A test suite of the same name created independently
+		require.NoError(t, dst.CreateContext(ctx, "foo"))
+		suiteID, err := dst.CreateTestSuite(ctx, &test.TestSuite{ID: uuid.New(), ContextID: "foo", Name: "suite"})
+		require.NoError(t, err)
+
+		_, err = NewImporter(dst, WithConflictPolicy(ConflictSkip)).Import(ctx, bytes.NewReader(archive.Bytes()))
+		assert.ErrorIs(t, err, ErrConflict)
+
+		suites, err := dst.ListTestSuites(ctx, "foo", test.PageFilter[string]{Size: 10})
+		require.NoError(t, err)
+		require.Len(t, suites, 1)
+		assert.Equal(t, suiteID, suites[0].ID)
+	})
+}
+
+func TestExportImport_encryptedInputs(t *testing.T) {
+	ctx := context.Background()
+
+	srcCodec := newAESCodec(t, "src")
+	src := newRepository(t)
+	seedContext(ctx, t, src, "foo", srcCodec)
+
+	t.Run("reencrypted with the key of the destination", func(t *testing.T) {
+		var archive bytes.Buffer
+		_, err := NewExporter(src, WithPayloadDecoder(srcCodec)).Export(ctx, &archive, "foo")
+		require.NoError(t, err)
+
+		// Inputs are archived in plaintext
+		for _, rec := range readRecords(t, archive.Bytes()) {
+			if rec.Kind != kindTest {
+				continue
+			}
+			tr, err := decodeRecord[testRecord](rec)
+			require.NoError(t, err)
+			if tr.DefaultInput != nil {
+				assert.Equal(t, plainInput.Data, tr.DefaultInput.Data)
+			}
+		}
+
+		dstCodec := newAESCodec(t, "dst")
+		dst := newRepository(t)
+		_, err = NewImporter(dst, WithPayloadEncoder(dstCodec)).Import(ctx, bytes.NewReader(archive.Bytes()))
+		require.NoError(t, err)
+
+		suites, err := dst.ListTestSuites(ctx, "foo", test.PageFilter[string]{Size: 10})
+		require.NoError(t, err)
+		require.Len(t, suites, 1)
+		tests, err := dst.ListTests(ctx, "foo", suites[0].ID, test.PageFilter[uuid.V7]{Size: 10})
+		require.NoError(t, err)
+		i := slices.IndexFunc(tests, func(tt *test.Test) bool { return tt.HasInput })
+		require.NotEqual(t, -1, i)
+		stored, err := dst.GetTestDefaultInput(ctx, tests[i].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "dst", string(stored.Metadata[codec.MetadataEncryptionKeyID]))
+		decoded, err := dstCodec.Decode([]*commonpb.Payload{{Metadata: stored.Metadata, Data: stored.Data}})
+		require.NoError(t, err)
+		assert.Equal(t, plainInput.Data, decoded[0].Data)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := NewExporter(src).Export(ctx, io.Discard, "foo")
+		assert.ErrorIs(t, err, ErrPayloadEncrypted)
+
+		_, err = NewExporter(src, WithPayloadDecoder(newAESCodec(t, "other"))).Export(ctx, io.Discard, "foo")
+		assert.ErrorContains(t, err, "payload encrypted with unknown key 'src'")
+	})
+}
+
+func TestExport_contextNotFound(t *testing.T) {
+	_, err := NewExporter(newRepository(t)).Export(context.Background(), io.Discard, "foo")
+	assert.ErrorIs(t, err, ErrContextNotFound)
+}
+
+func TestImport_invalidArchive(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		records []record
+		wantErr string
+	}{
+		{
+			name:    "missing header",
+			records: []record{{Kind: kindTestSuite, Data: []byte(`{}`)}},
+			wantErr: "missing header",
+		},
+		{
+			name:    "unsupported version",
+			records: []record{{Kind: kindHeader, Data: []byte(`{"format": "annex-context", "version": 2, "context": "foo"}`)}},
+			wantErr: "unsupported archive version 2",
+		},
+		{
+			name: "unknown reference",
+			records: []record{
+				{Kind: kindHeader, Data: []byte(`{"format": "annex-context", "version": 1, "context": "foo"}`)},
+				{Kind: kindTest, Data: []byte(`{"id": "` + uuid.NewString() + `", "testSuiteId": "` + uuid.NewString() + `"}`)},
+			},
+			wantErr: "references unknown test suite",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			aw := newArchiveWriter(&archive)
+			for _, rec := range tt.records {
+				require.NoError(t, aw.enc.Encode(rec))
+			}
+			require.NoError(t, aw.Close())
+
+			_, err := NewImporter(newRepository(t)).Import(ctx, &archive)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+var plainInput = &test.Payload{Metadata: map[string][]byte{"encoding": []byte("json/plain")}, Data: []byte(`{"foo":"bar"}`)}
+
+func newRepository(t *testing.T) test.Repository {
+	db, err := sqlite.Open(sqlite.WithPath(filepath.Join(t.TempDir(), "annex.db")), sqlite.WithMigration())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return sqlite.NewTestRepository(sqlite.NewDB(db))
+}
+
+// seedContext creates a test suite with a test that has a finished and
+// a scheduled execution, and a test that has none. Inputs are encoded with
+// the codec if set.
+func seedContext(ctx context.Context, t *testing.T, repo test.Repository, contextID string, payloadCodec converter.PayloadCodec) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	require.NoError(t, repo.CreateContext(ctx, contextID))
+	suiteID, err := repo.CreateTestSuite(ctx, &test.TestSuite{
+		ID:          uuid.New(),
+		ContextID:   contextID,
+		Name:        "suite",
+		Description: ptr.Get("description"),
+	})
+	require.NoError(t, err)
+
+	tt, err := repo.CreateTest(ctx, &test.Test{
+		ContextID:   contextID,
+		TestSuiteID: suiteID,
+		ID:          uuid.New(),
+		Name:        "with-input",
+		HasInput:    true,
+		CreateTime:  now,
+	})
+	require.NoError(t, err)
+	input := plainInput
+	if payloadCodec != nil {
+		encoded, err := payloadCodec.Encode([]*commonpb.Payload{{Metadata: input.Metadata, Data: input.Data}})
+		require.NoError(t, err)
+		input = &test.Payload{Metadata: encoded[0].Metadata, Data: encoded[0].Data}
+	}
+	require.NoError(t, repo.CreateTestDefaultInput(ctx, tt.ID, input))
+
+	_, err = repo.CreateTest(ctx, &test.Test{
+		ContextID:   contextID,
+		TestSuiteID: suiteID,
+		ID:          uuid.New(),
+		Name:        "without-input",
+		CreateTime:  now,
+	})
+	require.NoError(t, err)
+
+	finished, err := repo.CreateTestExecutionScheduled(ctx, &test.ScheduledTestExecution{
+		ID:           test.NewTestExecutionID(),
+		TestID:       tt.ID,
+		HasInput:     true,
+		ScheduleTime: now,
+		Metadata:     test.ExecutionMetadata{GitSHA: ptr.Get("abc123"), Labels: map[string]string{"env": "ci"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateTestExecutionInput(ctx, finished.ID, input))
+	_, err = repo.UpdateTestExecutionStarted(ctx, &test.StartedTestExecution{ID: finished.ID, StartTime: now.Add(time.Second)})
+	require.NoError(t, err)
+
+	caseExec, err := repo.CreateCaseExecutionScheduled(ctx, &test.ScheduledCaseExecution{
+		ID:              1,
+		TestExecutionID: finished.ID,
+		CaseName:        "case",
+		ScheduleTime:    now.Add(time.Second),
+	})
+	require.NoError(t, err)
+	_, err = repo.UpdateCaseExecutionStarted(ctx, &test.StartedCaseExecution{ID: caseExec.ID, TestExecutionID: finished.ID, StartTime: now.Add(2 * time.Second)})
+	require.NoError(t, err)
+	_, err = repo.UpdateCaseExecutionFinished(ctx, &test.FinishedCaseExecution{ID: caseExec.ID, TestExecutionID: finished.ID, FinishTime: now.Add(3 * time.Second), Error: ptr.Get("boom")})
+	require.NoError(t, err)
+
+	logs := test.LogList{
+		{ID: uuid.New(), TestExecutionID: finished.ID, Level: "INFO", Message: "first", CreateTime: now.Add(time.Second)},
+		{ID: uuid.New(), TestExecutionID: finished.ID, CaseExecutionID: &caseExec.ID, Level: "ERROR", Message: "second", CreateTime: now.Add(2 * time.Second), Attributes: map[string]string{"step": "1"}},
+	}
+	require.NoError(t, repo.CreateLogs(ctx, logs))
+
+	_, err = repo.UpdateTestExecutionFinished(ctx, &test.FinishedTestExecution{ID: finished.ID, FinishTime: now.Add(4 * time.Second), Error: ptr.Get("boom")})
+	require.NoError(t, err)
+
+	events := []*test.ExecutionEvent{
+		{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, &testsv1.TestExecution{Id: finished.ID.String(), TestId: tt.ID.String(), ScheduleTime: timestamppb.New(now)})},
+		{LogID: &logs[0].ID, Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, &testsv1.Log{Id: logs[0].ID.String(), TestExecutionId: finished.ID.String(), Message: "first", CreateTime: timestamppb.New(now)})},
+		{CaseExecutionID: &caseExec.ID, Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, &testsv1.TestExecution{Id: finished.ID.String(), TestId: tt.ID.String(), ScheduleTime: timestamppb.New(now)})},
+	}
+	for _, e := range events {
+		e.TestExecutionID = finished.ID
+		e.Sequence, err = repo.NextExecutionEventSequence(ctx, finished.ID)
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateExecutionEvent(ctx, e))
+	}
+
+	_, err = repo.CreateTestExecutionScheduled(ctx, &test.ScheduledTestExecution{
+		ID:           test.NewTestExecutionID(),
+		TestID:       tt.ID,
+		ScheduleTime: now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+}
+
+func newAESCodec(t *testing.T, keyID string) *codec.AESCodec {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	c, err := codec.NewAESCodec(keyID, key)
+	require.NoError(t, err)
+	return c
+}
+
+func readRecords(t *testing.T, archive []byte) []record {
+	ar, err := newArchiveReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	defer ar.Close()
+
+	var records []record
+	for {
+		rec, err := ar.next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+}
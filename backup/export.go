@@ -0,0 +1,298 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+const defaultPageSize = 100
+
+// ErrContextNotFound is returned when exporting a context that doesn't exist.
+var ErrContextNotFound = errors.New("context not found")
+
+type ExporterOption func(e *Exporter)
+
+// WithPayloadDecoder decodes inputs with the codec before they are archived.
+// It must be the codec that encoded the inputs when they were stored.
+func WithPayloadDecoder(payloadCodec converter.PayloadCodec) ExporterOption {
+	return func(e *Exporter) {
+		e.codec = payloadCodec
+	}
+}
+
+// Exporter writes the test history of a context to an archive.
+type Exporter struct {
+	repo  test.Repository
+	codec converter.PayloadCodec
+	now   func() time.Time
+}
+
+func NewExporter(repo test.Repository, opts ...ExporterOption) *Exporter {
+	e := &Exporter{
+		repo: repo,
+		now:  time.Now,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Export writes the test suites, tests, default inputs, test executions,
+// inputs, case executions, logs and events of the context to the archive.
+// Artifacts and test suite registrations are not exported. Inputs are
+// exported in plaintext and the export fails if an input is encrypted with a
+// key that the payload decoder can't decrypt.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, contextID string) (Result, error) {
+	var res Result
+
+	exists, err := e.contextExists(ctx, contextID)
+	if err != nil {
+		return res, err
+	}
+	if !exists {
+		return res, fmt.Errorf("%w: %s", ErrContextNotFound, contextID)
+	}
+
+	aw := newArchiveWriter(w)
+
+	if err = aw.write(kindHeader, header{
+		Format:     archiveFormat,
+		Version:    archiveVersion,
+		Context:    contextID,
+		ExportTime: e.now().UTC(),
+	}); err != nil {
+		return res, err
+	}
+
+	suites, err := listAll(func(filter test.PageFilter[string]) ([]*test.TestSuite, error) {
+		return e.repo.ListTestSuites(ctx, contextID, filter)
+	}, func(s *test.TestSuite) string { return s.Name })
+	if err != nil {
+		return res, fmt.Errorf("failed to list test suites: %w", err)
+	}
+
+	for _, suite := range suites {
+		if err = aw.write(kindTestSuite, testSuite{
+			ID:          suite.ID,
+			Name:        suite.Name,
+			Description: suite.Description,
+		}); err != nil {
+			return res, err
+		}
+		res.TestSuites++
+	}
+
+	for _, suite := range suites {
+		if err = e.exportTests(ctx, aw, contextID, suite.ID, &res); err != nil {
+			return res, err
+		}
+	}
+
+	if err = aw.Close(); err != nil {
+		return res, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	return res, nil
+}
+
+func (e *Exporter) exportTests(ctx context.Context, aw *archiveWriter, contextID string, suiteID uuid.V7, res *Result) error {
+	tests, err := listAll(func(filter test.PageFilter[uuid.V7]) ([]*test.Test, error) {
+		return e.repo.ListTests(ctx, contextID, suiteID, filter)
+	}, func(t *test.Test) uuid.V7 { return t.ID })
+	if err != nil {
+		return fmt.Errorf("failed to list tests: %w", err)
+	}
+
+	for _, t := range tests {
+		rec := testRecord{
+			ID:          t.ID,
+			TestSuiteID: t.TestSuiteID,
+			Name:        t.Name,
+			HasInput:    t.HasInput,
+			CreateTime:  t.CreateTime,
+		}
+		if t.HasInput {
+			input, err := e.repo.GetTestDefaultInput(ctx, t.ID)
+			if err != nil && !errors.Is(err, test.ErrorTestPayloadNotFound) {
+				return fmt.Errorf("failed to get default input of test %s: %w", t.ID, err)
+			}
+			if rec.DefaultInput, err = newPayload(e.codec, input); err != nil {
+				return fmt.Errorf("failed to export default input of test %s: %w", t.ID, err)
+			}
+		}
+		if err = aw.write(kindTest, rec); err != nil {
+			return err
+		}
+		res.Tests++
+
+		if err = e.exportTestExecutions(ctx, aw, t.ID, res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportTestExecutions(ctx context.Context, aw *archiveWriter, testID uuid.V7, res *Result) error {
+	testExecs, err := listAll(func(filter test.PageFilter[test.TestExecutionID]) ([]*test.TestExecution, error) {
+		return e.repo.ListTestExecutions(ctx, testID, filter)
+	}, func(te *test.TestExecution) test.TestExecutionID { return te.ID })
+	if err != nil {
+		return fmt.Errorf("failed to list test executions of test %s: %w", testID, err)
+	}
+
+	// Listed in descending order
+	slices.Reverse(testExecs)
+
+	for _, testExec := range testExecs {
+		if err = e.exportTestExecution(ctx, aw, testExec, res); err != nil {
+			return fmt.Errorf("failed to export test execution %s: %w", testExec.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportTestExecution(ctx context.Context, aw *archiveWriter, testExec *test.TestExecution, res *Result) error {
+	rec := testExecution{
+		ID:           testExec.ID,
+		TestID:       testExec.TestID,
+		HasInput:     testExec.HasInput,
+		ScheduleTime: testExec.ScheduleTime,
+		StartTime:    testExec.StartTime,
+		FinishTime:   testExec.FinishTime,
+		Error:        testExec.Error,
+		Metadata: executionMetadata{
+			TriggeredBy: testExec.Metadata.TriggeredBy,
+			GitSHA:      testExec.Metadata.GitSHA,
+			GitBranch:   testExec.Metadata.GitBranch,
+			CIJobURL:    testExec.Metadata.CIJobURL,
+			Environment: testExec.Metadata.Environment,
+			Labels:      testExec.Metadata.Labels,
+		},
+	}
+	if testExec.HasInput {
+		input, err := e.repo.GetTestExecutionInput(ctx, testExec.ID)
+		if err != nil && !errors.Is(err, test.ErrorTestExecutionPayloadNotFound) {
+			return fmt.Errorf("failed to get input: %w", err)
+		}
+		if rec.Input, err = newPayload(e.codec, input); err != nil {
+			return fmt.Errorf("failed to export input: %w", err)
+		}
+	}
+	if err := aw.write(kindTestExecution, rec); err != nil {
+		return err
+	}
+	res.TestExecutions++
+
+	caseExecs, err := listAll(func(filter test.PageFilter[test.CaseExecutionID]) ([]*test.CaseExecution, error) {
+		return e.repo.ListCaseExecutions(ctx, testExec.ID, filter)
+	}, func(ce *test.CaseExecution) test.CaseExecutionID { return ce.ID })
+	if err != nil {
+		return fmt.Errorf("failed to list case executions: %w", err)
+	}
+	for _, caseExec := range caseExecs {
+		if err = aw.write(kindCaseExecution, caseExecution{
+			ID:              caseExec.ID,
+			TestExecutionID: caseExec.TestExecutionID,
+			CaseName:        caseExec.CaseName,
+			ScheduleTime:    caseExec.ScheduleTime,
+			StartTime:       caseExec.StartTime,
+			FinishTime:      caseExec.FinishTime,
+			Error:           caseExec.Error,
+		}); err != nil {
+			return err
+		}
+		res.CaseExecutions++
+	}
+
+	logs, err := listAll(func(filter test.PageFilter[uuid.V7]) ([]*test.Log, error) {
+		return e.repo.ListLogs(ctx, testExec.ID, filter, test.LogFilter{})
+	}, func(l *test.Log) uuid.V7 { return l.ID })
+	if err != nil {
+		return fmt.Errorf("failed to list logs: %w", err)
+	}
+
+	// Listed in descending order
+	slices.Reverse(logs)
+
+	for _, l := range logs {
+		if err = aw.write(kindLog, logRecord{
+			ID:              l.ID,
+			TestExecutionID: l.TestExecutionID,
+			CaseExecutionID: l.CaseExecutionID,
+			Level:           l.Level,
+			Message:         l.Message,
+			CreateTime:      l.CreateTime,
+			Attributes:      l.Attributes,
+			RedactionCount:  l.RedactionCount,
+		}); err != nil {
+			return err
+		}
+		res.Logs++
+	}
+
+	events, err := listAll(func(filter test.PageFilter[uint64]) ([]*test.ExecutionEvent, error) {
+		return e.repo.ListExecutionEvents(ctx, testExec.ID, filter, test.LogFilter{})
+	}, func(ev *test.ExecutionEvent) uint64 { return ev.Sequence })
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+	for _, ev := range events {
+		data, err := protojson.Marshal(ev.Event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %d: %w", ev.Sequence, err)
+		}
+		if err = aw.write(kindEvent, eventRecord{
+			TestExecutionID: ev.TestExecutionID,
+			Sequence:        ev.Sequence,
+			CaseExecutionID: ev.CaseExecutionID,
+			LogID:           ev.LogID,
+			Event:           data,
+		}); err != nil {
+			return err
+		}
+		res.Events++
+	}
+
+	return nil
+}
+
+func (e *Exporter) contextExists(ctx context.Context, contextID string) (bool, error) {
+	contexts, err := listAll(func(filter test.PageFilter[string]) ([]string, error) {
+		return e.repo.ListContexts(ctx, filter)
+	}, func(id string) string { return id })
+	if err != nil {
+		return false, fmt.Errorf("failed to list contexts: %w", err)
+	}
+	return slices.Contains(contexts, contextID), nil
+}
+
+// listAll lists every page of a repository list method.
+func listAll[T any, ID test.Identifier](list func(filter test.PageFilter[ID]) ([]T, error), id func(T) ID) ([]T, error) {
+	var all []T
+	filter := test.PageFilter[ID]{Size: defaultPageSize}
+	for {
+		page, err := list(filter)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < filter.Size {
+			return all, nil
+		}
+		offset := id(page[len(page)-1])
+		filter.OffsetID = &offset
+	}
+}
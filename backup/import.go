@@ -0,0 +1,505 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+const logBatchSize = 500
+
+// ErrConflict is returned when an imported record conflicts with an existing
+// record.
+var ErrConflict = errors.New("conflicting record")
+
+// ConflictPolicy determines how records that already exist are imported.
+type ConflictPolicy string
+
+const (
+	// ConflictFail fails the import if any test suite, test or test execution
+	// already exists.
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictSkip keeps test suites, tests and test executions that already
+	// exist with the same ID, so that an archive can be imported again to
+	// add the records that are missing. Records that exist with a different
+	// ID, such as a test of the same name, still fail the import.
+	ConflictSkip ConflictPolicy = "skip"
+)
+
+// ParseConflictPolicy returns the conflict policy of its name.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(s); p {
+	case ConflictFail, ConflictSkip:
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid conflict policy %q: must be %q or %q", s, ConflictFail, ConflictSkip)
+}
+
+type ImporterOption func(i *Importer)
+
+// WithConflictPolicy sets how records that already exist are imported.
+// Defaults to ConflictFail.
+func WithConflictPolicy(policy ConflictPolicy) ImporterOption {
+	return func(i *Importer) {
+		i.policy = policy
+	}
+}
+
+// WithPayloadEncoder encodes inputs with the codec before they are stored.
+// It must be the codec that the instance encodes stored inputs with.
+func WithPayloadEncoder(payloadCodec converter.PayloadCodec) ImporterOption {
+	return func(i *Importer) {
+		i.codec = payloadCodec
+	}
+}
+
+// Importer reads the test history of a context from an archive into the
+// repository. Record IDs are preserved.
+type Importer struct {
+	repo   test.Repository
+	policy ConflictPolicy
+	codec  converter.PayloadCodec
+}
+
+func NewImporter(repo test.Repository, opts ...ImporterOption) *Importer {
+	i := &Importer{
+		repo:   repo,
+		policy: ConflictFail,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Import creates the context and records of the archive in a single
+// transaction, so that nothing is imported if it fails.
+func (i *Importer) Import(ctx context.Context, r io.Reader) (Result, error) {
+	ar, err := newArchiveReader(r)
+	if err != nil {
+		return Result{}, err
+	}
+	defer ar.Close()
+
+	var res Result
+	err = i.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		imp := &importer{
+			repo:         repo,
+			policy:       i.policy,
+			codec:        i.codec,
+			suites:       map[uuid.V7]bool{},
+			tests:        map[uuid.V7]bool{},
+			testExecs:    map[test.TestExecutionID]bool{},
+			existingExec: map[test.TestExecutionID]bool{},
+		}
+		if err := imp.run(ctx, ar); err != nil {
+			return err
+		}
+		res = imp.res
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+// importer imports the records of a single archive.
+type importer struct {
+	repo      test.Repository
+	policy    ConflictPolicy
+	codec     converter.PayloadCodec
+	contextID string
+	// existingSuites are the IDs of the test suites of the context by name.
+	existingSuites map[string]uuid.V7
+	// suites, tests and testExecs are the IDs of records that have been
+	// imported or already exist, which records later in the archive may
+	// reference.
+	suites    map[uuid.V7]bool
+	tests     map[uuid.V7]bool
+	testExecs map[test.TestExecutionID]bool
+	// existingExec are the test executions that already existed, whose case
+	// executions, logs and events are skipped.
+	existingExec map[test.TestExecutionID]bool
+	logs         test.LogList
+	res          Result
+}
+
+func (i *importer) run(ctx context.Context, ar *archiveReader) error {
+	rec, err := ar.next()
+	if errors.Is(err, io.EOF) {
+		return errors.New("invalid archive: missing header")
+	}
+	if err != nil {
+		return err
+	}
+	if err = i.importHeader(ctx, rec); err != nil {
+		return err
+	}
+
+	for {
+		rec, err = ar.next()
+		if errors.Is(err, io.EOF) {
+			return i.flushLogs(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		if rec.Kind != kindLog {
+			// Events reference the logs before them
+			if err = i.flushLogs(ctx); err != nil {
+				return err
+			}
+		}
+
+		switch rec.Kind {
+		case kindTestSuite:
+			err = i.importTestSuite(ctx, rec)
+		case kindTest:
+			err = i.importTest(ctx, rec)
+		case kindTestExecution:
+			err = i.importTestExecution(ctx, rec)
+		case kindCaseExecution:
+			err = i.importCaseExecution(ctx, rec)
+		case kindLog:
+			err = i.importLog(ctx, rec)
+		case kindEvent:
+			err = i.importEvent(ctx, rec)
+		default:
+			err = fmt.Errorf("invalid archive: unknown record kind %q", rec.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (i *importer) importHeader(ctx context.Context, rec record) error {
+	if rec.Kind != kindHeader {
+		return errors.New("invalid archive: missing header")
+	}
+	h, err := decodeRecord[header](rec)
+	if err != nil {
+		return err
+	}
+	if h.Format != archiveFormat {
+		return fmt.Errorf("invalid archive: unknown format %q", h.Format)
+	}
+	if h.Version < 1 || h.Version > archiveVersion {
+		return fmt.Errorf("unsupported archive version %d: latest supported version is %d", h.Version, archiveVersion)
+	}
+	if h.Context == "" {
+		return errors.New("invalid archive: missing context")
+	}
+	i.contextID = h.Context
+
+	if err = i.repo.CreateContext(ctx, i.contextID); err != nil && !errors.Is(err, test.ErrorContextAlreadyExists) {
+		return fmt.Errorf("failed to create context: %w", err)
+	}
+
+	suites, err := listAll(func(filter test.PageFilter[string]) ([]*test.TestSuite, error) {
+		return i.repo.ListTestSuites(ctx, i.contextID, filter)
+	}, func(s *test.TestSuite) string { return s.Name })
+	if err != nil {
+		return fmt.Errorf("failed to list test suites: %w", err)
+	}
+	i.existingSuites = make(map[string]uuid.V7, len(suites))
+	for _, s := range suites {
+		i.existingSuites[s.Name] = s.ID
+	}
+
+	return nil
+}
+
+func (i *importer) importTestSuite(ctx context.Context, rec record) error {
+	suite, err := decodeRecord[testSuite](rec)
+	if err != nil {
+		return err
+	}
+
+	if existingID, ok := i.existingSuites[suite.Name]; ok {
+		if existingID != suite.ID {
+			return fmt.Errorf("%w: test suite %q already exists with ID %s", ErrConflict, suite.Name, existingID)
+		}
+		if i.policy != ConflictSkip {
+			return fmt.Errorf("%w: test suite %q already exists", ErrConflict, suite.Name)
+		}
+		i.suites[suite.ID] = true
+		i.res.Skipped++
+		return nil
+	}
+
+	id, err := i.repo.CreateTestSuite(ctx, &test.TestSuite{
+		ID:          suite.ID,
+		ContextID:   i.contextID,
+		Name:        suite.Name,
+		Description: suite.Description,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create test suite %q: %w", suite.Name, err)
+	}
+	if id != suite.ID {
+		return fmt.Errorf("%w: test suite %q already exists with ID %s", ErrConflict, suite.Name, id)
+	}
+
+	i.suites[suite.ID] = true
+	i.res.TestSuites++
+	return nil
+}
+
+func (i *importer) importTest(ctx context.Context, rec record) error {
+	t, err := decodeRecord[testRecord](rec)
+	if err != nil {
+		return err
+	}
+	if !i.suites[t.TestSuiteID] {
+		return fmt.Errorf("invalid archive: test %s references unknown test suite %s", t.ID, t.TestSuiteID)
+	}
+
+	existing, err := i.repo.GetTest(ctx, t.ID)
+	if err != nil && !errors.Is(err, test.ErrorTestNotFound) {
+		return fmt.Errorf("failed to get test %s: %w", t.ID, err)
+	}
+	if existing != nil {
+		if existing.ContextID != i.contextID || existing.TestSuiteID != t.TestSuiteID || existing.Name != t.Name {
+			return fmt.Errorf("%w: test ID %s is used by test %q of context %s", ErrConflict, t.ID, existing.Name, existing.ContextID)
+		}
+		if i.policy != ConflictSkip {
+			return fmt.Errorf("%w: test %q already exists", ErrConflict, t.Name)
+		}
+		i.tests[t.ID] = true
+		i.res.Skipped++
+		return nil
+	}
+
+	created, err := i.repo.CreateTest(ctx, &test.Test{
+		ContextID:   i.contextID,
+		TestSuiteID: t.TestSuiteID,
+		ID:          t.ID,
+		Name:        t.Name,
+		HasInput:    t.HasInput,
+		CreateTime:  t.CreateTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create test %q: %w", t.Name, err)
+	}
+	if created.ID != t.ID {
+		return fmt.Errorf("%w: test %q already exists with ID %s", ErrConflict, t.Name, created.ID)
+	}
+
+	if t.DefaultInput != nil {
+		input, err := t.DefaultInput.toTest(i.codec)
+		if err != nil {
+			return fmt.Errorf("failed to import default input of test %q: %w", t.Name, err)
+		}
+		if err = i.repo.CreateTestDefaultInput(ctx, t.ID, input); err != nil {
+			return fmt.Errorf("failed to create default input of test %q: %w", t.Name, err)
+		}
+	}
+
+	i.tests[t.ID] = true
+	i.res.Tests++
+	return nil
+}
+
+func (i *importer) importTestExecution(ctx context.Context, rec record) error {
+	te, err := decodeRecord[testExecution](rec)
+	if err != nil {
+		return err
+	}
+	if !i.tests[te.TestID] {
+		return fmt.Errorf("invalid archive: test execution %s references unknown test %s", te.ID, te.TestID)
+	}
+
+	_, err = i.repo.GetTestExecution(ctx, te.ID)
+	if err == nil {
+		if i.policy != ConflictSkip {
+			return fmt.Errorf("%w: test execution %s already exists", ErrConflict, te.ID)
+		}
+		i.testExecs[te.ID] = true
+		i.existingExec[te.ID] = true
+		i.res.Skipped++
+		return nil
+	}
+	if !errors.Is(err, test.ErrorTestExecutionNotFound) {
+		return fmt.Errorf("failed to get test execution %s: %w", te.ID, err)
+	}
+
+	if _, err = i.repo.CreateTestExecutionScheduled(ctx, &test.ScheduledTestExecution{
+		ID:           te.ID,
+		TestID:       te.TestID,
+		HasInput:     te.HasInput,
+		ScheduleTime: te.ScheduleTime,
+		Metadata: test.ExecutionMetadata{
+			TriggeredBy: te.Metadata.TriggeredBy,
+			GitSHA:      te.Metadata.GitSHA,
+			GitBranch:   te.Metadata.GitBranch,
+			CIJobURL:    te.Metadata.CIJobURL,
+			Environment: te.Metadata.Environment,
+			Labels:      te.Metadata.Labels,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to create test execution %s: %w", te.ID, err)
+	}
+	if te.Input != nil {
+		input, err := te.Input.toTest(i.codec)
+		if err != nil {
+			return fmt.Errorf("failed to import input of test execution %s: %w", te.ID, err)
+		}
+		if err = i.repo.CreateTestExecutionInput(ctx, te.ID, input); err != nil {
+			return fmt.Errorf("failed to create input of test execution %s: %w", te.ID, err)
+		}
+	}
+	if te.StartTime != nil {
+		if _, err = i.repo.UpdateTestExecutionStarted(ctx, &test.StartedTestExecution{
+			ID:        te.ID,
+			StartTime: *te.StartTime,
+		}); err != nil {
+			return fmt.Errorf("failed to update test execution %s: %w", te.ID, err)
+		}
+	}
+	if te.FinishTime != nil {
+		if _, err = i.repo.UpdateTestExecutionFinished(ctx, &test.FinishedTestExecution{
+			ID:         te.ID,
+			FinishTime: *te.FinishTime,
+			Error:      te.Error,
+		}); err != nil {
+			return fmt.Errorf("failed to update test execution %s: %w", te.ID, err)
+		}
+	}
+
+	i.testExecs[te.ID] = true
+	i.res.TestExecutions++
+	return nil
+}
+
+func (i *importer) importCaseExecution(ctx context.Context, rec record) error {
+	ce, err := decodeRecord[caseExecution](rec)
+	if err != nil {
+		return err
+	}
+	if skip, err := i.skipChild(ce.TestExecutionID, rec.Kind); skip || err != nil {
+		return err
+	}
+
+	if _, err = i.repo.CreateCaseExecutionScheduled(ctx, &test.ScheduledCaseExecution{
+		ID:              ce.ID,
+		TestExecutionID: ce.TestExecutionID,
+		CaseName:        ce.CaseName,
+		ScheduleTime:    ce.ScheduleTime,
+	}); err != nil {
+		return fmt.Errorf("failed to create case execution %d of test execution %s: %w", ce.ID, ce.TestExecutionID, err)
+	}
+	if ce.StartTime != nil {
+		if _, err = i.repo.UpdateCaseExecutionStarted(ctx, &test.StartedCaseExecution{
+			ID:              ce.ID,
+			TestExecutionID: ce.TestExecutionID,
+			StartTime:       *ce.StartTime,
+		}); err != nil {
+			return fmt.Errorf("failed to update case execution %d of test execution %s: %w", ce.ID, ce.TestExecutionID, err)
+		}
+	}
+	if ce.FinishTime != nil {
+		if _, err = i.repo.UpdateCaseExecutionFinished(ctx, &test.FinishedCaseExecution{
+			ID:              ce.ID,
+			TestExecutionID: ce.TestExecutionID,
+			FinishTime:      *ce.FinishTime,
+			Error:           ce.Error,
+		}); err != nil {
+			return fmt.Errorf("failed to update case execution %d of test execution %s: %w", ce.ID, ce.TestExecutionID, err)
+		}
+	}
+
+	i.res.CaseExecutions++
+	return nil
+}
+
+func (i *importer) importLog(ctx context.Context, rec record) error {
+	l, err := decodeRecord[logRecord](rec)
+	if err != nil {
+		return err
+	}
+	if skip, err := i.skipChild(l.TestExecutionID, rec.Kind); skip || err != nil {
+		return err
+	}
+
+	i.logs = append(i.logs, &test.Log{
+		ID:              l.ID,
+		TestExecutionID: l.TestExecutionID,
+		CaseExecutionID: l.CaseExecutionID,
+		Level:           l.Level,
+		Message:         l.Message,
+		CreateTime:      l.CreateTime,
+		Attributes:      l.Attributes,
+		RedactionCount:  l.RedactionCount,
+	})
+	if len(i.logs) >= logBatchSize {
+		return i.flushLogs(ctx)
+	}
+	return nil
+}
+
+func (i *importer) flushLogs(ctx context.Context) error {
+	if len(i.logs) == 0 {
+		return nil
+	}
+	if err := i.repo.CreateLogs(ctx, i.logs); err != nil {
+		return fmt.Errorf("failed to create logs: %w", err)
+	}
+	i.res.Logs += len(i.logs)
+	i.logs = nil
+	return nil
+}
+
+func (i *importer) importEvent(ctx context.Context, rec record) error {
+	ev, err := decodeRecord[eventRecord](rec)
+	if err != nil {
+		return err
+	}
+	if skip, err := i.skipChild(ev.TestExecutionID, rec.Kind); skip || err != nil {
+		return err
+	}
+
+	var e eventsv1.Event
+	if err = protojson.Unmarshal(ev.Event, &e); err != nil {
+		return fmt.Errorf("invalid event record: %w", err)
+	}
+
+	// Sequences are allocated again since events may have been deleted from
+	// the exported sequence, the order is preserved
+	seq, err := i.repo.NextExecutionEventSequence(ctx, ev.TestExecutionID)
+	if err != nil {
+		return fmt.Errorf("failed to allocate event sequence of test execution %s: %w", ev.TestExecutionID, err)
+	}
+	if err = i.repo.CreateExecutionEvent(ctx, &test.ExecutionEvent{
+		TestExecutionID: ev.TestExecutionID,
+		Sequence:        seq,
+		CaseExecutionID: ev.CaseExecutionID,
+		LogID:           ev.LogID,
+		Event:           &e,
+	}); err != nil {
+		return fmt.Errorf("failed to create event of test execution %s: %w", ev.TestExecutionID, err)
+	}
+
+	i.res.Events++
+	return nil
+}
+
+// skipChild returns whether a record of a test execution is skipped because
+// the test execution already existed.
+func (i *importer) skipChild(testExecID test.TestExecutionID, kind string) (bool, error) {
+	if !i.testExecs[testExecID] {
+		return false, fmt.Errorf("invalid archive: %s references unknown test execution %s", kind, testExecID)
+	}
+	return i.existingExec[testExecID], nil
+}
@@ -0,0 +1,25 @@
+// Package blob provides stores for the content of large binary objects such
+// as test execution artifacts.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when a blob doesn't exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Store stores blobs by key.
+type Store interface {
+	// Put stores the content read from the reader under the key, replacing
+	// any existing blob. The blob is not stored if reading fails.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get opens the blob stored under the key. ErrNotFound is returned if
+	// the blob doesn't exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the blob stored under the key. Deleting a blob that
+	// doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,75 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	err = s.Put(ctx, "foo", strings.NewReader("hello world"))
+	require.NoError(t, err)
+
+	r, err := s.Get(ctx, "foo")
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "hello world", string(got))
+
+	err = s.Delete(ctx, "foo")
+	require.NoError(t, err)
+	_, err = s.Get(ctx, "foo")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting a missing blob is not an error
+	err = s.Delete(ctx, "foo")
+	assert.NoError(t, err)
+}
+
+func TestFileStore_Put_readError(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	wantErr := errors.New("bang")
+	err = s.Put(ctx, "foo", io.MultiReader(strings.NewReader("partial"), errReader{wantErr}))
+	assert.ErrorIs(t, err, wantErr)
+
+	_, err = s.Get(ctx, "foo")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary file should be removed")
+}
+
+func TestFileStore_invalidKey(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", ".", "..", "../foo", "foo/bar", `foo\bar`, ".tmp-123"} {
+		err = s.Put(ctx, key, strings.NewReader("bar"))
+		assert.Error(t, err, key)
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
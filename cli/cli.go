@@ -0,0 +1,210 @@
+// Package cli implements the annex command-line client.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/annexsh/annex-proto/go/gen/annex/events/v1/eventsv1connect"
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
+
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK = 0
+	// ExitTestFailed is returned when the test execution of a command
+	// finished with an error.
+	ExitTestFailed = 1
+	// ExitError is returned when a command could not be completed, including
+	// invalid usage.
+	ExitError = 2
+)
+
+const (
+	defaultServerURL  = "http://localhost:4400/connect"
+	serverURLEnv      = "ANNEX_SERVER_URL"
+	eventServerURLEnv = "ANNEX_EVENT_SERVER_URL"
+	tokenEnv          = "ANNEX_TOKEN"
+	contextEnv        = "ANNEX_CONTEXT"
+)
+
+// errUsage is returned by commands that were invoked incorrectly. The usage of
+// the command has already been printed.
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, c *cli, args []string) (int, error)
+}
+
+var commands = []command{
+	{name: "contexts", summary: "List contexts", run: listContexts},
+	{name: "suites", summary: "List the test suites of a context", run: listTestSuites},
+	{name: "tests", summary: "List the tests of a context", run: listTests},
+	{name: "execute", summary: "Execute a test", run: executeTest},
+	{name: "retry", summary: "Retry a test execution", run: retryTestExecution},
+	{name: "tail", summary: "Stream the events of a test execution until it finishes", run: tailTestExecution},
+	{name: "export", summary: "Export the results of a test execution as JSON", run: exportTestExecution},
+}
+
+type cli struct {
+	tests           testsv1connect.TestServiceClient
+	executions      executionsv1connect.ExecutionServiceClient
+	events          eventsv1connect.EventServiceClient
+	executionEvents executionsv1connect.ExecutionEventServiceClient
+	out             *printer
+	stdin           io.Reader
+	stderr          io.Writer
+}
+
+// Run runs the command of the arguments, excluding the program name, and
+// returns the exit code.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("annex", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	serverURL := fs.String("server", envOr(serverURLEnv, defaultServerURL), "Connect URL of the test service (env "+serverURLEnv+")")
+	eventServerURL := fs.String("event-server", os.Getenv(eventServerURLEnv), "Connect URL of the event service if not served by the test service (env "+eventServerURLEnv+")")
+	token := fs.String("token", os.Getenv(tokenEnv), "API key or token to authenticate with (env "+tokenEnv+")")
+	output := fs.String("output", "text", "Output format: text or json")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: annex [flags] <command> [command flags]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		for _, cmd := range commands {
+			fmt.Fprintf(stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+		}
+		fmt.Fprintln(stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(stderr, "error: invalid output format %q: must be text or json\n", *output)
+		return ExitError
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return ExitError
+	}
+
+	cmd, ok := lookupCommand(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "error: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return ExitError
+	}
+
+	if *eventServerURL == "" {
+		*eventServerURL = *serverURL
+	}
+
+	// Streams can last as long as a test execution so the client has no timeout
+	httpClient := &http.Client{Transport: &bearerTransport{token: *token, base: http.DefaultTransport}}
+
+	c := &cli{
+		tests:           testsv1connect.NewTestServiceClient(httpClient, strings.TrimSuffix(*serverURL, "/")),
+		executions:      executionsv1connect.NewExecutionServiceClient(httpClient, strings.TrimSuffix(*serverURL, "/")),
+		events:          eventsv1connect.NewEventServiceClient(httpClient, strings.TrimSuffix(*eventServerURL, "/")),
+		executionEvents: executionsv1connect.NewExecutionEventServiceClient(httpClient, strings.TrimSuffix(*eventServerURL, "/")),
+		out:             &printer{w: stdout, json: *output == "json"},
+		stdin:           stdin,
+		stderr:          stderr,
+	}
+
+	code, err := cmd.run(ctx, c, fs.Args()[1:])
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, errUsage):
+		return ExitError
+	case err != nil:
+		fmt.Fprintf(stderr, "error: %s\n", err)
+		return ExitError
+	}
+	return code
+}
+
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// newFlagSet returns the flag set of a command that prints errors and usage
+// to the CLI stderr.
+func (c *cli) newFlagSet(name string, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: annex %s %s\n\nFlags:\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the command flags and checks the required flags are set.
+func parseFlags(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+		return errUsage
+	}
+	for _, name := range required {
+		if fs.Lookup(name).Value.String() == "" {
+			fmt.Fprintf(fs.Output(), "flag -%s is required\n", name)
+			fs.Usage()
+			return errUsage
+		}
+	}
+	return nil
+}
+
+func contextFlag(fs *flag.FlagSet) *string {
+	return fs.String("context", os.Getenv(contextEnv), "Context of the tests (env "+contextEnv+")")
+}
+
+func envOr(key string, fallback string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return fallback
+}
+
+// bearerTransport authenticates requests with a bearer token.
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.token == "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
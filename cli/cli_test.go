@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"github.com/annexsh/annex-proto/go/gen/annex/events/v1/eventsv1connect"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+	"github.com/annexsh/annex/internal/ptr"
+)
+
+func TestRun_contexts(t *testing.T) {
+	srv := newServer(t, &testServiceHandler{
+		listContexts: func(req *testsv1.ListContextsRequest) (*testsv1.ListContextsResponse, error) {
+			if req.NextPageToken == "" {
+				return &testsv1.ListContextsResponse{Contexts: []string{"foo"}, NextPageToken: "next"}, nil
+			}
+			return &testsv1.ListContextsResponse{Contexts: []string{"bar"}}, nil
+		},
+	}, nil)
+
+	code, stdout, stderr := run(t, nil, "-server", srv.URL, "-token", "secret", "-output", "json", "contexts")
+	require.Equal(t, ExitOK, code, stderr)
+	assert.JSONEq(t, `{"contexts": ["foo", "bar"]}`, stdout)
+}
+
+func TestRun_execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *string
+		wantCode int
+	}{
+		{
+			name:     "passed",
+			wantCode: ExitOK,
+		},
+		{
+			name:     "failed",
+			err:      ptr.Get("boom"),
+			wantCode: ExitTestFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(`{"foo": "bar"}`)
+			inputFile := filepath.Join(t.TempDir(), "input.json")
+			require.NoError(t, os.WriteFile(inputFile, input, 0o600))
+
+			scheduled := &testsv1.TestExecution{Id: "exec-id", TestId: "test-id", ScheduleTime: timestamppb.Now()}
+			finished := &testsv1.TestExecution{
+				Id:           scheduled.Id,
+				TestId:       scheduled.TestId,
+				Error:        tt.err,
+				ScheduleTime: scheduled.ScheduleTime,
+				StartTime:    timestamppb.Now(),
+				FinishTime:   timestamppb.Now(),
+			}
+
+			srv := newServer(t, &testServiceHandler{
+				executeTest: func(req *testsv1.ExecuteTestRequest) (*testsv1.ExecuteTestResponse, error) {
+					assert.Equal(t, "ctx", req.Context)
+					assert.Equal(t, "test-id", req.TestId)
+					assert.Equal(t, input, req.Input.GetData())
+					assert.Equal(t, []byte(converter.MetadataEncodingJSON), req.Input.GetMetadata()[converter.MetadataEncoding])
+					return &testsv1.ExecuteTestResponse{TestExecution: scheduled}, nil
+				},
+			}, []*eventsv1.Event{
+				event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_SCHEDULED, scheduled),
+				event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, finished),
+			})
+
+			code, stdout, stderr := run(t, nil, "-server", srv.URL, "-output", "json",
+				"execute", "-context", "ctx", "-test", "test-id", "-input", inputFile, "-wait")
+			require.Equal(t, tt.wantCode, code, stderr)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal([]byte(stdout), &got))
+			assert.Equal(t, "exec-id", got["id"])
+			assert.NotNil(t, got["finishTime"])
+		})
+	}
+}
+
+func TestRun_tail(t *testing.T) {
+	testExec := &testsv1.TestExecution{Id: "exec-id", TestId: "test-id", ScheduleTime: timestamppb.Now()}
+	finished := &testsv1.TestExecution{
+		Id:           testExec.Id,
+		TestId:       testExec.TestId,
+		Error:        ptr.Get("boom"),
+		ScheduleTime: testExec.ScheduleTime,
+		StartTime:    timestamppb.Now(),
+		FinishTime:   timestamppb.Now(),
+	}
+
+	srv := newServer(t, &testServiceHandler{}, []*eventsv1.Event{
+		event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, testExec),
+		event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, &testsv1.Log{
+			TestExecutionId: testExec.Id,
+			Level:           "INFO",
+			Message:         "hello",
+			CreateTime:      timestamppb.Now(),
+		}),
+		event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, finished),
+	})
+
+	code, stdout, stderr := run(t, nil, "-server", srv.URL, "tail", "-context", "ctx", "-id", "exec-id")
+	require.Equal(t, ExitTestFailed, code, stderr)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "TEST_EXECUTION_STARTED")
+	assert.Contains(t, lines[1], "[INFO] hello")
+	assert.Contains(t, lines[2], "TEST_EXECUTION_FINISHED: boom")
+}
+
+func TestRun_usage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "no command",
+		},
+		{
+			name: "unknown command",
+			args: []string{"foo"},
+		},
+		{
+			name: "missing required flag",
+			args: []string{"execute", "-context", "ctx"},
+		},
+		{
+			name: "invalid output",
+			args: []string{"-output", "yaml", "contexts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, _ := run(t, nil, tt.args...)
+			assert.Equal(t, ExitError, code)
+			assert.Empty(t, stdout)
+		})
+	}
+}
+
+func run(t *testing.T, stdin []byte, args ...string) (int, string, string) {
+	t.Setenv(contextEnv, "")
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), args, bytes.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// newServer serves the test service handler and an event service that
+// streams the events. Waits return the test executions of the finished
+// events.
+func newServer(t *testing.T, testHandler *testServiceHandler, events []*eventsv1.Event) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(testsv1connect.NewTestServiceHandler(testHandler))
+	mux.Handle(eventsv1connect.NewEventServiceHandler(&eventServiceHandler{events: events}))
+	mux.Handle(executionsv1connect.NewExecutionEventServiceHandler(&executionEventServiceHandler{events: events}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			assert.Equal(t, "Bearer secret", auth)
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+type testServiceHandler struct {
+	testsv1connect.UnimplementedTestServiceHandler
+	listContexts func(req *testsv1.ListContextsRequest) (*testsv1.ListContextsResponse, error)
+	executeTest  func(req *testsv1.ExecuteTestRequest) (*testsv1.ExecuteTestResponse, error)
+}
+
+func (h *testServiceHandler) ListContexts(_ context.Context, req *connect.Request[testsv1.ListContextsRequest]) (*connect.Response[testsv1.ListContextsResponse], error) {
+	res, err := h.listContexts(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (h *testServiceHandler) ExecuteTest(_ context.Context, req *connect.Request[testsv1.ExecuteTestRequest]) (*connect.Response[testsv1.ExecuteTestResponse], error) {
+	res, err := h.executeTest(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(res), nil
+}
+
+type eventServiceHandler struct {
+	eventsv1connect.UnimplementedEventServiceHandler
+	events []*eventsv1.Event
+}
+
+func (h *eventServiceHandler) StreamTestExecutionEvents(
+	_ context.Context,
+	_ *connect.Request[eventsv1.StreamTestExecutionEventsRequest],
+	stream *connect.ServerStream[eventsv1.StreamTestExecutionEventsResponse],
+) error {
+	for _, e := range h.events {
+		if err := stream.Send(&eventsv1.StreamTestExecutionEventsResponse{Event: e}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type executionEventServiceHandler struct {
+	executionsv1connect.UnimplementedExecutionEventServiceHandler
+	events []*eventsv1.Event
+}
+
+func (h *executionEventServiceHandler) WaitForTestExecutions(
+	_ context.Context,
+	req *connect.Request[executionsv1.WaitForTestExecutionsRequest],
+	stream *connect.ServerStream[executionsv1.WaitForTestExecutionsResponse],
+) error {
+	summary := &executionsv1.WaitSummary{Verdict: executionsv1.Verdict_VERDICT_PASSED}
+	for _, e := range h.events {
+		testExec := e.GetData().GetTestExecution()
+		if e.Type != eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED || !slices.Contains(req.Msg.TestExecutionIds, testExec.GetId()) {
+			continue
+		}
+		if testExec.GetError() != "" {
+			summary.FailedTestExecutionIds = append(summary.FailedTestExecutionIds, testExec.Id)
+			summary.Verdict = executionsv1.Verdict_VERDICT_FAILED
+		} else {
+			summary.PassedTestExecutionIds = append(summary.PassedTestExecutionIds, testExec.Id)
+		}
+		if err := stream.Send(&executionsv1.WaitForTestExecutionsResponse{
+			Result: &executionsv1.WaitForTestExecutionsResponse_TestExecution{TestExecution: testExec},
+		}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&executionsv1.WaitForTestExecutionsResponse{
+		Result: &executionsv1.WaitForTestExecutionsResponse_Summary{Summary: summary},
+	})
+}
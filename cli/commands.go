@@ -0,0 +1,420 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"connectrpc.com/connect"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+func listContexts(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("contexts", "")
+	if err := parseFlags(fs, args); err != nil {
+		return 0, err
+	}
+
+	contexts, err := listAll(func(pageToken string) ([]string, string, error) {
+		res, err := c.tests.ListContexts(ctx, connect.NewRequest(&testsv1.ListContextsRequest{
+			NextPageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list contexts: %w", err)
+		}
+		return res.Msg.Contexts, res.Msg.NextPageToken, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if c.out.json {
+		return ExitOK, c.out.message(&testsv1.ListContextsResponse{Contexts: contexts})
+	}
+
+	rows := make([][]string, len(contexts))
+	for i, contextID := range contexts {
+		rows[i] = []string{contextID}
+	}
+	return ExitOK, c.out.table([]string{"CONTEXT"}, rows)
+}
+
+func listTestSuites(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("suites", "-context <context>")
+	contextID := contextFlag(fs)
+	if err := parseFlags(fs, args, "context"); err != nil {
+		return 0, err
+	}
+
+	suites, err := listAll(func(pageToken string) ([]*testsv1.TestSuite, string, error) {
+		res, err := c.tests.ListTestSuites(ctx, connect.NewRequest(&testsv1.ListTestSuitesRequest{
+			Context:       *contextID,
+			NextPageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list test suites: %w", err)
+		}
+		return res.Msg.TestSuites, res.Msg.NextPageToken, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if c.out.json {
+		return ExitOK, c.out.message(&testsv1.ListTestSuitesResponse{TestSuites: suites})
+	}
+
+	rows := make([][]string, len(suites))
+	for i, suite := range suites {
+		rows[i] = []string{suite.Id, suite.Name, strconv.FormatBool(suite.Available), suite.GetDescription()}
+	}
+	return ExitOK, c.out.table([]string{"ID", "NAME", "AVAILABLE", "DESCRIPTION"}, rows)
+}
+
+func listTests(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("tests", "-context <context> [-suite <test suite id>]")
+	contextID := contextFlag(fs)
+	suiteID := fs.String("suite", "", "Only list the tests of the test suite")
+	if err := parseFlags(fs, args, "context"); err != nil {
+		return 0, err
+	}
+
+	tests, err := listAll(func(pageToken string) ([]*testsv1.Test, string, error) {
+		res, err := c.tests.ListTests(ctx, connect.NewRequest(&testsv1.ListTestsRequest{
+			Context:       *contextID,
+			TestSuiteId:   *suiteID,
+			NextPageToken: pageToken,
+		}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list tests: %w", err)
+		}
+		return res.Msg.Tests, res.Msg.NextPageToken, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if c.out.json {
+		return ExitOK, c.out.message(&testsv1.ListTestsResponse{Tests: tests})
+	}
+
+	rows := make([][]string, len(tests))
+	for i, t := range tests {
+		rows[i] = []string{t.Id, t.TestSuiteId, t.Name, strconv.FormatBool(t.HasInput)}
+	}
+	return ExitOK, c.out.table([]string{"ID", "SUITE ID", "NAME", "HAS INPUT"}, rows)
+}
+
+func executeTest(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("execute", "-context <context> -test <test id> [-input <file>] [-wait | -follow]")
+	contextID := contextFlag(fs)
+	testID := fs.String("test", "", "ID of the test to execute")
+	inputFile := fs.String("input", "", "JSON file of the test input, or - to read it from stdin")
+	wait := fs.Bool("wait", false, "Wait for the test execution to finish and exit with its outcome")
+	follow := fs.Bool("follow", false, "Stream the test execution events until it finishes and exit with its outcome")
+	if err := parseFlags(fs, args, "context", "test"); err != nil {
+		return 0, err
+	}
+
+	req := &testsv1.ExecuteTestRequest{
+		Context: *contextID,
+		TestId:  *testID,
+	}
+	if *inputFile != "" {
+		input, err := c.readInput(*inputFile)
+		if err != nil {
+			return 0, err
+		}
+		req.Input = input
+	}
+
+	res, err := c.tests.ExecuteTest(ctx, connect.NewRequest(req))
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute test: %w", err)
+	}
+
+	return c.awaitTestExecution(ctx, *contextID, res.Msg.TestExecution, *wait, *follow)
+}
+
+func retryTestExecution(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("retry", "-context <context> -id <test execution id> [-wait | -follow]")
+	contextID := contextFlag(fs)
+	testExecID := fs.String("id", "", "ID of the test execution to retry")
+	wait := fs.Bool("wait", false, "Wait for the test execution to finish and exit with its outcome")
+	follow := fs.Bool("follow", false, "Stream the test execution events until it finishes and exit with its outcome")
+	if err := parseFlags(fs, args, "context", "id"); err != nil {
+		return 0, err
+	}
+
+	res, err := c.tests.RetryTestExecution(ctx, connect.NewRequest(&testsv1.RetryTestExecutionRequest{
+		Context:         *contextID,
+		TestExecutionId: *testExecID,
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to retry test execution: %w", err)
+	}
+
+	return c.awaitTestExecution(ctx, *contextID, res.Msg.TestExecution, *wait, *follow)
+}
+
+func tailTestExecution(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("tail", "-context <context> -id <test execution id>")
+	contextID := contextFlag(fs)
+	testExecID := fs.String("id", "", "ID of the test execution")
+	if err := parseFlags(fs, args, "context", "id"); err != nil {
+		return 0, err
+	}
+
+	finished, err := c.waitForFinish(ctx, *contextID, *testExecID, c.out.event)
+	if err != nil {
+		return 0, err
+	}
+	return exitCode(finished), nil
+}
+
+// executionExport is the exported results of a test execution. The messages
+// are encoded with the protobuf JSON mapping.
+type executionExport struct {
+	TestExecution  json.RawMessage   `json:"testExecution"`
+	Metadata       json.RawMessage   `json:"metadata,omitempty"`
+	Input          json.RawMessage   `json:"input,omitempty"`
+	CaseExecutions []json.RawMessage `json:"caseExecutions"`
+	// Logs are annex.executions.v1.Log messages, which include the
+	// attributes of the logs.
+	Logs []json.RawMessage `json:"logs"`
+}
+
+func exportTestExecution(ctx context.Context, c *cli, args []string) (int, error) {
+	fs := c.newFlagSet("export", "-context <context> -id <test execution id> [-file <file>]")
+	contextID := contextFlag(fs)
+	testExecID := fs.String("id", "", "ID of the test execution")
+	file := fs.String("file", "", "File to write the results to instead of stdout")
+	if err := parseFlags(fs, args, "context", "id"); err != nil {
+		return 0, err
+	}
+
+	testExecRes, err := c.executions.GetTestExecution(ctx, connect.NewRequest(&executionsv1.GetTestExecutionRequest{
+		Context:         *contextID,
+		TestExecutionId: *testExecID,
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get test execution: %w", err)
+	}
+	testExec := testExecRes.Msg.TestExecution.TestExecution
+	metadata := testExecRes.Msg.TestExecution.Metadata
+
+	caseExecs, err := listAll(func(pageToken string) ([]*testsv1.CaseExecution, string, error) {
+		res, err := c.tests.ListCaseExecutions(ctx, connect.NewRequest(&testsv1.ListCaseExecutionsRequest{
+			Context:         *contextID,
+			TestExecutionId: *testExecID,
+			NextPageToken:   pageToken,
+		}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list case executions: %w", err)
+		}
+		return res.Msg.CaseExecutions, res.Msg.NextPageToken, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Logs are listed by the execution service to include their attributes
+	logs, err := listAll(func(pageToken string) ([]*executionsv1.Log, string, error) {
+		res, err := c.executions.SearchTestExecutionLogs(ctx, connect.NewRequest(&executionsv1.SearchTestExecutionLogsRequest{
+			Context:         *contextID,
+			TestExecutionId: *testExecID,
+			NextPageToken:   pageToken,
+		}))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list test execution logs: %w", err)
+		}
+		return res.Msg.Logs, res.Msg.NextPageToken, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	export := executionExport{
+		CaseExecutions: []json.RawMessage{},
+		Logs:           []json.RawMessage{},
+	}
+	if export.TestExecution, err = protojson.Marshal(testExec); err != nil {
+		return 0, err
+	}
+	if metadata != nil {
+		if export.Metadata, err = protojson.Marshal(metadata); err != nil {
+			return 0, err
+		}
+	}
+	if testExecRes.Msg.Input != nil {
+		if export.Input, err = protojson.Marshal(testExecRes.Msg.Input); err != nil {
+			return 0, err
+		}
+	}
+	if export.CaseExecutions, err = marshalAll(caseExecs); err != nil {
+		return 0, err
+	}
+	if export.Logs, err = marshalAll(logs); err != nil {
+		return 0, err
+	}
+
+	out := c.out
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer f.Close()
+		out = &printer{w: f, json: true}
+	}
+	if err = out.value(export); err != nil {
+		return 0, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return exitCode(testExec), nil
+}
+
+// awaitTestExecution prints the test execution, or waits for it to finish
+// first if wait or follow is set. Events are printed as they're received if
+// follow is set.
+func (c *cli) awaitTestExecution(ctx context.Context, contextID string, testExec *testsv1.TestExecution, wait bool, follow bool) (int, error) {
+	switch {
+	case follow:
+		finished, err := c.waitForFinish(ctx, contextID, testExec.Id, c.out.event)
+		if err != nil {
+			return 0, err
+		}
+		return exitCode(finished), nil
+	case wait:
+		finished, err := c.waitForTestExecution(ctx, contextID, testExec.Id)
+		if err != nil {
+			return 0, err
+		}
+		if err = c.out.testExecution(finished); err != nil {
+			return 0, err
+		}
+		return exitCode(finished), nil
+	default:
+		return ExitOK, c.out.testExecution(testExec)
+	}
+}
+
+// waitForTestExecution waits for a test execution to finish and returns the
+// finished test execution.
+func (c *cli) waitForTestExecution(ctx context.Context, contextID string, testExecID string) (*testsv1.TestExecution, error) {
+	stream, err := c.executionEvents.WaitForTestExecutions(ctx, connect.NewRequest(&executionsv1.WaitForTestExecutionsRequest{
+		Context:          contextID,
+		TestExecutionIds: []string{testExecID},
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for test execution: %w", err)
+	}
+	defer stream.Close()
+
+	for stream.Receive() {
+		if finished := stream.Msg().GetTestExecution(); finished.GetId() == testExecID {
+			return finished, nil
+		}
+	}
+	if err = stream.Err(); err != nil {
+		return nil, fmt.Errorf("failed to wait for test execution: %w", err)
+	}
+	return nil, errors.New("wait ended before the test execution finished")
+}
+
+// waitForFinish streams the events of a test execution until it finishes and
+// returns the finished test execution.
+func (c *cli) waitForFinish(
+	ctx context.Context,
+	contextID string,
+	testExecID string,
+	onEvent func(e *eventsv1.Event) error,
+) (*testsv1.TestExecution, error) {
+	stream, err := c.events.StreamTestExecutionEvents(ctx, connect.NewRequest(&eventsv1.StreamTestExecutionEventsRequest{
+		Context:         contextID,
+		TestExecutionId: testExecID,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream test execution events: %w", err)
+	}
+	defer stream.Close()
+
+	for stream.Receive() {
+		e := stream.Msg().Event
+		if err = onEvent(e); err != nil {
+			return nil, err
+		}
+		if e.Type == eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED {
+			if finished := e.GetData().GetTestExecution(); finished != nil {
+				return finished, nil
+			}
+			return nil, errors.New("test execution finished event has no test execution")
+		}
+	}
+	if err = stream.Err(); err != nil {
+		return nil, fmt.Errorf("failed to stream test execution events: %w", err)
+	}
+	return nil, errors.New("event stream ended before the test execution finished")
+}
+
+// readInput reads a JSON test input from the file, or stdin if the file is -.
+func (c *cli) readInput(file string) (*testsv1.Payload, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(c.stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if !json.Valid(data) {
+		return nil, errors.New("input must be valid JSON")
+	}
+	return &testsv1.Payload{
+		Metadata: map[string][]byte{
+			converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON),
+		},
+		Data: data,
+	}, nil
+}
+
+// listAll fetches all pages of a list.
+func listAll[T any](fetch func(pageToken string) ([]T, string, error)) ([]T, error) {
+	var items []T
+	var pageToken string
+	for {
+		page, nextPageToken, err := fetch(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if nextPageToken == "" {
+			return items, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func marshalAll[T proto.Message](msgs []T) ([]json.RawMessage, error) {
+	raw := make([]json.RawMessage, len(msgs))
+	for i, msg := range msgs {
+		b, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		raw[i] = b
+	}
+	return raw, nil
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// printer writes command output as text tables or JSON.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// message writes an indented JSON message.
+func (p *printer) message(m proto.Message) error {
+	b, err := protojson.MarshalOptions{Multiline: true}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(p.w, string(b))
+	return err
+}
+
+// messageLine writes a JSON message on a single line so that streamed
+// messages can be read as newline-delimited JSON.
+func (p *printer) messageLine(m proto.Message) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(p.w, string(b))
+	return err
+}
+
+// value writes an indented JSON value.
+func (p *printer) value(v any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// table writes tab-aligned rows following the header.
+func (p *printer) table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// testExecution writes a test execution.
+func (p *printer) testExecution(testExec *testsv1.TestExecution) error {
+	if p.json {
+		return p.message(testExec)
+	}
+	return p.table(
+		[]string{"ID", "TEST ID", "STATUS", "SCHEDULED", "FINISHED", "ERROR"},
+		[][]string{{
+			testExec.Id,
+			testExec.TestId,
+			testExecutionStatus(testExec),
+			formatTime(testExec.ScheduleTime),
+			formatTime(testExec.FinishTime),
+			testExec.GetError(),
+		}},
+	)
+}
+
+// event writes a streamed event.
+func (p *printer) event(e *eventsv1.Event) error {
+	if p.json {
+		return p.messageLine(e)
+	}
+
+	line := fmt.Sprintf("%s %s", formatTime(e.CreateTime), strings.TrimPrefix(e.Type.String(), "TYPE_"))
+
+	switch data := e.GetData().GetData().(type) {
+	case *eventsv1.Event_Data_TestExecution:
+		if errMsg := data.TestExecution.GetError(); errMsg != "" {
+			line += ": " + errMsg
+		}
+	case *eventsv1.Event_Data_CaseExecution:
+		line += " " + data.CaseExecution.CaseName
+		if errMsg := data.CaseExecution.GetError(); errMsg != "" {
+			line += ": " + errMsg
+		}
+	case *eventsv1.Event_Data_Log:
+		line = fmt.Sprintf("%s [%s] %s", formatTime(data.Log.CreateTime), data.Log.Level, data.Log.Message)
+	}
+
+	_, err := fmt.Fprintln(p.w, line)
+	return err
+}
+
+// testExecutionStatus returns the status of a test execution.
+func testExecutionStatus(testExec *testsv1.TestExecution) string {
+	switch {
+	case testExec.FinishTime != nil && testExec.Error != nil:
+		return "failed"
+	case testExec.FinishTime != nil:
+		return "passed"
+	case testExec.StartTime != nil:
+		return "running"
+	default:
+		return "scheduled"
+	}
+}
+
+// exitCode returns the exit code reflecting the outcome of a test execution.
+func exitCode(testExec *testsv1.TestExecution) int {
+	if testExecutionStatus(testExec) == "failed" {
+		return ExitTestFailed
+	}
+	return ExitOK
+}
+
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
@@ -0,0 +1,60 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+func TestAESCodec(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	codec, err := NewAESCodec("test", key)
+	require.NoError(t, err)
+
+	original := &commonpb.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON)},
+		Data:     []byte(`{"password":"secret"}`),
+	}
+
+	encoded, err := codec.Encode([]*commonpb.Payload{original})
+	require.NoError(t, err)
+	require.Len(t, encoded, 1)
+	assert.Equal(t, MetadataEncodingEncrypted, string(encoded[0].Metadata[converter.MetadataEncoding]))
+	assert.Equal(t, "test", string(encoded[0].Metadata[MetadataEncryptionKeyID]))
+	assert.NotContains(t, string(encoded[0].Data), "secret")
+
+	decoded, err := codec.Decode(encoded)
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	assert.Equal(t, original.Metadata, decoded[0].Metadata)
+	assert.Equal(t, original.Data, decoded[0].Data)
+
+	t.Run("plaintext payloads are unchanged", func(t *testing.T) {
+		decoded, err := codec.Decode([]*commonpb.Payload{original})
+		require.NoError(t, err)
+		assert.Same(t, original, decoded[0])
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		other, err := NewAESCodec("other", key)
+		require.NoError(t, err)
+		_, err = other.Decode(encoded)
+		require.EqualError(t, err, "payload encrypted with unknown key 'test'")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := NewAESCodec("test", bytes.Repeat([]byte{2}, 32))
+		require.NoError(t, err)
+		_, err = other.Decode(encoded)
+		require.ErrorContains(t, err, "failed to decrypt payload")
+	})
+}
+
+func TestNewAESCodec_invalidKey(t *testing.T) {
+	_, err := NewAESCodec("test", []byte("short"))
+	require.ErrorContains(t, err, "invalid encryption key")
+}
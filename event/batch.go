@@ -0,0 +1,58 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+// batchFieldNumber is the wire field number of each event in an encoded
+// batch. An encoded batch has the same wire format as a message with a
+// single repeated event field.
+const batchFieldNumber protowire.Number = 1
+
+// MarshalBatch encodes the events as a single message, preserving their
+// order.
+func MarshalBatch(events []*executionsv1.ExecutionEvent) ([]byte, error) {
+	var out []byte
+	for _, e := range events {
+		b, err := proto.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		out = protowire.AppendTag(out, batchFieldNumber, protowire.BytesType)
+		out = protowire.AppendBytes(out, b)
+	}
+	return out, nil
+}
+
+// UnmarshalBatch decodes events encoded by MarshalBatch in order.
+func UnmarshalBatch(data []byte) ([]*executionsv1.ExecutionEvent, error) {
+	var events []*executionsv1.ExecutionEvent
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		if num != batchFieldNumber || typ != protowire.BytesType {
+			return nil, errors.New("invalid event batch field")
+		}
+		b, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		e := &executionsv1.ExecutionEvent{}
+		if err := proto.Unmarshal(b, e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal batched event: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
@@ -0,0 +1,34 @@
+package event
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/annexsh/annex/internal/conc"
+	"github.com/annexsh/annex/internal/metrics"
+)
+
+// subscriptions holds the open subscriptions for the buffered events gauge.
+var subscriptions = conc.NewMap[*Subscription]()
+
+var droppedEvents = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "subscriber_dropped_events_total",
+	Help:      "Total number of events dropped for subscribers that did not receive events fast enough by overflow policy.",
+}, []string{"policy"})
+
+var _ = metrics.Factory.NewGaugeFunc(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Name:      "subscriber_buffered_events",
+	Help:      "Number of events buffered for open subscriptions that subscribers have not yet received.",
+}, func() float64 {
+	return float64(bufferedEvents())
+})
+
+func bufferedEvents() int {
+	var buffered int
+	subscriptions.Range(func(_ any, s *Subscription) bool {
+		buffered += len(s.ch)
+		return true
+	})
+	return buffered
+}
@@ -1,13 +1,16 @@
 package event
 
-import eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+import executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 
 type Publisher interface {
-	Publish(testExecID string, event *eventsv1.Event) error
+	Publish(testExecID string, event *executionsv1.ExecutionEvent) error
+	// PublishBatch publishes the events as a single message. Subscribers
+	// receive the events individually in order.
+	PublishBatch(testExecID string, events []*executionsv1.ExecutionEvent) error
 }
 
 type Subscriber interface {
-	Subscribe(testExecID string) (<-chan *eventsv1.Event, func(), error)
+	Subscribe(testExecID string) (<-chan *executionsv1.ExecutionEvent, func(), error)
 }
 
 type PubSub interface {
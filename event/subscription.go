@@ -0,0 +1,209 @@
+package event
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+// OverflowPolicy determines how events are handled when a subscriber's buffer
+// is full because the subscriber is not receiving events fast enough.
+//
+// Terminal events are never dropped by OverflowDropOldest or
+// OverflowDropNewest. A buffered non-terminal event is evicted to make room
+// for them instead, so that subscribers always learn that a test execution
+// has finished.
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest discards the oldest buffered event to make room for
+	// the new event.
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowDropNewest discards the new event.
+	OverflowDropNewest
+	// OverflowDisconnect closes the subscription. Subscribers can detect
+	// the disconnect by the closed channel and resubscribe to resume.
+	OverflowDisconnect
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropOldest:
+		return "drop_oldest"
+	case OverflowDropNewest:
+		return "drop_newest"
+	case OverflowDisconnect:
+		return "disconnect"
+	default:
+		return "unknown"
+	}
+}
+
+// SubscriptionStats is a snapshot of the delivery metrics of a subscription.
+type SubscriptionStats struct {
+	Subject      string
+	Delivered    uint64
+	Dropped      uint64
+	Buffered     int
+	Disconnected bool
+}
+
+// Subscription delivers events to a subscriber without blocking the event bus
+// dispatcher. Delivery and closing are serialised so that an event is never
+// sent on a closed channel.
+type Subscription struct {
+	subject      string
+	policy       OverflowPolicy
+	mu           sync.Mutex
+	ch           chan *executionsv1.ExecutionEvent
+	closed       bool
+	delivered    atomic.Uint64
+	dropped      atomic.Uint64
+	disconnected atomic.Bool
+}
+
+// NewSubscription creates a subscription to the subject that buffers up to
+// bufferSize events and applies the overflow policy when the buffer is full.
+// The subscription counts towards the subscriber metrics until it is closed.
+func NewSubscription(subject string, policy OverflowPolicy, bufferSize int) *Subscription {
+	s := &Subscription{
+		subject: subject,
+		policy:  policy,
+		ch:      make(chan *executionsv1.ExecutionEvent, bufferSize),
+	}
+	subscriptions.Set(s, s)
+	return s
+}
+
+// Events returns the channel events are delivered on. The channel is closed
+// when the subscription is closed or disconnected.
+func (s *Subscription) Events() <-chan *executionsv1.ExecutionEvent {
+	return s.ch
+}
+
+// Deliver buffers the event for the subscriber, applying the overflow policy
+// if the buffer is full. It reports whether the delivery disconnected the
+// subscriber.
+func (s *Subscription) Deliver(e *executionsv1.ExecutionEvent) (disconnected bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		if s.disconnected.Load() {
+			s.drop()
+		}
+		return false
+	}
+
+	select {
+	case s.ch <- e:
+		s.delivered.Add(1)
+		return false
+	default:
+	}
+
+	if s.policy == OverflowDisconnect {
+		s.drop()
+		s.disconnected.Store(true)
+		s.closeLocked()
+		return true
+	}
+
+	s.evictLocked(e)
+	return false
+}
+
+// evictLocked makes room for the event in the full buffer by dropping the
+// event selected by the overflow policy.
+func (s *Subscription) evictLocked(e *executionsv1.ExecutionEvent) {
+	buffered := make([]*executionsv1.ExecutionEvent, 0, cap(s.ch)+1)
+drain:
+	for len(buffered) < cap(s.ch) {
+		select {
+		case b := <-s.ch:
+			buffered = append(buffered, b)
+		default:
+			break drain
+		}
+	}
+	buffered = append(buffered, e)
+
+	// The subscriber may have received events while the buffer was drained,
+	// in which case there is room for the event.
+	delivered := true
+	if len(buffered) > cap(s.ch) {
+		victim := overflowVictim(s.policy, buffered)
+		delivered = victim < len(buffered)-1
+		buffered = slices.Delete(buffered, victim, victim+1)
+		s.drop()
+	}
+	if delivered {
+		s.delivered.Add(1)
+	}
+
+	for _, b := range buffered {
+		s.ch <- b
+	}
+}
+
+// overflowVictim returns the index of the event to drop from the buffered
+// events, the last of which is the event being delivered. The last event is
+// dropped if every event is terminal.
+func overflowVictim(policy OverflowPolicy, events []*executionsv1.ExecutionEvent) int {
+	last := len(events) - 1
+	switch policy {
+	case OverflowDropOldest:
+		if i := slices.IndexFunc(events, isDroppable); i >= 0 {
+			return i
+		}
+	case OverflowDropNewest:
+		for i := last; i >= 0; i-- {
+			if isDroppable(events[i]) {
+				return i
+			}
+		}
+	}
+	return last
+}
+
+// isDroppable reports whether the event can be dropped on overflow. Terminal
+// events are kept since subscribers rely on them to stop waiting.
+func isDroppable(e *executionsv1.ExecutionEvent) bool {
+	return e.GetEvent().GetType() != eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED
+}
+
+func (s *Subscription) drop() {
+	s.dropped.Add(1)
+	droppedEvents.WithLabelValues(s.policy.String()).Inc()
+}
+
+// Close closes the subscriber channel. It is safe to call more than once and
+// concurrently with Deliver.
+func (s *Subscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+func (s *Subscription) closeLocked() {
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+		subscriptions.Delete(s)
+	}
+}
+
+// Stats returns a snapshot of the delivery metrics of the subscription.
+func (s *Subscription) Stats() SubscriptionStats {
+	return SubscriptionStats{
+		Subject:      s.subject,
+		Delivered:    s.delivered.Load(),
+		Dropped:      s.dropped.Load(),
+		Buffered:     len(s.ch),
+		Disconnected: s.disconnected.Load(),
+	}
+}
@@ -0,0 +1,127 @@
+package event
+
+import (
+	"strconv"
+	"testing"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+func TestSubscription_Deliver_overflow(t *testing.T) {
+	finished := eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED
+	logged := eventsv1.Event_TYPE_LOG_PUBLISHED
+
+	tests := []struct {
+		name       string
+		policy     OverflowPolicy
+		bufferSize int
+		deliver    []eventsv1.Event_Type
+		wantSeqs   []uint64
+	}{
+		{
+			name:       "drop oldest",
+			policy:     OverflowDropOldest,
+			bufferSize: 2,
+			deliver:    []eventsv1.Event_Type{logged, logged, logged, logged},
+			wantSeqs:   []uint64{3, 4},
+		},
+		{
+			name:       "drop newest",
+			policy:     OverflowDropNewest,
+			bufferSize: 2,
+			deliver:    []eventsv1.Event_Type{logged, logged, logged, logged},
+			wantSeqs:   []uint64{1, 2},
+		},
+		{
+			name:       "drop oldest keeps buffered terminal event",
+			policy:     OverflowDropOldest,
+			bufferSize: 2,
+			deliver:    []eventsv1.Event_Type{finished, logged, logged},
+			wantSeqs:   []uint64{1, 3},
+		},
+		{
+			name:       "drop newest keeps new terminal event",
+			policy:     OverflowDropNewest,
+			bufferSize: 2,
+			deliver:    []eventsv1.Event_Type{logged, logged, finished},
+			wantSeqs:   []uint64{1, 3},
+		},
+		{
+			name:       "drop newest keeps buffered terminal event",
+			policy:     OverflowDropNewest,
+			bufferSize: 1,
+			deliver:    []eventsv1.Event_Type{finished, logged},
+			wantSeqs:   []uint64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dropped := droppedEvents.WithLabelValues(tt.policy.String())
+			droppedBefore := testutil.ToFloat64(dropped)
+
+			sub := NewSubscription("foo", tt.policy, tt.bufferSize)
+			defer sub.Close()
+
+			for i, eventType := range tt.deliver {
+				assert.False(t, sub.Deliver(newTestEvent(uint64(i)+1, eventType)))
+			}
+
+			var gotSeqs []uint64
+			for range len(sub.Events()) {
+				gotSeqs = append(gotSeqs, (<-sub.Events()).Sequence)
+			}
+			assert.Equal(t, tt.wantSeqs, gotSeqs)
+
+			wantDropped := len(tt.deliver) - len(tt.wantSeqs)
+			assert.Equal(t, uint64(wantDropped), sub.Stats().Dropped)
+			assert.Equal(t, float64(wantDropped), testutil.ToFloat64(dropped)-droppedBefore)
+		})
+	}
+}
+
+func TestSubscription_Deliver_disconnect(t *testing.T) {
+	sub := NewSubscription("foo", OverflowDisconnect, 1)
+
+	assert.False(t, sub.Deliver(newTestEvent(1, eventsv1.Event_TYPE_LOG_PUBLISHED)))
+	assert.True(t, sub.Deliver(newTestEvent(2, eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED)))
+	assert.False(t, sub.Deliver(newTestEvent(3, eventsv1.Event_TYPE_LOG_PUBLISHED)))
+
+	got, ok := <-sub.Events()
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), got.Sequence)
+	_, ok = <-sub.Events()
+	assert.False(t, ok, "subscription should be closed")
+
+	stats := sub.Stats()
+	assert.True(t, stats.Disconnected)
+	assert.Equal(t, uint64(2), stats.Dropped)
+
+	sub.Close() // close must be idempotent
+}
+
+func TestSubscription_bufferedEventsMetric(t *testing.T) {
+	sub := NewSubscription("foo", OverflowDropOldest, 5)
+
+	sub.Deliver(newTestEvent(1, eventsv1.Event_TYPE_LOG_PUBLISHED))
+	sub.Deliver(newTestEvent(2, eventsv1.Event_TYPE_LOG_PUBLISHED))
+	assert.Equal(t, 2, bufferedEvents())
+
+	sub.Close()
+	assert.Zero(t, bufferedEvents())
+}
+
+func newTestEvent(seq uint64, eventType eventsv1.Event_Type) *executionsv1.ExecutionEvent {
+	return &executionsv1.ExecutionEvent{
+		Event: &eventsv1.Event{
+			EventId: strconv.FormatUint(seq, 10),
+			Type:    eventType,
+		},
+		Sequence: seq,
+	}
+}
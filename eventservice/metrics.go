@@ -0,0 +1,19 @@
+package eventservice
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/annexsh/annex/internal/metrics"
+)
+
+var activeStreamSubscribers = metrics.Factory.NewGauge(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Name:      "event_stream_subscribers",
+	Help:      "Number of clients currently streaming test execution events.",
+})
+
+var activeTestExecutionWaiters = metrics.Factory.NewGauge(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Name:      "test_execution_waiters",
+	Help:      "Number of clients currently waiting for test executions to finish.",
+})
@@ -0,0 +1,70 @@
+package eventservice
+
+import (
+	"maps"
+	"slices"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+// sequencer orders the sequenced events of a test execution. Events that
+// arrive ahead of the next expected sequence number are buffered until the
+// gap is filled or skipped.
+type sequencer struct {
+	next    uint64
+	pending map[uint64]*executionsv1.ExecutionEvent
+}
+
+// newSequencer creates a sequencer that expects the event following the
+// watermark sequence number next.
+func newSequencer(watermark uint64) *sequencer {
+	return &sequencer{
+		next:    watermark + 1,
+		pending: map[uint64]*executionsv1.ExecutionEvent{},
+	}
+}
+
+// push adds an event to the sequencer and returns the events that are ready
+// to be emitted in order. Events without a sequence number are returned
+// immediately and events that have already been emitted are dropped.
+func (s *sequencer) push(e *executionsv1.ExecutionEvent) []*executionsv1.ExecutionEvent {
+	if e.Sequence == 0 {
+		return []*executionsv1.ExecutionEvent{e}
+	}
+	if e.Sequence < s.next {
+		return nil
+	}
+	s.pending[e.Sequence] = e
+	return s.drain()
+}
+
+// hasGap reports whether events are buffered waiting for a missing sequence
+// number.
+func (s *sequencer) hasGap() bool {
+	return len(s.pending) > 0
+}
+
+// skipGap advances past the missing sequence numbers to the lowest buffered
+// event and returns the events that are then ready to be emitted. The range
+// of skipped sequence numbers is returned as [from, to).
+func (s *sequencer) skipGap() (ready []*executionsv1.ExecutionEvent, from uint64, to uint64) {
+	if len(s.pending) == 0 {
+		return nil, s.next, s.next
+	}
+	from, to = s.next, slices.Min(slices.Collect(maps.Keys(s.pending)))
+	s.next = to
+	return s.drain(), from, to
+}
+
+func (s *sequencer) drain() []*executionsv1.ExecutionEvent {
+	var ready []*executionsv1.ExecutionEvent
+	for {
+		e, ok := s.pending[s.next]
+		if !ok {
+			return ready
+		}
+		delete(s.pending, s.next)
+		ready = append(ready, e)
+		s.next++
+	}
+}
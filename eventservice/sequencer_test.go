@@ -0,0 +1,93 @@
+package eventservice
+
+import (
+	"testing"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestSequencer_push(t *testing.T) {
+	events := genSequencedEvents(1, 5)
+
+	seq := newSequencer(0)
+
+	assert.Equal(t, events[:1], seq.push(events[0]))
+	assert.False(t, seq.hasGap())
+
+	// Out of order events are buffered until the gap is filled
+	assert.Empty(t, seq.push(events[3]))
+	assert.Empty(t, seq.push(events[2]))
+	assert.True(t, seq.hasGap())
+	assert.Equal(t, events[1:4], seq.push(events[1]))
+	assert.False(t, seq.hasGap())
+
+	// Previously emitted events are dropped
+	assert.Empty(t, seq.push(events[2]))
+
+	assert.Equal(t, events[4:], seq.push(events[4]))
+}
+
+func TestSequencer_push_watermark(t *testing.T) {
+	events := genSequencedEvents(1, 4)
+
+	seq := newSequencer(2)
+
+	assert.Empty(t, seq.push(events[0]))
+	assert.Empty(t, seq.push(events[1]))
+	assert.Equal(t, events[2:3], seq.push(events[2]))
+	assert.Equal(t, events[3:], seq.push(events[3]))
+}
+
+func TestSequencer_push_unsequenced(t *testing.T) {
+	seq := newSequencer(0)
+
+	e := &executionsv1.ExecutionEvent{
+		Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, fake.GenTestExec(uuid.New()).Proto()),
+	}
+	assert.Equal(t, []*executionsv1.ExecutionEvent{e}, seq.push(e))
+	assert.False(t, seq.hasGap())
+}
+
+func TestSequencer_skipGap(t *testing.T) {
+	events := genSequencedEvents(1, 6)
+
+	seq := newSequencer(0)
+
+	assert.Equal(t, events[:1], seq.push(events[0]))
+	assert.Empty(t, seq.push(events[3]))
+	assert.Empty(t, seq.push(events[5]))
+
+	ready, from, to := seq.skipGap()
+	assert.Equal(t, events[3:4], ready)
+	assert.Equal(t, uint64(2), from)
+	assert.Equal(t, uint64(4), to)
+	assert.True(t, seq.hasGap())
+
+	ready, from, to = seq.skipGap()
+	assert.Equal(t, events[5:], ready)
+	assert.Equal(t, uint64(5), from)
+	assert.Equal(t, uint64(6), to)
+	assert.False(t, seq.hasGap())
+
+	// Late events of a skipped gap are dropped
+	assert.Empty(t, seq.push(events[1]))
+}
+
+func genSequencedEvents(start uint64, count int) []*executionsv1.ExecutionEvent {
+	execEvents := fake.GenExecutionEvents(test.NewTestExecutionID(), start-1, count)
+	events := make([]*executionsv1.ExecutionEvent, count)
+	for i, e := range execEvents {
+		events[i] = &executionsv1.ExecutionEvent{
+			Event:    e.Event,
+			Sequence: e.Sequence,
+		}
+	}
+	return events
+}
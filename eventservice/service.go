@@ -2,7 +2,10 @@ package eventservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"connectrpc.com/connect"
 	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
@@ -11,21 +14,34 @@ import (
 	mapset "github.com/deckarep/golang-set/v2"
 
 	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/test"
 )
 
 var _ eventsv1connect.EventServiceHandler = (*Service)(nil)
 
 type EventSubscriber interface {
-	Subscribe(testExecID string) (<-chan *eventsv1.Event, func(), error)
+	Subscribe(testExecID string) (<-chan *executionsv1.ExecutionEvent, func(), error)
 }
 
 type ExecutionFetcher interface {
 	GetTestExecution(ctx context.Context, req *connect.Request[testsv1.GetTestExecutionRequest]) (*connect.Response[testsv1.GetTestExecutionResponse], error)
 	ListCaseExecutions(ctx context.Context, req *connect.Request[testsv1.ListCaseExecutionsRequest]) (*connect.Response[testsv1.ListCaseExecutionsResponse], error)
-	ListTestExecutionLogs(ctx context.Context, req *connect.Request[testsv1.ListTestExecutionLogsRequest]) (*connect.Response[testsv1.ListTestExecutionLogsResponse], error)
+	SearchTestExecutionLogs(ctx context.Context, req *connect.Request[executionsv1.SearchTestExecutionLogsRequest]) (*connect.Response[executionsv1.SearchTestExecutionLogsResponse], error)
+	ListTestExecutionEvents(ctx context.Context, req *connect.Request[executionsv1.ListTestExecutionEventsRequest]) (*connect.Response[executionsv1.ListTestExecutionEventsResponse], error)
 }
 
+const defaultGapTimeout = 5 * time.Second
+
+// errSubscriptionClosed is returned when the event subscription is closed
+// before the test execution finished, for example when the subscriber could
+// not keep up with the event bus. Clients can resume by restarting the stream
+// since all recorded events are replayed.
+var errSubscriptionClosed = errors.New("test execution event subscription closed: restart the stream to resume")
+
 type ServiceOption func(s *Service)
 
 func WithLogger(logger log.Logger) ServiceOption {
@@ -34,10 +50,20 @@ func WithLogger(logger log.Logger) ServiceOption {
 	}
 }
 
+// WithGapTimeout sets how long out-of-order events are buffered while
+// waiting for a missing event before the recorded events are listed to fill
+// the gap. Events that still haven't been recorded are then skipped.
+func WithGapTimeout(timeout time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.gapTimeout = timeout
+	}
+}
+
 type Service struct {
 	subscriber  EventSubscriber
 	execFetcher ExecutionFetcher
 	logger      log.Logger
+	gapTimeout  time.Duration
 }
 
 func New(subscriber EventSubscriber, execFetcher ExecutionFetcher, opts ...ServiceOption) *Service {
@@ -45,6 +71,7 @@ func New(subscriber EventSubscriber, execFetcher ExecutionFetcher, opts ...Servi
 		subscriber:  subscriber,
 		execFetcher: execFetcher,
 		logger:      log.DefaultLogger(),
+		gapTimeout:  defaultGapTimeout,
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -57,9 +84,51 @@ func (s *Service) StreamTestExecutionEvents(
 	req *connect.Request[eventsv1.StreamTestExecutionEventsRequest],
 	stream *connect.ServerStream[eventsv1.StreamTestExecutionEventsResponse],
 ) error {
-	testExecID := req.Msg.TestExecutionId
-	contextID := req.Msg.Context
+	return s.stream(ctx, req.Msg.Context, req.Msg.TestExecutionId, nil, func(e *executionsv1.ExecutionEvent) error {
+		return stream.Send(&eventsv1.StreamTestExecutionEventsResponse{
+			Event: e.Event,
+		})
+	})
+}
+
+// executionEventService streams test execution events with log filtering. It
+// is a separate handler because the methods of both event services have the
+// same name.
+type executionEventService struct {
+	*Service
+}
+
+// ExecutionEventHandler returns the handler of the execution event service,
+// which streams test execution events like the event service but accepts a
+// log filter.
+func (s *Service) ExecutionEventHandler() executionsv1connect.ExecutionEventServiceHandler {
+	return executionEventService{Service: s}
+}
+
+func (s executionEventService) StreamTestExecutionEvents(
+	ctx context.Context,
+	req *connect.Request[executionsv1.StreamTestExecutionEventsRequest],
+	stream *connect.ServerStream[executionsv1.StreamTestExecutionEventsResponse],
+) error {
+	return s.stream(ctx, req.Msg.Context, req.Msg.TestExecutionId, req.Msg.LogFilter, func(e *executionsv1.ExecutionEvent) error {
+		return stream.Send(&executionsv1.StreamTestExecutionEventsResponse{
+			Event:    e.Event,
+			Sequence: e.Sequence,
+		})
+	})
+}
 
+// stream sends the existing and live events of a test execution until it
+// finishes. Log events that don't match the log filter are omitted. Stored
+// events are filtered by the test service while live events are filtered
+// after they've been ordered so that filtered events don't leave gaps.
+func (s *Service) stream(
+	ctx context.Context,
+	contextID string,
+	testExecID string,
+	logFilter *executionsv1.LogFilter,
+	sendEvent func(e *executionsv1.ExecutionEvent) error,
+) error {
 	testExecRes, err := s.execFetcher.GetTestExecution(ctx, connect.NewRequest(&testsv1.GetTestExecutionRequest{
 		Context:         contextID,
 		TestExecutionId: testExecID,
@@ -75,48 +144,159 @@ func (s *Service) StreamTestExecutionEvents(
 	}
 	defer unsub()
 
-	// Produce events for cases that existed prior to stream
-	existingEvents, err := s.getExistingEvents(ctx, contextID, testExec)
+	activeStreamSubscribers.Inc()
+	defer activeStreamSubscribers.Dec()
+
+	storedEvents, watermark, err := s.listStoredEvents(ctx, contextID, testExecID, logFilter, nil)
 	if err != nil {
 		return err
 	}
 
+	existingEvents := storedEvents
+	if watermark == 0 {
+		// Produce events for executions that were created before events were recorded
+		unrecorded, err := s.getExistingEvents(ctx, contextID, testExec, logFilter)
+		if err != nil {
+			return err
+		}
+		existingEvents = make([]*executionsv1.ExecutionEvent, len(unrecorded))
+		for i, e := range unrecorded {
+			existingEvents[i] = &executionsv1.ExecutionEvent{Event: e}
+		}
+	}
+
 	seenEventIDs := mapset.NewSet[string]()
 	for _, existing := range existingEvents {
-		if err = stream.Send(&eventsv1.StreamTestExecutionEventsResponse{
-			Event: existing,
-		}); err != nil {
+		if err = sendEvent(existing); err != nil {
 			return err
 		}
-		seenEventIDs.Add(existing.EventId)
-		if existing.Type == eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED {
+		seenEventIDs.Add(existing.Event.EventId)
+		if existing.Event.Type == eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED {
 			return nil
 		}
 	}
 
+	matchesFilter := func(e *executionsv1.ExecutionEvent) bool { return true }
+	if logFilter != nil {
+		filter := test.LogFilterFromProto(logFilter)
+		matchesFilter = func(e *executionsv1.ExecutionEvent) bool {
+			logData, ok := e.Event.GetData().GetData().(*eventsv1.Event_Data_Log)
+			return !ok || filter.Matches(&executionsv1.Log{Log: logData.Log, Attributes: e.LogAttributes})
+		}
+	}
+
+	seq := newSequencer(watermark)
+	gapTimer := time.NewTimer(s.gapTimeout)
+	gapTimer.Stop()
+	defer gapTimer.Stop()
+
+	// send emits the ready events and reports whether the stream is finished.
+	send := func(ready []*executionsv1.ExecutionEvent) (bool, error) {
+		for _, e := range ready {
+			if seenEventIDs.Contains(e.Event.EventId) || !matchesFilter(e) {
+				continue
+			}
+			if err := sendEvent(e); err != nil {
+				return false, err
+			}
+			if e.Event.Type == eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
 	for {
+		var ready []*executionsv1.ExecutionEvent
+		hadGap := seq.hasGap()
+
 		select {
 		case <-ctx.Done():
 			return nil
 		case e, ok := <-sub:
 			if !ok {
-				return nil
+				return connect.NewError(connect.CodeUnavailable, errSubscriptionClosed)
+			}
+			ready = seq.push(e)
+		case <-gapTimer.C:
+			// The missing events may have been recorded but not delivered by
+			// the event bus. Recorded events are listed unfiltered since live
+			// events are filtered once they've been ordered.
+			recorded, _, err := s.listStoredEvents(ctx, contextID, testExecID, nil, ptr.Get(seq.next-1))
+			if err != nil {
+				return err
 			}
-			if !seenEventIDs.Contains(e.EventId) {
-				if err = stream.Send(&eventsv1.StreamTestExecutionEventsResponse{
-					Event: e,
-				}); err != nil {
-					return err
-				}
+			for _, e := range recorded {
+				ready = append(ready, seq.push(e)...)
 			}
-			if e.Type == eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED {
-				return nil
+			if seq.hasGap() {
+				skipped, from, to := seq.skipGap()
+				ready = append(ready, skipped...)
+				s.logger.Warn("skipped missing test execution events",
+					"test_execution_id", testExecID,
+					"from_sequence", from,
+					"to_sequence", to-1,
+				)
+			}
+			hadGap = false
+		}
+
+		if done, err := send(ready); done || err != nil {
+			return err
+		}
+
+		switch {
+		case seq.hasGap() && !hadGap:
+			gapTimer.Reset(s.gapTimeout)
+		case !seq.hasGap() && hadGap:
+			gapTimer.Stop()
+		}
+	}
+}
+
+// listStoredEvents lists the recorded events of the test execution that match
+// the log filter, after the sequence number if set. The returned watermark is
+// the sequence number of the last recorded event, including events omitted by
+// the filter, so that live events are resumed from it. A zero watermark means
+// no events were recorded.
+func (s *Service) listStoredEvents(
+	ctx context.Context,
+	contextID string,
+	testExecID string,
+	logFilter *executionsv1.LogFilter,
+	afterSequence *uint64,
+) ([]*executionsv1.ExecutionEvent, uint64, error) {
+	var events []*executionsv1.ExecutionEvent
+	var nextPageToken string
+
+	for {
+		res, err := s.execFetcher.ListTestExecutionEvents(ctx, connect.NewRequest(&executionsv1.ListTestExecutionEventsRequest{
+			Context:         contextID,
+			TestExecutionId: testExecID,
+			NextPageToken:   nextPageToken,
+			LogFilter:       logFilter,
+			AfterSequence:   afterSequence,
+		}))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list test execution events: %w", err)
+		}
+		events = append(events, res.Msg.Events...)
+		nextPageToken = res.Msg.NextPageToken
+		if nextPageToken != "" {
+			continue
+		}
+
+		watermark := res.Msg.LastSequence
+		if len(events) > 0 {
+			if last := events[len(events)-1].Sequence; last > watermark {
+				watermark = last
 			}
 		}
+		return events, watermark, nil
 	}
 }
 
-func (s *Service) getExistingEvents(ctx context.Context, contextID string, testExec *testsv1.TestExecution) ([]*eventsv1.Event, error) {
+func (s *Service) getExistingEvents(ctx context.Context, contextID string, testExec *testsv1.TestExecution, logFilter *executionsv1.LogFilter) ([]*eventsv1.Event, error) {
 	events := []*eventsv1.Event{event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_SCHEDULED, testExec)}
 
 	if testExec.StartTime == nil {
@@ -133,7 +313,7 @@ func (s *Service) getExistingEvents(ctx context.Context, contextID string, testE
 	}
 	currCaseExecs := caseExecsRes.Msg.CaseExecutions
 
-	testLogEvents, caseLogEventsMap, err := s.getLogEvents(ctx, contextID, testExec.Id)
+	testLogEvents, caseLogEventsMap, err := s.getLogEvents(ctx, contextID, testExec.Id, logFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -192,32 +372,44 @@ func (s *Service) getExistingEvents(ctx context.Context, contextID string, testE
 	return events, nil
 }
 
-func (s *Service) getLogEvents(ctx context.Context, contextID string, testExecID string) ([]*eventsv1.Event, map[int32][]*eventsv1.Event, error) {
-	logsRes, err := s.execFetcher.ListTestExecutionLogs(ctx, connect.NewRequest(&testsv1.ListTestExecutionLogsRequest{
-		Context:         contextID,
-		TestExecutionId: testExecID,
-	}))
-	if err != nil {
-		return nil, nil, err
+// getLogEvents lists all logs of the test execution that match the log filter
+// as log events in the order they were published, split into the events of
+// the test execution and of each case execution.
+func (s *Service) getLogEvents(ctx context.Context, contextID string, testExecID string, logFilter *executionsv1.LogFilter) ([]*eventsv1.Event, map[int32][]*eventsv1.Event, error) {
+	var currLogs []*executionsv1.Log
+	var nextPageToken string
+
+	for {
+		logsRes, err := s.execFetcher.SearchTestExecutionLogs(ctx, connect.NewRequest(&executionsv1.SearchTestExecutionLogsRequest{
+			Context:         contextID,
+			TestExecutionId: testExecID,
+			NextPageToken:   nextPageToken,
+			Filter:          logFilter,
+		}))
+		if err != nil {
+			return nil, nil, err
+		}
+		currLogs = append(currLogs, logsRes.Msg.Logs...)
+		nextPageToken = logsRes.Msg.NextPageToken
+		if nextPageToken == "" {
+			break
+		}
 	}
-	currLogs := logsRes.Msg.Logs
+
+	// Listed in descending order
+	slices.Reverse(currLogs)
 
 	var testLogEvents []*eventsv1.Event
 	caseLogEvents := map[int32][]*eventsv1.Event{}
 
-	for _, log := range currLogs {
+	for _, currLog := range currLogs {
+		log := currLog.Log
 		logEvent := event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, log)
 
 		if log.CaseExecutionId == nil {
 			testLogEvents = append(testLogEvents, logEvent)
 		} else {
-			caseLogEvent := logEvent
-			if got, ok := caseLogEvents[*log.CaseExecutionId]; ok {
-				got = append(got, caseLogEvent)
-				caseLogEvents[*log.CaseExecutionId] = got
-			} else {
-				caseLogEvents[*log.CaseExecutionId] = []*eventsv1.Event{caseLogEvent}
-			}
+			caseLogEvents[*log.CaseExecutionId] = append(caseLogEvents[*log.CaseExecutionId], logEvent)
 		}
 	}
 
@@ -0,0 +1,240 @@
+package eventservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_StreamTestExecutionEvents_unrecordedEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	testExec := fake.GenTestExec(uuid.New())
+	testExec.ScheduleTime = now
+	testExec.StartTime = ptr.Get(now.Add(time.Second))
+	testExec.FinishTime = ptr.Get(now.Add(10 * time.Second))
+
+	caseExec := fake.GenCaseExec(testExec.ID)
+	caseExec.ScheduleTime = now.Add(3 * time.Second)
+	caseExec.StartTime = ptr.Get(now.Add(3 * time.Second))
+	caseExec.FinishTime = ptr.Get(now.Add(6 * time.Second))
+
+	logs := test.LogList{
+		fake.GenTestExecLog(testExec.ID),
+		fake.GenCaseExecLog(testExec.ID, caseExec.ID),
+		fake.GenCaseExecLog(testExec.ID, caseExec.ID),
+		fake.GenTestExecLog(testExec.ID),
+	}
+	for i, l := range logs {
+		l.CreateTime = now.Add(time.Duration(2+i*2) * time.Second)
+	}
+
+	fetcher := newFetcherMock(t, map[string]*testsv1.TestExecution{testExec.ID.String(): testExec.Proto()})
+	fetcher.ListTestExecutionEventsFunc = func(ctx context.Context, req *connect.Request[executionsv1.ListTestExecutionEventsRequest]) (*connect.Response[executionsv1.ListTestExecutionEventsResponse], error) {
+		return connect.NewResponse(&executionsv1.ListTestExecutionEventsResponse{}), nil
+	}
+	fetcher.ListCaseExecutionsFunc = func(ctx context.Context, req *connect.Request[testsv1.ListCaseExecutionsRequest]) (*connect.Response[testsv1.ListCaseExecutionsResponse], error) {
+		return connect.NewResponse(&testsv1.ListCaseExecutionsResponse{
+			CaseExecutions: []*testsv1.CaseExecution{caseExec.Proto()},
+		}), nil
+	}
+	// Logs are listed newest first across two pages
+	fetcher.SearchTestExecutionLogsFunc = func(ctx context.Context, req *connect.Request[executionsv1.SearchTestExecutionLogsRequest]) (*connect.Response[executionsv1.SearchTestExecutionLogsResponse], error) {
+		if req.Msg.NextPageToken == "" {
+			return connect.NewResponse(&executionsv1.SearchTestExecutionLogsResponse{
+				Logs:          test.LogList{logs[3], logs[2]}.ExecutionProto(),
+				NextPageToken: "next",
+			}), nil
+		}
+		return connect.NewResponse(&executionsv1.SearchTestExecutionLogsResponse{
+			Logs: test.LogList{logs[1], logs[0]}.ExecutionProto(),
+		}), nil
+	}
+
+	subs := map[string]chan *executionsv1.ExecutionEvent{testExec.ID.String(): make(chan *executionsv1.ExecutionEvent)}
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), fetcher))
+	defer closer()
+
+	stream, err := cli.StreamTestExecutionEvents(ctx, connect.NewRequest(&executionsv1.StreamTestExecutionEventsRequest{
+		Context:         "foo",
+		TestExecutionId: testExec.ID.String(),
+	}))
+	require.NoError(t, err)
+
+	var got []string
+	for stream.Receive() {
+		e := stream.Msg().Event
+		if l := e.GetData().GetLog(); l != nil {
+			got = append(got, l.Message)
+			continue
+		}
+		got = append(got, e.Type.String())
+	}
+	require.NoError(t, stream.Err())
+
+	want := []string{
+		eventsv1.Event_TYPE_TEST_EXECUTION_SCHEDULED.String(),
+		eventsv1.Event_TYPE_TEST_EXECUTION_STARTED.String(),
+		logs[0].Message,
+		eventsv1.Event_TYPE_CASE_EXECUTION_SCHEDULED.String(),
+		eventsv1.Event_TYPE_CASE_EXECUTION_STARTED.String(),
+		logs[1].Message,
+		logs[2].Message,
+		eventsv1.Event_TYPE_CASE_EXECUTION_FINISHED.String(),
+		logs[3].Message,
+		eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED.String(),
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestService_StreamTestExecutionEvents_sequence(t *testing.T) {
+	ctx := context.Background()
+
+	testExec := fake.GenTestExec(uuid.New())
+	recorded := []*executionsv1.ExecutionEvent{
+		{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, testExec.Proto()), Sequence: 1},
+		{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, testExec.Proto()), Sequence: 2},
+	}
+
+	fetcher := newFetcherMock(t, map[string]*testsv1.TestExecution{testExec.ID.String(): testExec.Proto()})
+	fetcher.ListTestExecutionEventsFunc = func(ctx context.Context, req *connect.Request[executionsv1.ListTestExecutionEventsRequest]) (*connect.Response[executionsv1.ListTestExecutionEventsResponse], error) {
+		return connect.NewResponse(&executionsv1.ListTestExecutionEventsResponse{
+			Events:       recorded,
+			LastSequence: 2,
+		}), nil
+	}
+
+	subs := map[string]chan *executionsv1.ExecutionEvent{testExec.ID.String(): make(chan *executionsv1.ExecutionEvent)}
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), fetcher))
+	defer closer()
+
+	stream, err := cli.StreamTestExecutionEvents(ctx, connect.NewRequest(&executionsv1.StreamTestExecutionEventsRequest{
+		Context:         "foo",
+		TestExecutionId: testExec.ID.String(),
+	}))
+	require.NoError(t, err)
+
+	var got []uint64
+	for stream.Receive() {
+		got = append(got, stream.Msg().Sequence)
+	}
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []uint64{1, 2}, got)
+}
+
+func TestService_StreamTestExecutionEvents_droppedLiveEvent(t *testing.T) {
+	ctx := context.Background()
+
+	testExec := fake.GenTestExec(uuid.New())
+	testLog := fake.GenTestExecLog(testExec.ID)
+	recorded := []*executionsv1.ExecutionEvent{
+		{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, testExec.Proto()), Sequence: 1},
+		{Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, testLog.Proto()), Sequence: 2},
+		{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, testExec.Proto()), Sequence: 3},
+	}
+
+	fetcher := newFetcherMock(t, map[string]*testsv1.TestExecution{testExec.ID.String(): testExec.Proto()})
+	fetcher.ListTestExecutionEventsFunc = func(ctx context.Context, req *connect.Request[executionsv1.ListTestExecutionEventsRequest]) (*connect.Response[executionsv1.ListTestExecutionEventsResponse], error) {
+		if req.Msg.AfterSequence == nil {
+			return connect.NewResponse(&executionsv1.ListTestExecutionEventsResponse{
+				Events:       recorded[:1],
+				LastSequence: 1,
+			}), nil
+		}
+		return connect.NewResponse(&executionsv1.ListTestExecutionEventsResponse{
+			Events:       recorded[*req.Msg.AfterSequence:],
+			LastSequence: 3,
+		}), nil
+	}
+
+	sub := make(chan *executionsv1.ExecutionEvent, 1)
+	// The log event is recorded but dropped by the event bus
+	sub <- recorded[2]
+	subs := map[string]chan *executionsv1.ExecutionEvent{testExec.ID.String(): sub}
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), fetcher, WithGapTimeout(10*time.Millisecond)))
+	defer closer()
+
+	stream, err := cli.StreamTestExecutionEvents(ctx, connect.NewRequest(&executionsv1.StreamTestExecutionEventsRequest{
+		Context:         "foo",
+		TestExecutionId: testExec.ID.String(),
+	}))
+	require.NoError(t, err)
+
+	var got []uint64
+	for stream.Receive() {
+		got = append(got, stream.Msg().Sequence)
+	}
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []uint64{1, 2, 3}, got)
+}
+
+func TestService_StreamTestExecutionEvents_liveLogFilter(t *testing.T) {
+	ctx := context.Background()
+
+	testExec := fake.GenTestExec(uuid.New())
+	dbLog := fake.GenTestExecLog(testExec.ID)
+	otherLog := fake.GenTestExecLog(testExec.ID)
+
+	fetcher := newFetcherMock(t, map[string]*testsv1.TestExecution{testExec.ID.String(): testExec.Proto()})
+	fetcher.ListTestExecutionEventsFunc = func(ctx context.Context, req *connect.Request[executionsv1.ListTestExecutionEventsRequest]) (*connect.Response[executionsv1.ListTestExecutionEventsResponse], error) {
+		return connect.NewResponse(&executionsv1.ListTestExecutionEventsResponse{
+			Events: []*executionsv1.ExecutionEvent{
+				{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, testExec.Proto()), Sequence: 1},
+			},
+			LastSequence: 1,
+		}), nil
+	}
+
+	sub := make(chan *executionsv1.ExecutionEvent)
+	subs := map[string]chan *executionsv1.ExecutionEvent{testExec.ID.String(): sub}
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), fetcher))
+	defer closer()
+
+	go func() {
+		sub <- &executionsv1.ExecutionEvent{
+			Event:         event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, dbLog.Proto()),
+			Sequence:      2,
+			LogAttributes: map[string]string{"component": "db"},
+		}
+		sub <- &executionsv1.ExecutionEvent{
+			Event:    event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, otherLog.Proto()),
+			Sequence: 3,
+		}
+		sub <- &executionsv1.ExecutionEvent{
+			Event:    event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, testExec.Proto()),
+			Sequence: 4,
+		}
+	}()
+
+	stream, err := cli.StreamTestExecutionEvents(ctx, connect.NewRequest(&executionsv1.StreamTestExecutionEventsRequest{
+		Context:         "foo",
+		TestExecutionId: testExec.ID.String(),
+		LogFilter: &executionsv1.LogFilter{
+			Attributes: map[string]string{"component": "db"},
+		},
+	}))
+	require.NoError(t, err)
+
+	var got []uint64
+	for stream.Receive() {
+		got = append(got, stream.Msg().Sequence)
+	}
+	require.NoError(t, stream.Err())
+
+	// Live log events are filtered by the attributes they carry
+	assert.Equal(t, []uint64{1, 2, 4}, got)
+}
@@ -0,0 +1,31 @@
+package eventservice
+
+import (
+	"github.com/cohesivestack/valgo"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/validator"
+)
+
+const (
+	reqValidationBaseErrMsg = "invalid request"
+	maxWaitTestExecutions   = 100
+)
+
+func validateWaitForTestExecutionsRequest(req *executionsv1.WaitForTestExecutionsRequest) error {
+	v := validator.New(validator.WithBaseErrorMessage(reqValidationBaseErrMsg))
+	v.Is(
+		validator.Context(req.Context),
+		valgo.Int(len(req.TestExecutionIds), "test_execution_ids").Between(1, maxWaitTestExecutions,
+			"{{title}} must contain between {{min}} and {{max}} test execution IDs"),
+	)
+	for i, id := range req.TestExecutionIds {
+		v.InRow("test_execution_ids", i, valgo.Is(validator.TestExecID(id)))
+	}
+	if req.Timeout != nil {
+		v.Is(valgo.Any(req.Timeout, "timeout").Passing(func(any) bool {
+			return req.Timeout.IsValid() && req.Timeout.AsDuration() >= 0
+		}, "{{title}} must be a valid non-negative duration"))
+	}
+	return v.ConnectError()
+}
@@ -0,0 +1,196 @@
+package eventservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	mapset "github.com/deckarep/golang-set/v2"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+// finishedTestExecution is a test execution finished event received from a
+// subscription. The test execution is nil if the event has no payload.
+type finishedTestExecution struct {
+	id       string
+	testExec *testsv1.TestExecution
+}
+
+// WaitForTestExecutions sends each test execution as it finishes followed by
+// the aggregate verdict. Finished events are received from the event
+// subscriber so the test executions are only fetched once to resolve those
+// that already finished.
+func (s executionEventService) WaitForTestExecutions(
+	ctx context.Context,
+	req *connect.Request[executionsv1.WaitForTestExecutionsRequest],
+	stream *connect.ServerStream[executionsv1.WaitForTestExecutionsResponse],
+) error {
+	if err := validateWaitForTestExecutionsRequest(req.Msg); err != nil {
+		return err
+	}
+
+	// Stops the subscription forwarders on return
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var testExecIDs []string
+	pending := mapset.NewThreadUnsafeSet[string]()
+	for _, id := range req.Msg.TestExecutionIds {
+		if pending.Add(id) {
+			testExecIDs = append(testExecIDs, id)
+		}
+	}
+
+	finished := make(chan finishedTestExecution)
+	closed := make(chan string)
+
+	// Subscribe before fetching the test executions so that executions that
+	// finish in between aren't missed
+	for _, id := range testExecIDs {
+		sub, unsub, err := s.subscriber.Subscribe(id)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to test execution events: %w", err)
+		}
+		defer unsub()
+		go forwardFinished(ctx, id, sub, finished, closed)
+	}
+
+	activeTestExecutionWaiters.Inc()
+	defer activeTestExecutionWaiters.Dec()
+
+	var passed, failed []string
+
+	getTestExecution := func(id string) (*testsv1.TestExecution, error) {
+		res, err := s.execFetcher.GetTestExecution(ctx, connect.NewRequest(&testsv1.GetTestExecutionRequest{
+			Context:         req.Msg.Context,
+			TestExecutionId: id,
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test execution: %w", err)
+		}
+		return res.Msg.TestExecution, nil
+	}
+
+	// record sends a finished test execution and records its result.
+	record := func(id string, testExec *testsv1.TestExecution) error {
+		if !pending.Contains(id) {
+			return nil
+		}
+		pending.Remove(id)
+		if testExec.GetError() != "" {
+			failed = append(failed, id)
+		} else {
+			passed = append(passed, id)
+		}
+		return stream.Send(&executionsv1.WaitForTestExecutionsResponse{
+			Result: &executionsv1.WaitForTestExecutionsResponse_TestExecution{TestExecution: testExec},
+		})
+	}
+
+	for _, id := range testExecIDs {
+		testExec, err := getTestExecution(id)
+		if err != nil {
+			return err
+		}
+		if testExec.FinishTime != nil {
+			if err = record(id, testExec); err != nil {
+				return err
+			}
+		}
+	}
+
+	var timeout <-chan time.Time
+	if req.Msg.Timeout != nil && req.Msg.Timeout.AsDuration() > 0 {
+		timer := time.NewTimer(req.Msg.Timeout.AsDuration())
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+wait:
+	for pending.Cardinality() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			break wait
+		case f := <-finished:
+			testExec := f.testExec
+			if testExec == nil {
+				// The verdict can't be determined without the test execution
+				var err error
+				if testExec, err = getTestExecution(f.id); err != nil {
+					return err
+				}
+			}
+			if err := record(f.id, testExec); err != nil {
+				return err
+			}
+		case id := <-closed:
+			if pending.Contains(id) {
+				return connect.NewError(connect.CodeUnavailable, errSubscriptionClosed)
+			}
+		}
+	}
+
+	summary := &executionsv1.WaitSummary{
+		PassedTestExecutionIds: passed,
+		FailedTestExecutionIds: failed,
+	}
+	for _, id := range testExecIDs {
+		if pending.Contains(id) {
+			summary.PendingTestExecutionIds = append(summary.PendingTestExecutionIds, id)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		summary.Verdict = executionsv1.Verdict_VERDICT_FAILED
+	case len(summary.PendingTestExecutionIds) > 0:
+		summary.Verdict = executionsv1.Verdict_VERDICT_TIMED_OUT
+	default:
+		summary.Verdict = executionsv1.Verdict_VERDICT_PASSED
+	}
+
+	return stream.Send(&executionsv1.WaitForTestExecutionsResponse{
+		Result: &executionsv1.WaitForTestExecutionsResponse_Summary{Summary: summary},
+	})
+}
+
+// forwardFinished forwards the first test execution finished event of the
+// subscription. The test execution is forwarded as nil if the event has no
+// payload. The test execution ID is forwarded to closed if the subscription
+// is closed first.
+func forwardFinished(
+	ctx context.Context,
+	id string,
+	sub <-chan *executionsv1.ExecutionEvent,
+	finished chan<- finishedTestExecution,
+	closed chan<- string,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub:
+			if !ok {
+				select {
+				case closed <- id:
+				case <-ctx.Done():
+				}
+				return
+			}
+			if e.Event.Type != eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED {
+				continue
+			}
+			select {
+			case finished <- finishedTestExecution{id: id, testExec: e.Event.GetData().GetTestExecution()}:
+			case <-ctx.Done():
+			}
+			return
+		}
+	}
+}
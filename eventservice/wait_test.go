@@ -0,0 +1,255 @@
+package eventservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_WaitForTestExecutions(t *testing.T) {
+	ctx := context.Background()
+
+	alreadyFinished := fake.GenTestExec(uuid.New()).Proto()
+
+	running := fake.GenTestExec(uuid.New())
+	running.FinishTime = nil
+	finishedRunning := running.Proto()
+	finishedRunning.FinishTime = timestamppb.Now()
+	finishedRunning.Error = ptr.Get("boom")
+
+	getExecs := map[string]*testsv1.TestExecution{
+		alreadyFinished.Id:  alreadyFinished,
+		running.ID.String(): running.Proto(),
+	}
+
+	subs := map[string]chan *executionsv1.ExecutionEvent{
+		alreadyFinished.Id:  make(chan *executionsv1.ExecutionEvent, 1),
+		running.ID.String(): make(chan *executionsv1.ExecutionEvent, 2),
+	}
+	subs[running.ID.String()] <- &executionsv1.ExecutionEvent{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, running.Proto())}
+	subs[running.ID.String()] <- &executionsv1.ExecutionEvent{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, finishedRunning)}
+
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), newFetcherMock(t, getExecs)))
+	defer closer()
+
+	stream, err := cli.WaitForTestExecutions(ctx, connect.NewRequest(&executionsv1.WaitForTestExecutionsRequest{
+		Context:          "foo",
+		TestExecutionIds: []string{alreadyFinished.Id, running.ID.String(), alreadyFinished.Id},
+	}))
+	require.NoError(t, err)
+
+	res := receiveAll(t, stream)
+	require.Len(t, res, 3)
+	assert.True(t, proto.Equal(alreadyFinished, res[0].GetTestExecution()))
+	assert.True(t, proto.Equal(finishedRunning, res[1].GetTestExecution()))
+	assert.True(t, proto.Equal(&executionsv1.WaitSummary{
+		Verdict:                executionsv1.Verdict_VERDICT_FAILED,
+		PassedTestExecutionIds: []string{alreadyFinished.Id},
+		FailedTestExecutionIds: []string{running.ID.String()},
+	}, res[2].GetSummary()))
+}
+
+func TestService_WaitForTestExecutions_timeout(t *testing.T) {
+	ctx := context.Background()
+
+	running := fake.GenTestExec(uuid.New())
+	running.FinishTime = nil
+
+	subs := map[string]chan *executionsv1.ExecutionEvent{
+		running.ID.String(): make(chan *executionsv1.ExecutionEvent),
+	}
+	getExecs := map[string]*testsv1.TestExecution{
+		running.ID.String(): running.Proto(),
+	}
+
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), newFetcherMock(t, getExecs)))
+	defer closer()
+
+	stream, err := cli.WaitForTestExecutions(ctx, connect.NewRequest(&executionsv1.WaitForTestExecutionsRequest{
+		Context:          "foo",
+		TestExecutionIds: []string{running.ID.String()},
+		Timeout:          durationpb.New(10 * time.Millisecond),
+	}))
+	require.NoError(t, err)
+
+	res := receiveAll(t, stream)
+	require.Len(t, res, 1)
+	assert.True(t, proto.Equal(&executionsv1.WaitSummary{
+		Verdict:                 executionsv1.Verdict_VERDICT_TIMED_OUT,
+		PendingTestExecutionIds: []string{running.ID.String()},
+	}, res[0].GetSummary()))
+}
+
+func TestService_WaitForTestExecutions_finishedWithoutPayload(t *testing.T) {
+	ctx := context.Background()
+
+	running := fake.GenTestExec(uuid.New())
+	running.FinishTime = nil
+	finished := running.Proto()
+	finished.FinishTime = timestamppb.Now()
+	finished.Error = ptr.Get("boom")
+
+	subs := map[string]chan *executionsv1.ExecutionEvent{
+		running.ID.String(): make(chan *executionsv1.ExecutionEvent, 1),
+	}
+	subs[running.ID.String()] <- &executionsv1.ExecutionEvent{Event: &eventsv1.Event{
+		TestExecutionId: running.ID.String(),
+		Type:            eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED,
+	}}
+
+	// The test execution is running when first fetched and finished when
+	// fetched after the finished event
+	getExecs := []*testsv1.TestExecution{running.Proto(), finished}
+	fetcher := &ExecutionFetcherMock{
+		GetTestExecutionFunc: func(ctx context.Context, req *connect.Request[testsv1.GetTestExecutionRequest]) (*connect.Response[testsv1.GetTestExecutionResponse], error) {
+			assert.Equal(t, running.ID.String(), req.Msg.TestExecutionId)
+			testExec := getExecs[0]
+			getExecs = getExecs[1:]
+			return connect.NewResponse(&testsv1.GetTestExecutionResponse{TestExecution: testExec}), nil
+		},
+	}
+
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), fetcher))
+	defer closer()
+
+	stream, err := cli.WaitForTestExecutions(ctx, connect.NewRequest(&executionsv1.WaitForTestExecutionsRequest{
+		Context:          "foo",
+		TestExecutionIds: []string{running.ID.String()},
+	}))
+	require.NoError(t, err)
+
+	res := receiveAll(t, stream)
+	require.Len(t, res, 2)
+	assert.True(t, proto.Equal(finished, res[0].GetTestExecution()))
+	assert.Equal(t, executionsv1.Verdict_VERDICT_FAILED, res[1].GetSummary().GetVerdict())
+	assert.Len(t, fetcher.GetTestExecutionCalls(), 2)
+}
+
+func TestService_WaitForTestExecutions_subscriptionClosed(t *testing.T) {
+	ctx := context.Background()
+
+	running := fake.GenTestExec(uuid.New())
+	running.FinishTime = nil
+
+	sub := make(chan *executionsv1.ExecutionEvent)
+	close(sub)
+	subs := map[string]chan *executionsv1.ExecutionEvent{
+		running.ID.String(): sub,
+	}
+	getExecs := map[string]*testsv1.TestExecution{
+		running.ID.String(): running.Proto(),
+	}
+
+	cli, closer := newExecutionEventServiceServer(New(newSubscriberMock(subs), newFetcherMock(t, getExecs)))
+	defer closer()
+
+	stream, err := cli.WaitForTestExecutions(ctx, connect.NewRequest(&executionsv1.WaitForTestExecutionsRequest{
+		Context:          "foo",
+		TestExecutionIds: []string{running.ID.String()},
+	}))
+	require.NoError(t, err)
+
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(stream.Err()))
+}
+
+func TestService_WaitForTestExecutions_validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *executionsv1.WaitForTestExecutionsRequest
+	}{
+		{
+			name: "blank context",
+			req: &executionsv1.WaitForTestExecutionsRequest{
+				TestExecutionIds: []string{test.NewTestExecutionID().String()},
+			},
+		},
+		{
+			name: "no test execution ids",
+			req: &executionsv1.WaitForTestExecutionsRequest{
+				Context: "foo",
+			},
+		},
+		{
+			name: "invalid test execution id",
+			req: &executionsv1.WaitForTestExecutionsRequest{
+				Context:          "foo",
+				TestExecutionIds: []string{"bar"},
+			},
+		},
+		{
+			name: "negative timeout",
+			req: &executionsv1.WaitForTestExecutionsRequest{
+				Context:          "foo",
+				TestExecutionIds: []string{test.NewTestExecutionID().String()},
+				Timeout:          durationpb.New(-time.Second),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWaitForTestExecutionsRequest(tt.req)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		})
+	}
+}
+
+func newSubscriberMock(subs map[string]chan *executionsv1.ExecutionEvent) *EventSubscriberMock {
+	return &EventSubscriberMock{
+		SubscribeFunc: func(testExecID string) (<-chan *executionsv1.ExecutionEvent, func(), error) {
+			return subs[testExecID], func() {}, nil
+		},
+	}
+}
+
+func newFetcherMock(t *testing.T, testExecs map[string]*testsv1.TestExecution) *ExecutionFetcherMock {
+	return &ExecutionFetcherMock{
+		GetTestExecutionFunc: func(ctx context.Context, req *connect.Request[testsv1.GetTestExecutionRequest]) (*connect.Response[testsv1.GetTestExecutionResponse], error) {
+			assert.Equal(t, "foo", req.Msg.Context)
+			testExec, ok := testExecs[req.Msg.TestExecutionId]
+			if !ok {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("test execution not found"))
+			}
+			return connect.NewResponse(&testsv1.GetTestExecutionResponse{TestExecution: testExec}), nil
+		},
+	}
+}
+
+func receiveAll(t *testing.T, stream *connect.ServerStreamForClient[executionsv1.WaitForTestExecutionsResponse]) []*executionsv1.WaitForTestExecutionsResponse {
+	var res []*executionsv1.WaitForTestExecutionsResponse
+	for stream.Receive() {
+		res = append(res, stream.Msg())
+	}
+	require.NoError(t, stream.Err())
+	return res
+}
+
+func newExecutionEventServiceServer(s *Service) (executionsv1connect.ExecutionEventServiceClient, func()) {
+	mux := http.NewServeMux()
+	mux.Handle(executionsv1connect.NewExecutionEventServiceHandler(s.ExecutionEventHandler()))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.Start()
+	return executionsv1connect.NewExecutionEventServiceClient(srv.Client(), srv.URL, connect.WithGRPC()), srv.Close
+}
@@ -0,0 +1,307 @@
+// Code generated by protogen. DO NOT EDIT.
+//
+// Source: annex/auth/v1/auth_service.proto
+
+package authv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/annexsh/annex/gen/annex/auth/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AuthServiceName is the fully-qualified name of the AuthService service.
+	AuthServiceName = "annex.auth.v1.AuthService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AuthServiceCreateApiKeyProcedure is the fully-qualified name of the AuthService's CreateApiKey
+	// RPC.
+	AuthServiceCreateApiKeyProcedure = "/annex.auth.v1.AuthService/CreateApiKey"
+	// AuthServiceListApiKeysProcedure is the fully-qualified name of the AuthService's ListApiKeys RPC.
+	AuthServiceListApiKeysProcedure = "/annex.auth.v1.AuthService/ListApiKeys"
+	// AuthServiceRevokeApiKeyProcedure is the fully-qualified name of the AuthService's RevokeApiKey
+	// RPC.
+	AuthServiceRevokeApiKeyProcedure = "/annex.auth.v1.AuthService/RevokeApiKey"
+	// AuthServiceGrantRoleProcedure is the fully-qualified name of the AuthService's GrantRole RPC.
+	AuthServiceGrantRoleProcedure = "/annex.auth.v1.AuthService/GrantRole"
+	// AuthServiceRevokeRoleProcedure is the fully-qualified name of the AuthService's RevokeRole RPC.
+	AuthServiceRevokeRoleProcedure = "/annex.auth.v1.AuthService/RevokeRole"
+	// AuthServiceListRoleBindingsProcedure is the fully-qualified name of the AuthService's
+	// ListRoleBindings RPC.
+	AuthServiceListRoleBindingsProcedure = "/annex.auth.v1.AuthService/ListRoleBindings"
+	// AuthServiceWhoAmIProcedure is the fully-qualified name of the AuthService's WhoAmI RPC.
+	AuthServiceWhoAmIProcedure = "/annex.auth.v1.AuthService/WhoAmI"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	authServiceServiceDescriptor                = v1.File_annex_auth_v1_auth_service_proto.Services().ByName("AuthService")
+	authServiceCreateApiKeyMethodDescriptor     = authServiceServiceDescriptor.Methods().ByName("CreateApiKey")
+	authServiceListApiKeysMethodDescriptor      = authServiceServiceDescriptor.Methods().ByName("ListApiKeys")
+	authServiceRevokeApiKeyMethodDescriptor     = authServiceServiceDescriptor.Methods().ByName("RevokeApiKey")
+	authServiceGrantRoleMethodDescriptor        = authServiceServiceDescriptor.Methods().ByName("GrantRole")
+	authServiceRevokeRoleMethodDescriptor       = authServiceServiceDescriptor.Methods().ByName("RevokeRole")
+	authServiceListRoleBindingsMethodDescriptor = authServiceServiceDescriptor.Methods().ByName("ListRoleBindings")
+	authServiceWhoAmIMethodDescriptor           = authServiceServiceDescriptor.Methods().ByName("WhoAmI")
+)
+
+// AuthServiceClient is a client for the annex.auth.v1.AuthService service.
+type AuthServiceClient interface {
+	// CreateApiKey creates an API key. The key secret is only returned on
+	// creation and cannot be retrieved later.
+	CreateApiKey(context.Context, *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error)
+	// ListApiKeys lists API keys, newest first.
+	ListApiKeys(context.Context, *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error)
+	// RevokeApiKey revokes an API key so it can no longer be used.
+	RevokeApiKey(context.Context, *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error)
+	// GrantRole grants a role to a subject in a context. Granting an existing
+	// role binding has no effect.
+	GrantRole(context.Context, *connect.Request[v1.GrantRoleRequest]) (*connect.Response[v1.GrantRoleResponse], error)
+	// RevokeRole revokes a role from a subject in a context.
+	RevokeRole(context.Context, *connect.Request[v1.RevokeRoleRequest]) (*connect.Response[v1.RevokeRoleResponse], error)
+	// ListRoleBindings lists the role bindings of a context, newest first.
+	ListRoleBindings(context.Context, *connect.Request[v1.ListRoleBindingsRequest]) (*connect.Response[v1.ListRoleBindingsResponse], error)
+	// WhoAmI returns the principal authenticated by the request credentials.
+	WhoAmI(context.Context, *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error)
+}
+
+// NewAuthServiceClient constructs a client for the annex.auth.v1.AuthService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AuthServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &authServiceClient{
+		createApiKey: connect.NewClient[v1.CreateApiKeyRequest, v1.CreateApiKeyResponse](
+			httpClient,
+			baseURL+AuthServiceCreateApiKeyProcedure,
+			connect.WithSchema(authServiceCreateApiKeyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listApiKeys: connect.NewClient[v1.ListApiKeysRequest, v1.ListApiKeysResponse](
+			httpClient,
+			baseURL+AuthServiceListApiKeysProcedure,
+			connect.WithSchema(authServiceListApiKeysMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeApiKey: connect.NewClient[v1.RevokeApiKeyRequest, v1.RevokeApiKeyResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeApiKeyProcedure,
+			connect.WithSchema(authServiceRevokeApiKeyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		grantRole: connect.NewClient[v1.GrantRoleRequest, v1.GrantRoleResponse](
+			httpClient,
+			baseURL+AuthServiceGrantRoleProcedure,
+			connect.WithSchema(authServiceGrantRoleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		revokeRole: connect.NewClient[v1.RevokeRoleRequest, v1.RevokeRoleResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeRoleProcedure,
+			connect.WithSchema(authServiceRevokeRoleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listRoleBindings: connect.NewClient[v1.ListRoleBindingsRequest, v1.ListRoleBindingsResponse](
+			httpClient,
+			baseURL+AuthServiceListRoleBindingsProcedure,
+			connect.WithSchema(authServiceListRoleBindingsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		whoAmI: connect.NewClient[v1.WhoAmIRequest, v1.WhoAmIResponse](
+			httpClient,
+			baseURL+AuthServiceWhoAmIProcedure,
+			connect.WithSchema(authServiceWhoAmIMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// authServiceClient implements AuthServiceClient.
+type authServiceClient struct {
+	createApiKey     *connect.Client[v1.CreateApiKeyRequest, v1.CreateApiKeyResponse]
+	listApiKeys      *connect.Client[v1.ListApiKeysRequest, v1.ListApiKeysResponse]
+	revokeApiKey     *connect.Client[v1.RevokeApiKeyRequest, v1.RevokeApiKeyResponse]
+	grantRole        *connect.Client[v1.GrantRoleRequest, v1.GrantRoleResponse]
+	revokeRole       *connect.Client[v1.RevokeRoleRequest, v1.RevokeRoleResponse]
+	listRoleBindings *connect.Client[v1.ListRoleBindingsRequest, v1.ListRoleBindingsResponse]
+	whoAmI           *connect.Client[v1.WhoAmIRequest, v1.WhoAmIResponse]
+}
+
+// CreateApiKey calls annex.auth.v1.AuthService.CreateApiKey.
+func (c *authServiceClient) CreateApiKey(ctx context.Context, req *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error) {
+	return c.createApiKey.CallUnary(ctx, req)
+}
+
+// ListApiKeys calls annex.auth.v1.AuthService.ListApiKeys.
+func (c *authServiceClient) ListApiKeys(ctx context.Context, req *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error) {
+	return c.listApiKeys.CallUnary(ctx, req)
+}
+
+// RevokeApiKey calls annex.auth.v1.AuthService.RevokeApiKey.
+func (c *authServiceClient) RevokeApiKey(ctx context.Context, req *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error) {
+	return c.revokeApiKey.CallUnary(ctx, req)
+}
+
+// GrantRole calls annex.auth.v1.AuthService.GrantRole.
+func (c *authServiceClient) GrantRole(ctx context.Context, req *connect.Request[v1.GrantRoleRequest]) (*connect.Response[v1.GrantRoleResponse], error) {
+	return c.grantRole.CallUnary(ctx, req)
+}
+
+// RevokeRole calls annex.auth.v1.AuthService.RevokeRole.
+func (c *authServiceClient) RevokeRole(ctx context.Context, req *connect.Request[v1.RevokeRoleRequest]) (*connect.Response[v1.RevokeRoleResponse], error) {
+	return c.revokeRole.CallUnary(ctx, req)
+}
+
+// ListRoleBindings calls annex.auth.v1.AuthService.ListRoleBindings.
+func (c *authServiceClient) ListRoleBindings(ctx context.Context, req *connect.Request[v1.ListRoleBindingsRequest]) (*connect.Response[v1.ListRoleBindingsResponse], error) {
+	return c.listRoleBindings.CallUnary(ctx, req)
+}
+
+// WhoAmI calls annex.auth.v1.AuthService.WhoAmI.
+func (c *authServiceClient) WhoAmI(ctx context.Context, req *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error) {
+	return c.whoAmI.CallUnary(ctx, req)
+}
+
+// AuthServiceHandler is an implementation of the annex.auth.v1.AuthService service.
+type AuthServiceHandler interface {
+	// CreateApiKey creates an API key. The key secret is only returned on
+	// creation and cannot be retrieved later.
+	CreateApiKey(context.Context, *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error)
+	// ListApiKeys lists API keys, newest first.
+	ListApiKeys(context.Context, *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error)
+	// RevokeApiKey revokes an API key so it can no longer be used.
+	RevokeApiKey(context.Context, *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error)
+	// GrantRole grants a role to a subject in a context. Granting an existing
+	// role binding has no effect.
+	GrantRole(context.Context, *connect.Request[v1.GrantRoleRequest]) (*connect.Response[v1.GrantRoleResponse], error)
+	// RevokeRole revokes a role from a subject in a context.
+	RevokeRole(context.Context, *connect.Request[v1.RevokeRoleRequest]) (*connect.Response[v1.RevokeRoleResponse], error)
+	// ListRoleBindings lists the role bindings of a context, newest first.
+	ListRoleBindings(context.Context, *connect.Request[v1.ListRoleBindingsRequest]) (*connect.Response[v1.ListRoleBindingsResponse], error)
+	// WhoAmI returns the principal authenticated by the request credentials.
+	WhoAmI(context.Context, *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error)
+}
+
+// NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	authServiceCreateApiKeyHandler := connect.NewUnaryHandler(
+		AuthServiceCreateApiKeyProcedure,
+		svc.CreateApiKey,
+		connect.WithSchema(authServiceCreateApiKeyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListApiKeysHandler := connect.NewUnaryHandler(
+		AuthServiceListApiKeysProcedure,
+		svc.ListApiKeys,
+		connect.WithSchema(authServiceListApiKeysMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeApiKeyHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeApiKeyProcedure,
+		svc.RevokeApiKey,
+		connect.WithSchema(authServiceRevokeApiKeyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceGrantRoleHandler := connect.NewUnaryHandler(
+		AuthServiceGrantRoleProcedure,
+		svc.GrantRole,
+		connect.WithSchema(authServiceGrantRoleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeRoleHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeRoleProcedure,
+		svc.RevokeRole,
+		connect.WithSchema(authServiceRevokeRoleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListRoleBindingsHandler := connect.NewUnaryHandler(
+		AuthServiceListRoleBindingsProcedure,
+		svc.ListRoleBindings,
+		connect.WithSchema(authServiceListRoleBindingsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceWhoAmIHandler := connect.NewUnaryHandler(
+		AuthServiceWhoAmIProcedure,
+		svc.WhoAmI,
+		connect.WithSchema(authServiceWhoAmIMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/annex.auth.v1.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuthServiceCreateApiKeyProcedure:
+			authServiceCreateApiKeyHandler.ServeHTTP(w, r)
+		case AuthServiceListApiKeysProcedure:
+			authServiceListApiKeysHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeApiKeyProcedure:
+			authServiceRevokeApiKeyHandler.ServeHTTP(w, r)
+		case AuthServiceGrantRoleProcedure:
+			authServiceGrantRoleHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeRoleProcedure:
+			authServiceRevokeRoleHandler.ServeHTTP(w, r)
+		case AuthServiceListRoleBindingsProcedure:
+			authServiceListRoleBindingsHandler.ServeHTTP(w, r)
+		case AuthServiceWhoAmIProcedure:
+			authServiceWhoAmIHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuthServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuthServiceHandler struct{}
+
+func (UnimplementedAuthServiceHandler) CreateApiKey(context.Context, *connect.Request[v1.CreateApiKeyRequest]) (*connect.Response[v1.CreateApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.CreateApiKey is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListApiKeys(context.Context, *connect.Request[v1.ListApiKeysRequest]) (*connect.Response[v1.ListApiKeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.ListApiKeys is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeApiKey(context.Context, *connect.Request[v1.RevokeApiKeyRequest]) (*connect.Response[v1.RevokeApiKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.RevokeApiKey is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) GrantRole(context.Context, *connect.Request[v1.GrantRoleRequest]) (*connect.Response[v1.GrantRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.GrantRole is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeRole(context.Context, *connect.Request[v1.RevokeRoleRequest]) (*connect.Response[v1.RevokeRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.RevokeRole is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListRoleBindings(context.Context, *connect.Request[v1.ListRoleBindingsRequest]) (*connect.Response[v1.ListRoleBindingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.ListRoleBindings is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) WhoAmI(context.Context, *connect.Request[v1.WhoAmIRequest]) (*connect.Response[v1.WhoAmIResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.auth.v1.AuthService.WhoAmI is not implemented"))
+}
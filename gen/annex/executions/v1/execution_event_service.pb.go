@@ -0,0 +1,658 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: annex/executions/v1/execution_event_service.proto
+
+package executionsv1
+
+import (
+	v1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	v11 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Verdict int32
+
+const (
+	Verdict_VERDICT_UNSPECIFIED Verdict = 0
+	// All test executions finished without an error.
+	Verdict_VERDICT_PASSED Verdict = 1
+	// At least one test execution finished with an error.
+	Verdict_VERDICT_FAILED Verdict = 2
+	// The timeout elapsed before all test executions finished and none of the
+	// finished test executions failed.
+	Verdict_VERDICT_TIMED_OUT Verdict = 3
+)
+
+// Enum value maps for Verdict.
+var (
+	Verdict_name = map[int32]string{
+		0: "VERDICT_UNSPECIFIED",
+		1: "VERDICT_PASSED",
+		2: "VERDICT_FAILED",
+		3: "VERDICT_TIMED_OUT",
+	}
+	Verdict_value = map[string]int32{
+		"VERDICT_UNSPECIFIED": 0,
+		"VERDICT_PASSED":      1,
+		"VERDICT_FAILED":      2,
+		"VERDICT_TIMED_OUT":   3,
+	}
+)
+
+func (x Verdict) Enum() *Verdict {
+	p := new(Verdict)
+	*p = x
+	return p
+}
+
+func (x Verdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Verdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_annex_executions_v1_execution_event_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Verdict) Type() protoreflect.EnumType {
+	return &file_annex_executions_v1_execution_event_service_proto_enumTypes[0]
+}
+
+func (x Verdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Verdict.Descriptor instead.
+func (Verdict) EnumDescriptor() ([]byte, []int) {
+	return file_annex_executions_v1_execution_event_service_proto_rawDescGZIP(), []int{0}
+}
+
+type StreamTestExecutionEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context         string     `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	TestExecutionId string     `protobuf:"bytes,2,opt,name=test_execution_id,json=testExecutionId,proto3" json:"test_execution_id,omitempty"`
+	LogFilter       *LogFilter `protobuf:"bytes,3,opt,name=log_filter,json=logFilter,proto3" json:"log_filter,omitempty"`
+}
+
+func (x *StreamTestExecutionEventsRequest) Reset() {
+	*x = StreamTestExecutionEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamTestExecutionEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTestExecutionEventsRequest) ProtoMessage() {}
+
+func (x *StreamTestExecutionEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTestExecutionEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamTestExecutionEventsRequest) Descriptor() ([]byte, []int) {
+	return file_annex_executions_v1_execution_event_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamTestExecutionEventsRequest) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *StreamTestExecutionEventsRequest) GetTestExecutionId() string {
+	if x != nil {
+		return x.TestExecutionId
+	}
+	return ""
+}
+
+func (x *StreamTestExecutionEventsRequest) GetLogFilter() *LogFilter {
+	if x != nil {
+		return x.LogFilter
+	}
+	return nil
+}
+
+type StreamTestExecutionEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *v1.Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// The sequence number of the event. Zero for the events of test executions
+	// created before events were recorded. Clients can use it to detect
+	// duplicates when resuming a stream.
+	Sequence uint64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *StreamTestExecutionEventsResponse) Reset() {
+	*x = StreamTestExecutionEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamTestExecutionEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTestExecutionEventsResponse) ProtoMessage() {}
+
+func (x *StreamTestExecutionEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTestExecutionEventsResponse.ProtoReflect.Descriptor instead.
+func (*StreamTestExecutionEventsResponse) Descriptor() ([]byte, []int) {
+	return file_annex_executions_v1_execution_event_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreamTestExecutionEventsResponse) GetEvent() *v1.Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *StreamTestExecutionEventsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type WaitForTestExecutionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context          string   `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	TestExecutionIds []string `protobuf:"bytes,2,rep,name=test_execution_ids,json=testExecutionIds,proto3" json:"test_execution_ids,omitempty"`
+	// The maximum time to wait. Test executions that haven't finished when it
+	// elapses are reported as pending. Waits until the request deadline if unset.
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *WaitForTestExecutionsRequest) Reset() {
+	*x = WaitForTestExecutionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitForTestExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForTestExecutionsRequest) ProtoMessage() {}
+
+func (x *WaitForTestExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForTestExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*WaitForTestExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_annex_executions_v1_execution_event_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WaitForTestExecutionsRequest) GetContext() string {
+	if x != nil {
+		return x.Context
+	}
+	return ""
+}
+
+func (x *WaitForTestExecutionsRequest) GetTestExecutionIds() []string {
+	if x != nil {
+		return x.TestExecutionIds
+	}
+	return nil
+}
+
+func (x *WaitForTestExecutionsRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type WaitForTestExecutionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*WaitForTestExecutionsResponse_TestExecution
+	//	*WaitForTestExecutionsResponse_Summary
+	Result isWaitForTestExecutionsResponse_Result `protobuf_oneof:"result"`
+}
+
+func (x *WaitForTestExecutionsResponse) Reset() {
+	*x = WaitForTestExecutionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitForTestExecutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForTestExecutionsResponse) ProtoMessage() {}
+
+func (x *WaitForTestExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForTestExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*WaitForTestExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_annex_executions_v1_execution_event_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *WaitForTestExecutionsResponse) GetResult() isWaitForTestExecutionsResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *WaitForTestExecutionsResponse) GetTestExecution() *v11.TestExecution {
+	if x, ok := x.GetResult().(*WaitForTestExecutionsResponse_TestExecution); ok {
+		return x.TestExecution
+	}
+	return nil
+}
+
+func (x *WaitForTestExecutionsResponse) GetSummary() *WaitSummary {
+	if x, ok := x.GetResult().(*WaitForTestExecutionsResponse_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+type isWaitForTestExecutionsResponse_Result interface {
+	isWaitForTestExecutionsResponse_Result()
+}
+
+type WaitForTestExecutionsResponse_TestExecution struct {
+	// A test execution that finished.
+	TestExecution *v11.TestExecution `protobuf:"bytes,1,opt,name=test_execution,json=testExecution,proto3,oneof"`
+}
+
+type WaitForTestExecutionsResponse_Summary struct {
+	// The aggregate result, sent last.
+	Summary *WaitSummary `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*WaitForTestExecutionsResponse_TestExecution) isWaitForTestExecutionsResponse_Result() {}
+
+func (*WaitForTestExecutionsResponse_Summary) isWaitForTestExecutionsResponse_Result() {}
+
+type WaitSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Verdict                 Verdict  `protobuf:"varint,1,opt,name=verdict,proto3,enum=annex.executions.v1.Verdict" json:"verdict,omitempty"`
+	PassedTestExecutionIds  []string `protobuf:"bytes,2,rep,name=passed_test_execution_ids,json=passedTestExecutionIds,proto3" json:"passed_test_execution_ids,omitempty"`
+	FailedTestExecutionIds  []string `protobuf:"bytes,3,rep,name=failed_test_execution_ids,json=failedTestExecutionIds,proto3" json:"failed_test_execution_ids,omitempty"`
+	PendingTestExecutionIds []string `protobuf:"bytes,4,rep,name=pending_test_execution_ids,json=pendingTestExecutionIds,proto3" json:"pending_test_execution_ids,omitempty"`
+}
+
+func (x *WaitSummary) Reset() {
+	*x = WaitSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitSummary) ProtoMessage() {}
+
+func (x *WaitSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_annex_executions_v1_execution_event_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitSummary.ProtoReflect.Descriptor instead.
+func (*WaitSummary) Descriptor() ([]byte, []int) {
+	return file_annex_executions_v1_execution_event_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WaitSummary) GetVerdict() Verdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return Verdict_VERDICT_UNSPECIFIED
+}
+
+func (x *WaitSummary) GetPassedTestExecutionIds() []string {
+	if x != nil {
+		return x.PassedTestExecutionIds
+	}
+	return nil
+}
+
+func (x *WaitSummary) GetFailedTestExecutionIds() []string {
+	if x != nil {
+		return x.FailedTestExecutionIds
+	}
+	return nil
+}
+
+func (x *WaitSummary) GetPendingTestExecutionIds() []string {
+	if x != nil {
+		return x.PendingTestExecutionIds
+	}
+	return nil
+}
+
+var File_annex_executions_v1_execution_event_service_proto protoreflect.FileDescriptor
+
+var file_annex_executions_v1_execution_event_service_proto_rawDesc = []byte{
+	0x0a, 0x31, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x13, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2f, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x19, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2f,
+	0x76, 0x31, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa7, 0x01,
+	0x0a, 0x20, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x2a, 0x0a, 0x11,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61,
+	0x6e, 0x6e, 0x65, 0x78, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x6c, 0x6f,
+	0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x6d, 0x0a, 0x21, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x6e,
+	0x6e, 0x65, 0x78, 0x2e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x1c, 0x57, 0x61, 0x69, 0x74, 0x46,
+	0x6f, 0x72, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x74,
+	0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12,
+	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x22, 0xaf, 0x01, 0x0a, 0x1d, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72,
+	0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x0d, 0x74, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c,
+	0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x08, 0x0a, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xf8, 0x01, 0x0a, 0x0b, 0x57, 0x61, 0x69, 0x74, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2e,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x72, 0x64, 0x69, 0x63, 0x74, 0x52, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x12, 0x39,
+	0x0a, 0x19, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x16, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x39, 0x0a, 0x19, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x73, 0x12, 0x3b, 0x0a, 0x1a, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x17, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x73, 0x2a, 0x61, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x12, 0x17, 0x0a, 0x13,
+	0x56, 0x45, 0x52, 0x44, 0x49, 0x43, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x56, 0x45, 0x52, 0x44, 0x49, 0x43, 0x54,
+	0x5f, 0x50, 0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x56, 0x45, 0x52,
+	0x44, 0x49, 0x43, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x15, 0x0a,
+	0x11, 0x56, 0x45, 0x52, 0x44, 0x49, 0x43, 0x54, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x44, 0x5f, 0x4f,
+	0x55, 0x54, 0x10, 0x03, 0x32, 0xa9, 0x02, 0x0a, 0x15, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x8c,
+	0x01, 0x0a, 0x19, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x35, 0x2e, 0x61,
+	0x6e, 0x6e, 0x65, 0x78, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2e, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x80, 0x01,
+	0x0a, 0x15, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x2e, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2e,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x69, 0x74, 0x46, 0x6f, 0x72, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x61, 0x6e, 0x6e,
+	0x65, 0x78, 0x2e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x6e, 0x6e, 0x65, 0x78, 0x73, 0x68, 0x2f, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x61, 0x6e, 0x6e, 0x65, 0x78, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_annex_executions_v1_execution_event_service_proto_rawDescOnce sync.Once
+	file_annex_executions_v1_execution_event_service_proto_rawDescData = file_annex_executions_v1_execution_event_service_proto_rawDesc
+)
+
+func file_annex_executions_v1_execution_event_service_proto_rawDescGZIP() []byte {
+	file_annex_executions_v1_execution_event_service_proto_rawDescOnce.Do(func() {
+		file_annex_executions_v1_execution_event_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_annex_executions_v1_execution_event_service_proto_rawDescData)
+	})
+	return file_annex_executions_v1_execution_event_service_proto_rawDescData
+}
+
+var file_annex_executions_v1_execution_event_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_annex_executions_v1_execution_event_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_annex_executions_v1_execution_event_service_proto_goTypes = []any{
+	(Verdict)(0),                              // 0: annex.executions.v1.Verdict
+	(*StreamTestExecutionEventsRequest)(nil),  // 1: annex.executions.v1.StreamTestExecutionEventsRequest
+	(*StreamTestExecutionEventsResponse)(nil), // 2: annex.executions.v1.StreamTestExecutionEventsResponse
+	(*WaitForTestExecutionsRequest)(nil),      // 3: annex.executions.v1.WaitForTestExecutionsRequest
+	(*WaitForTestExecutionsResponse)(nil),     // 4: annex.executions.v1.WaitForTestExecutionsResponse
+	(*WaitSummary)(nil),                       // 5: annex.executions.v1.WaitSummary
+	(*LogFilter)(nil),                         // 6: annex.executions.v1.LogFilter
+	(*v1.Event)(nil),                          // 7: annex.events.v1.Event
+	(*durationpb.Duration)(nil),               // 8: google.protobuf.Duration
+	(*v11.TestExecution)(nil),                 // 9: annex.tests.v1.TestExecution
+}
+var file_annex_executions_v1_execution_event_service_proto_depIdxs = []int32{
+	6, // 0: annex.executions.v1.StreamTestExecutionEventsRequest.log_filter:type_name -> annex.executions.v1.LogFilter
+	7, // 1: annex.executions.v1.StreamTestExecutionEventsResponse.event:type_name -> annex.events.v1.Event
+	8, // 2: annex.executions.v1.WaitForTestExecutionsRequest.timeout:type_name -> google.protobuf.Duration
+	9, // 3: annex.executions.v1.WaitForTestExecutionsResponse.test_execution:type_name -> annex.tests.v1.TestExecution
+	5, // 4: annex.executions.v1.WaitForTestExecutionsResponse.summary:type_name -> annex.executions.v1.WaitSummary
+	0, // 5: annex.executions.v1.WaitSummary.verdict:type_name -> annex.executions.v1.Verdict
+	1, // 6: annex.executions.v1.ExecutionEventService.StreamTestExecutionEvents:input_type -> annex.executions.v1.StreamTestExecutionEventsRequest
+	3, // 7: annex.executions.v1.ExecutionEventService.WaitForTestExecutions:input_type -> annex.executions.v1.WaitForTestExecutionsRequest
+	2, // 8: annex.executions.v1.ExecutionEventService.StreamTestExecutionEvents:output_type -> annex.executions.v1.StreamTestExecutionEventsResponse
+	4, // 9: annex.executions.v1.ExecutionEventService.WaitForTestExecutions:output_type -> annex.executions.v1.WaitForTestExecutionsResponse
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_annex_executions_v1_execution_event_service_proto_init() }
+func file_annex_executions_v1_execution_event_service_proto_init() {
+	if File_annex_executions_v1_execution_event_service_proto != nil {
+		return
+	}
+	file_annex_executions_v1_execution_service_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_annex_executions_v1_execution_event_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamTestExecutionEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_annex_executions_v1_execution_event_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamTestExecutionEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_annex_executions_v1_execution_event_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*WaitForTestExecutionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_annex_executions_v1_execution_event_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*WaitForTestExecutionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_annex_executions_v1_execution_event_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*WaitSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_annex_executions_v1_execution_event_service_proto_msgTypes[3].OneofWrappers = []any{
+		(*WaitForTestExecutionsResponse_TestExecution)(nil),
+		(*WaitForTestExecutionsResponse_Summary)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_annex_executions_v1_execution_event_service_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_annex_executions_v1_execution_event_service_proto_goTypes,
+		DependencyIndexes: file_annex_executions_v1_execution_event_service_proto_depIdxs,
+		EnumInfos:         file_annex_executions_v1_execution_event_service_proto_enumTypes,
+		MessageInfos:      file_annex_executions_v1_execution_event_service_proto_msgTypes,
+	}.Build()
+	File_annex_executions_v1_execution_event_service_proto = out.File
+	file_annex_executions_v1_execution_event_service_proto_rawDesc = nil
+	file_annex_executions_v1_execution_event_service_proto_goTypes = nil
+	file_annex_executions_v1_execution_event_service_proto_depIdxs = nil
+}
@@ -0,0 +1,158 @@
+// Code generated by protogen. DO NOT EDIT.
+//
+// Source: annex/executions/v1/execution_event_service.proto
+
+package executionsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ExecutionEventServiceName is the fully-qualified name of the ExecutionEventService service.
+	ExecutionEventServiceName = "annex.executions.v1.ExecutionEventService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ExecutionEventServiceStreamTestExecutionEventsProcedure is the fully-qualified name of the
+	// ExecutionEventService's StreamTestExecutionEvents RPC.
+	ExecutionEventServiceStreamTestExecutionEventsProcedure = "/annex.executions.v1.ExecutionEventService/StreamTestExecutionEvents"
+	// ExecutionEventServiceWaitForTestExecutionsProcedure is the fully-qualified name of the
+	// ExecutionEventService's WaitForTestExecutions RPC.
+	ExecutionEventServiceWaitForTestExecutionsProcedure = "/annex.executions.v1.ExecutionEventService/WaitForTestExecutions"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	executionEventServiceServiceDescriptor                         = v1.File_annex_executions_v1_execution_event_service_proto.Services().ByName("ExecutionEventService")
+	executionEventServiceStreamTestExecutionEventsMethodDescriptor = executionEventServiceServiceDescriptor.Methods().ByName("StreamTestExecutionEvents")
+	executionEventServiceWaitForTestExecutionsMethodDescriptor     = executionEventServiceServiceDescriptor.Methods().ByName("WaitForTestExecutions")
+)
+
+// ExecutionEventServiceClient is a client for the annex.executions.v1.ExecutionEventService
+// service.
+type ExecutionEventServiceClient interface {
+	// StreamTestExecutionEvents streams the events of a test execution until
+	// it finishes, starting with the recorded events. Log events of logs that
+	// don't match the log filter are excluded.
+	StreamTestExecutionEvents(context.Context, *connect.Request[v1.StreamTestExecutionEventsRequest]) (*connect.ServerStreamForClient[v1.StreamTestExecutionEventsResponse], error)
+	// WaitForTestExecutions waits for test executions to finish. Each test
+	// execution is sent as it finishes, followed by a summary with the aggregate
+	// verdict once all test executions finished or the timeout elapsed.
+	WaitForTestExecutions(context.Context, *connect.Request[v1.WaitForTestExecutionsRequest]) (*connect.ServerStreamForClient[v1.WaitForTestExecutionsResponse], error)
+}
+
+// NewExecutionEventServiceClient constructs a client for the
+// annex.executions.v1.ExecutionEventService service. By default, it uses the Connect protocol with
+// the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To use
+// the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewExecutionEventServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ExecutionEventServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &executionEventServiceClient{
+		streamTestExecutionEvents: connect.NewClient[v1.StreamTestExecutionEventsRequest, v1.StreamTestExecutionEventsResponse](
+			httpClient,
+			baseURL+ExecutionEventServiceStreamTestExecutionEventsProcedure,
+			connect.WithSchema(executionEventServiceStreamTestExecutionEventsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		waitForTestExecutions: connect.NewClient[v1.WaitForTestExecutionsRequest, v1.WaitForTestExecutionsResponse](
+			httpClient,
+			baseURL+ExecutionEventServiceWaitForTestExecutionsProcedure,
+			connect.WithSchema(executionEventServiceWaitForTestExecutionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// executionEventServiceClient implements ExecutionEventServiceClient.
+type executionEventServiceClient struct {
+	streamTestExecutionEvents *connect.Client[v1.StreamTestExecutionEventsRequest, v1.StreamTestExecutionEventsResponse]
+	waitForTestExecutions     *connect.Client[v1.WaitForTestExecutionsRequest, v1.WaitForTestExecutionsResponse]
+}
+
+// StreamTestExecutionEvents calls
+// annex.executions.v1.ExecutionEventService.StreamTestExecutionEvents.
+func (c *executionEventServiceClient) StreamTestExecutionEvents(ctx context.Context, req *connect.Request[v1.StreamTestExecutionEventsRequest]) (*connect.ServerStreamForClient[v1.StreamTestExecutionEventsResponse], error) {
+	return c.streamTestExecutionEvents.CallServerStream(ctx, req)
+}
+
+// WaitForTestExecutions calls annex.executions.v1.ExecutionEventService.WaitForTestExecutions.
+func (c *executionEventServiceClient) WaitForTestExecutions(ctx context.Context, req *connect.Request[v1.WaitForTestExecutionsRequest]) (*connect.ServerStreamForClient[v1.WaitForTestExecutionsResponse], error) {
+	return c.waitForTestExecutions.CallServerStream(ctx, req)
+}
+
+// ExecutionEventServiceHandler is an implementation of the
+// annex.executions.v1.ExecutionEventService service.
+type ExecutionEventServiceHandler interface {
+	// StreamTestExecutionEvents streams the events of a test execution until
+	// it finishes, starting with the recorded events. Log events of logs that
+	// don't match the log filter are excluded.
+	StreamTestExecutionEvents(context.Context, *connect.Request[v1.StreamTestExecutionEventsRequest], *connect.ServerStream[v1.StreamTestExecutionEventsResponse]) error
+	// WaitForTestExecutions waits for test executions to finish. Each test
+	// execution is sent as it finishes, followed by a summary with the aggregate
+	// verdict once all test executions finished or the timeout elapsed.
+	WaitForTestExecutions(context.Context, *connect.Request[v1.WaitForTestExecutionsRequest], *connect.ServerStream[v1.WaitForTestExecutionsResponse]) error
+}
+
+// NewExecutionEventServiceHandler builds an HTTP handler from the service implementation. It
+// returns the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewExecutionEventServiceHandler(svc ExecutionEventServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	executionEventServiceStreamTestExecutionEventsHandler := connect.NewServerStreamHandler(
+		ExecutionEventServiceStreamTestExecutionEventsProcedure,
+		svc.StreamTestExecutionEvents,
+		connect.WithSchema(executionEventServiceStreamTestExecutionEventsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionEventServiceWaitForTestExecutionsHandler := connect.NewServerStreamHandler(
+		ExecutionEventServiceWaitForTestExecutionsProcedure,
+		svc.WaitForTestExecutions,
+		connect.WithSchema(executionEventServiceWaitForTestExecutionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/annex.executions.v1.ExecutionEventService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ExecutionEventServiceStreamTestExecutionEventsProcedure:
+			executionEventServiceStreamTestExecutionEventsHandler.ServeHTTP(w, r)
+		case ExecutionEventServiceWaitForTestExecutionsProcedure:
+			executionEventServiceWaitForTestExecutionsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedExecutionEventServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedExecutionEventServiceHandler struct{}
+
+func (UnimplementedExecutionEventServiceHandler) StreamTestExecutionEvents(context.Context, *connect.Request[v1.StreamTestExecutionEventsRequest], *connect.ServerStream[v1.StreamTestExecutionEventsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionEventService.StreamTestExecutionEvents is not implemented"))
+}
+
+func (UnimplementedExecutionEventServiceHandler) WaitForTestExecutions(context.Context, *connect.Request[v1.WaitForTestExecutionsRequest], *connect.ServerStream[v1.WaitForTestExecutionsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionEventService.WaitForTestExecutions is not implemented"))
+}
@@ -0,0 +1,403 @@
+// Code generated by protogen. DO NOT EDIT.
+//
+// Source: annex/executions/v1/execution_service.proto
+
+package executionsv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ExecutionServiceName is the fully-qualified name of the ExecutionService service.
+	ExecutionServiceName = "annex.executions.v1.ExecutionService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ExecutionServiceExecuteTestProcedure is the fully-qualified name of the ExecutionService's
+	// ExecuteTest RPC.
+	ExecutionServiceExecuteTestProcedure = "/annex.executions.v1.ExecutionService/ExecuteTest"
+	// ExecutionServiceGetTestExecutionProcedure is the fully-qualified name of the ExecutionService's
+	// GetTestExecution RPC.
+	ExecutionServiceGetTestExecutionProcedure = "/annex.executions.v1.ExecutionService/GetTestExecution"
+	// ExecutionServiceSearchTestExecutionsProcedure is the fully-qualified name of the
+	// ExecutionService's SearchTestExecutions RPC.
+	ExecutionServiceSearchTestExecutionsProcedure = "/annex.executions.v1.ExecutionService/SearchTestExecutions"
+	// ExecutionServiceListTestExecutionEventsProcedure is the fully-qualified name of the
+	// ExecutionService's ListTestExecutionEvents RPC.
+	ExecutionServiceListTestExecutionEventsProcedure = "/annex.executions.v1.ExecutionService/ListTestExecutionEvents"
+	// ExecutionServiceSearchTestExecutionLogsProcedure is the fully-qualified name of the
+	// ExecutionService's SearchTestExecutionLogs RPC.
+	ExecutionServiceSearchTestExecutionLogsProcedure = "/annex.executions.v1.ExecutionService/SearchTestExecutionLogs"
+	// ExecutionServicePublishLogsProcedure is the fully-qualified name of the ExecutionService's
+	// PublishLogs RPC.
+	ExecutionServicePublishLogsProcedure = "/annex.executions.v1.ExecutionService/PublishLogs"
+	// ExecutionServiceUploadArtifactProcedure is the fully-qualified name of the ExecutionService's
+	// UploadArtifact RPC.
+	ExecutionServiceUploadArtifactProcedure = "/annex.executions.v1.ExecutionService/UploadArtifact"
+	// ExecutionServiceListTestExecutionArtifactsProcedure is the fully-qualified name of the
+	// ExecutionService's ListTestExecutionArtifacts RPC.
+	ExecutionServiceListTestExecutionArtifactsProcedure = "/annex.executions.v1.ExecutionService/ListTestExecutionArtifacts"
+	// ExecutionServiceDownloadArtifactProcedure is the fully-qualified name of the ExecutionService's
+	// DownloadArtifact RPC.
+	ExecutionServiceDownloadArtifactProcedure = "/annex.executions.v1.ExecutionService/DownloadArtifact"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	executionServiceServiceDescriptor                          = v1.File_annex_executions_v1_execution_service_proto.Services().ByName("ExecutionService")
+	executionServiceExecuteTestMethodDescriptor                = executionServiceServiceDescriptor.Methods().ByName("ExecuteTest")
+	executionServiceGetTestExecutionMethodDescriptor           = executionServiceServiceDescriptor.Methods().ByName("GetTestExecution")
+	executionServiceSearchTestExecutionsMethodDescriptor       = executionServiceServiceDescriptor.Methods().ByName("SearchTestExecutions")
+	executionServiceListTestExecutionEventsMethodDescriptor    = executionServiceServiceDescriptor.Methods().ByName("ListTestExecutionEvents")
+	executionServiceSearchTestExecutionLogsMethodDescriptor    = executionServiceServiceDescriptor.Methods().ByName("SearchTestExecutionLogs")
+	executionServicePublishLogsMethodDescriptor                = executionServiceServiceDescriptor.Methods().ByName("PublishLogs")
+	executionServiceUploadArtifactMethodDescriptor             = executionServiceServiceDescriptor.Methods().ByName("UploadArtifact")
+	executionServiceListTestExecutionArtifactsMethodDescriptor = executionServiceServiceDescriptor.Methods().ByName("ListTestExecutionArtifacts")
+	executionServiceDownloadArtifactMethodDescriptor           = executionServiceServiceDescriptor.Methods().ByName("DownloadArtifact")
+)
+
+// ExecutionServiceClient is a client for the annex.executions.v1.ExecutionService service.
+type ExecutionServiceClient interface {
+	// ExecuteTest executes a test like annex.tests.v1.TestService.ExecuteTest
+	// with execution metadata.
+	ExecuteTest(context.Context, *connect.Request[v1.ExecuteTestRequest]) (*connect.Response[v1.ExecuteTestResponse], error)
+	// GetTestExecution gets a test execution like
+	// annex.tests.v1.TestService.GetTestExecution with its execution metadata.
+	GetTestExecution(context.Context, *connect.Request[v1.GetTestExecutionRequest]) (*connect.Response[v1.GetTestExecutionResponse], error)
+	// SearchTestExecutions lists the test executions of a context that match a
+	// filter in descending order.
+	SearchTestExecutions(context.Context, *connect.Request[v1.SearchTestExecutionsRequest]) (*connect.Response[v1.SearchTestExecutionsResponse], error)
+	// ListTestExecutionEvents lists the recorded events of a test execution in
+	// ascending sequence order.
+	ListTestExecutionEvents(context.Context, *connect.Request[v1.ListTestExecutionEventsRequest]) (*connect.Response[v1.ListTestExecutionEventsResponse], error)
+	// SearchTestExecutionLogs lists the logs of a test execution that match a
+	// filter in descending order.
+	SearchTestExecutionLogs(context.Context, *connect.Request[v1.SearchTestExecutionLogsRequest]) (*connect.Response[v1.SearchTestExecutionLogsResponse], error)
+	// PublishLogs publishes the logs of a test execution in batches. Each
+	// request is stored in a single transaction and published as a single
+	// event batch. Logs are stored and published in the order they are sent.
+	// All requests of a stream must belong to the same test execution. Each
+	// request counts towards the publish log rate limit. Requests are
+	// committed as they're received, so the logs of earlier requests remain
+	// published if the stream fails and retrying the stream is not idempotent.
+	// Their IDs are returned as a PublishLogsResponse error detail.
+	PublishLogs(context.Context) *connect.ClientStreamForClient[v1.PublishLogsRequest, v1.PublishLogsResponse]
+	// UploadArtifact uploads a file produced by a test or case execution, such
+	// as a screenshot or response dump. The first request must contain the
+	// artifact metadata followed by requests containing the content in chunks.
+	UploadArtifact(context.Context) *connect.ClientStreamForClient[v1.UploadArtifactRequest, v1.UploadArtifactResponse]
+	// ListTestExecutionArtifacts lists the artifacts of a test execution in
+	// the order they were uploaded.
+	ListTestExecutionArtifacts(context.Context, *connect.Request[v1.ListTestExecutionArtifactsRequest]) (*connect.Response[v1.ListTestExecutionArtifactsResponse], error)
+	// DownloadArtifact downloads an artifact. The first response contains the
+	// artifact metadata followed by responses containing the content in chunks.
+	DownloadArtifact(context.Context, *connect.Request[v1.DownloadArtifactRequest]) (*connect.ServerStreamForClient[v1.DownloadArtifactResponse], error)
+}
+
+// NewExecutionServiceClient constructs a client for the annex.executions.v1.ExecutionService
+// service. By default, it uses the Connect protocol with the binary Protobuf Codec, asks for
+// gzipped responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply
+// the connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewExecutionServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ExecutionServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &executionServiceClient{
+		executeTest: connect.NewClient[v1.ExecuteTestRequest, v1.ExecuteTestResponse](
+			httpClient,
+			baseURL+ExecutionServiceExecuteTestProcedure,
+			connect.WithSchema(executionServiceExecuteTestMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getTestExecution: connect.NewClient[v1.GetTestExecutionRequest, v1.GetTestExecutionResponse](
+			httpClient,
+			baseURL+ExecutionServiceGetTestExecutionProcedure,
+			connect.WithSchema(executionServiceGetTestExecutionMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		searchTestExecutions: connect.NewClient[v1.SearchTestExecutionsRequest, v1.SearchTestExecutionsResponse](
+			httpClient,
+			baseURL+ExecutionServiceSearchTestExecutionsProcedure,
+			connect.WithSchema(executionServiceSearchTestExecutionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listTestExecutionEvents: connect.NewClient[v1.ListTestExecutionEventsRequest, v1.ListTestExecutionEventsResponse](
+			httpClient,
+			baseURL+ExecutionServiceListTestExecutionEventsProcedure,
+			connect.WithSchema(executionServiceListTestExecutionEventsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		searchTestExecutionLogs: connect.NewClient[v1.SearchTestExecutionLogsRequest, v1.SearchTestExecutionLogsResponse](
+			httpClient,
+			baseURL+ExecutionServiceSearchTestExecutionLogsProcedure,
+			connect.WithSchema(executionServiceSearchTestExecutionLogsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		publishLogs: connect.NewClient[v1.PublishLogsRequest, v1.PublishLogsResponse](
+			httpClient,
+			baseURL+ExecutionServicePublishLogsProcedure,
+			connect.WithSchema(executionServicePublishLogsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		uploadArtifact: connect.NewClient[v1.UploadArtifactRequest, v1.UploadArtifactResponse](
+			httpClient,
+			baseURL+ExecutionServiceUploadArtifactProcedure,
+			connect.WithSchema(executionServiceUploadArtifactMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listTestExecutionArtifacts: connect.NewClient[v1.ListTestExecutionArtifactsRequest, v1.ListTestExecutionArtifactsResponse](
+			httpClient,
+			baseURL+ExecutionServiceListTestExecutionArtifactsProcedure,
+			connect.WithSchema(executionServiceListTestExecutionArtifactsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		downloadArtifact: connect.NewClient[v1.DownloadArtifactRequest, v1.DownloadArtifactResponse](
+			httpClient,
+			baseURL+ExecutionServiceDownloadArtifactProcedure,
+			connect.WithSchema(executionServiceDownloadArtifactMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// executionServiceClient implements ExecutionServiceClient.
+type executionServiceClient struct {
+	executeTest                *connect.Client[v1.ExecuteTestRequest, v1.ExecuteTestResponse]
+	getTestExecution           *connect.Client[v1.GetTestExecutionRequest, v1.GetTestExecutionResponse]
+	searchTestExecutions       *connect.Client[v1.SearchTestExecutionsRequest, v1.SearchTestExecutionsResponse]
+	listTestExecutionEvents    *connect.Client[v1.ListTestExecutionEventsRequest, v1.ListTestExecutionEventsResponse]
+	searchTestExecutionLogs    *connect.Client[v1.SearchTestExecutionLogsRequest, v1.SearchTestExecutionLogsResponse]
+	publishLogs                *connect.Client[v1.PublishLogsRequest, v1.PublishLogsResponse]
+	uploadArtifact             *connect.Client[v1.UploadArtifactRequest, v1.UploadArtifactResponse]
+	listTestExecutionArtifacts *connect.Client[v1.ListTestExecutionArtifactsRequest, v1.ListTestExecutionArtifactsResponse]
+	downloadArtifact           *connect.Client[v1.DownloadArtifactRequest, v1.DownloadArtifactResponse]
+}
+
+// ExecuteTest calls annex.executions.v1.ExecutionService.ExecuteTest.
+func (c *executionServiceClient) ExecuteTest(ctx context.Context, req *connect.Request[v1.ExecuteTestRequest]) (*connect.Response[v1.ExecuteTestResponse], error) {
+	return c.executeTest.CallUnary(ctx, req)
+}
+
+// GetTestExecution calls annex.executions.v1.ExecutionService.GetTestExecution.
+func (c *executionServiceClient) GetTestExecution(ctx context.Context, req *connect.Request[v1.GetTestExecutionRequest]) (*connect.Response[v1.GetTestExecutionResponse], error) {
+	return c.getTestExecution.CallUnary(ctx, req)
+}
+
+// SearchTestExecutions calls annex.executions.v1.ExecutionService.SearchTestExecutions.
+func (c *executionServiceClient) SearchTestExecutions(ctx context.Context, req *connect.Request[v1.SearchTestExecutionsRequest]) (*connect.Response[v1.SearchTestExecutionsResponse], error) {
+	return c.searchTestExecutions.CallUnary(ctx, req)
+}
+
+// ListTestExecutionEvents calls annex.executions.v1.ExecutionService.ListTestExecutionEvents.
+func (c *executionServiceClient) ListTestExecutionEvents(ctx context.Context, req *connect.Request[v1.ListTestExecutionEventsRequest]) (*connect.Response[v1.ListTestExecutionEventsResponse], error) {
+	return c.listTestExecutionEvents.CallUnary(ctx, req)
+}
+
+// SearchTestExecutionLogs calls annex.executions.v1.ExecutionService.SearchTestExecutionLogs.
+func (c *executionServiceClient) SearchTestExecutionLogs(ctx context.Context, req *connect.Request[v1.SearchTestExecutionLogsRequest]) (*connect.Response[v1.SearchTestExecutionLogsResponse], error) {
+	return c.searchTestExecutionLogs.CallUnary(ctx, req)
+}
+
+// PublishLogs calls annex.executions.v1.ExecutionService.PublishLogs.
+func (c *executionServiceClient) PublishLogs(ctx context.Context) *connect.ClientStreamForClient[v1.PublishLogsRequest, v1.PublishLogsResponse] {
+	return c.publishLogs.CallClientStream(ctx)
+}
+
+// UploadArtifact calls annex.executions.v1.ExecutionService.UploadArtifact.
+func (c *executionServiceClient) UploadArtifact(ctx context.Context) *connect.ClientStreamForClient[v1.UploadArtifactRequest, v1.UploadArtifactResponse] {
+	return c.uploadArtifact.CallClientStream(ctx)
+}
+
+// ListTestExecutionArtifacts calls annex.executions.v1.ExecutionService.ListTestExecutionArtifacts.
+func (c *executionServiceClient) ListTestExecutionArtifacts(ctx context.Context, req *connect.Request[v1.ListTestExecutionArtifactsRequest]) (*connect.Response[v1.ListTestExecutionArtifactsResponse], error) {
+	return c.listTestExecutionArtifacts.CallUnary(ctx, req)
+}
+
+// DownloadArtifact calls annex.executions.v1.ExecutionService.DownloadArtifact.
+func (c *executionServiceClient) DownloadArtifact(ctx context.Context, req *connect.Request[v1.DownloadArtifactRequest]) (*connect.ServerStreamForClient[v1.DownloadArtifactResponse], error) {
+	return c.downloadArtifact.CallServerStream(ctx, req)
+}
+
+// ExecutionServiceHandler is an implementation of the annex.executions.v1.ExecutionService service.
+type ExecutionServiceHandler interface {
+	// ExecuteTest executes a test like annex.tests.v1.TestService.ExecuteTest
+	// with execution metadata.
+	ExecuteTest(context.Context, *connect.Request[v1.ExecuteTestRequest]) (*connect.Response[v1.ExecuteTestResponse], error)
+	// GetTestExecution gets a test execution like
+	// annex.tests.v1.TestService.GetTestExecution with its execution metadata.
+	GetTestExecution(context.Context, *connect.Request[v1.GetTestExecutionRequest]) (*connect.Response[v1.GetTestExecutionResponse], error)
+	// SearchTestExecutions lists the test executions of a context that match a
+	// filter in descending order.
+	SearchTestExecutions(context.Context, *connect.Request[v1.SearchTestExecutionsRequest]) (*connect.Response[v1.SearchTestExecutionsResponse], error)
+	// ListTestExecutionEvents lists the recorded events of a test execution in
+	// ascending sequence order.
+	ListTestExecutionEvents(context.Context, *connect.Request[v1.ListTestExecutionEventsRequest]) (*connect.Response[v1.ListTestExecutionEventsResponse], error)
+	// SearchTestExecutionLogs lists the logs of a test execution that match a
+	// filter in descending order.
+	SearchTestExecutionLogs(context.Context, *connect.Request[v1.SearchTestExecutionLogsRequest]) (*connect.Response[v1.SearchTestExecutionLogsResponse], error)
+	// PublishLogs publishes the logs of a test execution in batches. Each
+	// request is stored in a single transaction and published as a single
+	// event batch. Logs are stored and published in the order they are sent.
+	// All requests of a stream must belong to the same test execution. Each
+	// request counts towards the publish log rate limit. Requests are
+	// committed as they're received, so the logs of earlier requests remain
+	// published if the stream fails and retrying the stream is not idempotent.
+	// Their IDs are returned as a PublishLogsResponse error detail.
+	PublishLogs(context.Context, *connect.ClientStream[v1.PublishLogsRequest]) (*connect.Response[v1.PublishLogsResponse], error)
+	// UploadArtifact uploads a file produced by a test or case execution, such
+	// as a screenshot or response dump. The first request must contain the
+	// artifact metadata followed by requests containing the content in chunks.
+	UploadArtifact(context.Context, *connect.ClientStream[v1.UploadArtifactRequest]) (*connect.Response[v1.UploadArtifactResponse], error)
+	// ListTestExecutionArtifacts lists the artifacts of a test execution in
+	// the order they were uploaded.
+	ListTestExecutionArtifacts(context.Context, *connect.Request[v1.ListTestExecutionArtifactsRequest]) (*connect.Response[v1.ListTestExecutionArtifactsResponse], error)
+	// DownloadArtifact downloads an artifact. The first response contains the
+	// artifact metadata followed by responses containing the content in chunks.
+	DownloadArtifact(context.Context, *connect.Request[v1.DownloadArtifactRequest], *connect.ServerStream[v1.DownloadArtifactResponse]) error
+}
+
+// NewExecutionServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewExecutionServiceHandler(svc ExecutionServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	executionServiceExecuteTestHandler := connect.NewUnaryHandler(
+		ExecutionServiceExecuteTestProcedure,
+		svc.ExecuteTest,
+		connect.WithSchema(executionServiceExecuteTestMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceGetTestExecutionHandler := connect.NewUnaryHandler(
+		ExecutionServiceGetTestExecutionProcedure,
+		svc.GetTestExecution,
+		connect.WithSchema(executionServiceGetTestExecutionMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceSearchTestExecutionsHandler := connect.NewUnaryHandler(
+		ExecutionServiceSearchTestExecutionsProcedure,
+		svc.SearchTestExecutions,
+		connect.WithSchema(executionServiceSearchTestExecutionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceListTestExecutionEventsHandler := connect.NewUnaryHandler(
+		ExecutionServiceListTestExecutionEventsProcedure,
+		svc.ListTestExecutionEvents,
+		connect.WithSchema(executionServiceListTestExecutionEventsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceSearchTestExecutionLogsHandler := connect.NewUnaryHandler(
+		ExecutionServiceSearchTestExecutionLogsProcedure,
+		svc.SearchTestExecutionLogs,
+		connect.WithSchema(executionServiceSearchTestExecutionLogsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServicePublishLogsHandler := connect.NewClientStreamHandler(
+		ExecutionServicePublishLogsProcedure,
+		svc.PublishLogs,
+		connect.WithSchema(executionServicePublishLogsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceUploadArtifactHandler := connect.NewClientStreamHandler(
+		ExecutionServiceUploadArtifactProcedure,
+		svc.UploadArtifact,
+		connect.WithSchema(executionServiceUploadArtifactMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceListTestExecutionArtifactsHandler := connect.NewUnaryHandler(
+		ExecutionServiceListTestExecutionArtifactsProcedure,
+		svc.ListTestExecutionArtifacts,
+		connect.WithSchema(executionServiceListTestExecutionArtifactsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	executionServiceDownloadArtifactHandler := connect.NewServerStreamHandler(
+		ExecutionServiceDownloadArtifactProcedure,
+		svc.DownloadArtifact,
+		connect.WithSchema(executionServiceDownloadArtifactMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/annex.executions.v1.ExecutionService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ExecutionServiceExecuteTestProcedure:
+			executionServiceExecuteTestHandler.ServeHTTP(w, r)
+		case ExecutionServiceGetTestExecutionProcedure:
+			executionServiceGetTestExecutionHandler.ServeHTTP(w, r)
+		case ExecutionServiceSearchTestExecutionsProcedure:
+			executionServiceSearchTestExecutionsHandler.ServeHTTP(w, r)
+		case ExecutionServiceListTestExecutionEventsProcedure:
+			executionServiceListTestExecutionEventsHandler.ServeHTTP(w, r)
+		case ExecutionServiceSearchTestExecutionLogsProcedure:
+			executionServiceSearchTestExecutionLogsHandler.ServeHTTP(w, r)
+		case ExecutionServicePublishLogsProcedure:
+			executionServicePublishLogsHandler.ServeHTTP(w, r)
+		case ExecutionServiceUploadArtifactProcedure:
+			executionServiceUploadArtifactHandler.ServeHTTP(w, r)
+		case ExecutionServiceListTestExecutionArtifactsProcedure:
+			executionServiceListTestExecutionArtifactsHandler.ServeHTTP(w, r)
+		case ExecutionServiceDownloadArtifactProcedure:
+			executionServiceDownloadArtifactHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedExecutionServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedExecutionServiceHandler struct{}
+
+func (UnimplementedExecutionServiceHandler) ExecuteTest(context.Context, *connect.Request[v1.ExecuteTestRequest]) (*connect.Response[v1.ExecuteTestResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.ExecuteTest is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) GetTestExecution(context.Context, *connect.Request[v1.GetTestExecutionRequest]) (*connect.Response[v1.GetTestExecutionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.GetTestExecution is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) SearchTestExecutions(context.Context, *connect.Request[v1.SearchTestExecutionsRequest]) (*connect.Response[v1.SearchTestExecutionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.SearchTestExecutions is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) ListTestExecutionEvents(context.Context, *connect.Request[v1.ListTestExecutionEventsRequest]) (*connect.Response[v1.ListTestExecutionEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.ListTestExecutionEvents is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) SearchTestExecutionLogs(context.Context, *connect.Request[v1.SearchTestExecutionLogsRequest]) (*connect.Response[v1.SearchTestExecutionLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.SearchTestExecutionLogs is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) PublishLogs(context.Context, *connect.ClientStream[v1.PublishLogsRequest]) (*connect.Response[v1.PublishLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.PublishLogs is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) UploadArtifact(context.Context, *connect.ClientStream[v1.UploadArtifactRequest]) (*connect.Response[v1.UploadArtifactResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.UploadArtifact is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) ListTestExecutionArtifacts(context.Context, *connect.Request[v1.ListTestExecutionArtifactsRequest]) (*connect.Response[v1.ListTestExecutionArtifactsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.ListTestExecutionArtifacts is not implemented"))
+}
+
+func (UnimplementedExecutionServiceHandler) DownloadArtifact(context.Context, *connect.Request[v1.DownloadArtifactRequest], *connect.ServerStream[v1.DownloadArtifactResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("annex.executions.v1.ExecutionService.DownloadArtifact is not implemented"))
+}
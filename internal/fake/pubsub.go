@@ -3,8 +3,7 @@ package fake
 import (
 	"sync"
 
-	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
-
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/test"
 )
 
@@ -18,25 +17,34 @@ func NewPubSub() *PubSub {
 	}
 }
 
-func (p *PubSub) Publish(testExecID string, event *eventsv1.Event) error {
+func (p *PubSub) Publish(testExecID string, event *executionsv1.ExecutionEvent) error {
 	v, ok := p.topics.Load(testExecID)
 	if !ok {
-		events := make(chan *eventsv1.Event, 10)
+		events := make(chan *executionsv1.ExecutionEvent, 10)
 		events <- event
 		p.topics.Store(testExecID, events)
 		return nil
 	}
 
-	events := v.(chan *eventsv1.Event)
+	events := v.(chan *executionsv1.ExecutionEvent)
 	events <- event
 	return nil
 }
 
-func (p *PubSub) Subscribe(testExecID string) (<-chan *eventsv1.Event, func(), error) {
+func (p *PubSub) PublishBatch(testExecID string, events []*executionsv1.ExecutionEvent) error {
+	for _, e := range events {
+		if err := p.Publish(testExecID, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PubSub) Subscribe(testExecID string) (<-chan *executionsv1.ExecutionEvent, func(), error) {
 	v, ok := p.topics.Load(testExecID)
 	if !ok {
 		return nil, nil, test.ErrorTestExecutionNotFound
 	}
 	unsubNop := func() {}
-	return v.(chan *eventsv1.Event), unsubNop, nil
+	return v.(chan *executionsv1.ExecutionEvent), unsubNop, nil
 }
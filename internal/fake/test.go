@@ -5,8 +5,10 @@ import (
 	"sync"
 	"time"
 
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	"go.temporal.io/sdk/converter"
 
+	"github.com/annexsh/annex/event"
 	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -88,6 +90,17 @@ func GenScheduledTestExec(testID uuid.V7) *test.ScheduledTestExecution {
 	}
 }
 
+func GenExecutionMetadata() test.ExecutionMetadata {
+	return test.ExecutionMetadata{
+		TriggeredBy: ptr.Get("ci"),
+		GitSHA:      ptr.Get("4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+		GitBranch:   ptr.Get("main"),
+		CIJobURL:    ptr.Get("https://ci.example.com/jobs/" + uuid.NewString()),
+		Environment: ptr.Get("staging"),
+		Labels:      map[string]string{"team": "payments"},
+	}
+}
+
 func GenStartedTestExec(testExecID test.TestExecutionID) *test.StartedTestExecution {
 	return &test.StartedTestExecution{
 		ID:        testExecID,
@@ -204,6 +217,45 @@ func genExecLog(testExecID test.TestExecutionID, caseExecID *test.CaseExecutionI
 	}
 }
 
+func GenTestExecArtifact(testExecID test.TestExecutionID) *test.Artifact {
+	return genExecArtifact(testExecID, nil)
+}
+
+func GenCaseExecArtifact(testExecID test.TestExecutionID, caseExecID test.CaseExecutionID) *test.Artifact {
+	return genExecArtifact(testExecID, &caseExecID)
+}
+
+func genExecArtifact(testExecID test.TestExecutionID, caseExecID *test.CaseExecutionID) *test.Artifact {
+	return &test.Artifact{
+		ID:              uuid.New(),
+		TestExecutionID: testExecID,
+		CaseExecutionID: caseExecID,
+		Name:            uuid.NewString() + ".txt",
+		ContentType:     "text/plain",
+		Size:            rand.Int63n(1 << 20),
+		Checksum:        "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		CreateTime:      time.Now().UTC().Truncate(time.Microsecond),
+	}
+}
+
+// GenExecutionEvents generates log events with consecutive sequence numbers
+// starting after the offset sequence.
+func GenExecutionEvents(testExecID test.TestExecutionID, offsetSequence uint64, count int) test.ExecutionEventList {
+	events := make(test.ExecutionEventList, count)
+	for i := range count {
+		log := genExecLog(testExecID, nil)
+		seq := offsetSequence + uint64(i) + 1
+		e := event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, log.Proto())
+		events[i] = &test.ExecutionEvent{
+			TestExecutionID: testExecID,
+			Sequence:        seq,
+			LogID:           &log.ID,
+			Event:           e,
+		}
+	}
+	return events
+}
+
 var (
 	mu         = new(sync.RWMutex)
 	currCaseID = test.CaseExecutionID(0)
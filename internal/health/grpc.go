@@ -2,33 +2,46 @@ package health
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/annexsh/annex-proto/go/gen/annex/events/v1/eventsv1connect"
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
 	"github.com/cenkalti/backoff/v4"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/nats-io/nats.go"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
 	"golang.org/x/sync/errgroup"
 	grpchealth "google.golang.org/grpc/health"
 	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/annexsh/annex/gen/annex/audit/v1/auditv1connect"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
 	"github.com/annexsh/annex/log"
 )
 
 const (
-	dependencyCheckInterval = 5 * time.Minute
-	dependencyCheckTimeout  = 10 * time.Second
-	maxConc                 = 5
+	defaultCheckInterval   = 5 * time.Second
+	dependencyCheckTimeout = 10 * time.Second
+	maxConc                = 5
 )
 
 const (
-	ServiceNameTest     = "rpc.testservice.v1.TestService"
-	ServiceNameEvent    = "rpc.eventservice.v1.EventService"
-	ServiceNameWorkflow = "temporal.api.workflowservice.v1.WorkflowService"
-	ServiceNamePostgres = "postgres"
+	ServiceNameAudit     = auditv1connect.AuditServiceName
+	ServiceNameAuth      = authv1connect.AuthServiceName
+	ServiceNameTest      = testsv1connect.TestServiceName
+	ServiceNameExecution = executionsv1connect.ExecutionServiceName
+	ServiceNameEvent     = eventsv1connect.EventServiceName
+	ServiceNameWorkflow  = "temporal.api.workflowservice.v1.WorkflowService"
+	ServiceNamePostgres  = "postgres"
+	ServiceNameSQLite    = "sqlite"
+	ServiceNameNats      = "nats"
 )
 
 type ErrorLogger interface {
@@ -36,23 +49,27 @@ type ErrorLogger interface {
 }
 
 type Config struct {
-	ServiceNames []string            // service names to register
-	Dependencies []DependencyChecker // dependencies to register (optional)
-	Logger       ErrorLogger         // logger for health check errors (optional)
+	ServiceNames  []string            // service names to register
+	Dependencies  []DependencyChecker // dependencies to register (optional)
+	CheckInterval time.Duration       // interval between dependency checks (optional)
+	Logger        ErrorLogger         // logger for health check errors (optional)
 }
 
 type GRPCService struct {
 	*grpchealth.Server
-	deps   []DependencyChecker
-	stop   chan struct{}
-	logger ErrorLogger
+	deps     []DependencyChecker
+	interval time.Duration
+	stop     chan struct{}
+	logger   ErrorLogger
 }
 
 // NewGRPCService returns a new health service. All registered dependency checks are
 // performed on function invocation and an error is returned if any fail.
-// Thereafter, all checks are scheduled at 5 minute intervals as background
-// processes, where any failing check sets the overall health service status to
-// 'not serving'.
+// Thereafter, all checks are scheduled at the check interval (5 seconds by
+// default) as background processes, where any failing check sets the overall
+// health service status to 'not serving'. The default interval is kept short
+// so that readiness probes reflect an unavailable dependency within seconds,
+// so dependency checks should be cheap.
 func NewGRPCService(ctx context.Context, config Config) (*GRPCService, error) {
 	if len(config.ServiceNames) == 0 {
 		return nil, errors.New("at least one service name required")
@@ -60,10 +77,14 @@ func NewGRPCService(ctx context.Context, config Config) (*GRPCService, error) {
 	if config.Logger == nil {
 		config.Logger = log.NewNopLogger()
 	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = defaultCheckInterval
+	}
 
 	s := &GRPCService{
-		Server: grpchealth.NewServer(),
-		logger: config.Logger,
+		Server:   grpchealth.NewServer(),
+		interval: config.CheckInterval,
+		logger:   config.Logger,
 	}
 
 	errg, depCtx := errgroup.WithContext(ctx)
@@ -129,22 +150,24 @@ func (s *GRPCService) Check(ctx context.Context, req *grpchealthv1.HealthCheckRe
 }
 
 func (s *GRPCService) scheduleDependencyChecks(ctx context.Context) {
-	ticker := time.NewTicker(dependencyCheckInterval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
 	sem := make(chan struct{}, maxConc)
 
 	for {
 		select {
 		case <-ticker.C:
 			wg := new(sync.WaitGroup)
-			sem <- struct{}{}
 
 			for _, dep := range s.deps {
 				wg.Add(1)
+				sem <- struct{}{}
 				currDep := dep
 				go func() {
+					defer func() { <-sem }()
+					defer wg.Done()
 					checkCtx, cancel := context.WithTimeout(ctx, dependencyCheckTimeout)
 					defer cancel()
-					defer wg.Done()
 					if err := currDep.Check(checkCtx); err != nil {
 						s.Server.SetServingStatus(currDep.ServiceName(), grpchealthv1.HealthCheckResponse_NOT_SERVING)
 						s.logger.Error("unhealthy dependency", "service.name", currDep.ServiceName(), "error", err)
@@ -152,9 +175,9 @@ func (s *GRPCService) scheduleDependencyChecks(ctx context.Context) {
 					}
 					s.Server.SetServingStatus(currDep.ServiceName(), grpchealthv1.HealthCheckResponse_SERVING)
 				}()
-
-				wg.Wait()
 			}
+
+			wg.Wait()
 		case <-s.stop:
 			return
 		case <-ctx.Done():
@@ -188,21 +211,15 @@ func (d *dependency) ServiceName() string {
 	return d.serviceName
 }
 
+// WithTemporal checks that the Temporal frontend is serving and the namespace
+// exists. Both are cheap calls since the check runs at the check interval.
 func WithTemporal(c client.Client, namespace string) DependencyChecker {
 	return NewDependencyChecker(ServiceNameWorkflow, func(ctx context.Context) error {
 		if _, err := c.CheckHealth(ctx, &client.CheckHealthRequest{}); err != nil {
 			return err
 		}
 
-		in := &workflowservice.DescribeNamespaceRequest{
-			Namespace: namespace,
-		}
-		wfService := c.WorkflowService()
-		if _, err := wfService.DescribeNamespace(ctx, in); err != nil {
-			return err
-		}
-
-		_, err := wfService.ListOpenWorkflowExecutions(ctx, &workflowservice.ListOpenWorkflowExecutionsRequest{
+		_, err := c.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
 			Namespace: namespace,
 		})
 		return err
@@ -212,3 +229,16 @@ func WithTemporal(c client.Client, namespace string) DependencyChecker {
 func WithPostgres(pg *pgxpool.Pool) DependencyChecker {
 	return NewDependencyChecker(ServiceNamePostgres, pg.Ping)
 }
+
+func WithSQLite(db *sql.DB) DependencyChecker {
+	return NewDependencyChecker(ServiceNameSQLite, db.PingContext)
+}
+
+func WithNats(nc *nats.Conn) DependencyChecker {
+	return NewDependencyChecker(ServiceNameNats, func(_ context.Context) error {
+		if status := nc.Status(); status != nats.CONNECTED {
+			return fmt.Errorf("nats connection %s", strings.ToLower(status.String()))
+		}
+		return nil
+	})
+}
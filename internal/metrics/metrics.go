@@ -0,0 +1,36 @@
+// Package metrics provides the Prometheus registry shared by all Annex
+// services.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes all Annex metric names.
+const Namespace = "annex"
+
+// Registry is the registry all Annex metrics are registered with.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Factory creates metrics registered with the Annex registry.
+var Factory = promauto.With(Registry)
+
+// Handler returns an HTTP handler exposing all registered metrics in the
+// Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry: Registry,
+	})
+}
@@ -0,0 +1,251 @@
+// Package migration applies the versioned schema migrations of a database.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+var (
+	// ErrNewerSchema is returned when the database has been migrated to a
+	// version this build doesn't have migrations for.
+	ErrNewerSchema = errors.New("database schema is newer than the latest migration")
+	// ErrDirty is returned when a migration failed part way through. The
+	// database must be repaired manually and the version forced.
+	ErrDirty = errors.New("database schema is dirty")
+)
+
+// LockFunc acquires a lock held for the duration of a migration so that
+// concurrent processes don't migrate the same database simultaneously.
+type LockFunc func(ctx context.Context) (unlock func(), err error)
+
+// Status is the migration status of a database.
+type Status struct {
+	// Version is the applied version, or 0 if no migrations have been
+	// applied.
+	Version uint `json:"version"`
+	// Latest is the version of the latest migration.
+	Latest uint `json:"latest"`
+	// Dirty reports whether the migration to Version failed.
+	Dirty bool `json:"dirty"`
+}
+
+// Pending returns whether there are migrations to apply.
+func (s Status) Pending() bool {
+	return s.Version < s.Latest
+}
+
+// Newer returns whether the database has been migrated beyond the latest
+// migration.
+func (s Status) Newer() bool {
+	return s.Version > s.Latest
+}
+
+type Option func(m *Migrator)
+
+// WithLock acquires the lock before migrating and checking the version.
+func WithLock(lock LockFunc) Option {
+	return func(m *Migrator) {
+		m.lock = lock
+	}
+}
+
+// Migrator applies the up and down migrations in a file system to a
+// database.
+type Migrator struct {
+	m        *migrate.Migrate
+	versions []uint
+	lock     LockFunc
+}
+
+// New returns a Migrator of the migrations in the root of fsys, named as
+// '<version>_<title>.<up|down>.sql'. The database driver is closed by Close.
+func New(fsys fs.FS, dbName string, driver database.Driver, opts ...Option) (*Migrator, error) {
+	sd, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := readVersions(sd)
+	if err != nil {
+		sd.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sd, dbName, driver)
+	if err != nil {
+		sd.Close()
+		return nil, err
+	}
+
+	migrator := &Migrator{
+		m:        m,
+		versions: versions,
+		lock: func(context.Context) (func(), error) {
+			return func() {}, nil
+		},
+	}
+	for _, opt := range opts {
+		opt(migrator)
+	}
+
+	return migrator, nil
+}
+
+// Status returns the migration status of the database.
+func (m *Migrator) Status() (Status, error) {
+	status := Status{Latest: m.latest()}
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return Status{}, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	status.Version = version
+	status.Dirty = dirty
+	return status, nil
+}
+
+// Check returns an error if the database schema is newer than the latest
+// migration or dirty.
+func (m *Migrator) Check(ctx context.Context) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	_, err = m.check()
+	return err
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, err = m.check(); err != nil {
+		return err
+	}
+	if err = m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down reverts the number of applied migrations, or all of them if steps is
+// zero.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 0 {
+		return fmt.Errorf("invalid number of steps %d", steps)
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	status, err := m.check()
+	if err != nil {
+		return err
+	}
+	if applied := m.applied(status.Version); steps > applied {
+		return fmt.Errorf("can't revert %d migrations: %d applied", steps, applied)
+	}
+	if steps == 0 {
+		err = m.m.Down()
+	} else {
+		err = m.m.Steps(-steps)
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force sets the version without migrating and clears the dirty state. It's
+// used to recover from a failed migration once the database has been
+// repaired manually. A version of -1 removes the version.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	if version < -1 || version > int(m.latest()) {
+		return fmt.Errorf("invalid version %d: must be between -1 and %d", version, m.latest())
+	}
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return m.m.Force(version)
+}
+
+// Close closes the migration source and database driver.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+func (m *Migrator) check() (Status, error) {
+	status, err := m.Status()
+	if err != nil {
+		return Status{}, err
+	}
+	if status.Newer() {
+		return Status{}, fmt.Errorf("%w: schema version %d, latest migration %d", ErrNewerSchema, status.Version, status.Latest)
+	}
+	if status.Dirty {
+		return Status{}, fmt.Errorf("%w: migration %d failed", ErrDirty, status.Version)
+	}
+	return status, nil
+}
+
+func (m *Migrator) latest() uint {
+	if len(m.versions) == 0 {
+		return 0
+	}
+	return m.versions[len(m.versions)-1]
+}
+
+// applied returns the number of migrations applied to reach the version.
+func (m *Migrator) applied(version uint) int {
+	n := 0
+	for _, v := range m.versions {
+		if v <= version {
+			n++
+		}
+	}
+	return n
+}
+
+// readVersions returns the versions of the migrations in ascending order.
+func readVersions(sd source.Driver) ([]uint, error) {
+	version, err := sd.First()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	versions := []uint{version}
+	for {
+		next, err := sd.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return versions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		versions = append(versions, next)
+		version = next
+	}
+}
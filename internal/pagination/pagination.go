@@ -37,6 +37,12 @@ func WithCaseExecutionID() OffsetOption[test.CaseExecutionID] {
 	}
 }
 
+func WithSequence() OffsetOption[uint64] {
+	return func(id string) (uint64, error) {
+		return strconv.ParseUint(id, 10, 64)
+	}
+}
+
 func WithUUID() OffsetOption[uuid.V7] {
 	return uuid.Parse
 }
@@ -84,6 +90,8 @@ func encodeNextPageToken[T test.Identifier](tkn NextPageToken[T]) (string, error
 	switch id := any(tkn.OffsetID).(type) {
 	case string:
 		offsetID = id
+	case uint64:
+		offsetID = strconv.FormatUint(id, 10)
 	case uuid.V7:
 		offsetID = id.String()
 	case test.TestExecutionID:
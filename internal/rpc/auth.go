@@ -0,0 +1,213 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+
+	"github.com/annexsh/annex/auth"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+var errUnauthenticated = errors.New("missing or invalid credentials")
+
+// unauthenticatedGRPCServices are gRPC services that can be called without
+// credentials.
+var unauthenticatedGRPCServices = map[string]bool{
+	grpchealthv1.Health_ServiceDesc.ServiceName:                true,
+	reflectionv1.ServerReflection_ServiceDesc.ServiceName:      true,
+	reflectionv1alpha.ServerReflection_ServiceDesc.ServiceName: true,
+}
+
+type bearerTokenKey struct{}
+
+// authenticate authenticates the bearer token of the authorization header
+// value. The returned context carries the principal and the token so the
+// token can be forwarded to other Annex services.
+func authenticate(ctx context.Context, authenticator auth.Authenticator, authorization string) (context.Context, error) {
+	token, ok := strings.CutPrefix(authorization, bearerPrefix)
+	if !ok || token == "" {
+		return nil, auth.ErrorUnauthenticated
+	}
+
+	principal, err := authenticator.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = auth.ContextWithPrincipal(ctx, principal)
+	return context.WithValue(ctx, bearerTokenKey{}, token), nil
+}
+
+func bearerTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(bearerTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+func toConnectAuthError(err error) error {
+	if errors.Is(err, auth.ErrorUnauthenticated) {
+		return connect.NewError(connect.CodeUnauthenticated, errUnauthenticated)
+	}
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authenticate: %w", err))
+}
+
+func toGRPCAuthError(err error) error {
+	if errors.Is(err, auth.ErrorUnauthenticated) {
+		return status.Error(codes.Unauthenticated, errUnauthenticated.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to authenticate: %v", err)
+}
+
+// ConnectAuthInterceptor authenticates the bearer token of Connect handler
+// requests.
+type ConnectAuthInterceptor struct {
+	authenticator auth.Authenticator
+}
+
+func NewConnectAuthInterceptor(authenticator auth.Authenticator) *ConnectAuthInterceptor {
+	return &ConnectAuthInterceptor{authenticator: authenticator}
+}
+
+func (c *ConnectAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		ctx, err := authenticate(ctx, c.authenticator, req.Header().Get(authorizationHeader))
+		if err != nil {
+			return nil, toConnectAuthError(err)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (c *ConnectAuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (c *ConnectAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := authenticate(ctx, c.authenticator, conn.RequestHeader().Get(authorizationHeader))
+		if err != nil {
+			return toConnectAuthError(err)
+		}
+		return next(ctx, conn)
+	}
+}
+
+// ConnectCredentialsInterceptor forwards the bearer token of the request
+// being handled to Connect client requests made while handling it.
+type ConnectCredentialsInterceptor struct{}
+
+func NewConnectCredentialsInterceptor() *ConnectCredentialsInterceptor {
+	return &ConnectCredentialsInterceptor{}
+}
+
+func (c *ConnectCredentialsInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			setBearerToken(ctx, req.Header())
+		}
+		return next(ctx, req)
+	}
+}
+
+func (c *ConnectCredentialsInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		setBearerToken(ctx, conn.RequestHeader())
+		return conn
+	}
+}
+
+func (c *ConnectCredentialsInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+func setBearerToken(ctx context.Context, header http.Header) {
+	if header.Get(authorizationHeader) != "" {
+		return
+	}
+	if token, ok := bearerTokenFromContext(ctx); ok {
+		header.Set(authorizationHeader, bearerPrefix+token)
+	}
+}
+
+func authUnaryServerInterceptor(authenticator auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticateGRPC(ctx, authenticator, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStreamServerInterceptor(authenticator auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateGRPC(ss.Context(), authenticator, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticateGRPC(ctx context.Context, authenticator auth.Authenticator, fullMethod string) (context.Context, error) {
+	service, _ := splitProcedure(fullMethod)
+	if unauthenticatedGRPCServices[service] {
+		return ctx, nil
+	}
+
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(authorizationHeader); len(vals) > 0 {
+			authorization = vals[0]
+		}
+	}
+
+	authCtx, err := authenticate(ctx, authenticator, authorization)
+	if err != nil {
+		return nil, toGRPCAuthError(err)
+	}
+	return authCtx, nil
+}
+
+func credentialsUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withOutgoingBearerToken(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+func credentialsStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withOutgoingBearerToken(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withOutgoingBearerToken(ctx context.Context) context.Context {
+	token, ok := bearerTokenFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if len(md.Get(authorizationHeader)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, authorizationHeader, bearerPrefix+token)
+}
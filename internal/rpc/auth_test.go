@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/annexsh/annex/auth"
+)
+
+const testToken = "annex_secret"
+
+type testAuthenticator struct{}
+
+func (testAuthenticator) Authenticate(_ context.Context, token string) (*auth.Principal, error) {
+	if token != testToken {
+		return nil, auth.ErrorUnauthenticated
+	}
+	return &auth.Principal{Subject: "tester", Method: auth.MethodAPIKey}, nil
+}
+
+func TestConnectAuthInterceptor(t *testing.T) {
+	var gotPrincipal *auth.Principal
+
+	procedure := "/annex.test.v1.AuthTestService/Ping"
+	handler := connect.NewUnaryHandler(procedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			gotPrincipal, _ = auth.PrincipalFromContext(ctx)
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithInterceptors(NewConnectAuthInterceptor(testAuthenticator{})),
+	)
+	httpSrv := httptest.NewServer(handler)
+	defer httpSrv.Close()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](httpSrv.Client(), httpSrv.URL+procedure)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      connect.Code
+	}{
+		{
+			name:          "valid token",
+			authorization: "Bearer " + testToken,
+		},
+		{
+			name:          "missing token",
+			authorization: "",
+			wantCode:      connect.CodeUnauthenticated,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer annex_invalid",
+			wantCode:      connect.CodeUnauthenticated,
+		},
+		{
+			name:          "not a bearer token",
+			authorization: "Basic " + testToken,
+			wantCode:      connect.CodeUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPrincipal = nil
+
+			req := connect.NewRequest(&emptypb.Empty{})
+			if tt.authorization != "" {
+				req.Header().Set(authorizationHeader, tt.authorization)
+			}
+
+			_, err := client.CallUnary(context.Background(), req)
+			if tt.wantCode != 0 {
+				assert.Equal(t, tt.wantCode, connect.CodeOf(err))
+				assert.Nil(t, gotPrincipal)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, gotPrincipal)
+			assert.Equal(t, "tester", gotPrincipal.Subject)
+		})
+	}
+}
+
+func TestConnectCredentialsInterceptor(t *testing.T) {
+	var gotAuthorization string
+
+	procedure := "/annex.test.v1.AuthTestService/Ping"
+	handler := connect.NewUnaryHandler(procedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			gotAuthorization = req.Header().Get(authorizationHeader)
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+	)
+	httpSrv := httptest.NewServer(handler)
+	defer httpSrv.Close()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](httpSrv.Client(), httpSrv.URL+procedure, WithConnectClientInterceptors())
+
+	// The token of the request being handled is forwarded
+	ctx, err := authenticate(context.Background(), testAuthenticator{}, "Bearer "+testToken)
+	require.NoError(t, err)
+	_, err = client.CallUnary(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer "+testToken, gotAuthorization)
+
+	// Nothing is forwarded outside an authenticated request
+	_, err = client.CallUnary(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	assert.Empty(t, gotAuthorization)
+}
+
+func TestGRPCAuthInterceptors(t *testing.T) {
+	interceptor := authUnaryServerInterceptor(testAuthenticator{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/annex.test.v1.AuthTestService/Ping"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		p, ok := auth.PrincipalFromContext(ctx)
+		if !ok {
+			return nil, nil
+		}
+		return p, nil
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := interceptor(context.Background(), nil, info, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("health service skipped", func(t *testing.T) {
+		healthInfo := &grpc.UnaryServerInfo{FullMethod: "/" + grpchealthv1.Health_ServiceDesc.ServiceName + "/Check"}
+		res, err := interceptor(context.Background(), nil, healthInfo, handler)
+		require.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("valid token forwarded", func(t *testing.T) {
+		md := metadata.Pairs(authorizationHeader, "Bearer "+testToken)
+		res, err := interceptor(metadata.NewIncomingContext(context.Background(), md), nil, info, func(ctx context.Context, req any) (any, error) {
+			var outgoing metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				outgoing, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+			require.NoError(t, credentialsUnaryClientInterceptor()(ctx, info.FullMethod, nil, nil, nil, invoker))
+			assert.Equal(t, []string{"Bearer " + testToken}, outgoing.Get(authorizationHeader))
+			return handler(ctx, req)
+		})
+		require.NoError(t, err)
+		require.IsType(t, &auth.Principal{}, res)
+		assert.Equal(t, "tester", res.(*auth.Principal).Subject)
+	})
+}
@@ -2,11 +2,67 @@ package rpc
 
 import (
 	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/auth"
 )
 
-func WithConnectInterceptors(logger Logger) connect.Option {
-	return connect.WithInterceptors(
+type interceptorOptions struct {
+	authenticator auth.Authenticator
+	limits        *Limits
+}
+
+type InterceptorOption func(opts *interceptorOptions)
+
+// WithAuthenticator requires requests to be authenticated by the
+// authenticator. Requests are not authenticated if the authenticator is nil.
+func WithAuthenticator(authenticator auth.Authenticator) InterceptorOption {
+	return func(opts *interceptorOptions) {
+		opts.authenticator = authenticator
+	}
+}
+
+// WithLimits rejects requests that exceed the rate and size limits. Limits
+// are enforced after authentication so callers are identified by their
+// principal.
+func WithLimits(limits Limits) InterceptorOption {
+	return func(opts *interceptorOptions) {
+		opts.limits = &limits
+	}
+}
+
+func newInterceptorOptions(opts []InterceptorOption) interceptorOptions {
+	var o interceptorOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func WithConnectInterceptors(logger Logger, opts ...InterceptorOption) connect.Option {
+	o := newInterceptorOptions(opts)
+
+	interceptors := []connect.Interceptor{
 		//connect.WithRecover() TODO,
+		NewConnectTracingInterceptor(),
 		NewConnectLogInterceptor(logger),
+		NewConnectMetricsInterceptor(),
+	}
+	if o.authenticator != nil {
+		interceptors = append(interceptors, NewConnectAuthInterceptor(o.authenticator))
+	}
+	if o.limits != nil {
+		interceptors = append(interceptors, NewConnectLimitInterceptor(*o.limits))
+	}
+
+	return connect.WithInterceptors(interceptors...)
+}
+
+// WithConnectClientInterceptors returns the interceptors for Connect clients
+// calling other Annex services. The bearer token of the request being handled
+// is forwarded.
+func WithConnectClientInterceptors() connect.Option {
+	return connect.WithInterceptors(
+		NewConnectTracingInterceptor(),
+		NewConnectCredentialsInterceptor(),
 	)
 }
@@ -16,7 +16,8 @@ import (
 	"github.com/annexsh/annex/log"
 )
 
-func WithGRPCInterceptors(logger log.Logger) []grpc.ServerOption {
+func WithGRPCInterceptors(logger log.Logger, opts ...InterceptorOption) []grpc.ServerOption {
+	o := newInterceptorOptions(opts)
 	grpcLogger := toGRPCLogger(logger)
 
 	logOpts := []grpclog.Option{
@@ -30,24 +31,53 @@ func WithGRPCInterceptors(logger log.Logger) []grpc.ServerOption {
 		grpcrecovery.WithRecoveryHandler(recoveryHandler()),
 	}
 
-	return []grpc.ServerOption{
-		grpc.ChainUnaryInterceptor(
-			grpcselector.UnaryServerInterceptor(
-				grpclog.UnaryServerInterceptor(grpcLogger, logOpts...),
-				grpcselector.MatchFunc(func(ctx context.Context, callMeta interceptors.CallMeta) bool {
-					if callMeta.Service == grpchealthv1.Health_ServiceDesc.ServiceName {
-						return false // ignore health probe logs
-					}
-					return true
-				}),
-			),
-			grpcrecovery.UnaryServerInterceptor(recoveryOpts...),
-		),
-		grpc.ChainStreamInterceptor(
-			grpclog.StreamServerInterceptor(grpcLogger, logOpts...),
-			grpcrecovery.StreamServerInterceptor(recoveryOpts...),
+	unary := []grpc.UnaryServerInterceptor{
+		tracingUnaryServerInterceptor(),
+		metricsUnaryServerInterceptor(),
+		grpcselector.UnaryServerInterceptor(
+			grpclog.UnaryServerInterceptor(grpcLogger, logOpts...),
+			grpcselector.MatchFunc(func(ctx context.Context, callMeta interceptors.CallMeta) bool {
+				if callMeta.Service == grpchealthv1.Health_ServiceDesc.ServiceName {
+					return false // ignore health probe logs
+				}
+				return true
+			}),
 		),
 	}
+	stream := []grpc.StreamServerInterceptor{
+		tracingStreamServerInterceptor(),
+		metricsStreamServerInterceptor(),
+		grpclog.StreamServerInterceptor(grpcLogger, logOpts...),
+	}
+	if o.authenticator != nil {
+		unary = append(unary, authUnaryServerInterceptor(o.authenticator))
+		stream = append(stream, authStreamServerInterceptor(o.authenticator))
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(append(unary, grpcrecovery.UnaryServerInterceptor(recoveryOpts...))...),
+		grpc.ChainStreamInterceptor(append(stream, grpcrecovery.StreamServerInterceptor(recoveryOpts...))...),
+	}
+}
+
+// WithGRPCClientInterceptors returns the dial options for gRPC clients
+// calling other services, such as Temporal.
+func WithGRPCClientInterceptors() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(tracingUnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tracingStreamClientInterceptor()),
+	}
+}
+
+// WithGRPCAnnexClientInterceptors returns the dial options for gRPC clients
+// calling other Annex services, such as the workflow proxy. Unlike
+// WithGRPCClientInterceptors, the bearer token of the request being handled
+// is forwarded.
+func WithGRPCAnnexClientInterceptors() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(tracingUnaryClientInterceptor(), credentialsUnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(tracingStreamClientInterceptor(), credentialsStreamClientInterceptor()),
+	}
 }
 
 func recoveryHandler() grpcrecovery.RecoveryHandlerFunc {
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	livenessPath   = "/healthz"
+	readinessPath  = "/readyz"
+	readinessCheck = 5 * time.Second
+)
+
+// RegisterHealth registers the gRPC health service along with HTTP liveness
+// and readiness endpoints for probes that do not support gRPC. The liveness
+// endpoint succeeds while the server is running. The readiness endpoint
+// succeeds when the health service reports the overall status as serving.
+// The HTTP endpoints are served on the probe address when set.
+func (s *Server) RegisterHealth(healthSvc grpchealthv1.HealthServer) {
+	s.RegisterGRPC(&grpchealthv1.Health_ServiceDesc, healthSvc)
+
+	s.probeMux.HandleFunc(livenessPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeHealthStatus(w, http.StatusOK, "ok")
+	})
+
+	s.probeMux.HandleFunc(readinessPath, func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessCheck)
+		defer cancel()
+
+		res, err := healthSvc.Check(ctx, &grpchealthv1.HealthCheckRequest{})
+		if err != nil {
+			writeHealthStatus(w, http.StatusServiceUnavailable, fmt.Sprintf("health check failed: %s", err))
+			return
+		}
+		if res.Status != grpchealthv1.HealthCheckResponse_SERVING {
+			writeHealthStatus(w, http.StatusServiceUnavailable, res.Status.String())
+			return
+		}
+		writeHealthStatus(w, http.StatusOK, "ok")
+	})
+}
+
+func writeHealthStatus(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintln(w, msg)
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServer_RegisterHealth(t *testing.T) {
+	healthSvc := health.NewServer()
+	srv := NewServer("127.0.0.1:0")
+	srv.RegisterHealth(healthSvc)
+
+	get := func(path string) int {
+		rec := httptest.NewRecorder()
+		srv.probeMux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get(livenessPath))
+	assert.Equal(t, http.StatusOK, get(readinessPath))
+
+	healthSvc.SetServingStatus("", grpchealthv1.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, http.StatusOK, get(livenessPath))
+	require.Equal(t, http.StatusServiceUnavailable, get(readinessPath))
+
+	healthSvc.SetServingStatus("", grpchealthv1.HealthCheckResponse_SERVING)
+	assert.Equal(t, http.StatusOK, get(readinessPath))
+}
@@ -0,0 +1,321 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/annexsh/annex/auth"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+const (
+	retryAfterHeader = "Retry-After"
+
+	// limiterSweepInterval is how often limiters that have refilled, and so
+	// no longer hold any state, are removed.
+	limiterSweepInterval = time.Minute
+)
+
+// RateLimit is a token bucket rate limit.
+type RateLimit struct {
+	Rate  float64 // requests per second (zero disables the limit)
+	Burst int     // maximum requests at once (defaults to the rate rounded up)
+}
+
+func (r RateLimit) enabled() bool {
+	return r.Rate > 0
+}
+
+func (r RateLimit) burst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return int(math.Ceil(r.Rate))
+}
+
+// RateRule limits the rate of requests to a group of procedures. The
+// procedures of a rule share the same limits.
+type RateRule struct {
+	Name       string   // name of the group, used in errors
+	Procedures []string // procedures in the form /package.Service/Method
+	// PerCaller limits the requests of each caller, identified by the
+	// authenticated principal or the peer address if unauthenticated.
+	PerCaller RateLimit
+	// PerContext limits the requests of each context, identified by the
+	// context field of the request message.
+	PerContext RateLimit
+	// PerMessage counts every message of a client stream as a request.
+	// Otherwise, a stream counts as a single request.
+	PerMessage bool
+}
+
+// Limits are the rate and size limits of Connect handler requests. Zero
+// values disable the respective limit.
+type Limits struct {
+	Rates []RateRule
+	// MaxLogMessageSize is the maximum size in bytes of published log
+	// messages.
+	MaxLogMessageSize int
+	// MaxPayloadSize is the maximum encoded size in bytes of test input
+	// payloads.
+	MaxPayloadSize int
+}
+
+// ConnectLimitInterceptor rejects Connect handler requests and streams that
+// exceed rate or size limits with connect.CodeResourceExhausted. Rate limit errors carry
+// a RetryInfo error detail and a Retry-After header.
+type ConnectLimitInterceptor struct {
+	limits   Limits
+	rules    map[string]int // procedure to rule index
+	limiters *limiterStore
+	now      func() time.Time
+}
+
+func NewConnectLimitInterceptor(limits Limits) *ConnectLimitInterceptor {
+	rules := map[string]int{}
+	for i, rule := range limits.Rates {
+		for _, procedure := range rule.Procedures {
+			rules[procedure] = i
+		}
+	}
+	return &ConnectLimitInterceptor{
+		limits:   limits,
+		rules:    rules,
+		limiters: newLimiterStore(),
+		now:      time.Now,
+	}
+}
+
+func (c *ConnectLimitInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := c.checkSize(req.Any()); err != nil {
+			return nil, err
+		}
+		if err := c.checkRate(ctx, req.Spec().Procedure, req.Peer(), req.Any()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (c *ConnectLimitInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (c *ConnectLimitInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return next(ctx, &limitStreamingHandlerConn{
+			StreamingHandlerConn: conn,
+			ctx:                  ctx,
+			interceptor:          c,
+		})
+	}
+}
+
+// limitStreamingHandlerConn enforces size limits on every received message.
+// Rate limits are checked on the first message since the context is only
+// known from the message, and on every subsequent message if the rule of the
+// procedure counts messages.
+type limitStreamingHandlerConn struct {
+	connect.StreamingHandlerConn
+	ctx         context.Context
+	interceptor *ConnectLimitInterceptor
+	received    bool
+}
+
+func (c *limitStreamingHandlerConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	if err := c.interceptor.checkSize(msg); err != nil {
+		return err
+	}
+	if !c.received || c.interceptor.perMessage(c.Spec().Procedure) {
+		c.received = true
+		return c.interceptor.checkRate(c.ctx, c.Spec().Procedure, c.Peer(), msg)
+	}
+	return nil
+}
+
+// perMessage reports whether the rate limit rule of the procedure counts
+// every message of a stream.
+func (c *ConnectLimitInterceptor) perMessage(procedure string) bool {
+	i, ok := c.rules[procedure]
+	return ok && c.limits.Rates[i].PerMessage
+}
+
+func (c *ConnectLimitInterceptor) checkSize(msg any) error {
+	if maxSize := c.limits.MaxLogMessageSize; maxSize > 0 {
+		var messages []string
+		switch req := msg.(type) {
+		case *testsv1.PublishLogRequest:
+			messages = []string{req.GetMessage()}
+		case *executionsv1.PublishLogsRequest:
+			for _, entry := range req.GetLogs() {
+				messages = append(messages, entry.GetMessage())
+			}
+		}
+		for _, message := range messages {
+			if len(message) > maxSize {
+				return connect.NewError(connect.CodeResourceExhausted,
+					fmt.Errorf("log message size of %d bytes exceeds the maximum of %d bytes", len(message), maxSize))
+			}
+		}
+	}
+
+	if maxSize := c.limits.MaxPayloadSize; maxSize > 0 {
+		var payload *testsv1.Payload
+		switch req := msg.(type) {
+		case *testsv1.ExecuteTestRequest:
+			payload = req.GetInput()
+		case *executionsv1.ExecuteTestRequest:
+			payload = req.GetInput()
+		case *testsv1.RegisterTestsRequest:
+			payload = req.GetDefinition().GetDefaultInput()
+		}
+		if size := proto.Size(payload); size > maxSize {
+			return connect.NewError(connect.CodeResourceExhausted,
+				fmt.Errorf("input payload size of %d bytes exceeds the maximum of %d bytes", size, maxSize))
+		}
+	}
+
+	return nil
+}
+
+type contextGetter interface {
+	GetContext() string
+}
+
+func (c *ConnectLimitInterceptor) checkRate(ctx context.Context, procedure string, peer connect.Peer, msg any) error {
+	i, ok := c.rules[procedure]
+	if !ok {
+		return nil
+	}
+	rule := c.limits.Rates[i]
+	now := c.now()
+
+	var reservations []*rate.Reservation
+
+	if rule.PerCaller.enabled() {
+		key := limiterKey{rule: i, scope: "caller", id: callerID(ctx, peer)}
+		r, delay := c.limiters.reserve(key, rule.PerCaller, now)
+		if delay > 0 {
+			return rateLimitError(rule.Name, "caller", delay)
+		}
+		reservations = append(reservations, r)
+	}
+
+	if rule.PerContext.enabled() {
+		if getter, ok := msg.(contextGetter); ok && getter.GetContext() != "" {
+			key := limiterKey{rule: i, scope: "context", id: getter.GetContext()}
+			if _, delay := c.limiters.reserve(key, rule.PerContext, now); delay > 0 {
+				// The request is rejected so it shouldn't count towards the
+				// caller limit
+				for _, r := range reservations {
+					r.CancelAt(now)
+				}
+				return rateLimitError(rule.Name, "context", delay)
+			}
+		}
+	}
+
+	return nil
+}
+
+// callerID identifies the caller by its authenticated principal, otherwise
+// the host of its address.
+func callerID(ctx context.Context, peer connect.Peer) string {
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		return "principal:" + principal.Subject
+	}
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		host = peer.Addr
+	}
+	return "peer:" + host
+}
+
+func rateLimitError(name string, scope string, delay time.Duration) error {
+	err := connect.NewError(connect.CodeResourceExhausted,
+		fmt.Errorf("%s rate limit exceeded for %s: retry after %s", name, scope, delay.Round(time.Millisecond)))
+	if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(delay),
+	}); detailErr == nil {
+		err.AddDetail(detail)
+	}
+	err.Meta().Set(retryAfterHeader, strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	return err
+}
+
+type limiterKey struct {
+	rule  int
+	scope string
+	id    string
+}
+
+// limiterStore holds a rate limiter per key. Limiters are created on first
+// use and removed once they have refilled so that the store does not grow
+// with every caller or context ever seen.
+type limiterStore struct {
+	mu        sync.Mutex
+	limiters  map[limiterKey]*rate.Limiter
+	lastSweep time.Time
+}
+
+func newLimiterStore() *limiterStore {
+	return &limiterStore{
+		limiters: map[limiterKey]*rate.Limiter{},
+	}
+}
+
+// reserve takes a token from the limiter of the key. The delay until a token
+// is available is returned if there are none, in which case no token is
+// taken.
+func (s *limiterStore) reserve(key limiterKey, limit RateLimit, now time.Time) (*rate.Reservation, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= limiterSweepInterval {
+		s.sweep(now)
+	}
+
+	limiter, ok := s.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.Rate), limit.burst())
+		s.limiters[key] = limiter
+	}
+
+	r := limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return nil, time.Duration(math.MaxInt64)
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return nil, delay
+	}
+	return r, 0
+}
+
+func (s *limiterStore) sweep(now time.Time) {
+	for key, limiter := range s.limiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(s.limiters, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,266 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/annexsh/annex/auth"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+func TestConnectLimitInterceptor_rate(t *testing.T) {
+	now := time.Now()
+	interceptor := NewConnectLimitInterceptor(Limits{
+		Rates: []RateRule{
+			{
+				Name:       "publish log",
+				Procedures: []string{testsv1connect.TestServicePublishLogProcedure},
+				PerCaller:  RateLimit{Rate: 1, Burst: 2},
+				PerContext: RateLimit{Rate: 1, Burst: 3},
+			},
+		},
+	})
+	interceptor.now = func() time.Time { return now }
+
+	client := newLimitTestClient(t, interceptor)
+
+	publish := func(ctx, subject string) error {
+		req := connect.NewRequest(&testsv1.PublishLogRequest{Context: ctx})
+		req.Header().Set("X-Subject", subject)
+		_, err := client.CallUnary(context.Background(), req)
+		return err
+	}
+
+	// Caller limit
+	require.NoError(t, publish("foo", "alice"))
+	require.NoError(t, publish("foo", "alice"))
+	err := publish("foo", "alice")
+	assertRateLimited(t, err, time.Second)
+	assert.Contains(t, err.Error(), "publish log rate limit exceeded for caller")
+
+	// Context limit shared across callers
+	require.NoError(t, publish("foo", "bob"))
+	err = publish("foo", "bob")
+	assertRateLimited(t, err, time.Second)
+	assert.Contains(t, err.Error(), "publish log rate limit exceeded for context")
+
+	// Other contexts are unaffected
+	require.NoError(t, publish("bar", "bob"))
+
+	// Tokens are replenished
+	now = now.Add(time.Second)
+	require.NoError(t, publish("foo", "alice"))
+}
+
+func TestConnectLimitInterceptor_size(t *testing.T) {
+	interceptor := NewConnectLimitInterceptor(Limits{
+		MaxLogMessageSize: 5,
+		MaxPayloadSize:    10,
+	})
+	client := newLimitTestClient(t, interceptor)
+
+	tests := []struct {
+		name    string
+		msg     any
+		wantErr string
+	}{
+		{
+			name: "log message within limit",
+			msg:  &testsv1.PublishLogRequest{Message: "hello"},
+		},
+		{
+			name:    "log message exceeds limit",
+			msg:     &testsv1.PublishLogRequest{Message: "hello world"},
+			wantErr: "log message size of 11 bytes exceeds the maximum of 5 bytes",
+		},
+		{
+			name: "batched log message exceeds limit",
+			msg: &executionsv1.PublishLogsRequest{Logs: []*executionsv1.LogEntry{
+				{Message: "hello"},
+				{Message: "hello world"},
+			}},
+			wantErr: "log message size of 11 bytes exceeds the maximum of 5 bytes",
+		},
+		{
+			name: "input within limit",
+			msg:  &testsv1.ExecuteTestRequest{Input: &testsv1.Payload{Data: []byte("foo")}},
+		},
+		{
+			name:    "input exceeds limit",
+			msg:     &testsv1.ExecuteTestRequest{Input: &testsv1.Payload{Data: []byte(strings.Repeat("a", 20))}},
+			wantErr: "input payload size of 22 bytes exceeds the maximum of 10 bytes",
+		},
+		{
+			name: "default input exceeds limit",
+			msg: &testsv1.RegisterTestsRequest{Definition: &testsv1.TestDefinition{
+				DefaultInput: &testsv1.Payload{Data: []byte(strings.Repeat("a", 20))},
+			}},
+			wantErr: "input payload size of 22 bytes exceeds the maximum of 10 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := interceptor.checkSize(tt.msg)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	// Enforced by the interceptor
+	_, err := client.CallUnary(context.Background(), connect.NewRequest(&testsv1.PublishLogRequest{Message: "hello world"}))
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+}
+
+func TestConnectLimitInterceptor_stream(t *testing.T) {
+	interceptor := NewConnectLimitInterceptor(Limits{
+		Rates: []RateRule{
+			{
+				Name:       "register",
+				Procedures: []string{testsv1connect.TestServiceRegisterTestsProcedure},
+				PerCaller:  RateLimit{Rate: 1, Burst: 1},
+			},
+		},
+		MaxPayloadSize: 10,
+	})
+
+	register := newRegisterTestsStream(t, interceptor)
+
+	small := &testsv1.RegisterTestsRequest{Definition: &testsv1.TestDefinition{
+		DefaultInput: &testsv1.Payload{Data: []byte("foo")},
+	}}
+	large := &testsv1.RegisterTestsRequest{Definition: &testsv1.TestDefinition{
+		DefaultInput: &testsv1.Payload{Data: []byte(strings.Repeat("a", 20))},
+	}}
+
+	// Size limit applies to every message
+	err := register(small, large)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.Contains(t, err.Error(), "input payload size")
+
+	// The first stream used the only token
+	err = register(small)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.Contains(t, err.Error(), "register rate limit exceeded for caller")
+}
+
+func TestConnectLimitInterceptor_streamPerMessage(t *testing.T) {
+	interceptor := NewConnectLimitInterceptor(Limits{
+		Rates: []RateRule{
+			{
+				Name:       "register",
+				Procedures: []string{testsv1connect.TestServiceRegisterTestsProcedure},
+				PerCaller:  RateLimit{Rate: 1, Burst: 2},
+				PerMessage: true,
+			},
+		},
+	})
+	register := newRegisterTestsStream(t, interceptor)
+
+	msg := &testsv1.RegisterTestsRequest{Definition: &testsv1.TestDefinition{}}
+
+	// The third message exceeds the burst
+	err := register(msg, msg, msg)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.Contains(t, err.Error(), "register rate limit exceeded for caller")
+}
+
+// newRegisterTestsStream serves a RegisterTests client stream handler with
+// the interceptor and returns a function that sends the messages in a stream.
+func newRegisterTestsStream(t *testing.T, interceptor *ConnectLimitInterceptor) func(msgs ...*testsv1.RegisterTestsRequest) error {
+	procedure := testsv1connect.TestServiceRegisterTestsProcedure
+	handler := connect.NewClientStreamHandler(procedure,
+		func(ctx context.Context, stream *connect.ClientStream[testsv1.RegisterTestsRequest]) (*connect.Response[emptypb.Empty], error) {
+			for stream.Receive() {
+			}
+			if err := stream.Err(); err != nil {
+				return nil, err
+			}
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithInterceptors(interceptor),
+	)
+	httpSrv := httptest.NewUnstartedServer(handler)
+	httpSrv.EnableHTTP2 = true
+	httpSrv.StartTLS()
+	t.Cleanup(httpSrv.Close)
+
+	client := connect.NewClient[testsv1.RegisterTestsRequest, emptypb.Empty](httpSrv.Client(), httpSrv.URL+procedure)
+
+	return func(msgs ...*testsv1.RegisterTestsRequest) error {
+		stream := client.CallClientStream(context.Background())
+		for _, msg := range msgs {
+			if err := stream.Send(msg); err != nil {
+				break
+			}
+		}
+		_, err := stream.CloseAndReceive()
+		return err
+	}
+}
+
+func assertRateLimited(t *testing.T, err error, wantDelay time.Duration) {
+	var connectErr *connect.Error
+	require.True(t, errors.As(err, &connectErr))
+	assert.Equal(t, connect.CodeResourceExhausted, connectErr.Code())
+	assert.Equal(t, "1", connectErr.Meta().Get(retryAfterHeader))
+
+	require.Len(t, connectErr.Details(), 1)
+	detail, err := connectErr.Details()[0].Value()
+	require.NoError(t, err)
+	retryInfo, ok := detail.(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, wantDelay, retryInfo.RetryDelay.AsDuration())
+}
+
+// newLimitTestClient serves a PublishLog handler using the limit interceptor.
+// The principal subject is taken from the X-Subject header.
+func newLimitTestClient(t *testing.T, interceptor *ConnectLimitInterceptor) *connect.Client[testsv1.PublishLogRequest, emptypb.Empty] {
+	procedure := testsv1connect.TestServicePublishLogProcedure
+	handler := connect.NewUnaryHandler(procedure,
+		func(ctx context.Context, req *connect.Request[testsv1.PublishLogRequest]) (*connect.Response[emptypb.Empty], error) {
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithInterceptors(subjectInterceptor{}, interceptor),
+	)
+	httpSrv := httptest.NewServer(handler)
+	t.Cleanup(httpSrv.Close)
+
+	return connect.NewClient[testsv1.PublishLogRequest, emptypb.Empty](httpSrv.Client(), httpSrv.URL+procedure)
+}
+
+type subjectInterceptor struct{}
+
+func (subjectInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if subject := req.Header().Get("X-Subject"); subject != "" {
+			ctx = auth.ContextWithPrincipal(ctx, &auth.Principal{Subject: subject, Method: auth.MethodAPIKey})
+		}
+		return next(ctx, req)
+	}
+}
+
+func (subjectInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (subjectInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
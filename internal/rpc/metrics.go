@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/annexsh/annex/internal/metrics"
+)
+
+const (
+	metricsPath = "/metrics"
+
+	protocolConnect = "connect"
+	protocolGRPC    = "grpc"
+	codeOK          = "ok"
+)
+
+var (
+	rpcRequests = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "rpc",
+		Name:      "requests_total",
+		Help:      "Total number of RPCs handled by protocol, service, method and status code.",
+	}, []string{"protocol", "service", "method", "code"})
+
+	rpcDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "rpc",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of handled RPCs by protocol, service and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"protocol", "service", "method"})
+)
+
+func observeRPC(protocol string, procedure string, code string, start time.Time) {
+	service, method := splitProcedure(procedure)
+	rpcRequests.WithLabelValues(protocol, service, method, code).Inc()
+	rpcDuration.WithLabelValues(protocol, service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitProcedure splits a procedure in the form /package.Service/Method into
+// its service and method.
+func splitProcedure(procedure string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	if !ok {
+		return procedure, ""
+	}
+	return service, method
+}
+
+func connectCode(err error) string {
+	if err == nil {
+		return codeOK
+	}
+	return connect.CodeOf(err).String()
+}
+
+func grpcCode(err error) string {
+	if err == nil {
+		return codeOK
+	}
+	// Connect codes are identical to gRPC codes, so use the Connect names to
+	// keep label values consistent across protocols.
+	return connect.Code(status.Code(err)).String()
+}
+
+type ConnectMetricsInterceptor struct{}
+
+func NewConnectMetricsInterceptor() *ConnectMetricsInterceptor {
+	return &ConnectMetricsInterceptor{}
+}
+
+func (c *ConnectMetricsInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		observeRPC(protocolConnect, req.Spec().Procedure, connectCode(err), start)
+		return res, err
+	}
+}
+
+func (c *ConnectMetricsInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (c *ConnectMetricsInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, conn)
+		observeRPC(protocolConnect, conn.Spec().Procedure, connectCode(err), start)
+		return err
+	}
+}
+
+func metricsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		observeRPC(protocolGRPC, info.FullMethod, grpcCode(err), start)
+		return res, err
+	}
+}
+
+func metricsStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(protocolGRPC, info.FullMethod, grpcCode(err), start)
+		return err
+	}
+}
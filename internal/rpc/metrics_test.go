@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServer_metrics(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer("127.0.0.1:0")
+
+	procedure := "/annex.test.v1.MetricsTestService/Ping"
+	handler := connect.NewUnaryHandler(procedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithInterceptors(NewConnectMetricsInterceptor()),
+	)
+	httpSrv := httptest.NewServer(handler)
+	defer httpSrv.Close()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](httpSrv.Client(), httpSrv.URL+procedure)
+	_, err := client.CallUnary(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+
+	unary := metricsUnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/annex.test.v1.MetricsTestService/Fail"}
+	_, err = unary(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	require.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	srv.mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	scraped := string(body)
+
+	assert.Contains(t, scraped, `annex_rpc_requests_total{code="ok",method="Ping",protocol="connect",service="annex.test.v1.MetricsTestService"} 1`)
+	assert.Contains(t, scraped, `annex_rpc_request_duration_seconds_count{method="Ping",protocol="connect",service="annex.test.v1.MetricsTestService"} 1`)
+	assert.Contains(t, scraped, `annex_rpc_requests_total{code="not_found",method="Fail",protocol="grpc",service="annex.test.v1.MetricsTestService"} 1`)
+	assert.Contains(t, scraped, "go_goroutines")
+}
+
+func TestConnectCode(t *testing.T) {
+	assert.Equal(t, "ok", connectCode(nil))
+	assert.Equal(t, "unknown", connectCode(errors.New("bang")))
+	assert.Equal(t, "permission_denied", connectCode(connect.NewError(connect.CodePermissionDenied, errors.New("bang"))))
+}
@@ -2,7 +2,10 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -14,11 +17,16 @@ import (
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/annexsh/annex/internal/metrics"
 )
 
 const (
 	connectPath = "/connect"
 	grpcPath    = "/" // grpc does not support a custom path
+
+	internalBufferSize = 1 << 20
 )
 
 type Server struct {
@@ -27,15 +35,24 @@ type Server struct {
 	grpcSrv         *grpc.Server
 	grpcOptions     []grpc.ServerOption
 	httpSrv         *http.Server
+	tlsConfig       *tls.Config
+	internalLis     *bufconn.Listener
+	internalSrv     *http.Server
+	probeAddr       string
+	probeMux        *http.ServeMux
+	probeSrv        *http.Server
 	grpcServices    []grpcSvcRegistrar
 	connectSvcNames []string
 }
 
 func NewServer(address string) *Server {
 	mux := http.NewServeMux()
+	mux.Handle(metricsPath, metrics.Handler())
 	return &Server{
-		addr: address,
-		mux:  mux,
+		addr:        address,
+		mux:         mux,
+		probeMux:    http.NewServeMux(),
+		internalLis: bufconn.Listen(internalBufferSize),
 	}
 }
 
@@ -72,6 +89,19 @@ func (s *Server) WithGRPCOptions(opt ...grpc.ServerOption) {
 	s.grpcOptions = append(s.grpcOptions, opt...)
 }
 
+// WithTLS serves TLS using the config instead of plaintext HTTP/2 (h2c).
+func (s *Server) WithTLS(config *tls.Config) {
+	s.tlsConfig = config
+}
+
+// WithProbeAddress serves the HTTP liveness and readiness endpoints over
+// plaintext HTTP on a separate listener at the address instead of the server
+// address, so that probes don't need a client certificate when the server
+// requires mTLS.
+func (s *Server) WithProbeAddress(address string) {
+	s.probeAddr = address
+}
+
 func (s *Server) Serve() error {
 	numConnect := len(s.connectSvcNames)
 	numGRPC := len(s.grpcServices)
@@ -89,18 +119,50 @@ func (s *Server) Serve() error {
 		s.mux.Handle(grpcPath, s.grpcSrv)
 	}
 
-	s.httpSrv = &http.Server{
-		Addr:    s.addr,
+	if s.tlsConfig != nil {
+		s.httpSrv = &http.Server{
+			Addr:      s.addr,
+			Handler:   s.mux,
+			TLSConfig: s.tlsConfig,
+		}
+	} else {
+		s.httpSrv = &http.Server{
+			Addr:    s.addr,
+			Handler: h2c.NewHandler(s.mux, &http2.Server{}),
+		}
+	}
+
+	if s.probeAddr != "" {
+		probeLis, err := net.Listen("tcp", s.probeAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for probes: %w", err)
+		}
+		s.probeSrv = &http.Server{Handler: s.probeMux}
+		go s.probeSrv.Serve(probeLis)
+	} else {
+		s.mux.Handle(livenessPath, s.probeMux)
+		s.mux.Handle(readinessPath, s.probeMux)
+	}
+
+	// Connections from within the process are always plaintext
+	s.internalSrv = &http.Server{
 		Handler: h2c.NewHandler(s.mux, &http2.Server{}),
 	}
+	go s.internalSrv.Serve(s.internalLis)
 
+	if s.tlsConfig != nil {
+		return s.httpSrv.ListenAndServeTLS("", "")
+	}
 	return s.httpSrv.ListenAndServe()
 }
 
 func (s *Server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	err := s.httpSrv.Shutdown(ctx)
+	err := errors.Join(s.httpSrv.Shutdown(ctx), s.internalSrv.Shutdown(ctx))
+	if s.probeSrv != nil {
+		err = errors.Join(err, s.probeSrv.Shutdown(ctx))
+	}
 	if s.grpcSrv != nil {
 		s.grpcSrv.Stop()
 	}
@@ -108,6 +170,9 @@ func (s *Server) Stop() error {
 }
 
 func (s *Server) ConnectAddress() string {
+	if s.tlsConfig != nil {
+		return "https://" + s.addr + connectPath
+	}
 	return "http://" + s.addr + connectPath
 }
 
@@ -115,6 +180,32 @@ func (s *Server) GRPCAddress() string {
 	return s.addr
 }
 
+// InternalConnectAddress is the Connect address of the server for clients
+// using InternalTransport.
+func (s *Server) InternalConnectAddress() string {
+	return "http://" + s.addr + connectPath
+}
+
+// InternalTransport connects HTTP clients to the server from within the same
+// process without TLS, regardless of the TLS config of the server.
+func (s *Server) InternalTransport() http.RoundTripper {
+	return &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, _ string, _ string, _ *tls.Config) (net.Conn, error) {
+			return s.internalLis.DialContext(ctx)
+		},
+	}
+}
+
+// InternalGRPCDialOption connects gRPC clients to the server from within the
+// same process without TLS, regardless of the TLS config of the server. The
+// dialed address is ignored.
+func (s *Server) InternalGRPCDialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.internalLis.DialContext(ctx)
+	})
+}
+
 func (s *Server) registerConnectReflection() {
 	reflector := grpcreflect.NewStaticReflector(s.connectSvcNames...)
 	s.mux.Handle(grpcreflect.NewHandlerV1(reflector))
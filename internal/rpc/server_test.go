@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServer_internalConnections(t *testing.T) {
+	srv := NewServer("127.0.0.1:0")
+	srv.RegisterHealth(health.NewServer())
+
+	go srv.Serve()
+	defer func() {
+		require.NoError(t, srv.Stop())
+	}()
+
+	httpClient := &http.Client{Transport: srv.InternalTransport()}
+	res, err := httpClient.Get("http://" + srv.GRPCAddress() + livenessPath)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	conn, err := grpc.NewClient("passthrough:///internal",
+		srv.InternalGRPCDialOption(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	healthRes, err := grpchealthv1.NewHealthClient(conn).Check(context.Background(), &grpchealthv1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpchealthv1.HealthCheckResponse_SERVING, healthRes.Status)
+}
+
+func TestServer_WithProbeAddress(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	probeAddr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	srv := NewServer("127.0.0.1:0")
+	srv.WithProbeAddress(probeAddr)
+	srv.RegisterHealth(health.NewServer())
+
+	go srv.Serve()
+	defer func() {
+		require.NoError(t, srv.Stop())
+	}()
+
+	// The probes are served over plaintext HTTP on the probe address
+	for _, path := range []string{livenessPath, readinessPath} {
+		require.EventuallyWithT(t, func(c *assert.CollectT) {
+			res, err := http.Get("http://" + probeAddr + path)
+			require.NoError(c, err)
+			defer res.Body.Close()
+			assert.Equal(c, http.StatusOK, res.StatusCode)
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// and not on the server address
+	httpClient := &http.Client{Transport: srv.InternalTransport()}
+	res, err := httpClient.Get("http://" + srv.GRPCAddress() + livenessPath)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.NotEqual(t, http.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/annexsh/annex/internal/rpc"
+
+func startRPCSpan(ctx context.Context, system attribute.KeyValue, procedure string, kind trace.SpanKind) (context.Context, trace.Span) {
+	service, method := splitProcedure(procedure)
+	return otel.Tracer(tracerName).Start(ctx, service+"/"+method,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(system, semconv.RPCService(service), semconv.RPCMethod(method)),
+	)
+}
+
+func endConnectSpan(span trace.Span, err error) {
+	if err != nil {
+		span.SetAttributes(semconv.RPCConnectRPCErrorCodeKey.String(connect.CodeOf(err).String()))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func endGRPCSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ConnectTracingInterceptor starts a span for each Connect RPC. Trace context
+// is extracted from handler request headers and injected into client request
+// headers.
+type ConnectTracingInterceptor struct{}
+
+func NewConnectTracingInterceptor() *ConnectTracingInterceptor {
+	return &ConnectTracingInterceptor{}
+}
+
+func (c *ConnectTracingInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		propagator := otel.GetTextMapPropagator()
+		kind := trace.SpanKindServer
+		if req.Spec().IsClient {
+			kind = trace.SpanKindClient
+		} else {
+			ctx = propagator.Extract(ctx, propagation.HeaderCarrier(req.Header()))
+		}
+
+		ctx, span := startRPCSpan(ctx, semconv.RPCSystemConnectRPC, req.Spec().Procedure, kind)
+		if req.Spec().IsClient {
+			propagator.Inject(ctx, propagation.HeaderCarrier(req.Header()))
+		}
+
+		res, err := next(ctx, req)
+		endConnectSpan(span, err)
+		return res, err
+	}
+}
+
+func (c *ConnectTracingInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(conn.RequestHeader()))
+		return conn
+	}
+}
+
+func (c *ConnectTracingInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(conn.RequestHeader()))
+		ctx, span := startRPCSpan(ctx, semconv.RPCSystemConnectRPC, conn.Spec().Procedure, trace.SpanKindServer)
+		err := next(ctx, conn)
+		endConnectSpan(span, err)
+		return err
+	}
+}
+
+func tracingUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startGRPCServerSpan(ctx, info.FullMethod)
+		res, err := handler(ctx, req)
+		endGRPCSpan(span, err)
+		return res, err
+	}
+}
+
+func tracingStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startGRPCServerSpan(ss.Context(), info.FullMethod)
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		endGRPCSpan(span, err)
+		return err
+	}
+}
+
+func startGRPCServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	return startRPCSpan(ctx, semconv.RPCSystemGRPC, fullMethod, trace.SpanKindServer)
+}
+
+func tracingUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startGRPCClientSpan(ctx, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		endGRPCSpan(span, err)
+		return err
+	}
+}
+
+func tracingStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startGRPCClientSpan(ctx, method)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		// The span covers stream creation only since the stream lifetime is
+		// controlled by the caller.
+		endGRPCSpan(span, err)
+		return stream, err
+	}
+}
+
+func startGRPCClientSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	ctx, span := startRPCSpan(ctx, semconv.RPCSystemGRPC, fullMethod, trace.SpanKindClient)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// contextServerStream overrides the context of a server stream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// metadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	if vals := metadata.MD(m).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (m metadataCarrier) Set(key string, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func setupTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider := otel.GetTracerProvider()
+	prevPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return recorder
+}
+
+func TestConnectTracingInterceptor(t *testing.T) {
+	recorder := setupTestTracing(t)
+	ctx := context.Background()
+
+	var handlerSpanCtx trace.SpanContext
+
+	procedure := "/annex.test.v1.TracingTestService/Ping"
+	handler := connect.NewUnaryHandler(procedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			handlerSpanCtx = trace.SpanContextFromContext(ctx)
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		connect.WithInterceptors(NewConnectTracingInterceptor()),
+	)
+	httpSrv := httptest.NewServer(handler)
+	defer httpSrv.Close()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](httpSrv.Client(), httpSrv.URL+procedure, WithConnectClientInterceptors())
+
+	ctx, parent := otel.Tracer("test").Start(ctx, "parent")
+	_, err := client.CallUnary(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	serverSpan, clientSpan := spans[0], spans[1]
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, "annex.test.v1.TracingTestService/Ping", serverSpan.Name())
+
+	// Trace context propagates from the caller through the client to the handler
+	traceID := parent.SpanContext().TraceID()
+	assert.Equal(t, traceID, clientSpan.SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+	assert.Equal(t, traceID, serverSpan.SpanContext().TraceID())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), handlerSpanCtx.SpanID())
+}
+
+func TestGRPCTracingInterceptors(t *testing.T) {
+	recorder := setupTestTracing(t)
+	ctx := context.Background()
+	method := "/annex.test.v1.TracingTestService/Ping"
+
+	// Client injects the trace context into the outgoing metadata
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	err := tracingUnaryClientInterceptor()(ctx, method, nil, nil, nil, invoker)
+	require.NoError(t, err)
+	require.NotEmpty(t, outgoing.Get("traceparent"))
+
+	// Server extracts the trace context from the incoming metadata
+	incomingCtx := metadata.NewIncomingContext(ctx, outgoing)
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err = tracingUnaryServerInterceptor()(incomingCtx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	clientSpan, serverSpan := spans[0], spans[1]
+	assert.Equal(t, clientSpan.SpanContext().TraceID(), serverSpan.SpanContext().TraceID())
+	assert.Equal(t, clientSpan.SpanContext().SpanID(), serverSpan.Parent().SpanID())
+}
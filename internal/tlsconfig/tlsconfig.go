@@ -0,0 +1,253 @@
+// Package tlsconfig creates TLS configurations from certificate files that
+// are reloaded when the files change, so certificates can be rotated without
+// restarting services.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/annexsh/annex/log"
+)
+
+const defaultReloadInterval = 30 * time.Second
+
+type Config struct {
+	CertFile string // PEM certificate chain (required for servers)
+	KeyFile  string // PEM private key of the certificate (required with CertFile)
+	// CAFile is a PEM bundle of CAs used to verify peer certificates. Servers
+	// require and verify client certificates (mTLS) when set. Clients verify
+	// server certificates against the system roots when unset.
+	CAFile string
+	// ServerName overrides the server name clients verify in the server
+	// certificate. Defaults to the host that is dialed.
+	ServerName string
+	// ReloadInterval is how often the files are checked for changes.
+	// Defaults to 30 seconds.
+	ReloadInterval time.Duration
+	Logger         log.Logger // logger for reload errors (optional)
+}
+
+// NewServer creates a server TLS configuration. The files are reloaded in the
+// background until the context is cancelled.
+func NewServer(ctx context.Context, cfg Config) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("server tls requires a certificate and key file")
+	}
+
+	r, err := newReloader(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			files := r.current()
+			tlsCfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2", "http/1.1"},
+				Certificates: []tls.Certificate{*files.cert},
+			}
+			if files.caPool != nil {
+				tlsCfg.ClientCAs = files.caPool
+				tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return tlsCfg, nil
+		},
+	}, nil
+}
+
+// NewClient creates a client TLS configuration. A client certificate is only
+// presented if a certificate and key file are set. The files are reloaded in
+// the background until the context is cancelled.
+func NewClient(ctx context.Context, cfg Config) (*tls.Config, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("client tls requires both a certificate and key file or neither")
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+
+	if cfg.CertFile == "" && cfg.CAFile == "" {
+		return tlsCfg, nil
+	}
+
+	r, err := newReloader(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CertFile != "" {
+		tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.current().cert, nil
+		}
+	}
+
+	if cfg.CAFile != "" {
+		// The default verification is replaced so that the server certificate
+		// is verified against the latest CAs rather than those loaded when the
+		// config was created.
+		tlsCfg.InsecureSkipVerify = true
+		tlsCfg.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyServer(state, r.current().caPool)
+		}
+	}
+
+	return tlsCfg, nil
+}
+
+func verifyServer(state tls.ConnectionState, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+	if state.ServerName == "" {
+		return errors.New("server name required to verify server certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       state.ServerName,
+	})
+	return err
+}
+
+type loadedFiles struct {
+	cert     *tls.Certificate
+	caPool   *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// reloader holds the certificate and CA pool loaded from files, which are
+// replaced whenever any of the files are modified.
+type reloader struct {
+	cfg    Config
+	files  atomic.Pointer[loadedFiles]
+	logger log.Logger
+}
+
+func newReloader(ctx context.Context, cfg Config) (*reloader, error) {
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = defaultReloadInterval
+	}
+	r := &reloader{
+		cfg:    cfg,
+		logger: cfg.Logger,
+	}
+	if r.logger == nil {
+		r.logger = log.NewNopLogger()
+	}
+
+	files, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.files.Store(files)
+
+	go r.run(ctx)
+	return r, nil
+}
+
+func (r *reloader) current() *loadedFiles {
+	return r.files.Load()
+}
+
+func (r *reloader) run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.modified() {
+				continue
+			}
+			files, err := r.load()
+			if err != nil {
+				// Keep serving the previous files, which may have been
+				// observed mid-rotation
+				r.logger.Error("failed to reload tls files", "error", err)
+				continue
+			}
+			r.files.Store(files)
+			r.logger.Info("reloaded tls files")
+		}
+	}
+}
+
+func (r *reloader) paths() []string {
+	var paths []string
+	for _, path := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func (r *reloader) modified() bool {
+	prev := r.current().modTimes
+	for _, path := range r.paths() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return true // surface the error on load
+		}
+		if !info.ModTime().Equal(prev[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reloader) load() (*loadedFiles, error) {
+	files := &loadedFiles{
+		modTimes: map[string]time.Time{},
+	}
+
+	// Modification times are recorded before reading so that changes made
+	// while reading are picked up by the next check
+	for _, path := range r.paths() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		files.modTimes[path] = info.ModTime()
+	}
+
+	if r.cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate: %w", err)
+		}
+		files.cert = &cert
+	}
+
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ca file '%s'", r.cfg.CAFile)
+		}
+		files.caPool = pool
+	}
+
+	return files, nil
+}
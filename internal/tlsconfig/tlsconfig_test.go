@@ -0,0 +1,217 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.writeCert(t, dir, "server", "localhost")
+	caFile := ca.write(t, dir)
+
+	srvCfg, err := NewServer(ctx, Config{CertFile: serverCert, KeyFile: serverKey})
+	require.NoError(t, err)
+
+	clientCfg, err := NewClient(ctx, Config{CAFile: caFile, ServerName: "localhost"})
+	require.NoError(t, err)
+
+	state, err := handshake(t, srvCfg, clientCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "server", state.PeerCertificates[0].Subject.CommonName)
+
+	// Server name mismatch
+	clientCfg, err = NewClient(ctx, Config{CAFile: caFile, ServerName: "example.com"})
+	require.NoError(t, err)
+	_, err = handshake(t, srvCfg, clientCfg)
+	require.Error(t, err)
+
+	// Untrusted CA
+	clientCfg, err = NewClient(ctx, Config{CAFile: newTestCA(t).write(t, t.TempDir()), ServerName: "localhost"})
+	require.NoError(t, err)
+	_, err = handshake(t, srvCfg, clientCfg)
+	require.Error(t, err)
+}
+
+func TestNewServer_mTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.writeCert(t, dir, "server", "localhost")
+	clientCert, clientKey := ca.writeCert(t, dir, "client", "")
+	caFile := ca.write(t, dir)
+
+	srvCfg, err := NewServer(ctx, Config{CertFile: serverCert, KeyFile: serverKey, CAFile: caFile})
+	require.NoError(t, err)
+
+	clientCfg, err := NewClient(ctx, Config{CertFile: clientCert, KeyFile: clientKey, CAFile: caFile, ServerName: "localhost"})
+	require.NoError(t, err)
+	_, err = handshake(t, srvCfg, clientCfg)
+	require.NoError(t, err)
+
+	// No client certificate
+	clientCfg, err = NewClient(ctx, Config{CAFile: caFile, ServerName: "localhost"})
+	require.NoError(t, err)
+	_, err = handshake(t, srvCfg, clientCfg)
+	require.Error(t, err)
+}
+
+func TestNewServer_reload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	serverCert, serverKey := ca.writeCert(t, dir, "server", "localhost")
+	caFile := ca.write(t, dir)
+
+	srvCfg, err := NewServer(ctx, Config{CertFile: serverCert, KeyFile: serverKey, ReloadInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	clientCfg, err := NewClient(ctx, Config{CAFile: caFile, ServerName: "localhost"})
+	require.NoError(t, err)
+
+	// Rotate the certificate with a later modification time
+	ca.writeCert(t, dir, "rotated", "localhost")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(serverCert, later, later))
+	require.NoError(t, os.Chtimes(serverKey, later, later))
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		state, err := handshake(t, srvCfg, clientCfg)
+		if assert.NoError(c, err) {
+			assert.Equal(c, "rotated", state.PeerCertificates[0].Subject.CommonName)
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func handshake(t *testing.T, srvCfg *tls.Config, clientCfg *tls.Config) (tls.ConnectionState, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	srvErrCh := make(chan error, 1)
+	go func() {
+		srvConn, err := lis.Accept()
+		if err != nil {
+			srvErrCh <- err
+			return
+		}
+		defer srvConn.Close()
+		srv := tls.Server(srvConn, srvCfg)
+		err = srv.Handshake()
+		if err == nil {
+			// Wait for the client to verify the server certificate
+			_, err = srv.Read(make([]byte, 1))
+		}
+		srvErrCh <- err
+	}()
+
+	clientConn, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer clientConn.Close()
+
+	client := tls.Client(clientConn, clientCfg.Clone())
+	if err := client.Handshake(); err != nil {
+		return tls.ConnectionState{}, err
+	}
+	if _, err := client.Write([]byte{0}); err != nil {
+		return tls.ConnectionState{}, err
+	}
+	if err := <-srvErrCh; err != nil {
+		return tls.ConnectionState{}, err
+	}
+	return client.ConnectionState(), nil
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, der: der}
+}
+
+func (ca *testCA) write(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "ca.pem")
+	writePEM(t, path, "CERTIFICATE", ca.der)
+	return path
+}
+
+// writeCert writes a certificate signed by the CA to server.pem and
+// server-key.pem, or client.pem and client-key.pem if there is no host.
+func (ca *testCA) writeCert(t *testing.T, dir string, commonName string, host string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	name := "client"
+	if host != "" {
+		name = "server"
+		tmpl.DNSNames = []string{host}
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".pem")
+	keyPath := filepath.Join(dir, name+"-key.pem")
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+	return certPath, keyPath
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
@@ -0,0 +1,151 @@
+// Package tracing configures OpenTelemetry tracing for Annex services.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/annexsh/annex/log"
+)
+
+// Exporter is the destination spans are exported to.
+type Exporter string
+
+const (
+	// ExporterNone disables span exporting. Trace context is still
+	// propagated between services.
+	ExporterNone     Exporter = ""
+	ExporterStdout   Exporter = "stdout"
+	ExporterOTLPGRPC Exporter = "otlpGRPC"
+	ExporterOTLPHTTP Exporter = "otlpHTTP"
+)
+
+type ErrorLogger interface {
+	Error(msg string, args ...any)
+}
+
+type Config struct {
+	ServiceName string   // service name reported with all spans
+	Exporter    Exporter // span exporter (optional)
+	// Endpoint is the OTLP collector host and port. The OTLP exporters fall
+	// back to the standard OTEL_EXPORTER_OTLP_* environment variables when
+	// unset.
+	Endpoint    string
+	Insecure    bool        // disables TLS for the OTLP exporters
+	SampleRatio float64     // fraction of new traces sampled, defaults to 1 (optional)
+	Logger      ErrorLogger // logger for export errors (optional)
+	// Writer is the destination of the stdout exporter. Defaults to
+	// os.Stdout.
+	Writer io.Writer
+}
+
+// Setup installs the global tracer provider and trace context propagator.
+// Spans are exported asynchronously, so services start and run without a
+// collector present. The returned function flushes any buffered spans and
+// shuts the tracer provider down.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if cfg.Logger == nil {
+		cfg.Logger = log.NewNopLogger()
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	sampleRatio := cfg.SampleRatio
+	if sampleRatio <= 0 {
+		sampleRatio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		cfg.Logger.Error("opentelemetry error", "error", err)
+	}))
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		w := cfg.Writer
+		if w == nil {
+			w = os.Stdout
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	case ExporterOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, errors.New("unknown exporter")
+	}
+}
+
+// QueryName returns the name of a sqlc generated query, which is declared in
+// the first line of the query in the form "-- name: QueryName :kind".
+// Queries without a name are named after their first keyword.
+func QueryName(query string) string {
+	query = strings.TrimSpace(query)
+	if rest, ok := strings.CutPrefix(query, "-- name:"); ok {
+		if fields := strings.Fields(rest); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		exporter Exporter
+	}{
+		{name: "none", exporter: ExporterNone},
+		{name: "stdout", exporter: ExporterStdout},
+		{name: "otlp grpc without collector", exporter: ExporterOTLPGRPC},
+		{name: "otlp http without collector", exporter: ExporterOTLPHTTP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			shutdown, err := Setup(ctx, Config{
+				ServiceName: "annex-test",
+				Exporter:    tt.exporter,
+				Endpoint:    "127.0.0.1:1", // nothing listening
+				Insecure:    true,
+				Writer:      out,
+			})
+			require.NoError(t, err)
+
+			_, span := otel.Tracer("test").Start(ctx, "foo")
+			span.End()
+
+			if tt.exporter == ExporterStdout {
+				require.NoError(t, shutdown(ctx))
+				assert.Contains(t, out.String(), `"Name":"foo"`)
+				return
+			}
+
+			// Spans cannot be exported without a collector, so only ensure
+			// shutdown gives up once its context is done
+			shutdownCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			_ = shutdown(shutdownCtx)
+			assert.Less(t, time.Since(start), 5*time.Second)
+		})
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "-- name: CreateContext :exec\nINSERT INTO contexts (id) VALUES ($1)", want: "CreateContext"},
+		{query: "  -- name: ListTests :many\nSELECT * FROM tests", want: "ListTests"},
+		{query: "select 1", want: "SELECT"},
+		{query: "", want: "query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, QueryName(tt.query))
+		})
+	}
+}
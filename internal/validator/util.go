@@ -2,6 +2,7 @@ package validator
 
 import (
 	"net"
+	"time"
 
 	"github.com/cohesivestack/valgo"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -51,6 +52,17 @@ func HostPort(hostPort string, nameAndTitle ...string) valgo.Validator {
 	}, "{{title}} must be a network address of the form 'host:port'")
 }
 
+// Duration validates an optional non-negative duration string such as "1h30m".
+func Duration(duration string, nameAndTitle ...string) valgo.Validator {
+	return valgo.String(duration, nameAndTitle...).Passing(func(str string) bool {
+		if str == "" {
+			return true
+		}
+		d, err := time.ParseDuration(str)
+		return err == nil && d >= 0
+	}, "{{title}} must be a non-negative duration such as '720h'")
+}
+
 func Timestamppb(ts *timestamppb.Timestamp, nameAndTitle ...string) valgo.Validator {
 	return valgo.Any(ts, nameAndTitle...).Not().Nil().Passing(func(val any) bool {
 		tspb := val.(*timestamppb.Timestamp)
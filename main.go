@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 
+	"github.com/annexsh/annex/backup"
 	"github.com/annexsh/annex/server"
 )
 
@@ -22,29 +25,38 @@ func run(ctx context.Context) error {
 		return errors.New("server type argument required")
 	}
 
+	switch os.Args[1] {
+	case "config":
+		return runConfig()
+	case "migrate":
+		return runMigrate(ctx)
+	case "context":
+		return runContext(ctx)
+	}
+
 	srvType := os.Args[1]
 	os.Args = append(os.Args[:1], os.Args[2:]...)
 
 	switch srvType {
-	case "all":
+	case server.ServerTypeAllInOne:
 		cfg, err := server.LoadAllInOneConfig()
 		if err != nil {
 			return err
 		}
 		return server.ServeAllInOne(ctx, cfg)
-	case "test":
+	case server.ServerTypeTest:
 		cfg, err := server.LoadTestServiceConfig()
 		if err != nil {
 			return err
 		}
 		return server.ServeTestService(ctx, cfg)
-	case "event":
+	case server.ServerTypeEvent:
 		cfg, err := server.LoadEventServiceConfig()
 		if err != nil {
 			return err
 		}
 		return server.ServeEventService(ctx, cfg)
-	case "workflow-proxy":
+	case server.ServerTypeWorkflowProxy:
 		cfg, err := server.LoadWorkflowProxyServiceConfig()
 		if err != nil {
 			return err
@@ -54,3 +66,146 @@ func run(ctx context.Context) error {
 
 	return errors.New("invalid server type")
 }
+
+// runConfig runs the config subcommands, which show the effective config of
+// a server type without serving it:
+//
+//	config validate <server type> [flags]
+//	config print <server type> [flags]
+func runConfig() error {
+	if len(os.Args) < 4 {
+		return errors.New("usage: config <validate|print> <server type> [flags]")
+	}
+
+	action, srvType := os.Args[2], os.Args[3]
+	os.Args = append(os.Args[:1], os.Args[4:]...)
+
+	switch action {
+	case "validate":
+		if err := server.ValidateConfig(srvType); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("config is valid")
+		return nil
+	case "print":
+		if err := server.PrintConfig(os.Stdout, srvType); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("invalid config action %q", action)
+}
+
+// runMigrate runs the migrate subcommands, which manage the database schema
+// with the config of the all-in-one or test server:
+//
+//	migrate up [flags]
+//	migrate down [steps] [flags]
+//	migrate status [flags]
+//	migrate force <version> [flags]
+func runMigrate(ctx context.Context) error {
+	if len(os.Args) < 3 {
+		return errors.New("usage: migrate <up|down|status|force> [args] [flags]")
+	}
+
+	action := os.Args[2]
+	args := positionalArgs(3)
+
+	cfg, err := server.LoadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+	if err = server.Migrate(ctx, os.Stdout, cfg, action, args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return nil
+}
+
+// runContext runs the context subcommands, which export the test history of
+// a context to a backup archive and import it, with the config of the
+// all-in-one or test server. The file is stdout or stdin when "-":
+//
+//	context export <context> <file> [flags]
+//	context import <file> [fail|skip] [flags]
+func runContext(ctx context.Context) error {
+	const usage = "usage: context <export <context> <file>|import <file> [fail|skip]> [flags]"
+	if len(os.Args) < 3 {
+		return errors.New(usage)
+	}
+
+	action := os.Args[2]
+	args := positionalArgs(3)
+
+	var run func(cfg server.ContextConfig) (backup.Result, error)
+	switch {
+	case action == "export" && len(args) == 2:
+		run = func(cfg server.ContextConfig) (backup.Result, error) {
+			if args[1] == "-" {
+				return server.ExportContext(ctx, os.Stdout, cfg, args[0])
+			}
+			f, err := os.Create(args[1])
+			if err != nil {
+				return backup.Result{}, err
+			}
+			res, err := server.ExportContext(ctx, f, cfg, args[0])
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			return res, err
+		}
+	case action == "import" && (len(args) == 1 || len(args) == 2):
+		policy := backup.ConflictFail
+		if len(args) == 2 {
+			var err error
+			if policy, err = backup.ParseConflictPolicy(args[1]); err != nil {
+				return err
+			}
+		}
+		run = func(cfg server.ContextConfig) (backup.Result, error) {
+			if args[0] == "-" {
+				return server.ImportContext(ctx, os.Stdin, cfg, policy)
+			}
+			f, err := os.Open(args[0])
+			if err != nil {
+				return backup.Result{}, err
+			}
+			defer f.Close()
+			return server.ImportContext(ctx, f, cfg, policy)
+		}
+	default:
+		return errors.New(usage)
+	}
+
+	cfg, err := server.LoadContextConfig()
+	if err != nil {
+		return err
+	}
+	res, err := run(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%sed %d test suites, %d tests, %d test executions, %d case executions, %d logs and %d events (%d skipped)\n",
+		action, res.TestSuites, res.Tests, res.TestExecutions, res.CaseExecutions, res.Logs, res.Events, res.Skipped)
+	if action == "export" {
+		fmt.Fprintln(os.Stderr, "warning: test inputs are archived in plaintext, including secret fields; store the archive securely")
+	}
+	return nil
+}
+
+// positionalArgs removes the arguments from the index up to the first flag
+// from os.Args and returns them, leaving the flags to the config loader.
+func positionalArgs(from int) []string {
+	var args []string
+	rest := os.Args[from:]
+	for len(rest) > 0 && (rest[0] == "-" || !strings.HasPrefix(rest[0], "-")) {
+		args = append(args, rest[0])
+		rest = rest[1:]
+	}
+	os.Args = append(os.Args[:1], rest...)
+	return args
+}
@@ -1,16 +1,25 @@
 package nats
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
-	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	"github.com/nats-io/nats.go"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/conc"
 	"github.com/annexsh/annex/log"
 )
 
-const defaultSubBufferSize = 50
+const (
+	defaultSubBufferSize = 50
+	// batchHeader marks messages that contain a batch of events encoded by
+	// event.MarshalBatch.
+	batchHeader = "Annex-Event-Batch"
+)
 
 type PubSubOption func(opts *pubSubOptions)
 
@@ -26,20 +35,31 @@ func WithSubscriptionBufferSize(bufferSize int) PubSubOption {
 	}
 }
 
+// WithOverflowPolicy sets how events are handled for subscribers that are
+// not receiving events fast enough. Defaults to event.OverflowDropOldest.
+func WithOverflowPolicy(policy event.OverflowPolicy) PubSubOption {
+	return func(opts *pubSubOptions) {
+		opts.overflowPolicy = policy
+	}
+}
+
 type pubSubOptions struct {
-	bufferSize int
-	logger     log.Logger
+	bufferSize     int
+	overflowPolicy event.OverflowPolicy
+	logger         log.Logger
 }
 
 type PubSub struct {
 	conn *nats.Conn
 	opts pubSubOptions
+	subs *conc.Map[*event.Subscription]
 }
 
 func NewPubSub(conn *nats.Conn, opts ...PubSubOption) *PubSub {
 	options := pubSubOptions{
-		logger:     log.DefaultLogger(),
-		bufferSize: defaultSubBufferSize,
+		logger:         log.DefaultLogger(),
+		bufferSize:     defaultSubBufferSize,
+		overflowPolicy: event.OverflowDropOldest,
 	}
 	for _, opt := range opts {
 		opt(&options)
@@ -47,10 +67,11 @@ func NewPubSub(conn *nats.Conn, opts ...PubSubOption) *PubSub {
 	return &PubSub{
 		conn: conn,
 		opts: options,
+		subs: conc.NewMap[*event.Subscription](),
 	}
 }
 
-func (p *PubSub) Publish(testExecID string, event *eventsv1.Event) error {
+func (p *PubSub) Publish(testExecID string, event *executionsv1.ExecutionEvent) error {
 	msgb, err := proto.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal nats message: %w", err)
@@ -58,33 +79,81 @@ func (p *PubSub) Publish(testExecID string, event *eventsv1.Event) error {
 	return p.conn.Publish(testExecID, msgb)
 }
 
-func (p *PubSub) Subscribe(testExecID string) (<-chan *eventsv1.Event, func(), error) {
+func (p *PubSub) PublishBatch(testExecID string, events []*executionsv1.ExecutionEvent) error {
+	msgb, err := event.MarshalBatch(events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal nats message: %w", err)
+	}
+	msg := nats.NewMsg(testExecID)
+	msg.Header.Set(batchHeader, "true")
+	msg.Data = msgb
+	return p.conn.PublishMsg(msg)
+}
+
+func (p *PubSub) Subscribe(testExecID string) (<-chan *executionsv1.ExecutionEvent, func(), error) {
 	logger := p.opts.logger.With("subject", testExecID)
 
-	ch := make(chan *eventsv1.Event, p.opts.bufferSize)
+	subscr := event.NewSubscription(testExecID, p.opts.overflowPolicy, p.opts.bufferSize)
 
 	sub, err := p.conn.Subscribe(testExecID, func(msg *nats.Msg) {
-		out := &eventsv1.Event{}
-		if err := proto.Unmarshal(msg.Data, out); err != nil {
+		events, err := unmarshalMsg(msg)
+		if err != nil {
 			logger.Error("failed to unmarshal nats message", "error", err, "message", string(msg.Data))
 			return
 		}
-		ch <- out
+		for _, out := range events {
+			if subscr.Deliver(out) {
+				logger.Warn("disconnected slow nats subscriber", "buffer_size", p.opts.bufferSize)
+			}
+		}
 	})
 	if err != nil {
+		subscr.Close()
 		return nil, nil, err
 	}
 
-	if err = sub.SetPendingLimits(p.opts.bufferSize, nats.DefaultSubPendingBytesLimit); err != nil {
-		return nil, nil, err
-	}
+	p.subs.Set(subscr, subscr)
 
+	var once sync.Once
 	unsub := func() {
-		defer close(ch)
-		if uErr := sub.Unsubscribe(); err != nil {
-			logger.Error("failed to unsubscribe from nats subject", "error", uErr)
-		}
+		once.Do(func() {
+			if uErr := sub.Unsubscribe(); uErr != nil && !errors.Is(uErr, nats.ErrBadSubscription) {
+				logger.Error("failed to unsubscribe from nats subject", "error", uErr)
+			}
+			subscr.Close()
+			p.subs.Delete(subscr)
+
+			stats := subscr.Stats()
+			if stats.Dropped > 0 {
+				logger.Warn("nats subscriber dropped events",
+					"overflow_policy", p.opts.overflowPolicy.String(),
+					"delivered", stats.Delivered,
+					"dropped", stats.Dropped,
+				)
+			}
+		})
 	}
 
-	return ch, unsub, nil
+	return subscr.Events(), unsub, nil
+}
+
+// SubscriptionStats returns the delivery metrics of each active subscription.
+func (p *PubSub) SubscriptionStats() []event.SubscriptionStats {
+	var stats []event.SubscriptionStats
+	p.subs.Range(func(_ any, subscr *event.Subscription) bool {
+		stats = append(stats, subscr.Stats())
+		return true
+	})
+	return stats
+}
+
+func unmarshalMsg(msg *nats.Msg) ([]*executionsv1.ExecutionEvent, error) {
+	if msg.Header.Get(batchHeader) != "" {
+		return event.UnmarshalBatch(msg.Data)
+	}
+	out := &executionsv1.ExecutionEvent{}
+	if err := proto.Unmarshal(msg.Data, out); err != nil {
+		return nil, err
+	}
+	return []*executionsv1.ExecutionEvent{out}, nil
 }
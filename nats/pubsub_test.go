@@ -0,0 +1,222 @@
+package nats
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/test"
+)
+
+func TestPubSub(t *testing.T) {
+	pubSub := newTestPubSub(t)
+	testExecID := test.NewTestExecutionID()
+	subject := testExecID.String()
+
+	sub, unsub, err := pubSub.Subscribe(subject)
+	require.NoError(t, err)
+	defer unsub()
+
+	want := genEvents(testExecID, 3)
+	for _, e := range want {
+		require.NoError(t, pubSub.Publish(subject, e))
+	}
+
+	for _, e := range want {
+		got := receive(t, sub)
+		assert.Equal(t, e.Event.EventId, got.Event.EventId)
+	}
+
+	stats := pubSub.SubscriptionStats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, subject, stats[0].Subject)
+	assert.Equal(t, uint64(len(want)), stats[0].Delivered)
+	assert.Zero(t, stats[0].Dropped)
+}
+
+func TestPubSub_PublishBatch(t *testing.T) {
+	pubSub := newTestPubSub(t)
+	testExecID := test.NewTestExecutionID()
+	subject := testExecID.String()
+
+	sub, unsub, err := pubSub.Subscribe(subject)
+	require.NoError(t, err)
+	defer unsub()
+
+	want := genEvents(testExecID, 3)
+	require.NoError(t, pubSub.PublishBatch(subject, want))
+	require.NoError(t, pubSub.Publish(subject, want[0]))
+
+	var got []*executionsv1.ExecutionEvent
+	for range len(want) + 1 {
+		got = append(got, receive(t, sub))
+	}
+	for i, e := range append(want, want[0]) {
+		assert.Equal(t, e.Event.EventId, got[i].Event.EventId)
+		assert.Equal(t, e.Sequence, got[i].Sequence)
+	}
+}
+
+func TestPubSub_overflow(t *testing.T) {
+	bufferSize := 2
+	numEvents := 5
+
+	tests := []struct {
+		name             string
+		policy           event.OverflowPolicy
+		wantEventIndexes []int
+		wantDisconnected bool
+	}{
+		{
+			name:             "drop oldest",
+			policy:           event.OverflowDropOldest,
+			wantEventIndexes: []int{3, 4},
+		},
+		{
+			name:             "drop newest",
+			policy:           event.OverflowDropNewest,
+			wantEventIndexes: []int{0, 1},
+		},
+		{
+			name:             "disconnect",
+			policy:           event.OverflowDisconnect,
+			wantEventIndexes: []int{0, 1},
+			wantDisconnected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pubSub := newTestPubSub(t, WithSubscriptionBufferSize(bufferSize), WithOverflowPolicy(tt.policy))
+			testExecID := test.NewTestExecutionID()
+			subject := testExecID.String()
+
+			sub, unsub, err := pubSub.Subscribe(subject)
+			require.NoError(t, err)
+			defer unsub()
+
+			events := genEvents(testExecID, numEvents)
+			for _, e := range events {
+				require.NoError(t, pubSub.Publish(subject, e))
+			}
+			require.NoError(t, pubSub.conn.Flush())
+
+			require.Eventually(t, func() bool {
+				stats := pubSub.SubscriptionStats()
+				return len(stats) == 1 && stats[0].Delivered+stats[0].Dropped >= uint64(numEvents)
+			}, 5*time.Second, 10*time.Millisecond)
+
+			for _, i := range tt.wantEventIndexes {
+				got := receive(t, sub)
+				assert.Equal(t, events[i].Event.EventId, got.Event.EventId)
+			}
+
+			if tt.wantDisconnected {
+				_, ok := <-sub
+				assert.False(t, ok, "subscription should be closed")
+			}
+
+			stats := pubSub.SubscriptionStats()
+			require.Len(t, stats, 1)
+			assert.Equal(t, uint64(numEvents-bufferSize), stats[0].Dropped)
+			assert.Equal(t, tt.wantDisconnected, stats[0].Disconnected)
+		})
+	}
+}
+
+func TestPubSub_unsubscribe(t *testing.T) {
+	pubSub := newTestPubSub(t, WithSubscriptionBufferSize(1), WithOverflowPolicy(event.OverflowDisconnect))
+	testExecID := test.NewTestExecutionID()
+	subject := testExecID.String()
+
+	numSubs := 20
+	var wg sync.WaitGroup
+
+	// Publish continuously while subscribers unsubscribe concurrently with
+	// delivery and the disconnect overflow policy closing their channels.
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for _, e := range genEvents(testExecID, 1) {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					assert.NoError(t, pubSub.Publish(subject, e))
+				}
+			}
+		}
+	}()
+
+	for range numSubs {
+		sub, unsub, err := pubSub.Subscribe(subject)
+		require.NoError(t, err)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range sub {
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			time.Sleep(10 * time.Millisecond)
+			unsub()
+			unsub() // unsubscribe must be idempotent
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	assert.Empty(t, pubSub.SubscriptionStats())
+}
+
+func newTestPubSub(t *testing.T, opts ...PubSubOption) *PubSub {
+	ns, err := NewEmbeddedNatsServer("127.0.0.1:-1")
+	require.NoError(t, err)
+	go ns.Start()
+	require.True(t, ns.ReadyForConnections(10*time.Second))
+	t.Cleanup(ns.Shutdown)
+
+	nc, err := nats.Connect("", nats.InProcessServer(ns))
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	return NewPubSub(nc, append([]PubSubOption{WithLogger(log.NewNopLogger())}, opts...)...)
+}
+
+func genEvents(testExecID test.TestExecutionID, count int) []*executionsv1.ExecutionEvent {
+	events := make([]*executionsv1.ExecutionEvent, count)
+	for i := range events {
+		log := fake.GenTestExecLog(testExecID)
+		events[i] = &executionsv1.ExecutionEvent{
+			Event:    event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, log.Proto()),
+			Sequence: uint64(i) + 1,
+		}
+	}
+	return events
+}
+
+func receive(t *testing.T, sub <-chan *executionsv1.ExecutionEvent) *executionsv1.ExecutionEvent {
+	select {
+	case e, ok := <-sub:
+		require.True(t, ok, "subscription closed")
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for event")
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/postgres/sqlc"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+var (
+	_ auth.APIKeyReader = (*APIKeyReader)(nil)
+	_ auth.APIKeyWriter = (*APIKeyWriter)(nil)
+)
+
+type APIKeyReader struct {
+	db *DB
+}
+
+func NewAPIKeyReader(db *DB) *APIKeyReader {
+	return &APIKeyReader{db: db}
+}
+
+func (a *APIKeyReader) GetAPIKeyByHash(ctx context.Context, hash []byte) (*auth.APIKey, error) {
+	key, err := a.db.GetAPIKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, auth.ErrorAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return marshalAPIKey(key), nil
+}
+
+func (a *APIKeyReader) ListAPIKeys(ctx context.Context, filter test.PageFilter[uuid.V7]) (auth.APIKeyList, error) {
+	params := sqlc.ListAPIKeysParams{
+		PageSize: int32(filter.Size),
+	}
+	if filter.OffsetID != nil {
+		params.OffsetID = filter.OffsetID
+	}
+
+	keys, err := a.db.ListAPIKeys(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return marshalAPIKeys(keys), nil
+}
+
+type APIKeyWriter struct {
+	db *DB
+}
+
+func NewAPIKeyWriter(db *DB) *APIKeyWriter {
+	return &APIKeyWriter{db: db}
+}
+
+func (a *APIKeyWriter) CreateAPIKey(ctx context.Context, key *auth.APIKey, hash []byte) error {
+	return a.db.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
+		ID:         key.ID,
+		Name:       key.Name,
+		Hash:       hash,
+		CreateTime: key.CreateTime,
+	})
+}
+
+func (a *APIKeyWriter) RevokeAPIKey(ctx context.Context, id uuid.V7, revokeTime time.Time) error {
+	count, err := a.db.RevokeAPIKey(ctx, sqlc.RevokeAPIKeyParams{
+		ID:         id,
+		RevokeTime: &revokeTime,
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return auth.ErrorAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+
+	w := NewAPIKeyWriter(db)
+	r := NewAPIKeyReader(db)
+
+	count := 3
+	var want auth.APIKeyList
+	hashes := make([][]byte, count)
+	for i := 0; i < count; i++ {
+		_, hash, err := auth.NewAPIKeySecret()
+		require.NoError(t, err)
+		key := &auth.APIKey{
+			ID:         uuid.New(),
+			Name:       fmt.Sprint("key-", i),
+			CreateTime: time.Now().UTC().Truncate(time.Microsecond),
+		}
+		require.NoError(t, w.CreateAPIKey(ctx, key, hash))
+		want = append(auth.APIKeyList{key}, want...) // newest first
+		hashes[i] = hash
+	}
+
+	got, err := r.GetAPIKeyByHash(ctx, hashes[0])
+	require.NoError(t, err)
+	assert.Equal(t, want[count-1], got)
+
+	_, err = r.GetAPIKeyByHash(ctx, []byte("unknown"))
+	assert.ErrorIs(t, err, auth.ErrorAPIKeyNotFound)
+
+	page1, err := r.ListAPIKeys(ctx, test.PageFilter[uuid.V7]{Size: 2})
+	require.NoError(t, err)
+	assert.Equal(t, want[:2], page1)
+
+	page2, err := r.ListAPIKeys(ctx, test.PageFilter[uuid.V7]{Size: 2, OffsetID: &page1[1].ID})
+	require.NoError(t, err)
+	assert.Equal(t, want[2:], page2)
+
+	revokeTime := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, w.RevokeAPIKey(ctx, want[0].ID, revokeTime))
+	got, err = r.GetAPIKeyByHash(ctx, hashes[count-1])
+	require.NoError(t, err)
+	require.NotNil(t, got.RevokeTime)
+	assert.True(t, revokeTime.Equal(*got.RevokeTime))
+
+	err = w.RevokeAPIKey(ctx, want[0].ID, revokeTime)
+	assert.ErrorIs(t, err, auth.ErrorAPIKeyNotFound, "already revoked")
+
+	err = w.RevokeAPIKey(ctx, uuid.New(), revokeTime)
+	assert.ErrorIs(t, err, auth.ErrorAPIKeyNotFound)
+}
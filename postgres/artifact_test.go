@@ -0,0 +1,77 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestCreateGetArtifact(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewArtifactWriter(db)
+	r := NewArtifactReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+	want := fake.GenCaseExecArtifact(dummyTestExec.ID, fake.GenCaseID())
+
+	err := w.CreateArtifact(ctx, want)
+	require.NoError(t, err)
+
+	got, err := r.GetArtifact(ctx, want.ID)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = r.GetArtifact(ctx, uuid.New())
+	assert.ErrorIs(t, err, test.ErrorArtifactNotFound)
+}
+
+func TestListArtifacts(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewArtifactWriter(db)
+	r := NewArtifactReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+	caseExecID := fake.GenCaseID()
+
+	want := test.ArtifactList{
+		fake.GenTestExecArtifact(dummyTestExec.ID),
+		fake.GenCaseExecArtifact(dummyTestExec.ID, caseExecID),
+		fake.GenCaseExecArtifact(dummyTestExec.ID, caseExecID),
+	}
+	for _, artifact := range want {
+		err := w.CreateArtifact(ctx, artifact)
+		require.NoError(t, err)
+	}
+
+	t.Run("paginated", func(t *testing.T) {
+		got, err := r.ListArtifacts(ctx, dummyTestExec.ID, nil, test.PageFilter[uuid.V7]{Size: 2})
+		require.NoError(t, err)
+		assert.Equal(t, want[:2], got)
+
+		got, err = r.ListArtifacts(ctx, dummyTestExec.ID, nil, test.PageFilter[uuid.V7]{
+			Size:     2,
+			OffsetID: ptr.Get(got[1].ID),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, want[2:], got)
+	})
+
+	t.Run("case execution", func(t *testing.T) {
+		got, err := r.ListArtifacts(ctx, dummyTestExec.ID, &caseExecID, test.PageFilter[uuid.V7]{Size: 10})
+		require.NoError(t, err)
+		assert.Equal(t, want[1:], got)
+	})
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/postgres/sqlc"
+)
+
+var (
+	_ audit.Reader = (*AuditReader)(nil)
+	_ audit.Writer = (*AuditWriter)(nil)
+)
+
+type auditRepository struct {
+	*AuditReader
+	*AuditWriter
+}
+
+func NewAuditRepository(db *DB) audit.Repository {
+	return &auditRepository{
+		AuditReader: NewAuditReader(db),
+		AuditWriter: NewAuditWriter(db),
+	}
+}
+
+type AuditReader struct {
+	db *DB
+}
+
+func NewAuditReader(db *DB) *AuditReader {
+	return &AuditReader{db: db}
+}
+
+func (r *AuditReader) ListEntries(ctx context.Context, contextID string, filter audit.Filter) (audit.EntryList, error) {
+	params := sqlc.ListAuditEntriesParams{
+		ContextID: contextID,
+		Subject:   filter.Subject,
+		TestID:    filter.TestID,
+		OffsetID:  filter.OffsetID,
+		PageSize:  int32(filter.Size),
+	}
+	if filter.Action != nil {
+		params.Action = ptr.Get(string(*filter.Action))
+	}
+	if filter.TestExecutionID != nil {
+		params.TestExecutionID = &filter.TestExecutionID.V7
+	}
+
+	entries, err := r.db.ListAuditEntries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return marshalAuditEntries(entries), nil
+}
+
+type AuditWriter struct {
+	db *DB
+}
+
+func NewAuditWriter(db *DB) *AuditWriter {
+	return &AuditWriter{db: db}
+}
+
+func (w *AuditWriter) CreateEntry(ctx context.Context, entry *audit.Entry) error {
+	return w.db.CreateAuditEntry(ctx, sqlc.CreateAuditEntryParams{
+		ID:              entry.ID,
+		ContextID:       entry.Context,
+		Action:          string(entry.Action),
+		Subject:         entry.Subject,
+		AuthMethod:      string(entry.AuthMethod),
+		TestSuiteID:     entry.TestSuiteID,
+		TestID:          entry.TestID,
+		TestExecutionID: entry.TestExecutionID,
+		Summary:         entry.Summary,
+		CreateTime:      entry.CreateTime,
+	})
+}
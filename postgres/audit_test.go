@@ -0,0 +1,101 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestAuditEntries(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+
+	w := NewAuditWriter(db)
+	r := NewAuditReader(db)
+
+	testID := uuid.New()
+	testExecID := test.NewTestExecutionID()
+
+	entries := audit.EntryList{
+		{
+			ID:         uuid.New(),
+			Context:    "foo",
+			Action:     audit.ActionRegisterContext,
+			Summary:    "registered context 'foo'",
+			CreateTime: time.Now().UTC().Truncate(time.Microsecond),
+		},
+		{
+			ID:              uuid.New(),
+			Context:         "foo",
+			Action:          audit.ActionExecuteTest,
+			Subject:         "alice",
+			AuthMethod:      auth.MethodJWT,
+			TestSuiteID:     ptr.Get(uuid.New()),
+			TestID:          &testID,
+			TestExecutionID: &testExecID,
+			Summary:         "executed test 'bar'",
+			CreateTime:      time.Now().UTC().Truncate(time.Microsecond),
+		},
+		{
+			ID:              uuid.New(),
+			Context:         "foo",
+			Action:          audit.ActionRetryTestExecution,
+			Subject:         "bob",
+			AuthMethod:      auth.MethodAPIKey,
+			TestID:          &testID,
+			TestExecutionID: &testExecID,
+			Summary:         "retried test execution",
+			CreateTime:      time.Now().UTC().Truncate(time.Microsecond),
+		},
+		{
+			ID:         uuid.New(),
+			Context:    "bar",
+			Action:     audit.ActionRegisterContext,
+			Summary:    "registered context 'bar'",
+			CreateTime: time.Now().UTC().Truncate(time.Microsecond),
+		},
+	}
+	for _, e := range entries {
+		require.NoError(t, w.CreateEntry(ctx, e))
+	}
+
+	page := test.PageFilter[uuid.V7]{Size: 10}
+
+	got, err := r.ListEntries(ctx, "foo", audit.Filter{PageFilter: page})
+	require.NoError(t, err)
+	assert.Equal(t, audit.EntryList{entries[2], entries[1], entries[0]}, got)
+
+	got, err = r.ListEntries(ctx, "foo", audit.Filter{PageFilter: page, Subject: ptr.Get("alice")})
+	require.NoError(t, err)
+	assert.Equal(t, audit.EntryList{entries[1]}, got)
+
+	got, err = r.ListEntries(ctx, "foo", audit.Filter{PageFilter: page, Action: ptr.Get(audit.ActionRetryTestExecution)})
+	require.NoError(t, err)
+	assert.Equal(t, audit.EntryList{entries[2]}, got)
+
+	got, err = r.ListEntries(ctx, "foo", audit.Filter{PageFilter: page, TestID: &testID})
+	require.NoError(t, err)
+	assert.Equal(t, audit.EntryList{entries[2], entries[1]}, got)
+
+	got, err = r.ListEntries(ctx, "foo", audit.Filter{PageFilter: page, TestExecutionID: &testExecID})
+	require.NoError(t, err)
+	assert.Equal(t, audit.EntryList{entries[2], entries[1]}, got)
+
+	got, err = r.ListEntries(ctx, "foo", audit.Filter{
+		PageFilter: test.PageFilter[uuid.V7]{Size: 1, OffsetID: &entries[2].ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, audit.EntryList{entries[1]}, got)
+}
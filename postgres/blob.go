@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/annexsh/annex/blob"
+	"github.com/annexsh/annex/postgres/sqlc"
+)
+
+var _ blob.Store = (*BlobStore)(nil)
+
+// BlobStore stores blobs in the database. Blobs are read into memory so it
+// is only suited to small blobs.
+type BlobStore struct {
+	db *DB
+}
+
+func NewBlobStore(db *DB) *BlobStore {
+	return &BlobStore{db: db}
+}
+
+func (b *BlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return b.db.PutBlob(ctx, sqlc.PutBlobParams{
+		Key:        key,
+		Data:       data,
+		CreateTime: time.Now().UTC(),
+	})
+}
+
+func (b *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, err := b.db.GetBlob(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, blob.ErrNotFound
+		}
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b *BlobStore) Delete(ctx context.Context, key string) error {
+	return b.db.DeleteBlob(ctx, key)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -15,9 +17,12 @@ import (
 )
 
 const (
-	dbName          = "annex"
-	pgRetryInterval = 2 * time.Second
-	pgMaxRetries    = 5
+	// DefaultDatabase is the name of the Annex database when not configured.
+	DefaultDatabase = "annex"
+	// maintenanceDatabase is connected to in order to create the database.
+	maintenanceDatabase = "postgres"
+	pgRetryInterval     = 2 * time.Second
+	pgMaxRetries        = 5
 )
 
 type OpenOption func(opts *openOptions)
@@ -28,51 +33,160 @@ func WithMigration() OpenOption {
 	}
 }
 
+// WithSchemaCheck fails to open the pool if the database schema is newer than
+// the latest migration, or a migration failed, without migrating. It's
+// implied by WithMigration.
+func WithSchemaCheck() OpenOption {
+	return func(opts *openOptions) {
+		opts.checkSchema = true
+	}
+}
+
+// WithDatabaseCreation creates the database of the connection string, and the
+// schema if set, when they don't exist. The user must be permitted to create
+// databases.
+func WithDatabaseCreation() OpenOption {
+	return func(opts *openOptions) {
+		opts.createDatabase = true
+	}
+}
+
+// WithSchema sets the schema tables are created in and queried from. The
+// search path of the user is used when the schema is empty.
+func WithSchema(schema string) OpenOption {
+	return func(opts *openOptions) {
+		opts.schema = schema
+	}
+}
+
+// WithPoolSize sets the minimum and maximum number of pool connections. Zero
+// values keep the pgx defaults or the values of the connection string.
+func WithPoolSize(minConns int32, maxConns int32) OpenOption {
+	return func(opts *openOptions) {
+		opts.minConns = minConns
+		opts.maxConns = maxConns
+	}
+}
+
+// WithStatementTimeout aborts statements that take longer than the timeout.
+// Statements are not timed out when zero.
+func WithStatementTimeout(timeout time.Duration) OpenOption {
+	return func(opts *openOptions) {
+		opts.statementTimeout = timeout
+	}
+}
+
 type openOptions struct {
-	migrateUp bool
+	migrateUp        bool
+	checkSchema      bool
+	createDatabase   bool
+	schema           string
+	minConns         int32
+	maxConns         int32
+	statementTimeout time.Duration
 }
 
-func OpenPool(ctx context.Context, username string, password string, hostPort string, opts ...OpenOption) (*pgxpool.Pool, error) {
+// OpenPool opens a connection pool to the database of the connection string,
+// which is a URL or key/value connection string accepted by pgx.
+func OpenPool(ctx context.Context, connString string, opts ...OpenOption) (*pgxpool.Pool, error) {
 	var options openOptions
 	for _, opt := range opts {
 		opt(&options)
 	}
 
-	postgresURL := fmt.Sprintf("postgres://%s:%s@%s/postgres", username, password, hostPort)
-	pool, err := newPool(ctx, postgresURL)
+	cfg, err := pgxpool.ParseConfig(connString)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid postgres connection string: %w", err)
 	}
 
-	if _, err = pool.Exec(ctx, "CREATE DATABASE "+dbName); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code != pgerrcode.DuplicateDatabase {
-				return nil, err
-			}
-		}
+	if options.minConns > 0 {
+		cfg.MinConns = options.minConns
+	}
+	if options.maxConns > 0 {
+		cfg.MaxConns = options.maxConns
+	}
+	if options.schema != "" {
+		cfg.ConnConfig.RuntimeParams["search_path"] = options.schema
+	}
+	if options.statementTimeout > 0 {
+		cfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(options.statementTimeout.Milliseconds(), 10)
 	}
 
-	pool.Close()
+	if options.createDatabase {
+		if err = createDatabase(ctx, cfg); err != nil {
+			return nil, fmt.Errorf("failed to create database: %w", err)
+		}
+	}
 
-	annexURL := fmt.Sprintf("postgres://%s:%s@%s/%s", username, password, hostPort, dbName)
-	pool, err = newPool(ctx, annexURL)
+	pool, err := newPool(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	if options.createDatabase && options.schema != "" {
+		if _, err = pool.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+pgx.Identifier{options.schema}.Sanitize()); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to create schema: %w", err)
+		}
+	}
+
 	if options.migrateUp {
-		if err = migrations.MigrateDatabase(pool); err != nil {
+		if err = migrations.MigrateDatabase(ctx, pool); err != nil {
 			pool.Close()
 			return nil, fmt.Errorf("failed to migrate database: %w", err)
 		}
+	} else if options.checkSchema {
+		if err = migrations.CheckDatabase(ctx, pool); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to check database schema: %w", err)
+		}
 	}
 
 	return pool, nil
 }
 
-func newPool(ctx context.Context, url string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(ctx, url)
+// createDatabase creates the database of the config from the maintenance
+// database if it doesn't exist.
+func createDatabase(ctx context.Context, cfg *pgxpool.Config) error {
+	dbName := cfg.ConnConfig.Database
+	if dbName == "" || dbName == maintenanceDatabase {
+		return nil
+	}
+
+	maintenanceCfg := cfg.Copy()
+	maintenanceCfg.ConnConfig.Database = maintenanceDatabase
+	maintenanceCfg.MinConns = 0
+
+	pool, err := newPool(ctx, maintenanceCfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	var exists bool
+	if err = pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_database WHERE datname = $1)", dbName).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err = pool.Exec(ctx, "CREATE DATABASE "+pgx.Identifier{dbName}.Sanitize()); err != nil {
+		// The database may have been created concurrently by another replica
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.DuplicateDatabase {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func newPool(ctx context.Context, cfg *pgxpool.Config) (*pgxpool.Pool, error) {
+	cfg.ConnConfig.Tracer = &queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +197,7 @@ func newPool(ctx context.Context, url string) (*pgxpool.Pool, error) {
 
 	bo := backoff.WithMaxRetries(backoff.NewConstantBackOff(pgRetryInterval), pgMaxRetries)
 	if err = backoff.Retry(operation, bo); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("database connection unhealthy: %w", err)
 	}
 
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/postgres/sqlc"
+	"github.com/annexsh/annex/test"
+)
+
+var (
+	_ test.ExecutionEventReader = (*ExecutionEventReader)(nil)
+	_ test.ExecutionEventWriter = (*ExecutionEventWriter)(nil)
+)
+
+type ExecutionEventReader struct {
+	db *DB
+}
+
+func NewExecutionEventReader(db *DB) *ExecutionEventReader {
+	return &ExecutionEventReader{db: db}
+}
+
+func (e *ExecutionEventReader) ListExecutionEvents(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uint64], logFilter test.LogFilter) (test.ExecutionEventList, error) {
+	logParams, err := newLogFilterParams(logFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.ListExecutionEventsParams{
+		TestExecutionID: testExecID,
+		CaseExecutionID: logParams.caseExecutionID,
+		MinSeverity:     logParams.minSeverity,
+		Attributes:      logParams.attributes,
+		MessageContains: logParams.messageContains,
+		PageSize:        int32(filter.Size),
+	}
+	if filter.OffsetID != nil {
+		params.OffsetSequence = ptr.Get(int64(*filter.OffsetID))
+	}
+
+	events, err := e.db.ListExecutionEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return marshalExecutionEvents(events)
+}
+
+func (e *ExecutionEventReader) GetExecutionEventSequence(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+	seq, err := e.db.GetExecutionEventSequence(ctx, testExecID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return uint64(seq), nil
+}
+
+type ExecutionEventWriter struct {
+	db *DB
+}
+
+func NewExecutionEventWriter(db *DB) *ExecutionEventWriter {
+	return &ExecutionEventWriter{db: db}
+}
+
+func (e *ExecutionEventWriter) NextExecutionEventSequence(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+	seq, err := e.db.NextExecutionEventSequence(ctx, testExecID)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(seq), nil
+}
+
+func (e *ExecutionEventWriter) CreateExecutionEvent(ctx context.Context, event *test.ExecutionEvent) error {
+	data, err := proto.Marshal(event.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return e.db.CreateExecutionEvent(ctx, sqlc.CreateExecutionEventParams{
+		TestExecutionID: event.TestExecutionID,
+		Sequence:        int64(event.Sequence),
+		Type:            event.Event.Type.String(),
+		CaseExecutionID: event.CaseExecutionID,
+		LogID:           event.LogID,
+		Data:            data,
+		CreateTime:      event.Event.CreateTime.AsTime().UTC(),
+	})
+}
+
+func (e *ExecutionEventWriter) DeleteExecutionEvents(ctx context.Context, testExecID test.TestExecutionID, eventType eventsv1.Event_Type) error {
+	return e.db.DeleteExecutionEvents(ctx, sqlc.DeleteExecutionEventsParams{
+		TestExecutionID: testExecID,
+		Type:            eventType.String(),
+	})
+}
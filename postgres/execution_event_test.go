@@ -0,0 +1,183 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/annexsh/annex/event"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/postgres/sqlc"
+	"github.com/annexsh/annex/test"
+)
+
+func TestNextExecutionEventSequence(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewExecutionEventWriter(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+	otherTestExec, err := db.CreateTestExecutionScheduled(ctx, sqlc.CreateTestExecutionScheduledParams{
+		ID:           test.NewTestExecutionID(),
+		TestID:       dummyTestExec.TestID,
+		ScheduleTime: time.Now(),
+		Labels:       []byte("{}"),
+	})
+	require.NoError(t, err)
+
+	for want := uint64(1); want <= 3; want++ {
+		got, err := w.NextExecutionEventSequence(ctx, dummyTestExec.ID)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	got, err := w.NextExecutionEventSequence(ctx, otherTestExec.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), got)
+}
+
+func TestCreateListExecutionEvents(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewExecutionEventWriter(db)
+	r := NewExecutionEventReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+	count := 4
+	pageSize := 2
+
+	want := fake.GenExecutionEvents(dummyTestExec.ID, 0, count)
+	for _, e := range want {
+		e.LogID = nil
+		err := w.CreateExecutionEvent(ctx, e)
+		require.NoError(t, err)
+	}
+
+	// Page 1
+	got1, err := r.ListExecutionEvents(ctx, dummyTestExec.ID, test.PageFilter[uint64]{
+		Size:     pageSize,
+		OffsetID: nil,
+	}, test.LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, got1, pageSize)
+
+	// Page 2
+	got2, err := r.ListExecutionEvents(ctx, dummyTestExec.ID, test.PageFilter[uint64]{
+		Size:     pageSize,
+		OffsetID: ptr.Get(got1[1].Sequence),
+	}, test.LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, got2, pageSize)
+
+	got := append(got1, got2...)
+	for i := range want {
+		assert.Equal(t, want[i].TestExecutionID, got[i].TestExecutionID)
+		assert.Equal(t, want[i].Sequence, got[i].Sequence)
+		assert.True(t, proto.Equal(want[i].Event, got[i].Event))
+	}
+
+	// Page 3 (empty)
+	got3, err := r.ListExecutionEvents(ctx, dummyTestExec.ID, test.PageFilter[uint64]{
+		Size:     pageSize,
+		OffsetID: ptr.Get(got2[1].Sequence),
+	}, test.LogFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, got3)
+}
+
+func TestListExecutionEvents_logFilter(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewExecutionEventWriter(db)
+	r := NewExecutionEventReader(db)
+	logWriter := NewLogWriter(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+
+	infoLog := fake.GenTestExecLog(dummyTestExec.ID)
+	errLog := fake.GenTestExecLog(dummyTestExec.ID)
+	errLog.Level = "ERROR"
+	errLog.Attributes = map[string]string{"component": "db"}
+	err := logWriter.CreateLogs(ctx, test.LogList{infoLog, errLog})
+	require.NoError(t, err)
+
+	startEvent := event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, &testsv1.TestExecution{
+		Id: dummyTestExec.ID.String(),
+	})
+	events := test.ExecutionEventList{
+		{TestExecutionID: dummyTestExec.ID, Event: startEvent},
+		{TestExecutionID: dummyTestExec.ID, LogID: &infoLog.ID, Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, infoLog.Proto())},
+		{TestExecutionID: dummyTestExec.ID, LogID: &errLog.ID, Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, errLog.Proto())},
+	}
+	for _, e := range events {
+		e.Sequence, err = w.NextExecutionEventSequence(ctx, dummyTestExec.ID)
+		require.NoError(t, err)
+		err = w.CreateExecutionEvent(ctx, e)
+		require.NoError(t, err)
+	}
+
+	lastSeq, err := r.GetExecutionEventSequence(ctx, dummyTestExec.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), lastSeq)
+
+	lastSeq, err = r.GetExecutionEventSequence(ctx, test.NewTestExecutionID())
+	require.NoError(t, err)
+	assert.Zero(t, lastSeq)
+
+	// Events without logs are never filtered
+	got, err := r.ListExecutionEvents(ctx, dummyTestExec.ID, test.PageFilter[uint64]{Size: 10}, test.LogFilter{
+		MinLevel:   ptr.Get("WARN"),
+		Attributes: map[string]string{"component": "db"},
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(1), got[0].Sequence)
+	assert.Equal(t, uint64(3), got[1].Sequence)
+	assert.Equal(t, errLog.ID, *got[1].LogID)
+
+	got, err = r.ListExecutionEvents(ctx, dummyTestExec.ID, test.PageFilter[uint64]{Size: 10}, test.LogFilter{
+		MessageContains: ptr.Get(infoLog.Message),
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, infoLog.ID, *got[1].LogID)
+}
+
+func TestDeleteExecutionEvents(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewExecutionEventWriter(db)
+	r := NewExecutionEventReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+
+	events := fake.GenExecutionEvents(dummyTestExec.ID, 0, 2)
+	events[0].LogID = nil
+	events[0].Event.Type = eventsv1.Event_TYPE_TEST_EXECUTION_STARTED
+	events[1].LogID = nil
+	for _, e := range events {
+		err := w.CreateExecutionEvent(ctx, e)
+		require.NoError(t, err)
+	}
+
+	err := w.DeleteExecutionEvents(ctx, dummyTestExec.ID, eventsv1.Event_TYPE_TEST_EXECUTION_STARTED)
+	require.NoError(t, err)
+
+	got, err := r.ListExecutionEvents(ctx, dummyTestExec.ID, test.PageFilter[uint64]{Size: 10}, test.LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, events[1].Sequence, got[0].Sequence)
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 
+	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/postgres/sqlc"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -26,12 +27,21 @@ func (e *LogReader) GetLog(ctx context.Context, id uuid.V7) (*test.Log, error) {
 	if err != nil {
 		return nil, err
 	}
-	return marshalLog(execLog), nil
+	return marshalLog(execLog)
 }
 
-func (e *LogReader) ListLogs(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7]) (test.LogList, error) {
+func (e *LogReader) ListLogs(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7], logFilter test.LogFilter) (test.LogList, error) {
+	logParams, err := newLogFilterParams(logFilter)
+	if err != nil {
+		return nil, err
+	}
+
 	params := sqlc.ListLogsParams{
 		TestExecutionID: testExecID,
+		CaseExecutionID: logParams.caseExecutionID,
+		MinSeverity:     logParams.minSeverity,
+		Attributes:      logParams.attributes,
+		MessageContains: logParams.messageContains,
 		PageSize:        int32(filter.Size),
 	}
 	if filter.OffsetID != nil {
@@ -42,7 +52,7 @@ func (e *LogReader) ListLogs(ctx context.Context, testExecID test.TestExecutionI
 	if err != nil {
 		return nil, err
 	}
-	return marshalExecLogs(logs), nil
+	return marshalExecLogs(logs)
 }
 
 type LogWriter struct {
@@ -54,6 +64,10 @@ func NewLogWriter(db *DB) *LogWriter {
 }
 
 func (e *LogWriter) CreateLog(ctx context.Context, log *test.Log) error {
+	attrs, err := marshalStringMap(log.Attributes)
+	if err != nil {
+		return err
+	}
 	return e.db.CreateLog(ctx, sqlc.CreateLogParams{
 		ID:              log.ID,
 		TestExecutionID: log.TestExecutionID,
@@ -61,9 +75,64 @@ func (e *LogWriter) CreateLog(ctx context.Context, log *test.Log) error {
 		Level:           log.Level,
 		Message:         log.Message,
 		CreateTime:      log.CreateTime.UTC(),
+		RedactionCount:  int32(log.RedactionCount),
+		Attributes:      attrs,
+		Severity:        int32(test.LogSeverity(log.Level)),
 	})
 }
 
+// CreateLogs creates the logs using a single COPY statement.
+func (e *LogWriter) CreateLogs(ctx context.Context, logs test.LogList) error {
+	params := make([]sqlc.CreateLogsParams, len(logs))
+	for i, log := range logs {
+		attrs, err := marshalStringMap(log.Attributes)
+		if err != nil {
+			return err
+		}
+		params[i] = sqlc.CreateLogsParams{
+			ID:              log.ID,
+			TestExecutionID: log.TestExecutionID,
+			CaseExecutionID: log.CaseExecutionID,
+			Level:           log.Level,
+			Message:         log.Message,
+			CreateTime:      log.CreateTime.UTC(),
+			RedactionCount:  int32(log.RedactionCount),
+			Attributes:      attrs,
+			Severity:        int32(test.LogSeverity(log.Level)),
+		}
+	}
+	_, err := e.db.CreateLogs(ctx, params)
+	return err
+}
+
 func (e *LogWriter) DeleteLog(ctx context.Context, id uuid.V7) error {
 	return e.db.DeleteLog(ctx, id)
 }
+
+// logFilterParams are the query parameters of a log filter. Nil parameters
+// match all logs.
+type logFilterParams struct {
+	caseExecutionID *int32
+	minSeverity     *int32
+	attributes      []byte
+	messageContains *string
+}
+
+func newLogFilterParams(filter test.LogFilter) (logFilterParams, error) {
+	params := logFilterParams{
+		messageContains: filter.MessageContains,
+	}
+	if filter.CaseExecutionID != nil {
+		params.caseExecutionID = ptr.Get(filter.CaseExecutionID.Int32())
+	}
+	if filter.MinLevel != nil {
+		params.minSeverity = ptr.Get(int32(test.LogSeverity(*filter.MinLevel)))
+	}
+	if len(filter.Attributes) > 0 {
+		var err error
+		if params.attributes, err = marshalStringMap(filter.Attributes); err != nil {
+			return logFilterParams{}, err
+		}
+	}
+	return params, nil
+}
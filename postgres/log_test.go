@@ -4,6 +4,7 @@ package postgres
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -28,6 +29,8 @@ func TestCreateGetLog(t *testing.T) {
 
 	dummyTestExec := createDummyTestExec(ctx, t, db)
 	want := fake.GenTestExecLog(dummyTestExec.ID)
+	want.Attributes = map[string]string{"component": "db", "attempt": "1"}
+	want.RedactionCount = 2
 
 	err := w.CreateLog(ctx, want)
 	require.NoError(t, err)
@@ -37,6 +40,31 @@ func TestCreateGetLog(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestCreateLogs(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewLogWriter(db)
+	r := NewLogReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+	logs := append(
+		fake.GenTestExecLogs(dummyTestExec.ID, 2),
+		fake.GenCaseExecLogs(dummyTestExec.ID, 1, 2)...,
+	)
+	logs[1].RedactionCount = 2
+
+	err := w.CreateLogs(ctx, logs)
+	require.NoError(t, err)
+
+	got, err := r.ListLogs(ctx, dummyTestExec.ID, test.PageFilter[uuid.V7]{Size: 10}, test.LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, got, len(logs))
+	for i, log := range logs {
+		assert.Equal(t, log, got[len(got)-1-i]) // listed in descending order
+	}
+}
+
 func TestListLogs(t *testing.T) {
 	ctx := context.Background()
 	db, closer := newTestDB(t)
@@ -60,7 +88,7 @@ func TestListLogs(t *testing.T) {
 	got1, err := r.ListLogs(ctx, dummyTestExec.ID, test.PageFilter[uuid.V7]{
 		Size:     pageSize,
 		OffsetID: nil,
-	})
+	}, test.LogFilter{})
 	require.NoError(t, err)
 	require.Len(t, got1, pageSize)
 
@@ -68,7 +96,7 @@ func TestListLogs(t *testing.T) {
 	got2, err := r.ListLogs(ctx, dummyTestExec.ID, test.PageFilter[uuid.V7]{
 		Size:     pageSize,
 		OffsetID: ptr.Get(got1[1].ID),
-	})
+	}, test.LogFilter{})
 	require.NoError(t, err)
 	assert.Len(t, got2, pageSize)
 
@@ -79,11 +107,92 @@ func TestListLogs(t *testing.T) {
 	got3, err := r.ListLogs(ctx, dummyTestExec.ID, test.PageFilter[uuid.V7]{
 		Size:     pageSize,
 		OffsetID: ptr.Get(got2[1].ID),
-	})
+	}, test.LogFilter{})
 	require.NoError(t, err)
 	assert.Empty(t, got3)
 }
 
+func TestListLogs_filter(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	w := NewLogWriter(db)
+	r := NewLogReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+
+	debugLog := fake.GenTestExecLog(dummyTestExec.ID)
+	debugLog.Level = "DEBUG"
+	debugLog.Attributes = map[string]string{"component": "db"}
+
+	errLog := fake.GenCaseExecLog(dummyTestExec.ID, 1)
+	errLog.Level = "error"
+	errLog.Message = "query timeout after 5s"
+	errLog.Attributes = map[string]string{"component": "db", "attempt": "2"}
+
+	warnLog := fake.GenCaseExecLog(dummyTestExec.ID, 2)
+	warnLog.Level = "WARN"
+	warnLog.Attributes = map[string]string{"component": "http"}
+
+	err := w.CreateLogs(ctx, test.LogList{debugLog, errLog, warnLog})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		filter test.LogFilter
+		want   test.LogList
+	}{
+		{
+			name:   "no filter",
+			filter: test.LogFilter{},
+			want:   test.LogList{debugLog, errLog, warnLog},
+		},
+		{
+			name:   "min level",
+			filter: test.LogFilter{MinLevel: ptr.Get("warning")},
+			want:   test.LogList{errLog, warnLog},
+		},
+		{
+			name:   "case execution",
+			filter: test.LogFilter{CaseExecutionID: ptr.Get(test.CaseExecutionID(2))},
+			want:   test.LogList{warnLog},
+		},
+		{
+			name:   "attributes",
+			filter: test.LogFilter{Attributes: map[string]string{"component": "db"}},
+			want:   test.LogList{debugLog, errLog},
+		},
+		{
+			name:   "all attributes must match",
+			filter: test.LogFilter{Attributes: map[string]string{"component": "db", "attempt": "1"}},
+			want:   test.LogList{},
+		},
+		{
+			name:   "message contains",
+			filter: test.LogFilter{MessageContains: ptr.Get("timeout")},
+			want:   test.LogList{errLog},
+		},
+		{
+			name: "combined",
+			filter: test.LogFilter{
+				MinLevel:        ptr.Get("INFO"),
+				Attributes:      map[string]string{"component": "db"},
+				MessageContains: ptr.Get("5s"),
+			},
+			want: test.LogList{errLog},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.ListLogs(ctx, dummyTestExec.ID, test.PageFilter[uuid.V7]{Size: 10}, tt.filter)
+			require.NoError(t, err)
+			slices.Reverse(got) // listed in descending order
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestDeleteLog(t *testing.T) {
 	ctx := context.Background()
 	db, closer := newTestDB(t)
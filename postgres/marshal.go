@@ -1,8 +1,15 @@
 package postgres
 
 import (
+	"encoding/json"
+	"fmt"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
 	"github.com/annexsh/annex/postgres/sqlc"
 
 	"github.com/annexsh/annex/test"
@@ -44,21 +51,59 @@ func marshalTests(tests []*sqlc.Test) []*test.Test {
 	return out
 }
 
-func marshalTestDefaultInput(input *sqlc.TestDefaultInput) *test.Payload {
+func marshalTestDefaultInput(input *sqlc.TestDefaultInput) (*test.Payload, error) {
+	metadata, err := unmarshalPayloadMetadata(input.Metadata)
+	if err != nil {
+		return nil, err
+	}
 	return &test.Payload{
 		Data:     input.Data,
-		Metadata: map[string][]byte{"encoding": []byte(converter.MetadataEncodingJSON)},
-	}
+		Metadata: metadata,
+	}, nil
 }
 
-func marshalTestExecInput(input *sqlc.TestExecutionInput) *test.Payload {
+func marshalTestExecInput(input *sqlc.TestExecutionInput) (*test.Payload, error) {
+	metadata, err := unmarshalPayloadMetadata(input.Metadata)
+	if err != nil {
+		return nil, err
+	}
 	return &test.Payload{
 		Data:     input.Data,
-		Metadata: map[string][]byte{"encoding": []byte(converter.MetadataEncodingJSON)},
+		Metadata: metadata,
+	}, nil
+}
+
+// marshalPayloadMetadata encodes the metadata of an input so that encoded
+// inputs, such as encrypted inputs, can be decoded when read.
+func marshalPayloadMetadata(metadata map[string][]byte) ([]byte, error) {
+	if len(metadata) == 0 {
+		return nil, nil
 	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload metadata: %w", err)
+	}
+	return data, nil
 }
 
-func marshalTestExec(testExec *sqlc.TestExecution) *test.TestExecution {
+// unmarshalPayloadMetadata decodes the metadata of an input. Inputs stored
+// before their metadata was recorded are plain JSON.
+func unmarshalPayloadMetadata(data []byte) (map[string][]byte, error) {
+	if data == nil {
+		return map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON)}, nil
+	}
+	var metadata map[string][]byte
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+func marshalTestExec(testExec *sqlc.TestExecution) (*test.TestExecution, error) {
+	labels, err := unmarshalStringMap(testExec.Labels)
+	if err != nil {
+		return nil, err
+	}
 	return &test.TestExecution{
 		ID:           testExec.ID,
 		TestID:       testExec.TestID,
@@ -67,15 +112,52 @@ func marshalTestExec(testExec *sqlc.TestExecution) *test.TestExecution {
 		StartTime:    testExec.StartTime,
 		FinishTime:   testExec.FinishTime,
 		Error:        testExec.Error,
+		Metadata: test.ExecutionMetadata{
+			TriggeredBy: testExec.TriggeredBy,
+			GitSHA:      testExec.GitSha,
+			GitBranch:   testExec.GitBranch,
+			CIJobURL:    testExec.CiJobUrl,
+			Environment: testExec.Environment,
+			Labels:      labels,
+		},
+	}, nil
+}
+
+func marshalUpdatedTestExec(row *sqlc.UpdateTestExecutionStartedRow) (*test.UpdatedTestExecution, error) {
+	testExec, err := marshalTestExec(&sqlc.TestExecution{
+		ID:           row.ID,
+		TestID:       row.TestID,
+		HasInput:     row.HasInput,
+		ScheduleTime: row.ScheduleTime,
+		StartTime:    row.StartTime,
+		FinishTime:   row.FinishTime,
+		Error:        row.Error,
+		TriggeredBy:  row.TriggeredBy,
+		GitSha:       row.GitSha,
+		GitBranch:    row.GitBranch,
+		CiJobUrl:     row.CiJobUrl,
+		Environment:  row.Environment,
+		Labels:       row.Labels,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &test.UpdatedTestExecution{
+		TestExecution: testExec,
+		ContextID:     row.ContextID,
+		TestSuiteID:   row.TestSuiteID,
+	}, nil
 }
 
-func marshalTestExecs(testExecs []*sqlc.TestExecution) []*test.TestExecution {
+func marshalTestExecs(testExecs []*sqlc.TestExecution) ([]*test.TestExecution, error) {
 	out := make([]*test.TestExecution, len(testExecs))
 	for i, testExec := range testExecs {
-		out[i] = marshalTestExec(testExec)
+		var err error
+		if out[i], err = marshalTestExec(testExec); err != nil {
+			return nil, err
+		}
 	}
-	return out
+	return out, nil
 }
 
 func marshalCaseExec(caseExec *sqlc.CaseExecution) *test.CaseExecution {
@@ -98,7 +180,11 @@ func marshalCaseExecs(caseExecs []*sqlc.CaseExecution) []*test.CaseExecution {
 	return out
 }
 
-func marshalLog(log *sqlc.Log) *test.Log {
+func marshalLog(log *sqlc.Log) (*test.Log, error) {
+	attrs, err := unmarshalStringMap(log.Attributes)
+	if err != nil {
+		return nil, err
+	}
 	return &test.Log{
 		ID:              log.ID,
 		TestExecutionID: log.TestExecutionID,
@@ -106,13 +192,148 @@ func marshalLog(log *sqlc.Log) *test.Log {
 		Level:           log.Level,
 		Message:         log.Message,
 		CreateTime:      log.CreateTime,
-	}
+		Attributes:      attrs,
+		RedactionCount:  int(log.RedactionCount),
+	}, nil
 }
 
-func marshalExecLogs(logs []*sqlc.Log) []*test.Log {
+func marshalExecLogs(logs []*sqlc.Log) ([]*test.Log, error) {
 	out := make([]*test.Log, len(logs))
 	for i, log := range logs {
-		out[i] = marshalLog(log)
+		var err error
+		if out[i], err = marshalLog(log); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// marshalStringMap encodes log attributes or execution labels as a JSON
+// object.
+func marshalStringMap(m map[string]string) ([]byte, error) {
+	if len(m) == 0 {
+		return []byte("{}"), nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal string map: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalStringMap decodes log attributes or execution labels, returning
+// nil if the map is empty.
+func unmarshalStringMap(data []byte) (map[string]string, error) {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal string map: %w", err)
+	}
+	if len(m) == 0 {
+		return nil, nil
+	}
+	return m, nil
+}
+
+func marshalExecutionEvent(e *sqlc.ExecutionEvent) (*test.ExecutionEvent, error) {
+	var eventpb eventsv1.Event
+	if err := proto.Unmarshal(e.Data, &eventpb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return &test.ExecutionEvent{
+		TestExecutionID: e.TestExecutionID,
+		Sequence:        uint64(e.Sequence),
+		CaseExecutionID: e.CaseExecutionID,
+		LogID:           e.LogID,
+		Event:           &eventpb,
+	}, nil
+}
+
+func marshalExecutionEvents(events []*sqlc.ExecutionEvent) (test.ExecutionEventList, error) {
+	out := make(test.ExecutionEventList, len(events))
+	for i, e := range events {
+		var err error
+		if out[i], err = marshalExecutionEvent(e); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func marshalAPIKey(key *sqlc.ApiKey) *auth.APIKey {
+	return &auth.APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		CreateTime: key.CreateTime,
+		RevokeTime: key.RevokeTime,
+	}
+}
+
+func marshalAPIKeys(keys []*sqlc.ApiKey) auth.APIKeyList {
+	out := make(auth.APIKeyList, len(keys))
+	for i, key := range keys {
+		out[i] = marshalAPIKey(key)
+	}
+	return out
+}
+
+func marshalRoleBinding(binding *sqlc.RoleBinding) *auth.RoleBinding {
+	return &auth.RoleBinding{
+		ID:         binding.ID,
+		Context:    binding.ContextID,
+		Subject:    binding.Subject,
+		Role:       auth.Role(binding.Role),
+		CreateTime: binding.CreateTime,
+	}
+}
+
+func marshalRoleBindings(bindings []*sqlc.RoleBinding) auth.RoleBindingList {
+	out := make(auth.RoleBindingList, len(bindings))
+	for i, binding := range bindings {
+		out[i] = marshalRoleBinding(binding)
+	}
+	return out
+}
+
+func marshalAuditEntry(entry *sqlc.AuditEntry) *audit.Entry {
+	return &audit.Entry{
+		ID:              entry.ID,
+		Context:         entry.ContextID,
+		Action:          audit.Action(entry.Action),
+		Subject:         entry.Subject,
+		AuthMethod:      auth.Method(entry.AuthMethod),
+		TestSuiteID:     entry.TestSuiteID,
+		TestID:          entry.TestID,
+		TestExecutionID: entry.TestExecutionID,
+		Summary:         entry.Summary,
+		CreateTime:      entry.CreateTime,
+	}
+}
+
+func marshalAuditEntries(entries []*sqlc.AuditEntry) audit.EntryList {
+	out := make(audit.EntryList, len(entries))
+	for i, entry := range entries {
+		out[i] = marshalAuditEntry(entry)
+	}
+	return out
+}
+
+func marshalArtifact(artifact *sqlc.Artifact) *test.Artifact {
+	return &test.Artifact{
+		ID:              artifact.ID,
+		TestExecutionID: artifact.TestExecutionID,
+		CaseExecutionID: artifact.CaseExecutionID,
+		Name:            artifact.Name,
+		ContentType:     artifact.ContentType,
+		Size:            artifact.Size,
+		Checksum:        artifact.Checksum,
+		CreateTime:      artifact.CreateTime,
+	}
+}
+
+func marshalArtifacts(artifacts []*sqlc.Artifact) test.ArtifactList {
+	out := make(test.ArtifactList, len(artifacts))
+	for i, artifact := range artifacts {
+		out[i] = marshalArtifact(artifact)
 	}
 	return out
 }
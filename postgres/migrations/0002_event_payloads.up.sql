@@ -0,0 +1,8 @@
+CREATE TABLE event_payloads
+(
+    id          UUID      NOT NULL PRIMARY KEY,
+    data        BYTEA     NOT NULL,
+    create_time TIMESTAMP NOT NULL
+);
+
+CREATE INDEX event_payloads_create_time_idx ON event_payloads (create_time);
@@ -0,0 +1,2 @@
+DROP TABLE execution_events;
+DROP TABLE execution_event_sequences;
@@ -0,0 +1,18 @@
+CREATE TABLE execution_event_sequences
+(
+    test_execution_id UUID   NOT NULL REFERENCES test_executions (id) PRIMARY KEY,
+    sequence          BIGINT NOT NULL
+);
+
+CREATE TABLE execution_events
+(
+    test_execution_id UUID      NOT NULL REFERENCES test_executions (id),
+    sequence          BIGINT    NOT NULL,
+    type              TEXT      NOT NULL,
+    case_execution_id INTEGER,
+    log_id            UUID REFERENCES logs (id) ON DELETE CASCADE,
+    data              BYTEA     NOT NULL,
+    create_time       TIMESTAMP NOT NULL,
+    PRIMARY KEY (test_execution_id, sequence),
+    FOREIGN KEY (case_execution_id, test_execution_id) REFERENCES case_executions (id, test_execution_id) ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+CREATE TABLE api_keys
+(
+    id          UUID PRIMARY KEY,
+    name        TEXT      NOT NULL,
+    hash        BYTEA     NOT NULL UNIQUE,
+    create_time TIMESTAMP NOT NULL,
+    revoke_time TIMESTAMP
+);
@@ -0,0 +1 @@
+DROP TABLE role_bindings;
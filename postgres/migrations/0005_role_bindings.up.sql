@@ -0,0 +1,11 @@
+CREATE TABLE role_bindings
+(
+    id          UUID PRIMARY KEY,
+    context_id  TEXT      NOT NULL,
+    subject     TEXT      NOT NULL,
+    role        TEXT      NOT NULL,
+    create_time TIMESTAMP NOT NULL,
+    UNIQUE (context_id, subject, role)
+);
+
+CREATE INDEX role_bindings_subject_idx ON role_bindings (subject);
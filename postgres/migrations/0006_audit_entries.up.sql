@@ -0,0 +1,16 @@
+CREATE TABLE audit_entries
+(
+    id                UUID PRIMARY KEY,
+    context_id        TEXT      NOT NULL,
+    action            TEXT      NOT NULL,
+    subject           TEXT      NOT NULL,
+    auth_method       TEXT      NOT NULL,
+    test_suite_id     UUID,
+    test_id           UUID,
+    test_execution_id UUID,
+    summary           TEXT      NOT NULL,
+    create_time       TIMESTAMP NOT NULL
+);
+
+CREATE INDEX audit_entries_context_id_idx ON audit_entries (context_id, id DESC);
+CREATE INDEX audit_entries_test_execution_id_idx ON audit_entries (test_execution_id);
@@ -0,0 +1,5 @@
+DROP INDEX logs_attributes_idx;
+
+ALTER TABLE logs
+    DROP COLUMN attributes,
+    DROP COLUMN severity;
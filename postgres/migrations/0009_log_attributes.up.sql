@@ -0,0 +1,18 @@
+ALTER TABLE logs
+    ADD COLUMN attributes JSONB   NOT NULL DEFAULT '{}',
+    ADD COLUMN severity   INTEGER NOT NULL DEFAULT 3;
+
+-- Severities match test.LogSeverity
+UPDATE logs
+SET severity = CASE upper(level)
+                   WHEN 'TRACE' THEN 1
+                   WHEN 'DEBUG' THEN 2
+                   WHEN 'WARN' THEN 4
+                   WHEN 'WARNING' THEN 4
+                   WHEN 'ERROR' THEN 5
+                   WHEN 'FATAL' THEN 6
+                   WHEN 'CRITICAL' THEN 6
+                   ELSE 3
+    END;
+
+CREATE INDEX logs_attributes_idx ON logs USING GIN (attributes jsonb_path_ops);
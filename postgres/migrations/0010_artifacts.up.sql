@@ -0,0 +1,20 @@
+CREATE TABLE artifacts
+(
+    id                UUID PRIMARY KEY,
+    test_execution_id UUID      NOT NULL REFERENCES test_executions (id),
+    case_execution_id INTEGER,
+    name              TEXT      NOT NULL,
+    content_type      TEXT      NOT NULL,
+    size              BIGINT    NOT NULL,
+    checksum          TEXT      NOT NULL,
+    create_time       TIMESTAMP NOT NULL
+);
+
+CREATE INDEX artifacts_test_execution_id_idx ON artifacts (test_execution_id, id);
+
+CREATE TABLE blobs
+(
+    key         TEXT PRIMARY KEY,
+    data        BYTEA     NOT NULL,
+    create_time TIMESTAMP NOT NULL
+);
@@ -0,0 +1,8 @@
+-- Blob keys of the content of deleted artifacts are recorded in the same
+-- transaction as the artifacts are deleted and removed once the content is
+-- deleted from the blob store, so content is never leaked if that fails.
+CREATE TABLE pending_blob_deletions
+(
+    blob_key    TEXT PRIMARY KEY,
+    create_time TIMESTAMP NOT NULL
+);
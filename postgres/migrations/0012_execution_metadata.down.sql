@@ -0,0 +1,9 @@
+DROP INDEX test_executions_labels_idx;
+
+ALTER TABLE test_executions
+    DROP COLUMN triggered_by,
+    DROP COLUMN git_sha,
+    DROP COLUMN git_branch,
+    DROP COLUMN ci_job_url,
+    DROP COLUMN environment,
+    DROP COLUMN labels;
@@ -0,0 +1,9 @@
+ALTER TABLE test_executions
+    ADD COLUMN triggered_by TEXT,
+    ADD COLUMN git_sha      TEXT,
+    ADD COLUMN git_branch   TEXT,
+    ADD COLUMN ci_job_url   TEXT,
+    ADD COLUMN environment  TEXT,
+    ADD COLUMN labels       JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX test_executions_labels_idx ON test_executions USING GIN (labels jsonb_path_ops);
@@ -1,44 +1,90 @@
 package migrations
 
 import (
+	"context"
 	"embed"
-	"errors"
 
-	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/annexsh/annex/internal/migration"
 )
 
+// lockID is the key of the advisory lock held while migrating. It's distinct
+// from the lock taken by the migrate driver for each migration so that the
+// version check and migrations of a process are not interleaved with
+// another.
+const lockID int64 = 0x616e6e6578 // "annex"
+
 //go:embed *.sql
 var fs embed.FS
 
-func MigrateDatabase(pool *pgxpool.Pool) error {
-	sd, err := iofs.New(fs, ".")
+// NewMigrator returns a Migrator of the database. Migrations are serialized
+// across processes by a Postgres advisory lock. Migrations run on dedicated
+// connections without the statement timeout of the pool since waiting for
+// the lock and migrating large tables can take longer than a query.
+func NewMigrator(pool *pgxpool.Pool) (*migration.Migrator, error) {
+	db := stdlib.OpenDB(*migrationConnConfig(pool))
+
+	driver, err := postgres.WithInstance(db, new(postgres.Config))
 	if err != nil {
-		return err
+		db.Close()
+		return nil, err
 	}
-	defer sd.Close()
 
-	db := stdlib.OpenDBFromPool(pool)
-	defer db.Close()
+	return migration.New(fs, "postgres", driver, migration.WithLock(advisoryLock(pool)))
+}
 
-	driver, err := postgres.WithInstance(db, new(postgres.Config))
+// MigrateDatabase applies all pending migrations.
+func MigrateDatabase(ctx context.Context, pool *pgxpool.Pool) error {
+	m, err := NewMigrator(pool)
 	if err != nil {
 		return err
 	}
-	defer driver.Close()
+	defer m.Close()
+	return m.Up(ctx)
+}
 
-	m, err := migrate.NewWithInstance("iofs", sd, "postgres", driver)
+// CheckDatabase returns an error if the schema of the database is newer than
+// the latest migration or dirty.
+func CheckDatabase(ctx context.Context, pool *pgxpool.Pool) error {
+	m, err := NewMigrator(pool)
 	if err != nil {
 		return err
 	}
+	defer m.Close()
+	return m.Check(ctx)
+}
 
-	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return err
+// advisoryLock acquires the lock on a dedicated connection so that it's held
+// independently of the connection used by the migrate driver.
+func advisoryLock(pool *pgxpool.Pool) migration.LockFunc {
+	return func(ctx context.Context) (func(), error) {
+		conn, err := pgx.ConnectConfig(ctx, migrationConnConfig(pool))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+			conn.Close(ctx)
+			return nil, err
+		}
+		// Closing the connection releases the lock
+		return func() {
+			conn.Close(context.Background())
+		}, nil
 	}
+}
 
-	return nil
+// migrationConnConfig returns the connection config of the pool with the
+// statement timeout disabled.
+func migrationConnConfig(pool *pgxpool.Pool) *pgx.ConnConfig {
+	cfg := pool.Config().ConnConfig
+	if cfg.RuntimeParams == nil {
+		cfg.RuntimeParams = map[string]string{}
+	}
+	cfg.RuntimeParams["statement_timeout"] = "0"
+	return cfg
 }
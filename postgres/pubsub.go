@@ -0,0 +1,376 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/postgres/sqlc"
+	"github.com/annexsh/annex/uuid"
+)
+
+var _ event.PubSub = (*PubSub)(nil)
+
+const (
+	eventsChannel           = "annex_events"
+	defaultSubBufferSize    = 50
+	publishTimeout          = 10 * time.Second
+	listenRetryInterval     = 2 * time.Second
+	payloadPurgeInterval    = time.Minute
+	payloadRetentionPeriod  = 10 * time.Minute
+	maxNotificationByteSize = 7900 // postgres rejects notification payloads of 8000 bytes or more
+)
+
+type PubSubOption func(opts *pubSubOptions)
+
+func WithLogger(logger log.Logger) PubSubOption {
+	return func(opts *pubSubOptions) {
+		opts.logger = logger
+	}
+}
+
+func WithSubscriptionBufferSize(bufferSize int) PubSubOption {
+	return func(opts *pubSubOptions) {
+		opts.bufferSize = bufferSize
+	}
+}
+
+// WithOverflowPolicy sets how events are handled for subscribers that are
+// not receiving events fast enough. Defaults to event.OverflowDropOldest.
+func WithOverflowPolicy(policy event.OverflowPolicy) PubSubOption {
+	return func(opts *pubSubOptions) {
+		opts.overflowPolicy = policy
+	}
+}
+
+type pubSubOptions struct {
+	bufferSize     int
+	overflowPolicy event.OverflowPolicy
+	logger         log.Logger
+}
+
+// PubSub is an event.PubSub backed by Postgres LISTEN/NOTIFY. Events are sent
+// inline in the notification payload unless they exceed the notification size
+// limit, in which case they are stored in the event_payloads table and the
+// notification references the stored payload by ID.
+type PubSub struct {
+	pool   *pgxpool.Pool
+	db     *DB
+	opts   pubSubOptions
+	mu     *sync.RWMutex
+	subs   map[string]mapset.Set[*event.Subscription]
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// NewPubSub returns a PubSub that listens for events on a dedicated connection
+// acquired from the pool. The connection is held until the context is cancelled
+// or Close is called.
+func NewPubSub(ctx context.Context, pool *pgxpool.Pool, opts ...PubSubOption) (*PubSub, error) {
+	options := pubSubOptions{
+		logger:         log.DefaultLogger(),
+		bufferSize:     defaultSubBufferSize,
+		overflowPolicy: event.OverflowDropOldest,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	p := &PubSub{
+		pool:   pool,
+		db:     NewDB(pool),
+		opts:   options,
+		mu:     new(sync.RWMutex),
+		subs:   map[string]mapset.Set[*event.Subscription]{},
+		cancel: cancel,
+		wg:     new(sync.WaitGroup),
+	}
+
+	conn, err := p.acquireListenConn(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	p.wg.Add(2)
+	go func() {
+		defer p.wg.Done()
+		p.listen(ctx, conn)
+	}()
+	go func() {
+		defer p.wg.Done()
+		p.purgeEventPayloads(ctx)
+	}()
+
+	return p, nil
+}
+
+func (p *PubSub) Publish(testExecID string, event *executionsv1.ExecutionEvent) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return p.notify(notification{
+		TestExecutionID: testExecID,
+		Data:            data,
+	})
+}
+
+func (p *PubSub) PublishBatch(testExecID string, events []*executionsv1.ExecutionEvent) error {
+	data, err := event.MarshalBatch(events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event batch: %w", err)
+	}
+	return p.notify(notification{
+		TestExecutionID: testExecID,
+		Data:            data,
+		Batch:           true,
+	})
+}
+
+func (p *PubSub) notify(n notification) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	if len(payload) > maxNotificationByteSize {
+		payloadID := uuid.New()
+		if err = p.db.CreateEventPayload(ctx, sqlc.CreateEventPayloadParams{
+			ID:         payloadID,
+			Data:       n.Data,
+			CreateTime: time.Now().UTC(),
+		}); err != nil {
+			return fmt.Errorf("failed to store event payload: %w", err)
+		}
+
+		n.Data = nil
+		n.PayloadID = &payloadID
+		payload, err = json.Marshal(n)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification: %w", err)
+		}
+	}
+
+	return p.db.NotifyEvent(ctx, sqlc.NotifyEventParams{
+		Channel: eventsChannel,
+		Payload: string(payload),
+	})
+}
+
+func (p *PubSub) Subscribe(testExecID string) (<-chan *executionsv1.ExecutionEvent, func(), error) {
+	subscr := event.NewSubscription(testExecID, p.opts.overflowPolicy, p.opts.bufferSize)
+
+	p.mu.Lock()
+	topicSubs, ok := p.subs[testExecID]
+	if !ok {
+		topicSubs = mapset.NewThreadUnsafeSet[*event.Subscription]()
+		p.subs[testExecID] = topicSubs
+	}
+	topicSubs.Add(subscr)
+	p.mu.Unlock()
+
+	var once sync.Once
+	unsub := func() {
+		once.Do(func() {
+			p.mu.Lock()
+			if ts, ok := p.subs[testExecID]; ok {
+				ts.Remove(subscr)
+				if ts.Cardinality() == 0 {
+					delete(p.subs, testExecID)
+				}
+			}
+			p.mu.Unlock()
+			subscr.Close()
+
+			stats := subscr.Stats()
+			if stats.Dropped > 0 {
+				p.opts.logger.Warn("postgres subscriber dropped events",
+					"subject", testExecID,
+					"overflow_policy", p.opts.overflowPolicy.String(),
+					"delivered", stats.Delivered,
+					"dropped", stats.Dropped,
+				)
+			}
+		})
+	}
+
+	return subscr.Events(), unsub, nil
+}
+
+// Close stops listening for events, releases the listener connection back to
+// the pool and closes all subscriptions.
+func (p *PubSub) Close() {
+	p.cancel()
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, topicSubs := range p.subs {
+		for subscr := range topicSubs.Iter() {
+			subscr.Close()
+		}
+	}
+}
+
+// subscriptions returns the subscriptions to the test execution's events.
+func (p *PubSub) subscriptions(testExecID string) []*event.Subscription {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if topicSubs, ok := p.subs[testExecID]; ok {
+		return topicSubs.ToSlice()
+	}
+	return nil
+}
+
+func (p *PubSub) deliver(subs []*event.Subscription, e *executionsv1.ExecutionEvent) {
+	for _, subscr := range subs {
+		if subscr.Deliver(e) {
+			p.opts.logger.Warn("disconnected slow postgres subscriber",
+				"subject", subscr.Stats().Subject,
+				"buffer_size", p.opts.bufferSize,
+			)
+		}
+	}
+}
+
+type notification struct {
+	TestExecutionID string   `json:"testExecutionId"`
+	Data            []byte   `json:"data,omitempty"`
+	PayloadID       *uuid.V7 `json:"payloadId,omitempty"`
+	// Batch is true if the data contains events encoded by
+	// event.MarshalBatch.
+	Batch bool `json:"batch,omitempty"`
+}
+
+func (p *PubSub) listen(ctx context.Context, conn *pgxpool.Conn) {
+	defer func() {
+		if conn != nil {
+			conn.Release()
+		}
+	}()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.opts.logger.Error("lost postgres event listener connection: reconnecting", "error", err)
+
+			conn.Release()
+			conn = nil
+
+			if conn, err = p.acquireListenConn(ctx); err != nil {
+				if !errors.Is(err, context.Canceled) {
+					p.opts.logger.Error("failed to reconnect postgres event listener", "error", err)
+				}
+				return
+			}
+			continue
+		}
+
+		if err = p.handleNotification(ctx, n.Payload); err != nil {
+			p.opts.logger.Error("failed to handle postgres event notification", "error", err, "payload", n.Payload)
+		}
+	}
+}
+
+func (p *PubSub) acquireListenConn(ctx context.Context) (*pgxpool.Conn, error) {
+	var conn *pgxpool.Conn
+
+	op := func() error {
+		var err error
+		conn, err = p.pool.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err = conn.Exec(ctx, "LISTEN "+eventsChannel); err != nil {
+			conn.Release()
+			return err
+		}
+		return nil
+	}
+
+	bo := backoff.WithContext(backoff.NewConstantBackOff(listenRetryInterval), ctx)
+	if err := backoff.Retry(op, bo); err != nil {
+		return nil, fmt.Errorf("failed to listen for postgres events: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (p *PubSub) handleNotification(ctx context.Context, payload string) error {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+
+	// Every replica receives every notification, so skip events that no
+	// local subscriber is waiting for before fetching stored payloads.
+	subs := p.subscriptions(n.TestExecutionID)
+	if len(subs) == 0 {
+		return nil
+	}
+
+	data := n.Data
+	if n.PayloadID != nil {
+		var err error
+		data, err = p.db.GetEventPayload(ctx, *n.PayloadID)
+		if err != nil {
+			return fmt.Errorf("failed to get event payload: %w", err)
+		}
+	}
+
+	if n.Batch {
+		events, err := event.UnmarshalBatch(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal event batch: %w", err)
+		}
+		for _, e := range events {
+			p.deliver(subs, e)
+		}
+		return nil
+	}
+
+	out := &executionsv1.ExecutionEvent{}
+	if err := proto.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	p.deliver(subs, out)
+	return nil
+}
+
+func (p *PubSub) purgeEventPayloads(ctx context.Context) {
+	ticker := time.NewTicker(payloadPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expireTime := time.Now().UTC().Add(-payloadRetentionPeriod)
+			if err := p.db.DeleteEventPayloadsBefore(ctx, expireTime); err != nil && ctx.Err() == nil {
+				p.opts.logger.Error("failed to purge expired event payloads", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestPubSub(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{
+			name:    "inline payload",
+			message: "foo",
+		},
+		{
+			name:    "stored payload",
+			message: strings.Repeat("a", maxNotificationByteSize*2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			pool := newTestPool(t)
+			defer pool.Close()
+
+			pubSub, err := NewPubSub(ctx, pool, WithLogger(log.NewNopLogger()))
+			require.NoError(t, err)
+			defer pubSub.Close()
+
+			testExecID := test.NewTestExecutionID()
+			want := &executionsv1.ExecutionEvent{
+				Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, &testsv1.Log{
+					Id:              uuid.NewString(),
+					TestExecutionId: testExecID.String(),
+					Level:           "INFO",
+					Message:         tt.message,
+					CreateTime:      timestamppb.Now(),
+				}),
+				Sequence: 1,
+			}
+
+			sub, unsub, err := pubSub.Subscribe(testExecID.String())
+			require.NoError(t, err)
+			defer unsub()
+
+			err = pubSub.Publish(testExecID.String(), want)
+			require.NoError(t, err)
+
+			select {
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for event")
+			case got := <-sub:
+				assert.True(t, proto.Equal(want, got))
+			}
+		})
+	}
+}
+
+func TestPubSub_PublishBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool := newTestPool(t)
+	defer pool.Close()
+
+	pubSub, err := NewPubSub(ctx, pool, WithLogger(log.NewNopLogger()))
+	require.NoError(t, err)
+	defer pubSub.Close()
+
+	testExecID := test.NewTestExecutionID()
+	messages := []string{"foo", strings.Repeat("a", maxNotificationByteSize*2), "bar"}
+
+	want := make([]*executionsv1.ExecutionEvent, len(messages))
+	for i, msg := range messages {
+		want[i] = &executionsv1.ExecutionEvent{
+			Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, &testsv1.Log{
+				Id:              uuid.NewString(),
+				TestExecutionId: testExecID.String(),
+				Level:           "INFO",
+				Message:         msg,
+				CreateTime:      timestamppb.Now(),
+			}),
+			Sequence: uint64(i) + 1,
+		}
+	}
+
+	sub, unsub, err := pubSub.Subscribe(testExecID.String())
+	require.NoError(t, err)
+	defer unsub()
+
+	err = pubSub.PublishBatch(testExecID.String(), want)
+	require.NoError(t, err)
+
+	for _, w := range want {
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for event")
+		case got := <-sub:
+			assert.True(t, proto.Equal(w, got))
+		}
+	}
+}
+
+func TestPubSub_overflowPolicy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool := newTestPool(t)
+	defer pool.Close()
+
+	pubSub, err := NewPubSub(ctx, pool,
+		WithLogger(log.NewNopLogger()),
+		WithSubscriptionBufferSize(1),
+		WithOverflowPolicy(event.OverflowDropNewest),
+	)
+	require.NoError(t, err)
+	defer pubSub.Close()
+
+	testExecID := test.NewTestExecutionID()
+	testExec := &testsv1.TestExecution{Id: testExecID.String()}
+	logged := &executionsv1.ExecutionEvent{
+		Event: event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, &testsv1.Log{
+			Id:              uuid.NewString(),
+			TestExecutionId: testExecID.String(),
+			Level:           "INFO",
+			Message:         "foo",
+			CreateTime:      timestamppb.Now(),
+		}),
+		Sequence: 1,
+	}
+	finished := &executionsv1.ExecutionEvent{
+		Event:    event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, testExec),
+		Sequence: 2,
+	}
+
+	sub, unsub, err := pubSub.Subscribe(testExecID.String())
+	require.NoError(t, err)
+	defer unsub()
+
+	// The terminal event evicts the buffered log event if the buffer is full
+	// rather than being dropped as the newest event.
+	require.NoError(t, pubSub.PublishBatch(testExecID.String(), []*executionsv1.ExecutionEvent{logged, finished}))
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for terminal event")
+		case got := <-sub:
+			if got.Sequence == finished.Sequence {
+				assert.True(t, proto.Equal(finished, got))
+				return
+			}
+		}
+	}
+}
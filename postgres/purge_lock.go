@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/annexsh/annex/retention"
+)
+
+// purgeLockID is the key of the advisory lock held while purging expired test
+// executions. It's distinct from the lock held while migrating.
+const purgeLockID int64 = 0x616e6e6578707572 // "annexpur"
+
+// NewPurgeLock returns a lock that serializes the purges of processes sharing
+// the database with a Postgres advisory lock. The lock is held on a
+// connection acquired from the pool until it's released.
+func NewPurgeLock(pool *pgxpool.Pool) retention.LockFunc {
+	return func(ctx context.Context) (func(), bool, error) {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var acquired bool
+		if err = conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", purgeLockID).Scan(&acquired); err != nil {
+			conn.Release()
+			return nil, false, err
+		}
+		if !acquired {
+			conn.Release()
+			return nil, false, nil
+		}
+
+		return func() {
+			if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", purgeLockID); err != nil {
+				// Closing the connection releases the lock
+				_ = conn.Conn().Close(context.Background())
+			}
+			conn.Release()
+		}, true, nil
+	}
+}
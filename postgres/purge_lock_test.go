@@ -0,0 +1,38 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPurgeLock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pool := newTestPool(t)
+	defer pool.Close()
+
+	lock := NewPurgeLock(pool)
+
+	unlock, acquired, err := lock(ctx)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// The lock is held by another session until it's released
+	_, acquired, err = lock(ctx)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	unlock()
+
+	unlock, acquired, err = lock(ctx)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	unlock()
+}
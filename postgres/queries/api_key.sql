@@ -0,0 +1,21 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, name, hash, create_time)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetAPIKeyByHash :one
+SELECT *
+FROM api_keys
+WHERE hash = $1;
+
+-- name: ListAPIKeys :many
+SELECT *
+FROM api_keys
+WHERE (sqlc.narg('offset_id')::uuid IS NULL OR id < sqlc.narg('offset_id')::uuid)
+ORDER BY id DESC
+LIMIT @page_size;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoke_time = $2
+WHERE id = $1
+  AND revoke_time IS NULL;
@@ -0,0 +1,40 @@
+-- name: CreateArtifact :exec
+INSERT INTO artifacts (id, test_execution_id, case_execution_id, name, content_type, size, checksum, create_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetArtifact :one
+SELECT *
+FROM artifacts
+WHERE id = $1;
+
+-- name: ListArtifacts :many
+SELECT *
+FROM artifacts
+WHERE (test_execution_id = @test_execution_id)
+  AND (sqlc.narg('case_execution_id')::integer IS NULL OR case_execution_id = sqlc.narg('case_execution_id')::integer)
+  AND (sqlc.narg('offset_id')::uuid IS NULL OR id > sqlc.narg('offset_id')::uuid)
+ORDER BY id
+LIMIT @page_size;
+
+-- name: DeleteArtifacts :many
+DELETE
+FROM artifacts
+WHERE test_execution_id = $1
+RETURNING *;
+
+-- name: CreatePendingBlobDeletion :exec
+INSERT INTO pending_blob_deletions (blob_key, create_time)
+VALUES ($1, $2)
+ON CONFLICT (blob_key) DO NOTHING;
+
+-- name: ListPendingBlobDeletions :many
+SELECT blob_key
+FROM pending_blob_deletions
+WHERE (blob_key > COALESCE(sqlc.narg('offset_key'), ''))
+ORDER BY blob_key
+LIMIT @page_size;
+
+-- name: DeletePendingBlobDeletion :exec
+DELETE
+FROM pending_blob_deletions
+WHERE blob_key = $1;
@@ -0,0 +1,16 @@
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_entries (id, context_id, action, subject, auth_method, test_suite_id, test_id, test_execution_id,
+                           summary, create_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ListAuditEntries :many
+SELECT *
+FROM audit_entries
+WHERE (context_id = @context_id)
+  AND (sqlc.narg('subject')::text IS NULL OR subject = sqlc.narg('subject')::text)
+  AND (sqlc.narg('action')::text IS NULL OR action = sqlc.narg('action')::text)
+  AND (sqlc.narg('test_id')::uuid IS NULL OR test_id = sqlc.narg('test_id')::uuid)
+  AND (sqlc.narg('test_execution_id')::uuid IS NULL OR test_execution_id = sqlc.narg('test_execution_id')::uuid)
+  AND (sqlc.narg('offset_id')::uuid IS NULL OR id < sqlc.narg('offset_id')::uuid)
+ORDER BY id DESC
+LIMIT @page_size;
@@ -0,0 +1,15 @@
+-- name: PutBlob :exec
+INSERT INTO blobs (key, data, create_time)
+VALUES ($1, $2, $3)
+ON CONFLICT (key) DO UPDATE SET data        = excluded.data,
+                                create_time = excluded.create_time;
+
+-- name: GetBlob :one
+SELECT data
+FROM blobs
+WHERE key = $1;
+
+-- name: DeleteBlob :exec
+DELETE
+FROM blobs
+WHERE key = $1;
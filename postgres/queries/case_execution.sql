@@ -44,3 +44,8 @@ WHERE (test_execution_id = @test_execution_id)
   AND (sqlc.narg('offset_id')::integer IS NULL OR id > sqlc.narg('offset_id')::integer)
 ORDER BY id
 LIMIT @page_size;
+
+-- name: DeleteCaseExecutions :execrows
+DELETE
+FROM case_executions
+WHERE test_execution_id = $1;
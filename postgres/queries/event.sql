@@ -0,0 +1,16 @@
+-- name: CreateEventPayload :exec
+INSERT INTO event_payloads (id, data, create_time)
+VALUES ($1, $2, $3);
+
+-- name: GetEventPayload :one
+SELECT data
+FROM event_payloads
+WHERE id = $1;
+
+-- name: DeleteEventPayloadsBefore :exec
+DELETE
+FROM event_payloads
+WHERE create_time < $1;
+
+-- name: NotifyEvent :exec
+SELECT pg_notify(@channel::text, @payload::text);
@@ -0,0 +1,47 @@
+-- name: NextExecutionEventSequence :one
+INSERT INTO execution_event_sequences (test_execution_id, sequence)
+VALUES ($1, 1)
+ON CONFLICT (test_execution_id) DO UPDATE
+    SET sequence = execution_event_sequences.sequence + 1
+RETURNING sequence;
+
+-- name: CreateExecutionEvent :exec
+INSERT INTO execution_events (test_execution_id, sequence, type, case_execution_id, log_id, data, create_time)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListExecutionEvents :many
+SELECT execution_events.*
+FROM execution_events
+         LEFT JOIN logs ON logs.id = execution_events.log_id
+WHERE execution_events.test_execution_id = @test_execution_id
+  AND (sqlc.narg('offset_sequence')::bigint IS NULL OR sequence > sqlc.narg('offset_sequence')::bigint)
+  -- Log filters only apply to log events
+  AND (execution_events.log_id IS NULL OR (
+    (sqlc.narg('case_execution_id')::integer IS NULL OR logs.case_execution_id = sqlc.narg('case_execution_id')::integer)
+        AND (sqlc.narg('min_severity')::integer IS NULL OR logs.severity >= sqlc.narg('min_severity')::integer)
+        AND (sqlc.narg('attributes')::jsonb IS NULL OR logs.attributes @> sqlc.narg('attributes')::jsonb)
+        AND (sqlc.narg('message_contains')::text IS NULL OR strpos(logs.message, sqlc.narg('message_contains')::text) > 0)
+    ))
+ORDER BY sequence
+LIMIT @page_size;
+
+-- name: GetExecutionEventSequence :one
+SELECT sequence
+FROM execution_event_sequences
+WHERE test_execution_id = $1;
+
+-- name: DeleteExecutionEvents :exec
+DELETE
+FROM execution_events
+WHERE test_execution_id = $1
+  AND type = $2;
+
+-- name: DeleteTestExecutionEvents :execrows
+DELETE
+FROM execution_events
+WHERE test_execution_id = $1;
+
+-- name: DeleteExecutionEventSequence :exec
+DELETE
+FROM execution_event_sequences
+WHERE test_execution_id = $1;
@@ -1,6 +1,12 @@
 -- name: CreateLog :exec
-INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes,
+                  severity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: CreateLogs :copyfrom
+INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes,
+                  severity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 
 -- name: GetLog :one
 SELECT *
@@ -12,9 +18,19 @@ SELECT *
 FROM logs
 WHERE (test_execution_id = @test_execution_id)
   AND (sqlc.narg('offset_id')::uuid IS NULL OR id < sqlc.narg('offset_id')::uuid)
+  AND (sqlc.narg('case_execution_id')::integer IS NULL OR case_execution_id = sqlc.narg('case_execution_id')::integer)
+  AND (sqlc.narg('min_severity')::integer IS NULL OR severity >= sqlc.narg('min_severity')::integer)
+  AND (sqlc.narg('attributes')::jsonb IS NULL OR attributes @> sqlc.narg('attributes')::jsonb)
+  AND (sqlc.narg('message_contains')::text IS NULL OR strpos(message, sqlc.narg('message_contains')::text) > 0)
+ORDER BY id DESC
 LIMIT @page_size;
 
 -- name: DeleteLog :exec
 DELETE
 FROM logs
 WHERE id = $1;
+
+-- name: DeleteLogs :execrows
+DELETE
+FROM logs
+WHERE test_execution_id = $1;
@@ -0,0 +1,27 @@
+-- name: CreateRoleBinding :one
+INSERT INTO role_bindings (id, context_id, subject, role, create_time)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (context_id, subject, role) DO UPDATE -- no-op update to return the existing row
+    SET create_time = role_bindings.create_time
+RETURNING *;
+
+-- name: ListRoleBindings :many
+SELECT *
+FROM role_bindings
+WHERE (context_id = @context_id)
+  AND (sqlc.narg('offset_id')::uuid IS NULL OR id < sqlc.narg('offset_id')::uuid)
+ORDER BY id DESC
+LIMIT @page_size;
+
+-- name: ListSubjectRoles :many
+SELECT DISTINCT role
+FROM role_bindings
+WHERE subject = $1
+  AND (context_id = $2 OR context_id = '*');
+
+-- name: DeleteRoleBinding :execrows
+DELETE
+FROM role_bindings
+WHERE context_id = $1
+  AND subject = $2
+  AND role = $3;
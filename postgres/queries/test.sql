@@ -30,10 +30,11 @@ FROM tests
 WHERE id = $1;
 
 -- name: CreateTestDefaultInput :exec
-INSERT INTO test_default_inputs (test_id, data)
-VALUES ($1, $2)
+INSERT INTO test_default_inputs (test_id, data, metadata)
+VALUES ($1, $2, $3)
 ON CONFLICT (test_id) DO UPDATE
-    SET data = excluded.data;
+    SET data     = excluded.data,
+        metadata = excluded.metadata;
 
 -- name: GetTestDefaultInput :one
 SELECT *
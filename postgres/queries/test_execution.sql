@@ -1,20 +1,28 @@
 -- name: CreateTestExecutionScheduled :one
-INSERT INTO test_executions (id, test_id, has_input, schedule_time)
-VALUES ($1, $2, $3, $4)
+INSERT INTO test_executions (id, test_id, has_input, schedule_time, triggered_by, git_sha, git_branch, ci_job_url,
+                             environment, labels)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 ON CONFLICT (id) DO UPDATE
     SET test_id       = excluded.test_id,
         has_input     = excluded.has_input,
         schedule_time = excluded.schedule_time,
         start_time    = null,
         finish_time   = null,
-        error         = null
+        error         = null,
+        triggered_by  = excluded.triggered_by,
+        git_sha       = excluded.git_sha,
+        git_branch    = excluded.git_branch,
+        ci_job_url    = excluded.ci_job_url,
+        environment   = excluded.environment,
+        labels        = excluded.labels
 RETURNING *;
 
 -- name: CreateTestExecutionInput :exec
-INSERT INTO test_execution_inputs (test_execution_id, data)
-VALUES ($1, $2)
+INSERT INTO test_execution_inputs (test_execution_id, data, metadata)
+VALUES ($1, $2, $3)
 ON CONFLICT (test_execution_id) DO UPDATE
-    SET data = excluded.data;
+    SET data     = excluded.data,
+        metadata = excluded.metadata;
 
 -- name: GetTestExecutionInput :one
 SELECT *
@@ -22,19 +30,23 @@ FROM test_execution_inputs
 WHERE test_execution_id = $1;
 
 -- name: UpdateTestExecutionStarted :one
-UPDATE test_executions
+UPDATE test_executions te
 SET start_time  = $2,
     finish_time = null,
     error       = null
-WHERE id = $1
-RETURNING *;
+FROM tests t
+WHERE te.id = $1
+  AND t.id = te.test_id
+RETURNING te.*, t.context_id, t.test_suite_id;
 
 -- name: UpdateTestExecutionFinished :one
-UPDATE test_executions
+UPDATE test_executions te
 SET finish_time = $2,
     error       = $3
-WHERE id = $1
-RETURNING *;
+FROM tests t
+WHERE te.id = $1
+  AND t.id = te.test_id
+RETURNING te.*, t.context_id, t.test_suite_id;
 
 -- name: ResetTestExecution :one
 UPDATE test_executions
@@ -50,6 +62,12 @@ SELECT *
 FROM test_executions
 WHERE id = $1;
 
+-- name: GetTestExecutionContext :one
+SELECT t.context_id
+FROM test_executions te
+         JOIN tests t ON t.id = te.test_id
+WHERE te.id = $1;
+
 -- name: ListTestExecutions :many
 SELECT *
 FROM test_executions
@@ -58,3 +76,49 @@ WHERE test_id = @test_id
   AND (sqlc.narg('offset_id')::uuid IS NULL OR id < sqlc.narg('offset_id')::uuid)
 ORDER BY id DESC
 LIMIT @page_size;
+
+-- name: SearchTestExecutions :many
+SELECT te.*
+FROM test_executions te
+         JOIN tests t ON t.id = te.test_id
+WHERE t.context_id = @context_id
+  AND (sqlc.narg('test_id')::uuid IS NULL OR te.test_id = sqlc.narg('test_id')::uuid)
+  AND (sqlc.narg('offset_id')::uuid IS NULL OR te.id < sqlc.narg('offset_id')::uuid)
+  AND (sqlc.narg('triggered_by')::text IS NULL OR te.triggered_by = sqlc.narg('triggered_by')::text)
+  AND (sqlc.narg('git_sha')::text IS NULL OR te.git_sha = sqlc.narg('git_sha')::text)
+  AND (sqlc.narg('git_branch')::text IS NULL OR te.git_branch = sqlc.narg('git_branch')::text)
+  AND (sqlc.narg('ci_job_url')::text IS NULL OR te.ci_job_url = sqlc.narg('ci_job_url')::text)
+  AND (sqlc.narg('environment')::text IS NULL OR te.environment = sqlc.narg('environment')::text)
+  AND (sqlc.narg('labels')::jsonb IS NULL OR te.labels @> sqlc.narg('labels')::jsonb)
+ORDER BY te.id DESC
+LIMIT @page_size;
+
+-- name: ListExpiredTestExecutions :many
+SELECT id
+FROM (SELECT te.id,
+             te.finish_time,
+             te.error,
+             ROW_NUMBER() OVER (PARTITION BY te.test_id ORDER BY te.id DESC) AS position
+      FROM test_executions te
+               JOIN tests t ON t.id = te.test_id
+      WHERE t.context_id = @context_id) AS ranked
+WHERE finish_time IS NOT NULL
+  AND CASE
+          -- Failed executions are kept until they expire by the failed execution cutoff when set
+          WHEN error IS NOT NULL AND sqlc.narg('failed_finished_before')::timestamp IS NOT NULL
+              THEN finish_time < sqlc.narg('failed_finished_before')::timestamp
+          ELSE finish_time < sqlc.narg('finished_before')::timestamp
+              OR (@max_per_test::bigint > 0 AND position > @max_per_test::bigint)
+    END
+ORDER BY id
+LIMIT @page_size;
+
+-- name: DeleteTestExecutionInput :exec
+DELETE
+FROM test_execution_inputs
+WHERE test_execution_id = $1;
+
+-- name: DeleteTestExecution :execrows
+DELETE
+FROM test_executions
+WHERE id = $1;
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/postgres/sqlc"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+var (
+	_ auth.RoleBindingReader = (*RoleBindingReader)(nil)
+	_ auth.RoleBindingWriter = (*RoleBindingWriter)(nil)
+)
+
+type RoleBindingReader struct {
+	db *DB
+}
+
+func NewRoleBindingReader(db *DB) *RoleBindingReader {
+	return &RoleBindingReader{db: db}
+}
+
+func (r *RoleBindingReader) ListRoleBindings(ctx context.Context, contextID string, filter test.PageFilter[uuid.V7]) (auth.RoleBindingList, error) {
+	params := sqlc.ListRoleBindingsParams{
+		ContextID: contextID,
+		PageSize:  int32(filter.Size),
+	}
+	if filter.OffsetID != nil {
+		params.OffsetID = filter.OffsetID
+	}
+
+	bindings, err := r.db.ListRoleBindings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return marshalRoleBindings(bindings), nil
+}
+
+func (r *RoleBindingReader) ListSubjectRoles(ctx context.Context, subject string, contextID string) ([]auth.Role, error) {
+	roles, err := r.db.ListSubjectRoles(ctx, sqlc.ListSubjectRolesParams{
+		Subject:   subject,
+		ContextID: contextID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]auth.Role, len(roles))
+	for i, role := range roles {
+		out[i] = auth.Role(role)
+	}
+	return out, nil
+}
+
+type RoleBindingWriter struct {
+	db *DB
+}
+
+func NewRoleBindingWriter(db *DB) *RoleBindingWriter {
+	return &RoleBindingWriter{db: db}
+}
+
+func (r *RoleBindingWriter) CreateRoleBinding(ctx context.Context, binding *auth.RoleBinding) (*auth.RoleBinding, error) {
+	created, err := r.db.CreateRoleBinding(ctx, sqlc.CreateRoleBindingParams{
+		ID:         binding.ID,
+		ContextID:  binding.Context,
+		Subject:    binding.Subject,
+		Role:       string(binding.Role),
+		CreateTime: binding.CreateTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return marshalRoleBinding(created), nil
+}
+
+func (r *RoleBindingWriter) DeleteRoleBinding(ctx context.Context, contextID string, subject string, role auth.Role) error {
+	count, err := r.db.DeleteRoleBinding(ctx, sqlc.DeleteRoleBindingParams{
+		ContextID: contextID,
+		Subject:   subject,
+		Role:      string(role),
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return auth.ErrorRoleBindingNotFound
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestRoleBindings(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+
+	w := NewRoleBindingWriter(db)
+	r := NewRoleBindingReader(db)
+
+	newBinding := func(contextID string, subject string, role auth.Role) *auth.RoleBinding {
+		return &auth.RoleBinding{
+			ID:         uuid.New(),
+			Context:    contextID,
+			Subject:    subject,
+			Role:       role,
+			CreateTime: time.Now().UTC().Truncate(time.Microsecond),
+		}
+	}
+
+	viewer := newBinding("foo", "alice", auth.RoleViewer)
+	got, err := w.CreateRoleBinding(ctx, viewer)
+	require.NoError(t, err)
+	assert.Equal(t, viewer, got)
+
+	// Creating an existing binding returns the stored binding
+	got, err = w.CreateRoleBinding(ctx, newBinding("foo", "alice", auth.RoleViewer))
+	require.NoError(t, err)
+	assert.Equal(t, viewer, got)
+
+	executor := newBinding("foo", "alice", auth.RoleExecutor)
+	_, err = w.CreateRoleBinding(ctx, executor)
+	require.NoError(t, err)
+	admin := newBinding(auth.AllContexts, "alice", auth.RoleAdmin)
+	_, err = w.CreateRoleBinding(ctx, admin)
+	require.NoError(t, err)
+	_, err = w.CreateRoleBinding(ctx, newBinding("bar", "alice", auth.RoleRunner))
+	require.NoError(t, err)
+	_, err = w.CreateRoleBinding(ctx, newBinding("foo", "bob", auth.RoleRunner))
+	require.NoError(t, err)
+
+	roles, err := r.ListSubjectRoles(ctx, "alice", "foo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []auth.Role{auth.RoleViewer, auth.RoleExecutor, auth.RoleAdmin}, roles)
+
+	roles, err = r.ListSubjectRoles(ctx, "carol", "foo")
+	require.NoError(t, err)
+	assert.Empty(t, roles)
+
+	page1, err := r.ListRoleBindings(ctx, "foo", test.PageFilter[uuid.V7]{Size: 2})
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+	assert.Equal(t, "bob", page1[0].Subject) // newest first
+	assert.Equal(t, executor, page1[1])
+
+	page2, err := r.ListRoleBindings(ctx, "foo", test.PageFilter[uuid.V7]{Size: 2, OffsetID: &page1[1].ID})
+	require.NoError(t, err)
+	assert.Equal(t, auth.RoleBindingList{viewer}, page2)
+
+	err = w.DeleteRoleBinding(ctx, "foo", "alice", auth.RoleExecutor)
+	require.NoError(t, err)
+	roles, err = r.ListSubjectRoles(ctx, "alice", "foo")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []auth.Role{auth.RoleViewer, auth.RoleAdmin}, roles)
+
+	err = w.DeleteRoleBinding(ctx, "foo", "alice", auth.RoleExecutor)
+	assert.ErrorIs(t, err, auth.ErrorRoleBindingNotFound)
+}
@@ -68,6 +68,20 @@ func (q *Queries) DeleteCaseExecution(ctx context.Context, arg DeleteCaseExecuti
 	return err
 }
 
+const deleteCaseExecutions = `-- name: DeleteCaseExecutions :execrows
+DELETE
+FROM case_executions
+WHERE test_execution_id = $1
+`
+
+func (q *Queries) DeleteCaseExecutions(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCaseExecutions, testExecutionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getCaseExecution = `-- name: GetCaseExecution :one
 SELECT id, test_execution_id, case_name, schedule_time, start_time, finish_time, error
 FROM case_executions
@@ -14,8 +14,9 @@ import (
 )
 
 const createLog = `-- name: CreateLog :exec
-INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes,
+                  severity)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 `
 
 type CreateLogParams struct {
@@ -25,6 +26,9 @@ type CreateLogParams struct {
 	Level           string                `json:"level"`
 	Message         string                `json:"message"`
 	CreateTime      time.Time             `json:"create_time"`
+	RedactionCount  int32                 `json:"redaction_count"`
+	Attributes      []byte                `json:"attributes"`
+	Severity        int32                 `json:"severity"`
 }
 
 func (q *Queries) CreateLog(ctx context.Context, arg CreateLogParams) error {
@@ -35,10 +39,25 @@ func (q *Queries) CreateLog(ctx context.Context, arg CreateLogParams) error {
 		arg.Level,
 		arg.Message,
 		arg.CreateTime,
+		arg.RedactionCount,
+		arg.Attributes,
+		arg.Severity,
 	)
 	return err
 }
 
+type CreateLogsParams struct {
+	ID              uuid.V7               `json:"id"`
+	TestExecutionID test.TestExecutionID  `json:"test_execution_id"`
+	CaseExecutionID *test.CaseExecutionID `json:"case_execution_id"`
+	Level           string                `json:"level"`
+	Message         string                `json:"message"`
+	CreateTime      time.Time             `json:"create_time"`
+	RedactionCount  int32                 `json:"redaction_count"`
+	Attributes      []byte                `json:"attributes"`
+	Severity        int32                 `json:"severity"`
+}
+
 const deleteLog = `-- name: DeleteLog :exec
 DELETE
 FROM logs
@@ -50,8 +69,22 @@ func (q *Queries) DeleteLog(ctx context.Context, id uuid.V7) error {
 	return err
 }
 
+const deleteLogs = `-- name: DeleteLogs :execrows
+DELETE
+FROM logs
+WHERE test_execution_id = $1
+`
+
+func (q *Queries) DeleteLogs(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteLogs, testExecutionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getLog = `-- name: GetLog :one
-SELECT id, test_execution_id, case_execution_id, level, message, create_time
+SELECT id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes, severity
 FROM logs
 WHERE id = $1
 `
@@ -66,26 +99,46 @@ func (q *Queries) GetLog(ctx context.Context, id uuid.V7) (*Log, error) {
 		&i.Level,
 		&i.Message,
 		&i.CreateTime,
+		&i.RedactionCount,
+		&i.Attributes,
+		&i.Severity,
 	)
 	return &i, err
 }
 
 const listLogs = `-- name: ListLogs :many
-SELECT id, test_execution_id, case_execution_id, level, message, create_time
+SELECT id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes, severity
 FROM logs
 WHERE (test_execution_id = $1)
   AND ($2::uuid IS NULL OR id < $2::uuid)
-LIMIT $3
+  AND ($3::integer IS NULL OR case_execution_id = $3::integer)
+  AND ($4::integer IS NULL OR severity >= $4::integer)
+  AND ($5::jsonb IS NULL OR attributes @> $5::jsonb)
+  AND ($6::text IS NULL OR strpos(message, $6::text) > 0)
+ORDER BY id DESC
+LIMIT $7
 `
 
 type ListLogsParams struct {
 	TestExecutionID test.TestExecutionID `json:"test_execution_id"`
 	OffsetID        *uuid.V7             `json:"offset_id"`
+	CaseExecutionID *int32               `json:"case_execution_id"`
+	MinSeverity     *int32               `json:"min_severity"`
+	Attributes      []byte               `json:"attributes"`
+	MessageContains *string              `json:"message_contains"`
 	PageSize        int32                `json:"page_size"`
 }
 
 func (q *Queries) ListLogs(ctx context.Context, arg ListLogsParams) ([]*Log, error) {
-	rows, err := q.db.Query(ctx, listLogs, arg.TestExecutionID, arg.OffsetID, arg.PageSize)
+	rows, err := q.db.Query(ctx, listLogs,
+		arg.TestExecutionID,
+		arg.OffsetID,
+		arg.CaseExecutionID,
+		arg.MinSeverity,
+		arg.Attributes,
+		arg.MessageContains,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +153,9 @@ func (q *Queries) ListLogs(ctx context.Context, arg ListLogsParams) ([]*Log, err
 			&i.Level,
 			&i.Message,
 			&i.CreateTime,
+			&i.RedactionCount,
+			&i.Attributes,
+			&i.Severity,
 		); err != nil {
 			return nil, err
 		}
@@ -14,33 +14,42 @@ import (
 )
 
 const createTestExecutionInput = `-- name: CreateTestExecutionInput :exec
-INSERT INTO test_execution_inputs (test_execution_id, data)
-VALUES ($1, $2)
+INSERT INTO test_execution_inputs (test_execution_id, data, metadata)
+VALUES ($1, $2, $3)
 ON CONFLICT (test_execution_id) DO UPDATE
-    SET data = excluded.data
+    SET data     = excluded.data,
+        metadata = excluded.metadata
 `
 
 type CreateTestExecutionInputParams struct {
 	TestExecutionID test.TestExecutionID `json:"test_execution_id"`
 	Data            []byte               `json:"data"`
+	Metadata        []byte               `json:"metadata"`
 }
 
 func (q *Queries) CreateTestExecutionInput(ctx context.Context, arg CreateTestExecutionInputParams) error {
-	_, err := q.db.Exec(ctx, createTestExecutionInput, arg.TestExecutionID, arg.Data)
+	_, err := q.db.Exec(ctx, createTestExecutionInput, arg.TestExecutionID, arg.Data, arg.Metadata)
 	return err
 }
 
 const createTestExecutionScheduled = `-- name: CreateTestExecutionScheduled :one
-INSERT INTO test_executions (id, test_id, has_input, schedule_time)
-VALUES ($1, $2, $3, $4)
+INSERT INTO test_executions (id, test_id, has_input, schedule_time, triggered_by, git_sha, git_branch, ci_job_url,
+                             environment, labels)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 ON CONFLICT (id) DO UPDATE
     SET test_id       = excluded.test_id,
         has_input     = excluded.has_input,
         schedule_time = excluded.schedule_time,
         start_time    = null,
         finish_time   = null,
-        error         = null
-RETURNING id, test_id, has_input, schedule_time, start_time, finish_time, error
+        error         = null,
+        triggered_by  = excluded.triggered_by,
+        git_sha       = excluded.git_sha,
+        git_branch    = excluded.git_branch,
+        ci_job_url    = excluded.ci_job_url,
+        environment   = excluded.environment,
+        labels        = excluded.labels
+RETURNING id, test_id, has_input, schedule_time, start_time, finish_time, error, triggered_by, git_sha, git_branch, ci_job_url, environment, labels
 `
 
 type CreateTestExecutionScheduledParams struct {
@@ -48,6 +57,12 @@ type CreateTestExecutionScheduledParams struct {
 	TestID       uuid.V7              `json:"test_id"`
 	HasInput     bool                 `json:"has_input"`
 	ScheduleTime time.Time            `json:"schedule_time"`
+	TriggeredBy  *string              `json:"triggered_by"`
+	GitSha       *string              `json:"git_sha"`
+	GitBranch    *string              `json:"git_branch"`
+	CiJobUrl     *string              `json:"ci_job_url"`
+	Environment  *string              `json:"environment"`
+	Labels       []byte               `json:"labels"`
 }
 
 func (q *Queries) CreateTestExecutionScheduled(ctx context.Context, arg CreateTestExecutionScheduledParams) (*TestExecution, error) {
@@ -56,6 +71,12 @@ func (q *Queries) CreateTestExecutionScheduled(ctx context.Context, arg CreateTe
 		arg.TestID,
 		arg.HasInput,
 		arg.ScheduleTime,
+		arg.TriggeredBy,
+		arg.GitSha,
+		arg.GitBranch,
+		arg.CiJobUrl,
+		arg.Environment,
+		arg.Labels,
 	)
 	var i TestExecution
 	err := row.Scan(
@@ -66,12 +87,43 @@ func (q *Queries) CreateTestExecutionScheduled(ctx context.Context, arg CreateTe
 		&i.StartTime,
 		&i.FinishTime,
 		&i.Error,
+		&i.TriggeredBy,
+		&i.GitSha,
+		&i.GitBranch,
+		&i.CiJobUrl,
+		&i.Environment,
+		&i.Labels,
 	)
 	return &i, err
 }
 
+const deleteTestExecution = `-- name: DeleteTestExecution :execrows
+DELETE
+FROM test_executions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTestExecution(ctx context.Context, id test.TestExecutionID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTestExecution, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTestExecutionInput = `-- name: DeleteTestExecutionInput :exec
+DELETE
+FROM test_execution_inputs
+WHERE test_execution_id = $1
+`
+
+func (q *Queries) DeleteTestExecutionInput(ctx context.Context, testExecutionID test.TestExecutionID) error {
+	_, err := q.db.Exec(ctx, deleteTestExecutionInput, testExecutionID)
+	return err
+}
+
 const getTestExecution = `-- name: GetTestExecution :one
-SELECT id, test_id, has_input, schedule_time, start_time, finish_time, error
+SELECT id, test_id, has_input, schedule_time, start_time, finish_time, error, triggered_by, git_sha, git_branch, ci_job_url, environment, labels
 FROM test_executions
 WHERE id = $1
 `
@@ -87,12 +139,32 @@ func (q *Queries) GetTestExecution(ctx context.Context, id test.TestExecutionID)
 		&i.StartTime,
 		&i.FinishTime,
 		&i.Error,
+		&i.TriggeredBy,
+		&i.GitSha,
+		&i.GitBranch,
+		&i.CiJobUrl,
+		&i.Environment,
+		&i.Labels,
 	)
 	return &i, err
 }
 
+const getTestExecutionContext = `-- name: GetTestExecutionContext :one
+SELECT t.context_id
+FROM test_executions te
+         JOIN tests t ON t.id = te.test_id
+WHERE te.id = $1
+`
+
+func (q *Queries) GetTestExecutionContext(ctx context.Context, id test.TestExecutionID) (string, error) {
+	row := q.db.QueryRow(ctx, getTestExecutionContext, id)
+	var context_id string
+	err := row.Scan(&context_id)
+	return context_id, err
+}
+
 const getTestExecutionInput = `-- name: GetTestExecutionInput :one
-SELECT test_execution_id, data
+SELECT test_execution_id, data, metadata
 FROM test_execution_inputs
 WHERE test_execution_id = $1
 `
@@ -100,12 +172,67 @@ WHERE test_execution_id = $1
 func (q *Queries) GetTestExecutionInput(ctx context.Context, testExecutionID test.TestExecutionID) (*TestExecutionInput, error) {
 	row := q.db.QueryRow(ctx, getTestExecutionInput, testExecutionID)
 	var i TestExecutionInput
-	err := row.Scan(&i.TestExecutionID, &i.Data)
+	err := row.Scan(&i.TestExecutionID, &i.Data, &i.Metadata)
 	return &i, err
 }
 
+const listExpiredTestExecutions = `-- name: ListExpiredTestExecutions :many
+SELECT id
+FROM (SELECT te.id,
+             te.finish_time,
+             te.error,
+             ROW_NUMBER() OVER (PARTITION BY te.test_id ORDER BY te.id DESC) AS position
+      FROM test_executions te
+               JOIN tests t ON t.id = te.test_id
+      WHERE t.context_id = $1) AS ranked
+WHERE finish_time IS NOT NULL
+  AND CASE
+          -- Failed executions are kept until they expire by the failed execution cutoff when set
+          WHEN error IS NOT NULL AND $2::timestamp IS NOT NULL
+              THEN finish_time < $2::timestamp
+          ELSE finish_time < $3::timestamp
+              OR ($4::bigint > 0 AND position > $4::bigint)
+    END
+ORDER BY id
+LIMIT $5
+`
+
+type ListExpiredTestExecutionsParams struct {
+	ContextID            string     `json:"context_id"`
+	FailedFinishedBefore *time.Time `json:"failed_finished_before"`
+	FinishedBefore       *time.Time `json:"finished_before"`
+	MaxPerTest           int64      `json:"max_per_test"`
+	PageSize             int32      `json:"page_size"`
+}
+
+func (q *Queries) ListExpiredTestExecutions(ctx context.Context, arg ListExpiredTestExecutionsParams) ([]test.TestExecutionID, error) {
+	rows, err := q.db.Query(ctx, listExpiredTestExecutions,
+		arg.ContextID,
+		arg.FailedFinishedBefore,
+		arg.FinishedBefore,
+		arg.MaxPerTest,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []test.TestExecutionID
+	for rows.Next() {
+		var id test.TestExecutionID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTestExecutions = `-- name: ListTestExecutions :many
-SELECT id, test_id, has_input, schedule_time, start_time, finish_time, error
+SELECT id, test_id, has_input, schedule_time, start_time, finish_time, error, triggered_by, git_sha, git_branch, ci_job_url, environment, labels
 FROM test_executions
 WHERE test_id = $1
   -- Cast as uuid required below since sqlc.narg doesn't work with overridden column type
@@ -137,6 +264,12 @@ func (q *Queries) ListTestExecutions(ctx context.Context, arg ListTestExecutions
 			&i.StartTime,
 			&i.FinishTime,
 			&i.Error,
+			&i.TriggeredBy,
+			&i.GitSha,
+			&i.GitBranch,
+			&i.CiJobUrl,
+			&i.Environment,
+			&i.Labels,
 		); err != nil {
 			return nil, err
 		}
@@ -155,7 +288,7 @@ SET schedule_time = $2,
     finish_time   = null,
     error         = null
 WHERE id = $1
-RETURNING id, test_id, has_input, schedule_time, start_time, finish_time, error
+RETURNING id, test_id, has_input, schedule_time, start_time, finish_time, error, triggered_by, git_sha, git_branch, ci_job_url, environment, labels
 `
 
 type ResetTestExecutionParams struct {
@@ -174,16 +307,99 @@ func (q *Queries) ResetTestExecution(ctx context.Context, arg ResetTestExecution
 		&i.StartTime,
 		&i.FinishTime,
 		&i.Error,
+		&i.TriggeredBy,
+		&i.GitSha,
+		&i.GitBranch,
+		&i.CiJobUrl,
+		&i.Environment,
+		&i.Labels,
 	)
 	return &i, err
 }
 
+const searchTestExecutions = `-- name: SearchTestExecutions :many
+SELECT te.id, te.test_id, te.has_input, te.schedule_time, te.start_time, te.finish_time, te.error, te.triggered_by, te.git_sha, te.git_branch, te.ci_job_url, te.environment, te.labels
+FROM test_executions te
+         JOIN tests t ON t.id = te.test_id
+WHERE t.context_id = $1
+  AND ($2::uuid IS NULL OR te.test_id = $2::uuid)
+  AND ($3::uuid IS NULL OR te.id < $3::uuid)
+  AND ($4::text IS NULL OR te.triggered_by = $4::text)
+  AND ($5::text IS NULL OR te.git_sha = $5::text)
+  AND ($6::text IS NULL OR te.git_branch = $6::text)
+  AND ($7::text IS NULL OR te.ci_job_url = $7::text)
+  AND ($8::text IS NULL OR te.environment = $8::text)
+  AND ($9::jsonb IS NULL OR te.labels @> $9::jsonb)
+ORDER BY te.id DESC
+LIMIT $10
+`
+
+type SearchTestExecutionsParams struct {
+	ContextID   string   `json:"context_id"`
+	TestID      *uuid.V7 `json:"test_id"`
+	OffsetID    *uuid.V7 `json:"offset_id"`
+	TriggeredBy *string  `json:"triggered_by"`
+	GitSha      *string  `json:"git_sha"`
+	GitBranch   *string  `json:"git_branch"`
+	CiJobUrl    *string  `json:"ci_job_url"`
+	Environment *string  `json:"environment"`
+	Labels      []byte   `json:"labels"`
+	PageSize    int32    `json:"page_size"`
+}
+
+func (q *Queries) SearchTestExecutions(ctx context.Context, arg SearchTestExecutionsParams) ([]*TestExecution, error) {
+	rows, err := q.db.Query(ctx, searchTestExecutions,
+		arg.ContextID,
+		arg.TestID,
+		arg.OffsetID,
+		arg.TriggeredBy,
+		arg.GitSha,
+		arg.GitBranch,
+		arg.CiJobUrl,
+		arg.Environment,
+		arg.Labels,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*TestExecution
+	for rows.Next() {
+		var i TestExecution
+		if err := rows.Scan(
+			&i.ID,
+			&i.TestID,
+			&i.HasInput,
+			&i.ScheduleTime,
+			&i.StartTime,
+			&i.FinishTime,
+			&i.Error,
+			&i.TriggeredBy,
+			&i.GitSha,
+			&i.GitBranch,
+			&i.CiJobUrl,
+			&i.Environment,
+			&i.Labels,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateTestExecutionFinished = `-- name: UpdateTestExecutionFinished :one
-UPDATE test_executions
+UPDATE test_executions te
 SET finish_time = $2,
     error       = $3
-WHERE id = $1
-RETURNING id, test_id, has_input, schedule_time, start_time, finish_time, error
+FROM tests t
+WHERE te.id = $1
+  AND t.id = te.test_id
+RETURNING te.id, te.test_id, te.has_input, te.schedule_time, te.start_time, te.finish_time, te.error, te.triggered_by, te.git_sha, te.git_branch, te.ci_job_url, te.environment, te.labels, t.context_id, t.test_suite_id
 `
 
 type UpdateTestExecutionFinishedParams struct {
@@ -192,9 +408,27 @@ type UpdateTestExecutionFinishedParams struct {
 	Error      *string              `json:"error"`
 }
 
-func (q *Queries) UpdateTestExecutionFinished(ctx context.Context, arg UpdateTestExecutionFinishedParams) (*TestExecution, error) {
+type UpdateTestExecutionFinishedRow struct {
+	ID           test.TestExecutionID `json:"id"`
+	TestID       uuid.V7              `json:"test_id"`
+	HasInput     bool                 `json:"has_input"`
+	ScheduleTime time.Time            `json:"schedule_time"`
+	StartTime    *time.Time           `json:"start_time"`
+	FinishTime   *time.Time           `json:"finish_time"`
+	Error        *string              `json:"error"`
+	TriggeredBy  *string              `json:"triggered_by"`
+	GitSha       *string              `json:"git_sha"`
+	GitBranch    *string              `json:"git_branch"`
+	CiJobUrl     *string              `json:"ci_job_url"`
+	Environment  *string              `json:"environment"`
+	Labels       []byte               `json:"labels"`
+	ContextID    string               `json:"context_id"`
+	TestSuiteID  uuid.V7              `json:"test_suite_id"`
+}
+
+func (q *Queries) UpdateTestExecutionFinished(ctx context.Context, arg UpdateTestExecutionFinishedParams) (*UpdateTestExecutionFinishedRow, error) {
 	row := q.db.QueryRow(ctx, updateTestExecutionFinished, arg.ID, arg.FinishTime, arg.Error)
-	var i TestExecution
+	var i UpdateTestExecutionFinishedRow
 	err := row.Scan(
 		&i.ID,
 		&i.TestID,
@@ -203,17 +437,27 @@ func (q *Queries) UpdateTestExecutionFinished(ctx context.Context, arg UpdateTes
 		&i.StartTime,
 		&i.FinishTime,
 		&i.Error,
+		&i.TriggeredBy,
+		&i.GitSha,
+		&i.GitBranch,
+		&i.CiJobUrl,
+		&i.Environment,
+		&i.Labels,
+		&i.ContextID,
+		&i.TestSuiteID,
 	)
 	return &i, err
 }
 
 const updateTestExecutionStarted = `-- name: UpdateTestExecutionStarted :one
-UPDATE test_executions
+UPDATE test_executions te
 SET start_time  = $2,
     finish_time = null,
     error       = null
-WHERE id = $1
-RETURNING id, test_id, has_input, schedule_time, start_time, finish_time, error
+FROM tests t
+WHERE te.id = $1
+  AND t.id = te.test_id
+RETURNING te.id, te.test_id, te.has_input, te.schedule_time, te.start_time, te.finish_time, te.error, te.triggered_by, te.git_sha, te.git_branch, te.ci_job_url, te.environment, te.labels, t.context_id, t.test_suite_id
 `
 
 type UpdateTestExecutionStartedParams struct {
@@ -221,9 +465,27 @@ type UpdateTestExecutionStartedParams struct {
 	StartTime *time.Time           `json:"start_time"`
 }
 
-func (q *Queries) UpdateTestExecutionStarted(ctx context.Context, arg UpdateTestExecutionStartedParams) (*TestExecution, error) {
+type UpdateTestExecutionStartedRow struct {
+	ID           test.TestExecutionID `json:"id"`
+	TestID       uuid.V7              `json:"test_id"`
+	HasInput     bool                 `json:"has_input"`
+	ScheduleTime time.Time            `json:"schedule_time"`
+	StartTime    *time.Time           `json:"start_time"`
+	FinishTime   *time.Time           `json:"finish_time"`
+	Error        *string              `json:"error"`
+	TriggeredBy  *string              `json:"triggered_by"`
+	GitSha       *string              `json:"git_sha"`
+	GitBranch    *string              `json:"git_branch"`
+	CiJobUrl     *string              `json:"ci_job_url"`
+	Environment  *string              `json:"environment"`
+	Labels       []byte               `json:"labels"`
+	ContextID    string               `json:"context_id"`
+	TestSuiteID  uuid.V7              `json:"test_suite_id"`
+}
+
+func (q *Queries) UpdateTestExecutionStarted(ctx context.Context, arg UpdateTestExecutionStartedParams) (*UpdateTestExecutionStartedRow, error) {
 	row := q.db.QueryRow(ctx, updateTestExecutionStarted, arg.ID, arg.StartTime)
-	var i TestExecution
+	var i UpdateTestExecutionStartedRow
 	err := row.Scan(
 		&i.ID,
 		&i.TestID,
@@ -232,6 +494,14 @@ func (q *Queries) UpdateTestExecutionStarted(ctx context.Context, arg UpdateTest
 		&i.StartTime,
 		&i.FinishTime,
 		&i.Error,
+		&i.TriggeredBy,
+		&i.GitSha,
+		&i.GitBranch,
+		&i.CiJobUrl,
+		&i.Environment,
+		&i.Labels,
+		&i.ContextID,
+		&i.TestSuiteID,
 	)
 	return &i, err
 }
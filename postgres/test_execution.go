@@ -29,9 +29,23 @@ func NewTestExecutionReader(db *DB) *TestExecutionReader {
 func (t *TestExecutionReader) GetTestExecution(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
 	exec, err := t.db.GetTestExecution(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, test.ErrorTestExecutionNotFound
+		}
 		return nil, err
 	}
-	return marshalTestExec(exec), nil
+	return marshalTestExec(exec)
+}
+
+func (t *TestExecutionReader) GetTestExecutionContext(ctx context.Context, id test.TestExecutionID) (string, error) {
+	contextID, err := t.db.GetTestExecutionContext(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", test.ErrorTestExecutionNotFound
+		}
+		return "", err
+	}
+	return contextID, nil
 }
 
 func (t *TestExecutionReader) GetTestExecutionInput(ctx context.Context, id test.TestExecutionID) (*test.Payload, error) {
@@ -42,7 +56,7 @@ func (t *TestExecutionReader) GetTestExecutionInput(ctx context.Context, id test
 		}
 		return nil, err
 	}
-	return marshalTestExecInput(payload), nil
+	return marshalTestExecInput(payload)
 }
 
 func (t *TestExecutionReader) ListTestExecutions(ctx context.Context, testID uuid.V7, filter test.PageFilter[test.TestExecutionID]) (test.TestExecutionList, error) {
@@ -59,7 +73,51 @@ func (t *TestExecutionReader) ListTestExecutions(ctx context.Context, testID uui
 		return nil, err
 	}
 
-	return marshalTestExecs(execs), nil
+	return marshalTestExecs(execs)
+}
+
+func (t *TestExecutionReader) SearchTestExecutions(ctx context.Context, contextID string, filter test.PageFilter[test.TestExecutionID], execFilter test.TestExecutionFilter) (test.TestExecutionList, error) {
+	params := sqlc.SearchTestExecutionsParams{
+		ContextID:   contextID,
+		TestID:      execFilter.TestID,
+		TriggeredBy: execFilter.TriggeredBy,
+		GitSha:      execFilter.GitSHA,
+		GitBranch:   execFilter.GitBranch,
+		CiJobUrl:    execFilter.CIJobURL,
+		Environment: execFilter.Environment,
+		PageSize:    int32(filter.Size),
+	}
+	if filter.OffsetID != nil {
+		params.OffsetID = &filter.OffsetID.V7
+	}
+	if len(execFilter.Labels) > 0 {
+		var err error
+		if params.Labels, err = marshalStringMap(execFilter.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	execs, err := t.db.SearchTestExecutions(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalTestExecs(execs)
+}
+
+func (t *TestExecutionReader) ListExpiredTestExecutions(ctx context.Context, contextID string, filter test.ExpiredFilter) ([]test.TestExecutionID, error) {
+	params := sqlc.ListExpiredTestExecutionsParams{
+		ContextID:  contextID,
+		MaxPerTest: int64(filter.MaxPerTest),
+		PageSize:   int32(filter.Size),
+	}
+	if filter.FinishedBefore != nil {
+		params.FinishedBefore = ptr.Get(filter.FinishedBefore.UTC())
+	}
+	if filter.FailedFinishedBefore != nil {
+		params.FailedFinishedBefore = ptr.Get(filter.FailedFinishedBefore.UTC())
+	}
+	return t.db.ListExpiredTestExecutions(ctx, params)
 }
 
 type TestExecutionWriter struct {
@@ -71,27 +129,43 @@ func NewTestExecutionWriter(db *DB) *TestExecutionWriter {
 }
 
 func (t *TestExecutionWriter) CreateTestExecutionInput(ctx context.Context, testExecID test.TestExecutionID, input *test.Payload) error {
+	metadata, err := marshalPayloadMetadata(input.Metadata)
+	if err != nil {
+		return err
+	}
 	return t.db.CreateTestExecutionInput(ctx, sqlc.CreateTestExecutionInputParams{
 		TestExecutionID: testExecID,
 		Data:            input.Data,
+		Metadata:        metadata,
 	})
 }
 
 func (t *TestExecutionWriter) CreateTestExecutionScheduled(ctx context.Context, scheduled *test.ScheduledTestExecution) (*test.TestExecution, error) {
+	labels, err := marshalStringMap(scheduled.Metadata.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	testExec, err := t.db.CreateTestExecutionScheduled(ctx, sqlc.CreateTestExecutionScheduledParams{
 		ID:           scheduled.ID,
 		TestID:       scheduled.TestID,
 		HasInput:     scheduled.HasInput,
 		ScheduleTime: scheduled.ScheduleTime.UTC(),
+		TriggeredBy:  scheduled.Metadata.TriggeredBy,
+		GitSha:       scheduled.Metadata.GitSHA,
+		GitBranch:    scheduled.Metadata.GitBranch,
+		CiJobUrl:     scheduled.Metadata.CIJobURL,
+		Environment:  scheduled.Metadata.Environment,
+		Labels:       labels,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return marshalTestExec(testExec), nil
+	return marshalTestExec(testExec)
 }
 
-func (t *TestExecutionWriter) UpdateTestExecutionStarted(ctx context.Context, started *test.StartedTestExecution) (*test.TestExecution, error) {
+func (t *TestExecutionWriter) UpdateTestExecutionStarted(ctx context.Context, started *test.StartedTestExecution) (*test.UpdatedTestExecution, error) {
 	exec, err := t.db.UpdateTestExecutionStarted(ctx, sqlc.UpdateTestExecutionStartedParams{
 		ID:        started.ID,
 		StartTime: ptr.Get(started.StartTime.UTC()),
@@ -99,10 +173,10 @@ func (t *TestExecutionWriter) UpdateTestExecutionStarted(ctx context.Context, st
 	if err != nil {
 		return nil, err
 	}
-	return marshalTestExec(exec), nil
+	return marshalUpdatedTestExec(exec)
 }
 
-func (t *TestExecutionWriter) UpdateTestExecutionFinished(ctx context.Context, finished *test.FinishedTestExecution) (*test.TestExecution, error) {
+func (t *TestExecutionWriter) UpdateTestExecutionFinished(ctx context.Context, finished *test.FinishedTestExecution) (*test.UpdatedTestExecution, error) {
 	exec, err := t.db.UpdateTestExecutionFinished(ctx, sqlc.UpdateTestExecutionFinishedParams{
 		ID:         finished.ID,
 		FinishTime: ptr.Get(finished.FinishTime.UTC()),
@@ -111,7 +185,8 @@ func (t *TestExecutionWriter) UpdateTestExecutionFinished(ctx context.Context, f
 	if err != nil {
 		return nil, err
 	}
-	return marshalTestExec(exec), nil
+	// The rows of both updates have the same columns
+	return marshalUpdatedTestExec((*sqlc.UpdateTestExecutionStartedRow)(exec))
 }
 
 func (t *TestExecutionWriter) ResetTestExecution(ctx context.Context, testExecID test.TestExecutionID, resetTime time.Time) (*test.TestExecution, error) {
@@ -122,5 +197,58 @@ func (t *TestExecutionWriter) ResetTestExecution(ctx context.Context, testExecID
 	if err != nil {
 		return nil, err
 	}
-	return marshalTestExec(exec), nil
+	return marshalTestExec(exec)
+}
+
+// DeleteTestExecution deletes a test execution and its dependent records.
+// It should be called within a transaction so that the test execution is
+// removed atomically.
+func (t *TestExecutionWriter) DeleteTestExecution(ctx context.Context, id test.TestExecutionID) (*test.DeletedTestExecution, error) {
+	numEvents, err := t.db.DeleteTestExecutionEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.db.DeleteExecutionEventSequence(ctx, id); err != nil {
+		return nil, err
+	}
+	artifacts, err := t.db.DeleteArtifacts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	deletedArtifacts := marshalArtifacts(artifacts)
+	// The artifact content is deleted from the blob store once the deletion
+	// is committed, so the blob keys are recorded to retry on failure
+	for _, artifact := range deletedArtifacts {
+		if err = t.db.CreatePendingBlobDeletion(ctx, sqlc.CreatePendingBlobDeletionParams{
+			BlobKey:    artifact.BlobKey(),
+			CreateTime: time.Now().UTC(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	numLogs, err := t.db.DeleteLogs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	numCaseExecs, err := t.db.DeleteCaseExecutions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = t.db.DeleteTestExecutionInput(ctx, id); err != nil {
+		return nil, err
+	}
+	numTestExecs, err := t.db.DeleteTestExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if numTestExecs == 0 {
+		return nil, test.ErrorTestExecutionNotFound
+	}
+	return &test.DeletedTestExecution{
+		ID:             id,
+		CaseExecutions: int(numCaseExecs),
+		Logs:           int(numLogs),
+		Events:         int(numEvents),
+		Artifacts:      deletedArtifacts,
+	}, nil
 }
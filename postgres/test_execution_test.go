@@ -18,8 +18,9 @@ import (
 
 func TestCreateGetTestExecution(t *testing.T) {
 	tests := []struct {
-		name  string
-		input *test.Payload
+		name     string
+		input    *test.Payload
+		metadata test.ExecutionMetadata
 	}{
 		{
 			name:  "no input",
@@ -29,6 +30,20 @@ func TestCreateGetTestExecution(t *testing.T) {
 			name:  "has input",
 			input: fake.GenInput(),
 		},
+		{
+			name: "has encrypted input",
+			input: &test.Payload{
+				Metadata: map[string][]byte{
+					"encoding":          []byte("binary/encrypted"),
+					"encryption-key-id": []byte("default"),
+				},
+				Data: []byte("ciphertext"),
+			},
+		},
+		{
+			name:     "has metadata",
+			metadata: fake.GenExecutionMetadata(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -43,6 +58,7 @@ func TestCreateGetTestExecution(t *testing.T) {
 			dummyTest := createDummyTest(ctx, t, db, tt.input != nil)
 			scheduled := fake.GenScheduledTestExec(dummyTest.ID)
 			scheduled.HasInput = tt.input != nil
+			scheduled.Metadata = tt.metadata
 
 			assertEqual := func(got *test.TestExecution) {
 				assert.Equal(t, scheduled.ID, got.ID)
@@ -52,6 +68,7 @@ func TestCreateGetTestExecution(t *testing.T) {
 				assert.Nil(t, got.StartTime)
 				assert.Nil(t, got.FinishTime)
 				assert.Nil(t, got.Error)
+				assert.Equal(t, scheduled.Metadata, got.Metadata)
 			}
 
 			got, err := w.CreateTestExecutionScheduled(ctx, scheduled)
@@ -62,6 +79,10 @@ func TestCreateGetTestExecution(t *testing.T) {
 			require.NoError(t, err)
 			assertEqual(got)
 
+			gotContextID, err := r.GetTestExecutionContext(ctx, got.ID)
+			require.NoError(t, err)
+			assert.Equal(t, dummyTest.ContextID, gotContextID)
+
 			if tt.input != nil {
 				err = w.CreateTestExecutionInput(ctx, got.ID, tt.input)
 				require.NoError(t, err)
@@ -79,6 +100,17 @@ func TestCreateGetTestExecution(t *testing.T) {
 	}
 }
 
+func TestGetTestExecutionContext_notFound(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+
+	r := NewTestExecutionReader(db)
+
+	_, err := r.GetTestExecutionContext(ctx, test.NewTestExecutionID())
+	assert.ErrorIs(t, err, test.ErrorTestExecutionNotFound)
+}
+
 func TestListTestExecutions(t *testing.T) {
 	ctx := context.Background()
 	db, closer := newTestDB(t)
@@ -127,6 +159,111 @@ func TestListTestExecutions(t *testing.T) {
 	assert.Empty(t, got3)
 }
 
+func TestSearchTestExecutions(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+
+	w := NewTestExecutionWriter(db)
+	r := NewTestExecutionReader(db)
+
+	dummyTest := createDummyTest(ctx, t, db, true)
+
+	create := func(metadata test.ExecutionMetadata) *test.TestExecution {
+		scheduled := fake.GenScheduledTestExec(dummyTest.ID)
+		scheduled.Metadata = metadata
+		created, err := w.CreateTestExecutionScheduled(ctx, scheduled)
+		require.NoError(t, err)
+		return created
+	}
+
+	ci := create(fake.GenExecutionMetadata())
+	manual := create(test.ExecutionMetadata{
+		TriggeredBy: ptr.Get("alice"),
+		Environment: ptr.Get("staging"),
+		Labels:      map[string]string{"team": "payments", "suite": "smoke"},
+	})
+	unknown := create(test.ExecutionMetadata{})
+
+	tests := []struct {
+		name   string
+		filter test.TestExecutionFilter
+		want   test.TestExecutionList
+	}{
+		{
+			name:   "no filter",
+			filter: test.TestExecutionFilter{},
+			want:   test.TestExecutionList{unknown, manual, ci},
+		},
+		{
+			name:   "test",
+			filter: test.TestExecutionFilter{TestID: &dummyTest.ID},
+			want:   test.TestExecutionList{unknown, manual, ci},
+		},
+		{
+			name:   "environment",
+			filter: test.TestExecutionFilter{Environment: ptr.Get("staging")},
+			want:   test.TestExecutionList{manual, ci},
+		},
+		{
+			name:   "triggered by",
+			filter: test.TestExecutionFilter{TriggeredBy: ptr.Get("alice")},
+			want:   test.TestExecutionList{manual},
+		},
+		{
+			name: "git",
+			filter: test.TestExecutionFilter{
+				GitSHA:    ci.Metadata.GitSHA,
+				GitBranch: ci.Metadata.GitBranch,
+			},
+			want: test.TestExecutionList{ci},
+		},
+		{
+			name:   "ci job url",
+			filter: test.TestExecutionFilter{CIJobURL: ci.Metadata.CIJobURL},
+			want:   test.TestExecutionList{ci},
+		},
+		{
+			name:   "labels",
+			filter: test.TestExecutionFilter{Labels: map[string]string{"team": "payments"}},
+			want:   test.TestExecutionList{manual, ci},
+		},
+		{
+			name:   "all labels",
+			filter: test.TestExecutionFilter{Labels: map[string]string{"team": "payments", "suite": "smoke"}},
+			want:   test.TestExecutionList{manual},
+		},
+		{
+			name:   "no match",
+			filter: test.TestExecutionFilter{Environment: ptr.Get("production")},
+			want:   test.TestExecutionList{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.SearchTestExecutions(ctx, dummyTest.ContextID, test.PageFilter[test.TestExecutionID]{Size: 10}, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("paginated", func(t *testing.T) {
+		got, err := r.SearchTestExecutions(ctx, dummyTest.ContextID, test.PageFilter[test.TestExecutionID]{
+			Size:     1,
+			OffsetID: &manual.ID,
+		}, test.TestExecutionFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, test.TestExecutionList{ci}, got)
+	})
+
+	t.Run("other context", func(t *testing.T) {
+		got, err := r.SearchTestExecutions(ctx, "other", test.PageFilter[test.TestExecutionID]{Size: 10}, test.TestExecutionFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
 func TestUpdateStartedTestExecution(t *testing.T) {
 	ctx := context.Background()
 	db, closer := newTestDB(t)
@@ -140,6 +277,7 @@ func TestUpdateStartedTestExecution(t *testing.T) {
 		TestID:       dummyTest.ID,
 		HasInput:     false,
 		ScheduleTime: time.Now(),
+		Labels:       []byte("{}"),
 	})
 	require.NoError(t, err)
 
@@ -153,6 +291,8 @@ func TestUpdateStartedTestExecution(t *testing.T) {
 
 	assert.Equal(t, started.ID, got.ID)
 	assert.Equal(t, started.StartTime, *got.StartTime)
+	assert.Equal(t, dummyTest.ContextID, got.ContextID)
+	assert.Equal(t, dummyTest.TestSuiteID, got.TestSuiteID)
 	assert.Nil(t, got.FinishTime)
 	assert.Nil(t, got.Error)
 }
@@ -170,6 +310,7 @@ func TestUpdateFinishedTestExecution(t *testing.T) {
 		TestID:       dummyTest.ID,
 		HasInput:     false,
 		ScheduleTime: time.Now(),
+		Labels:       []byte("{}"),
 	})
 	require.NoError(t, err)
 
@@ -184,6 +325,8 @@ func TestUpdateFinishedTestExecution(t *testing.T) {
 
 	assert.Equal(t, finished.ID, got.ID)
 	assert.Equal(t, finished.FinishTime, *got.FinishTime)
+	assert.Equal(t, dummyTest.ContextID, got.ContextID)
+	assert.Equal(t, dummyTest.TestSuiteID, got.TestSuiteID)
 	assert.Equal(t, finished.Error, got.Error)
 	assert.Nil(t, got.StartTime)
 }
@@ -191,3 +334,182 @@ func TestUpdateFinishedTestExecution(t *testing.T) {
 func TestResetTestExecution(t *testing.T) {
 
 }
+
+func TestListExpiredTestExecutions(t *testing.T) {
+	now := time.Now().UTC()
+
+	type execSpec struct {
+		finishedAgo *time.Duration
+		failed      bool
+	}
+
+	tests := []struct {
+		name        string
+		execs       []execSpec // oldest first
+		filter      test.ExpiredFilter
+		wantExpired []int // indexes of execs
+	}{
+		{
+			name: "finished before",
+			execs: []execSpec{
+				{finishedAgo: ptr.Get(3 * time.Hour)},
+				{finishedAgo: ptr.Get(2 * time.Hour), failed: true},
+				{finishedAgo: ptr.Get(time.Minute)},
+				{finishedAgo: nil},
+			},
+			filter: test.ExpiredFilter{
+				FinishedBefore: ptr.Get(now.Add(-time.Hour)),
+			},
+			wantExpired: []int{0, 1},
+		},
+		{
+			name: "max per test",
+			execs: []execSpec{
+				{finishedAgo: ptr.Get(3 * time.Hour)},
+				{finishedAgo: ptr.Get(2 * time.Hour)},
+				{finishedAgo: ptr.Get(time.Hour)},
+				{finishedAgo: nil},
+			},
+			filter: test.ExpiredFilter{
+				MaxPerTest: 2,
+			},
+			wantExpired: []int{0, 1},
+		},
+		{
+			name: "failed kept longer",
+			execs: []execSpec{
+				{finishedAgo: ptr.Get(4 * time.Hour), failed: true},
+				{finishedAgo: ptr.Get(3 * time.Hour), failed: true},
+				{finishedAgo: ptr.Get(3 * time.Hour)},
+				{finishedAgo: ptr.Get(time.Minute)},
+			},
+			filter: test.ExpiredFilter{
+				FinishedBefore:       ptr.Get(now.Add(-time.Hour)),
+				MaxPerTest:           1,
+				FailedFinishedBefore: ptr.Get(now.Add(-(3*time.Hour + 30*time.Minute))),
+			},
+			wantExpired: []int{0, 2},
+		},
+		{
+			name: "unfinished never expired",
+			execs: []execSpec{
+				{finishedAgo: nil},
+				{finishedAgo: nil},
+			},
+			filter: test.ExpiredFilter{
+				MaxPerTest: 1,
+			},
+			wantExpired: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, closer := newTestDB(t)
+			defer closer()
+
+			w := NewTestExecutionWriter(db)
+			r := NewTestExecutionReader(db)
+
+			dummyTest := createDummyTest(ctx, t, db, false)
+
+			execIDs := make([]test.TestExecutionID, len(tt.execs))
+			for i, spec := range tt.execs {
+				scheduled := fake.GenScheduledTestExec(dummyTest.ID)
+				_, err := w.CreateTestExecutionScheduled(ctx, scheduled)
+				require.NoError(t, err)
+				execIDs[i] = scheduled.ID
+
+				if spec.finishedAgo == nil {
+					continue
+				}
+				finished := &test.FinishedTestExecution{
+					ID:         scheduled.ID,
+					FinishTime: now.Add(-*spec.finishedAgo),
+				}
+				if spec.failed {
+					finished.Error = ptr.Get("bang")
+				}
+				_, err = w.UpdateTestExecutionFinished(ctx, finished)
+				require.NoError(t, err)
+			}
+
+			filter := tt.filter
+			filter.Size = 10
+
+			got, err := r.ListExpiredTestExecutions(ctx, dummyTest.ContextID, filter)
+			require.NoError(t, err)
+
+			var want []test.TestExecutionID
+			for _, i := range tt.wantExpired {
+				want = append(want, execIDs[i])
+			}
+			assert.Equal(t, want, got)
+
+			got, err = r.ListExpiredTestExecutions(ctx, "other", filter)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func TestDeleteTestExecution(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+
+	w := NewTestExecutionWriter(db)
+	r := NewTestExecutionReader(db)
+
+	dummyTestExec := createDummyTestExec(ctx, t, db)
+	err := w.CreateTestExecutionInput(ctx, dummyTestExec.ID, fake.GenInput())
+	require.NoError(t, err)
+
+	caseExec, err := NewCaseExecutionWriter(db).CreateCaseExecutionScheduled(ctx, fake.GenScheduledCaseExec(dummyTestExec.ID))
+	require.NoError(t, err)
+
+	logs := append(fake.GenTestExecLogs(dummyTestExec.ID, 2), fake.GenCaseExecLog(dummyTestExec.ID, caseExec.ID))
+	for _, log := range logs {
+		err = NewLogWriter(db).CreateLog(ctx, log)
+		require.NoError(t, err)
+	}
+
+	events := fake.GenExecutionEvents(dummyTestExec.ID, 0, 1)
+	events[0].LogID = &logs[0].ID
+	err = NewExecutionEventWriter(db).CreateExecutionEvent(ctx, events[0])
+	require.NoError(t, err)
+
+	artifact := fake.GenCaseExecArtifact(dummyTestExec.ID, caseExec.ID)
+	err = NewArtifactWriter(db).CreateArtifact(ctx, artifact)
+	require.NoError(t, err)
+
+	got, err := w.DeleteTestExecution(ctx, dummyTestExec.ID)
+	require.NoError(t, err)
+
+	want := &test.DeletedTestExecution{
+		ID:             dummyTestExec.ID,
+		CaseExecutions: 1,
+		Logs:           len(logs),
+		Events:         len(events),
+		Artifacts:      test.ArtifactList{artifact},
+	}
+	assert.Equal(t, want, got)
+
+	// The artifact content is pending deletion from the blob store
+	pending, err := NewArtifactReader(db).ListPendingBlobDeletions(ctx, test.PageFilter[string]{Size: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{artifact.BlobKey()}, pending)
+
+	err = NewArtifactWriter(db).DeletePendingBlobDeletion(ctx, artifact.BlobKey())
+	require.NoError(t, err)
+	pending, err = NewArtifactReader(db).ListPendingBlobDeletions(ctx, test.PageFilter[string]{Size: 10})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	_, err = r.GetTestExecution(ctx, dummyTestExec.ID)
+	assert.ErrorIs(t, err, test.ErrorTestExecutionNotFound)
+
+	_, err = w.DeleteTestExecution(ctx, dummyTestExec.ID)
+	assert.ErrorIs(t, err, test.ErrorTestExecutionNotFound)
+}
@@ -0,0 +1,96 @@
+// Package redact removes secrets from text such as published logs.
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Replacement replaces redacted secrets.
+const Replacement = "[REDACTED]"
+
+// minSecretLength is the minimum length of literal secrets. Shorter values
+// are ignored since they would redact unrelated text.
+const minSecretLength = 4
+
+// KnownFormats match the formats of common secrets.
+var KnownFormats = []*regexp.Regexp{
+	// Annex API keys
+	regexp.MustCompile(`annex_[A-Za-z0-9_-]{20,}`),
+	// AWS access key IDs
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// GitHub tokens
+	regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+	// Slack tokens
+	regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`),
+	// JSON Web Tokens
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+	// Bearer tokens in authorization headers
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`),
+	// PEM private keys
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+}
+
+type RedactorOption func(r *Redactor)
+
+// WithPatterns redacts text matching the patterns.
+func WithPatterns(patterns ...*regexp.Regexp) RedactorOption {
+	return func(r *Redactor) {
+		r.patterns = append(r.patterns, patterns...)
+	}
+}
+
+// WithKnownFormats redacts secrets matching KnownFormats.
+func WithKnownFormats() RedactorOption {
+	return WithPatterns(KnownFormats...)
+}
+
+// Redactor redacts secrets matching patterns and literal secret values.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+func NewRedactor(opts ...RedactorOption) *Redactor {
+	r := &Redactor{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Redact replaces the literal secrets and text matching the patterns of the
+// redactor. The redacted text and the number of redactions are returned.
+func (r *Redactor) Redact(text string, secrets ...string) (string, int) {
+	count := 0
+
+	// Longest secrets first so that secrets containing other secrets are
+	// redacted entirely
+	literals := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLength {
+			literals = append(literals, secret)
+		}
+	}
+	sort.Slice(literals, func(i, j int) bool {
+		return len(literals[i]) > len(literals[j])
+	})
+	for _, secret := range literals {
+		if n := strings.Count(text, secret); n > 0 {
+			text = strings.ReplaceAll(text, secret, Replacement)
+			count += n
+		}
+	}
+
+	for _, pattern := range r.patterns {
+		text = pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if match == Replacement {
+				return match
+			}
+			count++
+			return Replacement
+		})
+	}
+
+	return text, count
+}
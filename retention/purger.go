@@ -0,0 +1,414 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/annexsh/annex/blob"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/test"
+)
+
+const (
+	defaultInterval              = time.Hour
+	defaultBatchSize             = 100
+	contextsPageSize             = 100
+	pendingBlobDeletionsPageSize = 100
+)
+
+// Policy determines how long finished test executions are kept. Test
+// executions that have not finished are never purged.
+type Policy struct {
+	// MaxAge purges test executions that finished longer ago than the age.
+	// Zero keeps test executions regardless of age.
+	MaxAge time.Duration
+	// MaxExecutionsPerTest purges test executions beyond the most recent
+	// executions of each test. Zero keeps any number of executions.
+	MaxExecutionsPerTest int
+	// FailedMaxAge keeps failed test executions until they finished longer ago
+	// than the age, regardless of MaxAge and MaxExecutionsPerTest. Zero applies
+	// the same policy to failed and successful test executions.
+	FailedMaxAge time.Duration
+}
+
+// Enabled reports whether the policy purges any test executions.
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxExecutionsPerTest > 0 || p.FailedMaxAge > 0
+}
+
+func (p Policy) expiredFilter(now time.Time, size int) test.ExpiredFilter {
+	filter := test.ExpiredFilter{
+		MaxPerTest: p.MaxExecutionsPerTest,
+		Size:       size,
+	}
+	if p.MaxAge > 0 {
+		filter.FinishedBefore = ptr.Get(now.Add(-p.MaxAge))
+	}
+	if p.FailedMaxAge > 0 {
+		filter.FailedFinishedBefore = ptr.Get(now.Add(-p.FailedMaxAge))
+	}
+	return filter
+}
+
+// Result is the number of records removed by a purge.
+type Result struct {
+	TestExecutions int
+	CaseExecutions int
+	Logs           int
+	Events         int
+	Artifacts      int
+	// Failed is the number of expired test executions that could not be
+	// deleted. They are retried by the next purge.
+	Failed int
+	// OrphanedArtifacts is the number of artifacts whose content could not be
+	// deleted from the blob store after their test execution was deleted. The
+	// content remains a pending blob deletion and is deleted by the next
+	// purge.
+	OrphanedArtifacts int
+}
+
+func (r *Result) add(deleted *test.DeletedTestExecution) {
+	r.TestExecutions++
+	r.CaseExecutions += deleted.CaseExecutions
+	r.Logs += deleted.Logs
+	r.Events += deleted.Events
+	r.Artifacts += len(deleted.Artifacts)
+}
+
+func (r *Result) merge(other Result) {
+	r.TestExecutions += other.TestExecutions
+	r.CaseExecutions += other.CaseExecutions
+	r.Logs += other.Logs
+	r.Events += other.Events
+	r.Artifacts += other.Artifacts
+	r.Failed += other.Failed
+	r.OrphanedArtifacts += other.OrphanedArtifacts
+}
+
+type PurgerOption func(p *Purger)
+
+func WithLogger(logger log.Logger) PurgerOption {
+	return func(p *Purger) {
+		p.logger = logger
+	}
+}
+
+// WithContextPolicy overrides the default policy for a context.
+func WithContextPolicy(contextID string, policy Policy) PurgerOption {
+	return func(p *Purger) {
+		p.contextPolicies[contextID] = policy
+	}
+}
+
+// WithInterval sets how often Run purges expired test executions. Defaults
+// to 1 hour.
+func WithInterval(interval time.Duration) PurgerOption {
+	return func(p *Purger) {
+		p.interval = interval
+	}
+}
+
+// WithBatchSize sets the number of test executions deleted per transaction.
+// Defaults to 100.
+func WithBatchSize(size int) PurgerOption {
+	return func(p *Purger) {
+		p.batchSize = size
+	}
+}
+
+// WithBlobStore deletes the content of the artifacts of purged test
+// executions from the blob store.
+func WithBlobStore(store blob.Store) PurgerOption {
+	return func(p *Purger) {
+		p.blobStore = store
+	}
+}
+
+// LockFunc acquires the lock held while purging. It reports whether the lock
+// was acquired and returns a function that releases it if so.
+type LockFunc func(ctx context.Context) (unlock func(), acquired bool, err error)
+
+// WithLock coordinates the purges of processes sharing a database. Run skips
+// a purge if the lock is held by another process.
+func WithLock(lock LockFunc) PurgerOption {
+	return func(p *Purger) {
+		p.lock = lock
+	}
+}
+
+// Purger deletes test executions that have expired under the retention
+// policy of their context.
+type Purger struct {
+	repo            test.Repository
+	policy          Policy
+	contextPolicies map[string]Policy
+	interval        time.Duration
+	batchSize       int
+	blobStore       blob.Store
+	lock            LockFunc
+	logger          log.Logger
+	now             func() time.Time
+}
+
+func NewPurger(repo test.Repository, policy Policy, opts ...PurgerOption) *Purger {
+	p := &Purger{
+		repo:            repo,
+		policy:          policy,
+		contextPolicies: map[string]Policy{},
+		interval:        defaultInterval,
+		batchSize:       defaultBatchSize,
+		logger:          log.DefaultLogger(),
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Run purges expired test executions at the purge interval until the context
+// is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purgeLocked(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeLocked purges expired test executions while holding the lock, if
+// configured, so that replicas don't purge concurrently.
+func (p *Purger) purgeLocked(ctx context.Context) {
+	if p.lock != nil {
+		unlock, acquired, err := p.lock(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				p.logger.Error("failed to acquire purge lock", "error", err)
+			}
+			return
+		}
+		if !acquired {
+			p.logger.Debug("skipped purge: purge lock held by another process")
+			return
+		}
+		defer unlock()
+	}
+
+	if _, err := p.Purge(ctx); err != nil && ctx.Err() == nil {
+		p.logger.Error("failed to purge expired test executions", "error", err)
+	}
+}
+
+// Purge deletes the expired test executions of all contexts. Test executions
+// that fail to be deleted are logged and skipped so that they don't prevent
+// the remaining test executions from being purged.
+func (p *Purger) Purge(ctx context.Context) (Result, error) {
+	var total Result
+	var offsetID *string
+
+	p.sweepPendingBlobDeletions(ctx)
+
+	for {
+		contextIDs, err := p.repo.ListContexts(ctx, test.PageFilter[string]{
+			Size:     contextsPageSize,
+			OffsetID: offsetID,
+		})
+		if err != nil {
+			return total, fmt.Errorf("failed to list contexts: %w", err)
+		}
+
+		for _, contextID := range contextIDs {
+			res, err := p.purgeContext(ctx, contextID)
+			total.merge(res)
+			if err != nil {
+				return total, fmt.Errorf("failed to purge context %s: %w", contextID, err)
+			}
+			if res.TestExecutions > 0 || res.Failed > 0 {
+				p.logger.Info("purged expired test executions",
+					"context", contextID,
+					"test_executions", res.TestExecutions,
+					"case_executions", res.CaseExecutions,
+					"logs", res.Logs,
+					"events", res.Events,
+					"artifacts", res.Artifacts,
+					"failed", res.Failed,
+					"orphaned_artifacts", res.OrphanedArtifacts,
+				)
+			}
+		}
+
+		if len(contextIDs) < contextsPageSize {
+			return total, nil
+		}
+		offsetID = &contextIDs[len(contextIDs)-1]
+	}
+}
+
+func (p *Purger) purgeContext(ctx context.Context, contextID string) (Result, error) {
+	var res Result
+
+	policy, ok := p.contextPolicies[contextID]
+	if !ok {
+		policy = p.policy
+	}
+	if !policy.Enabled() {
+		return res, nil
+	}
+
+	filter := policy.expiredFilter(p.now(), p.batchSize)
+	failed := map[test.TestExecutionID]bool{}
+
+	for {
+		ids, err := p.repo.ListExpiredTestExecutions(ctx, contextID, filter)
+		if err != nil {
+			return res, err
+		}
+
+		// Test executions that failed to be deleted are listed again until
+		// they're deleted, so the purge of the context stops once only those
+		// remain and they're retried by the next purge
+		pending := slices.DeleteFunc(slices.Clone(ids), func(id test.TestExecutionID) bool { return failed[id] })
+		if len(pending) == 0 {
+			return res, nil
+		}
+
+		batch, artifacts := p.deleteTestExecutions(ctx, contextID, pending, failed)
+		res.merge(batch)
+
+		// Artifact content is deleted once the deletion of its metadata is
+		// committed so that content is never missing for listed artifacts
+		res.OrphanedArtifacts += p.deleteArtifactContent(ctx, artifacts)
+
+		if len(ids) < p.batchSize {
+			return res, nil
+		}
+	}
+}
+
+// deleteTestExecutions deletes the test executions in a single transaction.
+// If the transaction fails, each test execution is deleted in its own
+// transaction so that a test execution that can't be deleted doesn't prevent
+// the others from being deleted. Test executions that fail to be deleted are
+// added to failed.
+func (p *Purger) deleteTestExecutions(
+	ctx context.Context,
+	contextID string,
+	ids []test.TestExecutionID,
+	failed map[test.TestExecutionID]bool,
+) (Result, test.ArtifactList) {
+	var res Result
+	var artifacts test.ArtifactList
+
+	deleteTx := func(ids []test.TestExecutionID) error {
+		var batch Result
+		var batchArtifacts test.ArtifactList
+		err := p.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+			for _, id := range ids {
+				deleted, err := repo.DeleteTestExecution(ctx, id)
+				if err != nil {
+					return fmt.Errorf("failed to delete test execution %s: %w", id, err)
+				}
+				batch.add(deleted)
+				batchArtifacts = append(batchArtifacts, deleted.Artifacts...)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		res.merge(batch)
+		artifacts = append(artifacts, batchArtifacts...)
+		return nil
+	}
+
+	if err := deleteTx(ids); err == nil {
+		return res, artifacts
+	}
+
+	for _, id := range ids {
+		if err := deleteTx([]test.TestExecutionID{id}); err != nil {
+			p.logger.Error("failed to purge expired test execution",
+				"context", contextID,
+				"test_execution_id", id.String(),
+				"error", err,
+			)
+			failed[id] = true
+			res.Failed++
+		}
+	}
+
+	return res, artifacts
+}
+
+// deleteArtifactContent deletes the content of the artifacts from the blob
+// store and returns the number of artifacts whose content failed to be
+// deleted. Their pending blob deletions are retried by the next purge.
+func (p *Purger) deleteArtifactContent(ctx context.Context, artifacts test.ArtifactList) int {
+	if p.blobStore == nil {
+		return 0
+	}
+	orphaned := 0
+	for _, artifact := range artifacts {
+		key := artifact.BlobKey()
+		if err := p.deleteBlob(ctx, key); err != nil {
+			p.logger.Error("failed to delete artifact content",
+				"artifact_id", artifact.ID.String(),
+				"blob_key", key,
+				"error", err,
+			)
+			orphaned++
+		}
+	}
+	return orphaned
+}
+
+// sweepPendingBlobDeletions retries deleting the content of artifacts that
+// failed to be deleted by a previous purge, including purges of processes that
+// stopped before deleting the content.
+func (p *Purger) sweepPendingBlobDeletions(ctx context.Context) {
+	if p.blobStore == nil {
+		return
+	}
+
+	var offsetKey *string
+	for {
+		keys, err := p.repo.ListPendingBlobDeletions(ctx, test.PageFilter[string]{
+			Size:     pendingBlobDeletionsPageSize,
+			OffsetID: offsetKey,
+		})
+		if err != nil {
+			p.logger.Error("failed to list pending blob deletions", "error", err)
+			return
+		}
+
+		for _, key := range keys {
+			if err = p.deleteBlob(ctx, key); err != nil {
+				p.logger.Warn("failed to delete orphaned artifact content", "blob_key", key, "error", err)
+			}
+		}
+
+		if len(keys) < pendingBlobDeletionsPageSize {
+			return
+		}
+		offsetKey = &keys[len(keys)-1]
+	}
+}
+
+// deleteBlob deletes the content from the blob store and then removes its
+// pending blob deletion.
+func (p *Purger) deleteBlob(ctx context.Context, key string) error {
+	if err := p.blobStore.Delete(ctx, key); err != nil {
+		return err
+	}
+	return p.repo.DeletePendingBlobDeletion(ctx, key)
+}
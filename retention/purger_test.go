@@ -0,0 +1,260 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/blob"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestPurger_Purge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	batchSize := 2
+
+	defaultPolicy := Policy{
+		MaxAge:               24 * time.Hour,
+		MaxExecutionsPerTest: 10,
+	}
+	fooPolicy := Policy{
+		MaxAge:       time.Hour,
+		FailedMaxAge: 48 * time.Hour,
+	}
+
+	expired := map[string][]test.TestExecutionID{
+		"default": {test.NewTestExecutionID(), test.NewTestExecutionID(), test.NewTestExecutionID()},
+		"foo":     {test.NewTestExecutionID()},
+	}
+
+	r := new(RepositoryMock)
+	r.ListContextsFunc = func(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+		assert.Nil(t, filter.OffsetID)
+		return []string{"bar", "default", "foo"}, nil
+	}
+	r.ListExpiredTestExecutionsFunc = func(ctx context.Context, contextID string, filter test.ExpiredFilter) ([]test.TestExecutionID, error) {
+		assert.Equal(t, batchSize, filter.Size)
+		switch contextID {
+		case "default":
+			assert.Equal(t, ptr.Get(now.Add(-defaultPolicy.MaxAge)), filter.FinishedBefore)
+			assert.Equal(t, defaultPolicy.MaxExecutionsPerTest, filter.MaxPerTest)
+			assert.Nil(t, filter.FailedFinishedBefore)
+		case "foo":
+			assert.Equal(t, ptr.Get(now.Add(-fooPolicy.MaxAge)), filter.FinishedBefore)
+			assert.Zero(t, filter.MaxPerTest)
+			assert.Equal(t, ptr.Get(now.Add(-fooPolicy.FailedMaxAge)), filter.FailedFinishedBefore)
+		default:
+			t.Fatalf("unexpected context: %s", contextID)
+		}
+		ids := expired[contextID]
+		if len(ids) > filter.Size {
+			ids = ids[:filter.Size]
+		}
+		return ids, nil
+	}
+	r.ExecuteTxFunc = func(ctx context.Context, query func(repo test.Repository) error) error {
+		return query(r)
+	}
+	blobStore, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	var artifacts test.ArtifactList
+	pending := mockPendingBlobDeletions(r)
+
+	r.DeleteTestExecutionFunc = func(ctx context.Context, id test.TestExecutionID) (*test.DeletedTestExecution, error) {
+		for contextID, ids := range expired {
+			for i, expiredID := range ids {
+				if expiredID == id {
+					expired[contextID] = append(ids[:i], ids[i+1:]...)
+				}
+			}
+		}
+		artifact := &test.Artifact{ID: uuid.New(), TestExecutionID: id}
+		err := blobStore.Put(ctx, artifact.BlobKey(), strings.NewReader("foo"))
+		require.NoError(t, err)
+		artifacts = append(artifacts, artifact)
+		pending[artifact.BlobKey()] = true
+
+		return &test.DeletedTestExecution{
+			ID:             id,
+			CaseExecutions: 2,
+			Logs:           3,
+			Events:         4,
+			Artifacts:      test.ArtifactList{artifact},
+		}, nil
+	}
+
+	p := NewPurger(r, defaultPolicy,
+		WithContextPolicy("foo", fooPolicy),
+		WithContextPolicy("bar", Policy{}),
+		WithBatchSize(batchSize),
+		WithBlobStore(blobStore),
+		WithLogger(log.NewNopLogger()),
+	)
+	p.now = func() time.Time { return now }
+
+	got, err := p.Purge(ctx)
+	require.NoError(t, err)
+
+	want := Result{
+		TestExecutions: 4,
+		CaseExecutions: 8,
+		Logs:           12,
+		Events:         16,
+		Artifacts:      4,
+	}
+	assert.Equal(t, want, got)
+	assert.Empty(t, expired["default"])
+	assert.Empty(t, expired["foo"])
+	assert.Len(t, r.ExecuteTxCalls(), 3) // default: 2 batches, foo: 1 batch
+
+	for _, artifact := range artifacts {
+		_, err = blobStore.Get(ctx, artifact.BlobKey())
+		assert.ErrorIs(t, err, blob.ErrNotFound)
+	}
+	assert.Empty(t, pending)
+}
+
+func TestPurger_Purge_failures(t *testing.T) {
+	ctx := context.Background()
+
+	failing := test.NewTestExecutionID()
+	expired := []test.TestExecutionID{test.NewTestExecutionID(), failing, test.NewTestExecutionID()}
+
+	r := new(RepositoryMock)
+	r.ListContextsFunc = func(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+		return []string{"default"}, nil
+	}
+	r.ListExpiredTestExecutionsFunc = func(ctx context.Context, contextID string, filter test.ExpiredFilter) ([]test.TestExecutionID, error) {
+		return expired, nil
+	}
+	r.ExecuteTxFunc = func(ctx context.Context, query func(repo test.Repository) error) error {
+		return query(r)
+	}
+
+	artifacts := map[test.TestExecutionID]*test.Artifact{}
+	for _, id := range expired {
+		artifacts[id] = &test.Artifact{ID: uuid.New(), TestExecutionID: id}
+	}
+	pending := mockPendingBlobDeletions(r)
+	r.DeleteTestExecutionFunc = func(ctx context.Context, id test.TestExecutionID) (*test.DeletedTestExecution, error) {
+		if id == failing {
+			return nil, errors.New("boom")
+		}
+		expired = slices.DeleteFunc(expired, func(expiredID test.TestExecutionID) bool { return expiredID == id })
+		pending[artifacts[id].BlobKey()] = true
+		return &test.DeletedTestExecution{ID: id, Artifacts: test.ArtifactList{artifacts[id]}}, nil
+	}
+
+	fileStore, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	blobStore := &failingDeleteStore{Store: fileStore, fail: true}
+
+	p := NewPurger(r, Policy{MaxAge: time.Hour},
+		WithBatchSize(10),
+		WithBlobStore(blobStore),
+		WithLogger(log.NewNopLogger()),
+	)
+
+	// The test executions that can be deleted are purged
+	got, err := p.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{TestExecutions: 2, Artifacts: 2, Failed: 1, OrphanedArtifacts: 2}, got)
+	assert.Equal(t, []test.TestExecutionID{failing}, expired)
+	assert.Len(t, pending, 2)
+
+	delete(artifacts, failing)
+	for _, artifact := range artifacts {
+		require.NoError(t, fileStore.Put(ctx, artifact.BlobKey(), strings.NewReader("foo")))
+	}
+
+	// Orphaned artifact content is deleted by the next purge, including the
+	// purge of another process
+	p = NewPurger(r, Policy{MaxAge: time.Hour},
+		WithBatchSize(10),
+		WithBlobStore(fileStore),
+		WithLogger(log.NewNopLogger()),
+	)
+	got, err = p.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Failed: 1}, got)
+
+	for _, artifact := range artifacts {
+		_, err = fileStore.Get(ctx, artifact.BlobKey())
+		assert.ErrorIs(t, err, blob.ErrNotFound)
+	}
+	assert.Empty(t, pending)
+}
+
+func TestPurger_Run_lock(t *testing.T) {
+	ctx := context.Background()
+
+	r := new(RepositoryMock)
+	r.ListContextsFunc = func(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+		return nil, nil
+	}
+
+	acquired := false
+	unlocked := 0
+	lock := func(ctx context.Context) (func(), bool, error) {
+		return func() { unlocked++ }, acquired, nil
+	}
+
+	p := NewPurger(r, Policy{MaxAge: time.Hour}, WithLock(lock), WithLogger(log.NewNopLogger()))
+
+	// The purge is skipped while another process holds the lock
+	p.purgeLocked(ctx)
+	assert.Empty(t, r.ListContextsCalls())
+
+	acquired = true
+	p.purgeLocked(ctx)
+	assert.Len(t, r.ListContextsCalls(), 1)
+	assert.Equal(t, 1, unlocked)
+}
+
+func TestPolicy_Enabled(t *testing.T) {
+	assert.False(t, Policy{}.Enabled())
+	assert.True(t, Policy{MaxAge: time.Hour}.Enabled())
+	assert.True(t, Policy{MaxExecutionsPerTest: 1}.Enabled())
+	assert.True(t, Policy{FailedMaxAge: time.Hour}.Enabled())
+}
+
+// mockPendingBlobDeletions fakes the pending blob deletions of the
+// repository. The returned set contains the pending blob keys.
+func mockPendingBlobDeletions(r *RepositoryMock) map[string]bool {
+	pending := map[string]bool{}
+	r.ListPendingBlobDeletionsFunc = func(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+		keys := slices.Sorted(maps.Keys(pending))
+		if filter.OffsetID != nil {
+			keys = slices.DeleteFunc(keys, func(key string) bool { return key <= *filter.OffsetID })
+		}
+		return keys[:min(len(keys), filter.Size)], nil
+	}
+	r.DeletePendingBlobDeletionFunc = func(ctx context.Context, blobKey string) error {
+		delete(pending, blobKey)
+		return nil
+	}
+	return pending
+}
+
+type failingDeleteStore struct {
+	blob.Store
+	fail bool
+}
+
+func (s *failingDeleteStore) Delete(ctx context.Context, key string) error {
+	if s.fail {
+		return errors.New("unavailable")
+	}
+	return s.Store.Delete(ctx, key)
+}
@@ -8,17 +8,22 @@ import (
 	"github.com/annexsh/annex-proto/go/gen/annex/events/v1/eventsv1connect"
 	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
 	"github.com/jackc/pgx/v5/pgxpool"
-	corenats "github.com/nats-io/nats.go"
 	workflowservicev1 "go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
-	"google.golang.org/grpc/health"
-	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/authservice"
+	"github.com/annexsh/annex/blob"
 	"github.com/annexsh/annex/eventservice"
+	"github.com/annexsh/annex/gen/annex/audit/v1/auditv1connect"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+	"github.com/annexsh/annex/internal/health"
 	"github.com/annexsh/annex/internal/rpc"
 	"github.com/annexsh/annex/log"
-	"github.com/annexsh/annex/nats"
 	"github.com/annexsh/annex/postgres"
+	"github.com/annexsh/annex/retention"
 	"github.com/annexsh/annex/sqlite"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/testservice"
@@ -31,30 +36,58 @@ func ServeAllInOne(ctx context.Context, cfg AllInOneConfig) error {
 		logger = log.NewLogger()
 	}
 
-	srv := rpc.NewServer(getHostPort(cfg.Port))
+	shutdownTracing, err := setupTracing(ctx, cfg.Tracing, "annex", logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	srv, err := newRPCServer(ctx, cfg.Port, cfg.TLS, cfg.Health, logger)
+	if err != nil {
+		return err
+	}
 
 	var pgPool *pgxpool.Pool
 	var repo test.Repository
-	var err error
+	var authRepo auth.Repository
+	var auditRepo audit.Repository
+	var dbBlobStore blob.Store
+	var purgeLock retention.LockFunc
+	var healthDeps []health.DependencyChecker
 
 	// Repository
 
 	if cfg.SQLite {
-		db, err := sqlite.Open(sqlite.WithMigration())
+		sqliteOpts := []sqlite.OpenOption{sqlite.WithMigration()}
+		if cfg.SQLitePath != "" {
+			sqliteOpts = append(sqliteOpts, sqlite.WithPath(cfg.SQLitePath))
+		}
+		db, err := sqlite.Open(sqliteOpts...)
 		if err != nil {
 			return err
 		}
 		defer db.Close()
-		repo = sqlite.NewTestRepository(sqlite.NewDB(db))
-		logger.Info("sqlite db created")
+		sqliteDB := sqlite.NewDB(db)
+		repo = sqlite.NewTestRepository(sqliteDB)
+		authRepo = sqlite.NewAuthRepository(sqliteDB)
+		auditRepo = sqlite.NewAuditRepository(sqliteDB)
+		dbBlobStore = sqlite.NewBlobStore(sqliteDB)
+		healthDeps = append(healthDeps, health.WithSQLite(db))
+		logger.Info("sqlite db created", "path", cfg.SQLitePath)
 	} else {
 		pgCfg := cfg.Postgres
-		pgPool, err = postgres.OpenPool(ctx, pgCfg.User, pgCfg.Password, pgCfg.HostPort, postgres.WithMigration())
+		pgPool, err = postgres.OpenPool(ctx, pgCfg.ConnString(), append(pgCfg.OpenOptions(), postgresMigrationOption(pgCfg))...)
 		if err != nil {
 			return err
 		}
 		defer pgPool.Close()
-		repo = postgres.NewTestRepository(postgres.NewDB(pgPool))
+		pgDB := postgres.NewDB(pgPool)
+		repo = postgres.NewTestRepository(pgDB)
+		authRepo = postgres.NewAuthRepository(pgDB)
+		auditRepo = postgres.NewAuditRepository(pgDB)
+		dbBlobStore = postgres.NewBlobStore(pgDB)
+		purgeLock = postgres.NewPurgeLock(pgPool)
+		healthDeps = append(healthDeps, health.WithPostgres(pgPool))
 		logger.Info("postgres db created")
 	}
 
@@ -62,60 +95,112 @@ func ServeAllInOne(ctx context.Context, cfg AllInOneConfig) error {
 		return err
 	}
 
+	blobStore, err := newBlobStore(cfg.Artifacts, dbBlobStore)
+	if err != nil {
+		return err
+	}
+
+	runPurger(ctx, cfg.Retention, repo, blobStore, purgeLock, logger.With("component", "retention_purger"))
+
+	// Auth
+
+	authenticator, err := newAuthenticator(ctx, cfg.Auth, authRepo, nil, logger)
+	if err != nil {
+		return err
+	}
+	authOpt := rpc.WithAuthenticator(authenticator)
+	authorizer := auth.NewRoleAuthorizer(authRepo)
+
+	authSvcLogger := logger.With("service", "auth_service")
+	authSvc := authservice.New(authRepo,
+		authservice.WithLogger(authSvcLogger),
+		authservice.WithAuthorizer(authorizer),
+		authservice.WithAuditRepository(auditRepo),
+	)
+	authPath, authHandler := authv1connect.NewAuthServiceHandler(authSvc, rpc.WithConnectInterceptors(authSvcLogger, authOpt))
+	srv.RegisterConnect(authPath, authHandler, cfg.CorsOrigins...)
+
 	// Pub/Sub
 
-	var nc *corenats.Conn
-	if cfg.Nats.Embedded {
-		ns, err := runEmbeddedNats(cfg.Nats.HostPort)
-		if err != nil {
-			return err
-		}
-		defer ns.Shutdown()
-		nc, err = corenats.Connect("", corenats.InProcessServer(ns))
-		if err != nil {
-			return err
-		}
-	} else {
-		nc, err = corenats.Connect(cfg.Nats.HostPort)
-		if err != nil {
-			return err
-		}
+	nc, closeNats, err := connectNats(cfg.Nats)
+	if err != nil {
+		return err
 	}
-	defer nc.Close()
-	pubSub := nats.NewPubSub(nc, nats.WithLogger(logger))
+	defer closeNats()
+	pubSub := newNatsPubSub(nc, cfg.Subscribers, logger)
+	healthDeps = append(healthDeps, health.WithNats(nc))
 
 	// Test service
 
 	testSvcLogger := logger.With("service", "test_service")
+	payloadCodec, dataConverter, err := newPayloadCodecs(cfg.Payloads)
+	if err != nil {
+		return err
+	}
+	logRedactor, err := newLogRedactor(cfg.LogRedaction)
+	if err != nil {
+		return err
+	}
 	workflowProxyClient, err := client.NewLazyClient(client.Options{
-		HostPort:  srv.GRPCAddress(),
-		Namespace: workflowservice.Namespace,
-		Logger:    testSvcLogger.With("component", "temporal_client"),
+		HostPort:      srv.GRPCAddress(),
+		Namespace:     workflowservice.Namespace,
+		Logger:        testSvcLogger.With("component", "temporal_client"),
+		DataConverter: dataConverter,
+		ConnectionOptions: client.ConnectionOptions{
+			// Connects in-process so that TLS certificates are not required
+			DialOptions: append(rpc.WithGRPCAnnexClientInterceptors(), srv.InternalGRPCDialOption()),
+		},
 	})
 	if err != nil {
 		return err
 	}
 
-	testSvc := testservice.New(repo, pubSub, workflowProxyClient, testservice.WithLogger(testSvcLogger))
-	testPath, testHandler := testsv1connect.NewTestServiceHandler(testSvc, rpc.WithConnectInterceptors(testSvcLogger))
+	testSvcOpts := []testservice.ServiceOption{
+		testservice.WithLogger(testSvcLogger),
+		testservice.WithAuthorizer(authorizer),
+		testservice.WithAuditRepository(auditRepo),
+		testservice.WithPayloadCodec(payloadCodec),
+		testservice.WithDataConverter(dataConverter),
+		testservice.WithSecretFields(cfg.Payloads.SecretFields...),
+		testservice.WithLogRedactor(logRedactor),
+	}
+	testSvc := testservice.New(repo, pubSub, workflowProxyClient, append(testSvcOpts, artifactOpts(cfg.Artifacts, blobStore)...)...)
+	limitsOpt := rpc.WithLimits(newRPCLimits(cfg.Limits))
+	testPath, testHandler := testsv1connect.NewTestServiceHandler(testSvc, rpc.WithConnectInterceptors(testSvcLogger, authOpt, limitsOpt))
 	srv.RegisterConnect(testPath, testHandler, cfg.CorsOrigins...)
+	execPath, execHandler := executionsv1connect.NewExecutionServiceHandler(testSvc.ExecutionHandler(), rpc.WithConnectInterceptors(testSvcLogger, authOpt, limitsOpt))
+	srv.RegisterConnect(execPath, execHandler, cfg.CorsOrigins...)
+	auditPath, auditHandler := auditv1connect.NewAuditServiceHandler(testSvc, rpc.WithConnectInterceptors(testSvcLogger, authOpt))
+	srv.RegisterConnect(auditPath, auditHandler, cfg.CorsOrigins...)
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	testClient := testsv1connect.NewTestServiceClient(httpClient, srv.ConnectAddress())
+	// Connects in-process so that TLS certificates are not required
+	internalClient := &http.Client{Transport: srv.InternalTransport(), Timeout: 30 * time.Second}
+	testClient := testsv1connect.NewTestServiceClient(internalClient, srv.InternalConnectAddress(), rpc.WithConnectClientInterceptors())
 
 	// Event service
 
 	eventSvcLogger := logger.With("service", "event_service")
-	eventSvc := eventservice.New(pubSub, testClient, eventservice.WithLogger(eventSvcLogger))
-	eventPath, eventHandler := eventsv1connect.NewEventServiceHandler(eventSvc, rpc.WithConnectInterceptors(eventSvcLogger))
+	execFetcher := newExecutionFetcher(internalClient, srv.InternalConnectAddress())
+	eventSvc := eventservice.New(pubSub, execFetcher, eventservice.WithLogger(eventSvcLogger))
+	eventPath, eventHandler := eventsv1connect.NewEventServiceHandler(eventSvc, rpc.WithConnectInterceptors(eventSvcLogger, authOpt))
 	srv.RegisterConnect(eventPath, eventHandler, cfg.CorsOrigins...)
+	execEventPath, execEventHandler := executionsv1connect.NewExecutionEventServiceHandler(eventSvc.ExecutionEventHandler(), rpc.WithConnectInterceptors(eventSvcLogger, authOpt))
+	srv.RegisterConnect(execEventPath, execEventHandler, cfg.CorsOrigins...)
 
 	// Workflow Proxy service
 	wfProxySvcLogger := logger.With("service", "workflow_proxy_service")
+	temporalTLS, err := newClientTLS(ctx, cfg.Temporal.TLS, wfProxySvcLogger)
+	if err != nil {
+		return err
+	}
 	temporalClient, err := client.NewLazyClient(client.Options{
 		HostPort:  cfg.Temporal.HostPort,
 		Namespace: workflowservice.Namespace,
 		Logger:    wfProxySvcLogger.With("component", "temporal_client"),
+		ConnectionOptions: client.ConnectionOptions{
+			TLS:         temporalTLS,
+			DialOptions: rpc.WithGRPCClientInterceptors(),
+		},
 	})
 	if err != nil {
 		return err
@@ -126,10 +211,25 @@ func ServeAllInOne(ctx context.Context, cfg AllInOneConfig) error {
 
 	// Misc
 
-	healthSvc := health.NewServer()
-	healthSvc.SetServingStatus(workflowservicev1.WorkflowService_ServiceDesc.ServiceName, grpchealthv1.HealthCheckResponse_SERVING)
-	srv.RegisterGRPC(&grpchealthv1.Health_ServiceDesc, healthSvc)
-	srv.WithGRPCOptions(rpc.WithGRPCInterceptors(logger)...)
+	healthDeps = append(healthDeps, health.WithTemporal(temporalClient, workflowservice.Namespace))
+	healthSvc, err := health.NewGRPCService(ctx, health.Config{
+		ServiceNames: []string{
+			health.ServiceNameAudit,
+			health.ServiceNameAuth,
+			health.ServiceNameTest,
+			health.ServiceNameExecution,
+			health.ServiceNameEvent,
+			health.ServiceNameWorkflow,
+		},
+		Dependencies:  healthDeps,
+		CheckInterval: cfg.Health.CheckInterval,
+		Logger:        logger,
+	})
+	if err != nil {
+		return err
+	}
+	srv.RegisterHealth(healthSvc)
+	srv.WithGRPCOptions(rpc.WithGRPCInterceptors(logger, authOpt)...)
 
 	return serve(ctx, srv, logger)
 }
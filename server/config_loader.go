@@ -0,0 +1,206 @@
+package server
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/cristalhq/aconfig"
+	"github.com/cristalhq/aconfig/aconfigyaml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables that override
+	// config fields, e.g. ANNEX_POSTGRES_PASSWORD for postgres.password.
+	EnvPrefix = "ANNEX"
+	// fileEnvSuffix is the suffix of environment variables that contain the
+	// path of a file to read a config field from, e.g.
+	// ANNEX_POSTGRES_PASSWORD_FILE.
+	fileEnvSuffix = "_FILE"
+	redactedValue = "[REDACTED]"
+)
+
+// Server types accepted by ValidateConfig and PrintConfig.
+const (
+	ServerTypeAllInOne      = "all"
+	ServerTypeTest          = "test"
+	ServerTypeEvent         = "event"
+	ServerTypeWorkflowProxy = "workflow-proxy"
+)
+
+type configValidator interface {
+	Validate() error
+}
+
+// newConfig returns a pointer to an empty config of the server type.
+func newConfig(srvType string) (configValidator, error) {
+	switch srvType {
+	case ServerTypeAllInOne:
+		return &AllInOneConfig{}, nil
+	case ServerTypeTest:
+		return &TestServiceConfig{}, nil
+	case ServerTypeEvent:
+		return &EventServiceConfig{}, nil
+	case ServerTypeWorkflowProxy:
+		return &WorkflowProxyServiceConfig{}, nil
+	}
+	return nil, fmt.Errorf("invalid server type %q", srvType)
+}
+
+// ValidateConfig loads the config of the server type from the command line
+// and environment and validates it.
+func ValidateConfig(srvType string) error {
+	cfg, err := newConfig(srvType)
+	if err != nil {
+		return err
+	}
+	return loadConfig(cfg)
+}
+
+// PrintConfig loads the config of the server type from the command line and
+// environment and writes it as YAML with secrets redacted. The config is
+// written even if it's invalid so that it can be inspected, in which case the
+// validation error is returned.
+func PrintConfig(w io.Writer, srvType string) error {
+	cfg, err := newConfig(srvType)
+	if err != nil {
+		return err
+	}
+	if err = readConfig(cfg, os.Args[1:], os.Environ()); err != nil {
+		return err
+	}
+
+	redactSecrets(reflect.ValueOf(cfg))
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err = enc.Encode(cfg); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err = enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return cfg.Validate()
+}
+
+// loadConfig loads the config from the optional config file, environment
+// variables and flags, in increasing order of precedence, and validates it.
+func loadConfig(dst configValidator) error {
+	if err := readConfig(dst, os.Args[1:], os.Environ()); err != nil {
+		return err
+	}
+	return dst.Validate()
+}
+
+func readConfig(dst any, args []string, environ []string) error {
+	envs, err := resolveFileEnvs(dst, environ)
+	if err != nil {
+		return err
+	}
+
+	loaderCfg := newLoaderConfig()
+	loaderCfg.Args = args
+	loaderCfg.Envs = envs
+
+	loader := aconfig.LoaderFor(dst, loaderCfg)
+	if err = loader.Load(); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return nil
+}
+
+func newLoaderConfig() aconfig.Config {
+	return aconfig.Config{
+		FileDecoders: map[string]aconfig.FileDecoder{
+			".yaml": aconfigyaml.New(),
+		},
+		// The config file is optional but must exist when the flag is set
+		FileFlag:           "config-file",
+		FailOnFileNotFound: true,
+		EnvPrefix:          EnvPrefix,
+		// Environment variables are shared with other processes such as
+		// the CLI
+		AllowUnknownEnvs: true,
+	}
+}
+
+// resolveFileEnvs replaces the environment variables of config fields that
+// have the file suffix with the trimmed content of the file, so that secrets
+// can be mounted as files. Variables that are config fields themselves, such
+// as ANNEX_TLS_CERT_FILE, are left unchanged.
+func resolveFileEnvs(dst any, environ []string) ([]string, error) {
+	fieldEnvs := map[string]bool{}
+	aconfig.LoaderFor(dst, newLoaderConfig()).Flags().VisitAll(func(f *flag.Flag) {
+		if f.Name != "config-file" {
+			fieldEnvs[envName(f.Name)] = true
+		}
+	})
+
+	set := map[string]bool{}
+	for _, env := range environ {
+		name, _, _ := strings.Cut(env, "=")
+		set[name] = true
+	}
+
+	resolved := make([]string, 0, len(environ))
+	for _, env := range environ {
+		name, path, _ := strings.Cut(env, "=")
+		fieldEnv, ok := strings.CutSuffix(name, fileEnvSuffix)
+		if !ok || fieldEnvs[name] || !fieldEnvs[fieldEnv] {
+			resolved = append(resolved, env)
+			continue
+		}
+		if set[fieldEnv] {
+			return nil, fmt.Errorf("environment variables %s and %s are mutually exclusive", fieldEnv, name)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		resolved = append(resolved, fieldEnv+"="+strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return resolved, nil
+}
+
+// envName returns the environment variable of a config flag.
+func envName(flagName string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(flagName, ".", "_"))
+}
+
+// redactSecrets replaces the non-empty string fields tagged as secret.
+func redactSecrets(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			redactSecrets(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Tag.Get("secret") == "true" && v.Field(i).Kind() == reflect.String {
+				if v.Field(i).String() != "" {
+					v.Field(i).SetString(redactedValue)
+				}
+				continue
+			}
+			redactSecrets(v.Field(i))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redactSecrets(v.Index(i))
+		}
+	}
+}
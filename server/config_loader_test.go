@@ -0,0 +1,125 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	cfgFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgFile, []byte("port: 4400\npostgres:\n  hostPort: localhost:5432\n  user: file-user\n"), 0o600))
+
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("file-password\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		args    []string
+		environ []string
+		want    TestServiceConfig
+		wantErr string
+	}{
+		{
+			name: "no config file",
+			environ: []string{
+				"ANNEX_PORT=5000",
+				"ANNEX_POSTGRES_HOST_PORT=db:5432",
+			},
+			want: TestServiceConfig{
+				Port:     5000,
+				Postgres: PostgresConfig{HostPort: "db:5432"},
+			},
+		},
+		{
+			name: "environment overrides file",
+			args: []string{"-config-file", cfgFile},
+			environ: []string{
+				"ANNEX_POSTGRES_USER=env-user",
+				"ANNEX_POSTGRES_PASSWORD=env-password",
+			},
+			want: TestServiceConfig{
+				Port:     4400,
+				Postgres: PostgresConfig{HostPort: "localhost:5432", User: "env-user", Password: "env-password"},
+			},
+		},
+		{
+			name:    "flags override environment",
+			args:    []string{"-config-file", cfgFile, "-postgres.user", "flag-user"},
+			environ: []string{"ANNEX_POSTGRES_USER=env-user"},
+			want: TestServiceConfig{
+				Port:     4400,
+				Postgres: PostgresConfig{HostPort: "localhost:5432", User: "flag-user"},
+			},
+		},
+		{
+			name:    "secret file",
+			args:    []string{"-config-file", cfgFile},
+			environ: []string{"ANNEX_POSTGRES_PASSWORD_FILE=" + passwordFile},
+			want: TestServiceConfig{
+				Port:     4400,
+				Postgres: PostgresConfig{HostPort: "localhost:5432", User: "file-user", Password: "file-password"},
+			},
+		},
+		{
+			name:    "file fields are not resolved",
+			environ: []string{"ANNEX_TLS_CERT_FILE=cert.pem"},
+			want: TestServiceConfig{
+				TLS: TLSConfig{CertFile: "cert.pem"},
+			},
+		},
+		{
+			name: "secret file and value",
+			environ: []string{
+				"ANNEX_POSTGRES_PASSWORD=env-password",
+				"ANNEX_POSTGRES_PASSWORD_FILE=" + passwordFile,
+			},
+			wantErr: "environment variables ANNEX_POSTGRES_PASSWORD and ANNEX_POSTGRES_PASSWORD_FILE are mutually exclusive",
+		},
+		{
+			name:    "missing secret file",
+			environ: []string{"ANNEX_POSTGRES_PASSWORD_FILE=" + filepath.Join(dir, "missing")},
+			wantErr: "failed to read ANNEX_POSTGRES_PASSWORD_FILE",
+		},
+		{
+			name:    "missing config file",
+			args:    []string{"-config-file", filepath.Join(dir, "missing.yaml")},
+			wantErr: "failed to load config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TestServiceConfig
+			err := readConfig(&got, append([]string{}, tt.args...), tt.environ)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Port, got.Port)
+			assert.Equal(t, tt.want.Postgres, got.Postgres)
+			assert.Equal(t, tt.want.TLS, got.TLS)
+		})
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	cfg := &AllInOneConfig{
+		Postgres: PostgresConfig{HostPort: "localhost:5432", User: "annex", Password: "secret"},
+		Auth:     AuthConfig{Enabled: true},
+		Payloads: PayloadsConfig{EncryptionKey: "secret"},
+	}
+
+	redactSecrets(reflect.ValueOf(cfg))
+
+	assert.Equal(t, PostgresConfig{HostPort: "localhost:5432", User: "annex", Password: redactedValue}, cfg.Postgres)
+	assert.Equal(t, AuthConfig{Enabled: true}, cfg.Auth, "empty secrets are not redacted")
+	assert.Equal(t, redactedValue, cfg.Payloads.EncryptionKey)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresConfig_ConnString(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  PostgresConfig
+		want string
+	}{
+		{
+			name: "dsn",
+			cfg:  PostgresConfig{DSN: "host=db user=annex"},
+			want: "host=db user=annex",
+		},
+		{
+			name: "default database",
+			cfg:  PostgresConfig{HostPort: "db:5432", User: "annex", Password: "p@ss"},
+			want: "postgres://annex:p%40ss@db:5432/annex",
+		},
+		{
+			name: "ssl",
+			cfg: PostgresConfig{
+				HostPort:    "db:5432",
+				User:        "annex",
+				Database:    "tests",
+				SSLMode:     "verify-full",
+				SSLRootCert: "/etc/ca.pem",
+			},
+			want: "postgres://annex:@db:5432/tests?sslmode=verify-full&sslrootcert=%2Fetc%2Fca.pem",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cfg.ConnString())
+		})
+	}
+}
+
+func TestPostgresConfig_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     PostgresConfig
+		wantErr bool
+	}{
+		{
+			name: "discrete fields",
+			cfg:  PostgresConfig{HostPort: "db:5432", User: "annex", SSLMode: "require", MaxConns: 10, StatementTimeout: time.Second},
+		},
+		{
+			name: "dsn",
+			cfg:  PostgresConfig{DSN: "postgres://annex@db:5432/annex", Schema: "annex"},
+		},
+		{
+			name:    "dsn with discrete field",
+			cfg:     PostgresConfig{DSN: "postgres://annex@db:5432/annex", User: "annex"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid dsn",
+			cfg:     PostgresConfig{DSN: "postgres://db:port"},
+			wantErr: true,
+		},
+		{
+			name:    "missing user",
+			cfg:     PostgresConfig{HostPort: "db:5432"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid ssl mode",
+			cfg:     PostgresConfig{HostPort: "db:5432", User: "annex", SSLMode: "always"},
+			wantErr: true,
+		},
+		{
+			name:    "min conns greater than max",
+			cfg:     PostgresConfig{HostPort: "db:5432", User: "annex", MinConns: 5, MaxConns: 2},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid := tt.cfg.Validation().Valid()
+			assert.Equal(t, !tt.wantErr, valid)
+		})
+	}
+}
+
+func TestRetentionPolicyConfig_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RetentionPolicyConfig
+		wantErr bool
+	}{
+		{
+			name: "max age",
+			cfg:  RetentionPolicyConfig{MaxAge: "720h"},
+		},
+		{
+			name: "failed max age greater than max age",
+			cfg:  RetentionPolicyConfig{MaxAge: "720h", FailedMaxAge: "2160h"},
+		},
+		{
+			name: "failed max age equal to max age",
+			cfg:  RetentionPolicyConfig{MaxAge: "720h", FailedMaxAge: "720h"},
+		},
+		{
+			name:    "failed max age less than max age",
+			cfg:     RetentionPolicyConfig{MaxAge: "720h", FailedMaxAge: "24h"},
+			wantErr: true,
+		},
+		{
+			name:    "failed max age without max age",
+			cfg:     RetentionPolicyConfig{FailedMaxAge: "2160h"},
+			wantErr: true,
+		},
+		{
+			name:    "failed max age with only max executions per test",
+			cfg:     RetentionPolicyConfig{MaxExecutionsPerTest: 10, FailedMaxAge: "2160h"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid failed max age",
+			cfg:     RetentionPolicyConfig{MaxAge: "720h", FailedMaxAge: "forever"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid := tt.cfg.Validation().Valid()
+			assert.Equal(t, !tt.wantErr, valid)
+		})
+	}
+}
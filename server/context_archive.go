@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	"github.com/annexsh/annex/backup"
+	"github.com/annexsh/annex/postgres"
+	"github.com/annexsh/annex/sqlite"
+	"github.com/annexsh/annex/test"
+)
+
+// ExportContext writes the test history of the context in the database of
+// the config to a backup archive. Inputs are decrypted with the encryption
+// key of the config.
+func ExportContext(ctx context.Context, w io.Writer, cfg ContextConfig, contextID string) (backup.Result, error) {
+	payloadCodec, _, err := newPayloadCodecs(cfg.Payloads)
+	if err != nil {
+		return backup.Result{}, err
+	}
+	repo, closeDB, err := openTestRepository(ctx, cfg.DatabaseConfig, false)
+	if err != nil {
+		return backup.Result{}, err
+	}
+	defer closeDB()
+	return backup.NewExporter(repo, backup.WithPayloadDecoder(payloadCodec)).Export(ctx, w, contextID)
+}
+
+// ImportContext reads a backup archive into the database of the config,
+// applying pending migrations first unless disabled. Inputs are encrypted
+// with the encryption key of the config.
+func ImportContext(ctx context.Context, r io.Reader, cfg ContextConfig, policy backup.ConflictPolicy) (backup.Result, error) {
+	payloadCodec, _, err := newPayloadCodecs(cfg.Payloads)
+	if err != nil {
+		return backup.Result{}, err
+	}
+	repo, closeDB, err := openTestRepository(ctx, cfg.DatabaseConfig, true)
+	if err != nil {
+		return backup.Result{}, err
+	}
+	defer closeDB()
+	return backup.NewImporter(repo, backup.WithConflictPolicy(policy), backup.WithPayloadEncoder(payloadCodec)).Import(ctx, r)
+}
+
+// openTestRepository opens the test repository of the database of the
+// config. The schema is migrated if migrate is set, or checked otherwise.
+func openTestRepository(ctx context.Context, cfg DatabaseConfig, migrate bool) (test.Repository, func(), error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if cfg.SQLite {
+		opt := sqlite.WithSchemaCheck()
+		if migrate {
+			opt = sqlite.WithMigration()
+		}
+		db, err := sqlite.Open(sqlite.WithPath(cfg.SQLitePath), opt)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sqlite.NewTestRepository(sqlite.NewDB(db)), func() { db.Close() }, nil
+	}
+
+	opt := postgres.WithSchemaCheck()
+	if migrate {
+		opt = postgresMigrationOption(cfg.Postgres)
+	}
+	pool, err := postgres.OpenPool(ctx, cfg.Postgres.ConnString(), append(cfg.Postgres.OpenOptions(), opt)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return postgres.NewTestRepository(postgres.NewDB(pool)), pool.Close, nil
+}
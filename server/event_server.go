@@ -2,60 +2,117 @@ package server
 
 import (
 	"context"
-	"net/http"
-	"time"
 
+	"connectrpc.com/connect"
 	"github.com/annexsh/annex-proto/go/gen/annex/events/v1/eventsv1connect"
 	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
-	corenats "github.com/nats-io/nats.go"
 
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/annexsh/annex/event"
 	"github.com/annexsh/annex/eventservice"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+	"github.com/annexsh/annex/internal/health"
 	"github.com/annexsh/annex/internal/rpc"
 	"github.com/annexsh/annex/log"
-	"github.com/annexsh/annex/nats"
+	"github.com/annexsh/annex/postgres"
 )
 
 func ServeEventService(ctx context.Context, cfg EventServiceConfig) error {
 	logger := log.NewLogger("service", "event_service")
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	testClient := testsv1connect.NewTestServiceClient(httpClient, cfg.TestServiceURL)
 
-	var natsOpts []corenats.Option
-	if cfg.Nats.Embedded {
-		ns, err := runEmbeddedNats(cfg.Nats.HostPort)
-		if err != nil {
-			return err
-		}
-		defer ns.Shutdown()
-		natsOpts = append(natsOpts, corenats.InProcessServer(ns))
+	shutdownTracing, err := setupTracing(ctx, cfg.Tracing, "annex-event-service", logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	httpClient, err := newHTTPClient(ctx, cfg.TestServiceTLS, logger)
+	if err != nil {
+		return err
 	}
+	execFetcher := newExecutionFetcher(httpClient, cfg.TestServiceURL)
 
-	var nc *corenats.Conn
-	var err error
-	if cfg.Nats.Embedded {
-		ns, err := runEmbeddedNats(cfg.Nats.HostPort)
+	var pubSub event.PubSub
+	var healthDeps []health.DependencyChecker
+
+	if cfg.EventBus.IsNats() {
+		nc, closeNats, err := connectNats(cfg.Nats)
 		if err != nil {
 			return err
 		}
-		defer ns.Shutdown()
-		nc, err = corenats.Connect("", corenats.InProcessServer(ns))
+		defer closeNats()
+		pubSub = newNatsPubSub(nc, cfg.Subscribers, logger)
+		healthDeps = append(healthDeps, health.WithNats(nc))
+	} else {
+		// The test service creates and migrates the database
+		pgCfg := cfg.Postgres
+		pgPool, err := postgres.OpenPool(ctx, pgCfg.ConnString(), append(pgCfg.PoolOptions(), postgres.WithSchemaCheck())...)
 		if err != nil {
 			return err
 		}
-	} else {
-		nc, err = corenats.Connect(cfg.Nats.HostPort)
+		defer pgPool.Close()
+		healthDeps = append(healthDeps, health.WithPostgres(pgPool))
+		pgPubSub, err := newPostgresPubSub(ctx, pgPool, cfg.Subscribers, logger)
 		if err != nil {
 			return err
 		}
+		defer pgPubSub.Close()
+		pubSub = pgPubSub
+	}
+
+	authClient := authv1connect.NewAuthServiceClient(httpClient, cfg.TestServiceURL, rpc.WithConnectClientInterceptors())
+	authenticator, err := newAuthenticator(ctx, cfg.Auth, nil, authClient, logger)
+	if err != nil {
+		return err
 	}
-	defer nc.Close()
-	pubSub := nats.NewPubSub(nc, nats.WithLogger(logger))
 
-	eventSvc := eventservice.New(pubSub, testClient, eventservice.WithLogger(logger))
+	eventSvc := eventservice.New(pubSub, execFetcher, eventservice.WithLogger(logger))
 
-	srv := rpc.NewServer(getHostPort(cfg.Port))
-	path, handler := eventsv1connect.NewEventServiceHandler(eventSvc, rpc.WithConnectInterceptors(logger))
+	srv, err := newRPCServer(ctx, cfg.Port, cfg.TLS, cfg.Health, logger)
+	if err != nil {
+		return err
+	}
+	authOpt := rpc.WithAuthenticator(authenticator)
+	path, handler := eventsv1connect.NewEventServiceHandler(eventSvc, rpc.WithConnectInterceptors(logger, authOpt))
 	srv.RegisterConnect(path, handler, cfg.CorsOrigins...)
+	execEventPath, execEventHandler := executionsv1connect.NewExecutionEventServiceHandler(eventSvc.ExecutionEventHandler(), rpc.WithConnectInterceptors(logger, authOpt))
+	srv.RegisterConnect(execEventPath, execEventHandler, cfg.CorsOrigins...)
+
+	healthSvc, err := health.NewGRPCService(ctx, health.Config{
+		ServiceNames:  []string{health.ServiceNameEvent},
+		Dependencies:  healthDeps,
+		CheckInterval: cfg.Health.CheckInterval,
+		Logger:        logger,
+	})
+	if err != nil {
+		return err
+	}
+	srv.RegisterHealth(healthSvc)
 
 	return serve(ctx, srv, logger)
 }
+
+// executionFetcher fetches test executions and their events from the test
+// service.
+type executionFetcher struct {
+	testsv1connect.TestServiceClient
+	executionsv1connect.ExecutionServiceClient
+}
+
+func newExecutionFetcher(httpClient connect.HTTPClient, baseURL string) *executionFetcher {
+	return &executionFetcher{
+		TestServiceClient:      testsv1connect.NewTestServiceClient(httpClient, baseURL, rpc.WithConnectClientInterceptors()),
+		ExecutionServiceClient: executionsv1connect.NewExecutionServiceClient(httpClient, baseURL, rpc.WithConnectClientInterceptors()),
+	}
+}
+
+// GetTestExecution gets the test execution from the test service. Both clients
+// have the method, the execution metadata returned by the execution service
+// isn't needed.
+func (f *executionFetcher) GetTestExecution(
+	ctx context.Context,
+	req *connect.Request[testsv1.GetTestExecutionRequest],
+) (*connect.Response[testsv1.GetTestExecutionResponse], error) {
+	return f.TestServiceClient.GetTestExecution(ctx, req)
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/annexsh/annex/internal/migration"
+	"github.com/annexsh/annex/postgres"
+	pgmigrations "github.com/annexsh/annex/postgres/migrations"
+	"github.com/annexsh/annex/sqlite"
+	sqlitemigrations "github.com/annexsh/annex/sqlite/migrations"
+)
+
+// Actions accepted by Migrate.
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+	MigrateForce  = "force"
+)
+
+// Migrate runs a migration action against the database of the config and
+// writes the resulting schema status:
+//
+//	up              applies all pending migrations
+//	down [steps]    reverts the number of applied migrations, defaulting to 1
+//	status          writes the schema status only
+//	force <version> sets the version of a dirty schema after manual repair
+func Migrate(ctx context.Context, w io.Writer, cfg DatabaseConfig, action string, args []string) error {
+	if err := validateMigrateArgs(action, args); err != nil {
+		return err
+	}
+
+	m, closeDB, err := newMigrator(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+	defer m.Close()
+
+	switch action {
+	case MigrateUp:
+		err = m.Up(ctx)
+	case MigrateDown:
+		steps := 1
+		if len(args) == 1 {
+			steps, _ = strconv.Atoi(args[0])
+		}
+		err = m.Down(ctx, steps)
+	case MigrateForce:
+		version, _ := strconv.Atoi(args[0])
+		err = m.Force(ctx, version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", action, err)
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	return writeMigrationStatus(w, status)
+}
+
+func validateMigrateArgs(action string, args []string) error {
+	switch action {
+	case MigrateUp, MigrateStatus:
+		if len(args) > 0 {
+			return fmt.Errorf("migrate %s takes no arguments", action)
+		}
+	case MigrateDown:
+		if len(args) > 1 {
+			return fmt.Errorf("usage: migrate %s [steps]", action)
+		}
+		if len(args) == 1 {
+			if steps, err := strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q: must be a positive integer", args[0])
+			}
+		}
+	case MigrateForce:
+		if len(args) != 1 {
+			return fmt.Errorf("usage: migrate %s <version>", action)
+		}
+		if _, err := strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid version %q: must be an integer", args[0])
+		}
+	default:
+		return fmt.Errorf("invalid migrate action %q", action)
+	}
+	return nil
+}
+
+// newMigrator opens the database of the config without migrating or
+// checking it, so that a schema newer than the migrations can be inspected.
+// The config is validated first so that SQLite never falls back to an
+// in-memory database.
+func newMigrator(ctx context.Context, cfg DatabaseConfig) (*migration.Migrator, func(), error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if cfg.SQLite {
+		db, err := sqlite.Open(sqlite.WithPath(cfg.SQLitePath))
+		if err != nil {
+			return nil, nil, err
+		}
+		m, err := sqlitemigrations.NewMigrator(db)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return m, func() { db.Close() }, nil
+	}
+
+	pool, err := postgres.OpenPool(ctx, cfg.Postgres.ConnString(), cfg.Postgres.OpenOptions()...)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := pgmigrations.NewMigrator(pool)
+	if err != nil {
+		pool.Close()
+		return nil, nil, err
+	}
+	return m, pool.Close, nil
+}
+
+func writeMigrationStatus(w io.Writer, status migration.Status) error {
+	state := "up to date"
+	switch {
+	case status.Dirty:
+		state = "dirty"
+	case status.Newer():
+		state = "newer than the latest migration"
+	case status.Pending():
+		state = fmt.Sprintf("%d pending", status.Latest-status.Version)
+	}
+	_, err := fmt.Fprintf(w, "version: %d\nlatest: %d\nstatus: %s\n", status.Version, status.Latest, state)
+	return err
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate_sqlite(t *testing.T) {
+	cfg := DatabaseConfig{SQLite: true, SQLitePath: filepath.Join(t.TempDir(), "annex.db")}
+
+	err := Migrate(context.Background(), io.Discard, cfg, MigrateUp, nil)
+	require.NoError(t, err)
+}
+
+func TestMigrate_sqliteWithoutPath(t *testing.T) {
+	err := Migrate(context.Background(), io.Discard, DatabaseConfig{SQLite: true}, MigrateStatus, nil)
+	assert.ErrorContains(t, err, "SQLite")
+}
@@ -10,6 +10,13 @@ import (
 	corenats "github.com/nats-io/nats.go"
 	"go.temporal.io/sdk/client"
 
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/authservice"
+	"github.com/annexsh/annex/event"
+	"github.com/annexsh/annex/gen/annex/audit/v1/auditv1connect"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
+	"github.com/annexsh/annex/internal/health"
 	"github.com/annexsh/annex/internal/rpc"
 	"github.com/annexsh/annex/log"
 	"github.com/annexsh/annex/nats"
@@ -20,10 +27,20 @@ import (
 
 func ServeTestService(ctx context.Context, cfg TestServiceConfig) error {
 	logger := log.NewLogger("service", "test_service")
-	srv := rpc.NewServer(getHostPort(cfg.Port))
+
+	shutdownTracing, err := setupTracing(ctx, cfg.Tracing, "annex-test-service", logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	srv, err := newRPCServer(ctx, cfg.Port, cfg.TLS, cfg.Health, logger)
+	if err != nil {
+		return err
+	}
 
 	pgCfg := cfg.Postgres
-	pgPool, err := postgres.OpenPool(ctx, pgCfg.User, pgCfg.Password, pgCfg.HostPort, postgres.WithMigration())
+	pgPool, err := postgres.OpenPool(ctx, pgCfg.ConnString(), append(pgCfg.OpenOptions(), postgresMigrationOption(pgCfg))...)
 	if err != nil {
 		return err
 	}
@@ -35,25 +52,104 @@ func ServeTestService(ctx context.Context, cfg TestServiceConfig) error {
 		return err
 	}
 
-	nc, err := corenats.Connect(cfg.Nats.HostPort)
+	blobStore, err := newBlobStore(cfg.Artifacts, postgres.NewBlobStore(db))
+	if err != nil {
+		return err
+	}
+
+	runPurger(ctx, cfg.Retention, repo, blobStore, postgres.NewPurgeLock(pgPool), logger.With("component", "retention_purger"))
+
+	authRepo := postgres.NewAuthRepository(db)
+	authenticator, err := newAuthenticator(ctx, cfg.Auth, authRepo, nil, logger)
+	if err != nil {
+		return err
+	}
+	interceptors := rpc.WithConnectInterceptors(logger,
+		rpc.WithAuthenticator(authenticator),
+		rpc.WithLimits(newRPCLimits(cfg.Limits)),
+	)
+	authorizer := auth.NewRoleAuthorizer(authRepo)
+
+	var pubSub event.PubSub
+	healthDeps := []health.DependencyChecker{health.WithPostgres(pgPool)}
+
+	if cfg.EventBus.IsNats() {
+		nc, err := corenats.Connect(cfg.Nats.HostPort)
+		if err != nil {
+			return err
+		}
+		defer nc.Close()
+		pubSub = newNatsPubSub(nc, cfg.Subscribers, logger)
+		healthDeps = append(healthDeps, health.WithNats(nc))
+	} else {
+		pgPubSub, err := newPostgresPubSub(ctx, pgPool, cfg.Subscribers, logger)
+		if err != nil {
+			return err
+		}
+		defer pgPubSub.Close()
+		pubSub = pgPubSub
+	}
+
+	payloadCodec, dataConverter, err := newPayloadCodecs(cfg.Payloads)
+	if err != nil {
+		return err
+	}
+	logRedactor, err := newLogRedactor(cfg.LogRedaction)
 	if err != nil {
 		return err
 	}
-	defer nc.Close()
-	pubSub := nats.NewPubSub(nc, nats.WithLogger(logger))
 
 	workflowProxyClient, err := client.NewLazyClient(client.Options{
-		HostPort:  srv.GRPCAddress(),
-		Namespace: workflowservice.Namespace,
-		Logger:    logger.With("component", "temporal_client"),
+		HostPort:      srv.GRPCAddress(),
+		Namespace:     workflowservice.Namespace,
+		Logger:        logger.With("component", "temporal_client"),
+		DataConverter: dataConverter,
+		ConnectionOptions: client.ConnectionOptions{
+			// Connects in-process so that TLS certificates are not required
+			DialOptions: append(rpc.WithGRPCAnnexClientInterceptors(), srv.InternalGRPCDialOption()),
+		},
 	})
 	if err != nil {
 		return err
 	}
 
-	testSvc := testservice.New(repo, pubSub, workflowProxyClient, testservice.WithLogger(logger))
-	path, handler := testsv1connect.NewTestServiceHandler(testSvc, rpc.WithConnectInterceptors(logger))
+	auditRepo := postgres.NewAuditRepository(db)
+
+	testSvcOpts := []testservice.ServiceOption{
+		testservice.WithLogger(logger),
+		testservice.WithAuthorizer(authorizer),
+		testservice.WithAuditRepository(auditRepo),
+		testservice.WithPayloadCodec(payloadCodec),
+		testservice.WithDataConverter(dataConverter),
+		testservice.WithSecretFields(cfg.Payloads.SecretFields...),
+		testservice.WithLogRedactor(logRedactor),
+	}
+	testSvc := testservice.New(repo, pubSub, workflowProxyClient, append(testSvcOpts, artifactOpts(cfg.Artifacts, blobStore)...)...)
+	path, handler := testsv1connect.NewTestServiceHandler(testSvc, interceptors)
 	srv.RegisterConnect(path, handler, cfg.CorsOrigins...)
+	execPath, execHandler := executionsv1connect.NewExecutionServiceHandler(testSvc.ExecutionHandler(), interceptors)
+	srv.RegisterConnect(execPath, execHandler, cfg.CorsOrigins...)
+	auditPath, auditHandler := auditv1connect.NewAuditServiceHandler(testSvc, interceptors)
+	srv.RegisterConnect(auditPath, auditHandler, cfg.CorsOrigins...)
+
+	authSvc := authservice.New(authRepo,
+		authservice.WithLogger(logger),
+		authservice.WithAuthorizer(authorizer),
+		authservice.WithAuditRepository(auditRepo),
+	)
+	authPath, authHandler := authv1connect.NewAuthServiceHandler(authSvc, interceptors)
+	srv.RegisterConnect(authPath, authHandler, cfg.CorsOrigins...)
+
+	healthSvc, err := health.NewGRPCService(ctx, health.Config{
+		ServiceNames:  []string{health.ServiceNameTest, health.ServiceNameExecution, health.ServiceNameAudit, health.ServiceNameAuth},
+		Dependencies:  healthDeps,
+		CheckInterval: cfg.Health.CheckInterval,
+		Logger:        logger,
+	})
+	if err != nil {
+		return err
+	}
+	srv.RegisterHealth(healthSvc)
 
 	return serve(ctx, srv, logger)
 }
@@ -2,10 +2,34 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
+	"github.com/jackc/pgx/v5/pgxpool"
+	corenats "github.com/nats-io/nats.go"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/blob"
+	"github.com/annexsh/annex/codec"
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
 	"github.com/annexsh/annex/internal/rpc"
+	"github.com/annexsh/annex/internal/tlsconfig"
+	"github.com/annexsh/annex/internal/tracing"
 	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/nats"
+	"github.com/annexsh/annex/postgres"
+	"github.com/annexsh/annex/redact"
+	"github.com/annexsh/annex/retention"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/testservice"
+	"github.com/annexsh/annex/workflowservice"
 )
 
 func serve(ctx context.Context, srv *rpc.Server, logger log.Logger) error {
@@ -27,6 +51,360 @@ func serve(ctx context.Context, srv *rpc.Server, logger log.Logger) error {
 	}
 }
 
+// connectNats connects to the configured NATS server, running an embedded
+// server first if enabled. The returned function closes the connection and
+// shuts down any embedded server.
+func connectNats(cfg NatsConfig) (*corenats.Conn, func(), error) {
+	if !cfg.Embedded {
+		nc, err := corenats.Connect(cfg.HostPort)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nc, nc.Close, nil
+	}
+
+	ns, err := runEmbeddedNats(cfg.HostPort)
+	if err != nil {
+		return nil, nil, err
+	}
+	nc, err := corenats.Connect("", corenats.InProcessServer(ns))
+	if err != nil {
+		ns.Shutdown()
+		return nil, nil, err
+	}
+
+	return nc, func() {
+		nc.Close()
+		ns.Shutdown()
+	}, nil
+}
+
+// newNatsPubSub creates a NATS event bus using the configured subscriber
+// options.
+func newNatsPubSub(nc *corenats.Conn, cfg SubscribersConfig, logger log.Logger) *nats.PubSub {
+	opts := []nats.PubSubOption{
+		nats.WithLogger(logger),
+		nats.WithOverflowPolicy(cfg.Overflow.OverflowPolicy()),
+	}
+	if cfg.BufferSize > 0 {
+		opts = append(opts, nats.WithSubscriptionBufferSize(cfg.BufferSize))
+	}
+	return nats.NewPubSub(nc, opts...)
+}
+
+// newPostgresPubSub creates a Postgres event bus using the configured
+// subscriber options.
+func newPostgresPubSub(ctx context.Context, pool *pgxpool.Pool, cfg SubscribersConfig, logger log.Logger) (*postgres.PubSub, error) {
+	opts := []postgres.PubSubOption{
+		postgres.WithLogger(logger),
+		postgres.WithOverflowPolicy(cfg.Overflow.OverflowPolicy()),
+	}
+	if cfg.BufferSize > 0 {
+		opts = append(opts, postgres.WithSubscriptionBufferSize(cfg.BufferSize))
+	}
+	return postgres.NewPubSub(ctx, pool, opts...)
+}
+
+// runPurger purges expired test executions in the background until the
+// context is cancelled if a retention policy is configured. Purges are
+// serialized across processes by the lock when set.
+func runPurger(ctx context.Context, cfg RetentionConfig, repo test.Repository, blobStore blob.Store, lock retention.LockFunc, logger log.Logger) {
+	if !cfg.Enabled() {
+		return
+	}
+
+	opts := []retention.PurgerOption{
+		retention.WithLogger(logger),
+		retention.WithBlobStore(blobStore),
+	}
+	if lock != nil {
+		opts = append(opts, retention.WithLock(lock))
+	}
+	if cfg.PurgeInterval > 0 {
+		opts = append(opts, retention.WithInterval(cfg.PurgeInterval))
+	}
+	if cfg.PurgeBatchSize > 0 {
+		opts = append(opts, retention.WithBatchSize(cfg.PurgeBatchSize))
+	}
+	for _, ctxCfg := range cfg.Contexts {
+		opts = append(opts, retention.WithContextPolicy(ctxCfg.Context, ctxCfg.Policy()))
+	}
+
+	go retention.NewPurger(repo, cfg.Policy(), opts...).Run(ctx)
+}
+
+// setupTracing installs the global tracer provider for the service. The
+// returned function flushes buffered spans and should be called on shutdown.
+func setupTracing(ctx context.Context, cfg TracingConfig, serviceName string, logger log.Logger) (func(), error) {
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: serviceName,
+		Exporter:    cfg.Exporter,
+		Endpoint:    cfg.Endpoint,
+		Insecure:    cfg.Insecure,
+		SampleRatio: cfg.SampleRatio,
+		Logger:      logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(shutdownCtx); err != nil {
+			logger.Error("failed to shutdown tracing", "error", err)
+		}
+	}, nil
+}
+
+// bootstrapAPIKeySubject is the principal subject of the bootstrap API key.
+const bootstrapAPIKeySubject = "bootstrap"
+
+// newAuthenticator creates the authenticator of API requests. API keys are
+// verified against the repository if set, otherwise by the remote auth
+// service. A nil authenticator is returned when authentication is disabled.
+func newAuthenticator(
+	ctx context.Context,
+	cfg AuthConfig,
+	apiKeys auth.APIKeyReader,
+	remote authv1connect.AuthServiceClient,
+	logger log.Logger,
+) (auth.Authenticator, error) {
+	if !cfg.Enabled {
+		logger.Warn("authentication disabled: all requests are allowed")
+		return nil, nil
+	}
+
+	var authenticators []auth.Authenticator
+
+	if apiKeys != nil {
+		authenticators = append(authenticators, auth.NewAPIKeyAuthenticator(apiKeys))
+		if cfg.BootstrapAPIKey != "" {
+			authenticators = append(authenticators, auth.NewStaticAuthenticator(cfg.BootstrapAPIKey, bootstrapAPIKeySubject))
+		}
+	} else {
+		authenticators = append(authenticators, auth.NewRemoteAuthenticator(remote))
+	}
+
+	if cfg.JWT.Enabled() {
+		opts := []auth.JWTOption{auth.WithLogger(logger)}
+		if cfg.JWT.Issuer != "" {
+			opts = append(opts, auth.WithIssuer(cfg.JWT.Issuer))
+		}
+		if cfg.JWT.Audience != "" {
+			opts = append(opts, auth.WithAudience(cfg.JWT.Audience))
+		}
+		if cfg.JWT.JWKSRefreshInterval > 0 {
+			opts = append(opts, auth.WithJWKSRefreshInterval(cfg.JWT.JWKSRefreshInterval))
+		}
+		jwtAuth, err := auth.NewJWTAuthenticator(ctx, cfg.JWT.JWKS, opts...)
+		if err != nil {
+			return nil, err
+		}
+		// JWTs are verified locally before falling back to the remote service
+		authenticators = append([]auth.Authenticator{jwtAuth}, authenticators...)
+	}
+
+	return auth.Chain(authenticators...), nil
+}
+
+// newRPCServer creates the RPC server of the service, serving TLS if
+// configured. Certificates are reloaded until the context is cancelled.
+// Probes are served on a separate plaintext port if configured.
+func newRPCServer(ctx context.Context, port int, cfg TLSConfig, healthCfg HealthConfig, logger log.Logger) (*rpc.Server, error) {
+	srv := rpc.NewServer(getHostPort(port))
+	if healthCfg.ProbePort > 0 {
+		srv.WithProbeAddress(getHostPort(healthCfg.ProbePort))
+	}
+	if cfg.Enabled() {
+		tlsCfg, err := tlsconfig.NewServer(ctx, tlsconfig.Config{
+			CertFile:       cfg.CertFile,
+			KeyFile:        cfg.KeyFile,
+			CAFile:         cfg.ClientCAFile,
+			ReloadInterval: cfg.ReloadInterval,
+			Logger:         logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create server tls config: %w", err)
+		}
+		srv.WithTLS(tlsCfg)
+	}
+	return srv, nil
+}
+
+// newClientTLS creates the TLS config of a client. A nil config is returned
+// when TLS is disabled.
+func newClientTLS(ctx context.Context, cfg ClientTLSConfig, logger log.Logger) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	tlsCfg, err := tlsconfig.NewClient(ctx, tlsconfig.Config{
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		CAFile:         cfg.CAFile,
+		ServerName:     cfg.ServerName,
+		ReloadInterval: cfg.ReloadInterval,
+		Logger:         logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client tls config: %w", err)
+	}
+	return tlsCfg, nil
+}
+
+// newHTTPClient creates the HTTP client of Connect services, using TLS if
+// enabled.
+func newHTTPClient(ctx context.Context, cfg ClientTLSConfig, logger log.Logger) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	tlsCfg, err := newClientTLS(ctx, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsCfg
+		transport.ForceAttemptHTTP2 = true
+		httpClient.Transport = transport
+	}
+	return httpClient, nil
+}
+
+// newRPCLimits creates the limits of test service requests.
+func newRPCLimits(cfg LimitsConfig) rpc.Limits {
+	rule := func(name string, rateCfg RateLimitConfig, procedures ...string) rpc.RateRule {
+		return rpc.RateRule{
+			Name:       name,
+			Procedures: procedures,
+			PerCaller:  rpc.RateLimit{Rate: rateCfg.PerCaller.Rate, Burst: rateCfg.PerCaller.Burst},
+			PerContext: rpc.RateLimit{Rate: rateCfg.PerContext.Rate, Burst: rateCfg.PerContext.Burst},
+		}
+	}
+	publishLog := rule("publish log", cfg.PublishLog,
+		testsv1connect.TestServicePublishLogProcedure,
+		executionsv1connect.ExecutionServicePublishLogsProcedure,
+	)
+	// Batch log streams are long-lived so each batch counts as a request
+	publishLog.PerMessage = true
+
+	return rpc.Limits{
+		Rates: []rpc.RateRule{
+			publishLog,
+			rule("execute test", cfg.ExecuteTest,
+				testsv1connect.TestServiceExecuteTestProcedure,
+				executionsv1connect.ExecutionServiceExecuteTestProcedure,
+				testsv1connect.TestServiceRetryTestExecutionProcedure,
+			),
+			rule("register", cfg.Register,
+				testsv1connect.TestServiceRegisterContextProcedure,
+				testsv1connect.TestServiceRegisterTestSuiteProcedure,
+				testsv1connect.TestServiceRegisterTestsProcedure,
+			),
+		},
+		MaxLogMessageSize: cfg.MaxLogMessageSize,
+		MaxPayloadSize:    cfg.MaxPayloadSize,
+	}
+}
+
+const defaultEncryptionKeyID = "default"
+
+// newPayloadCodecs creates the codec of test inputs stored at rest and the
+// data converter of the workflow client. A nil codec is returned when
+// encryption is disabled.
+func newPayloadCodecs(cfg PayloadsConfig) (converter.PayloadCodec, converter.DataConverter, error) {
+	dataConverter := converter.GetDefaultDataConverter()
+
+	var aesCodec *codec.AESCodec
+	if cfg.encrypted() {
+		var key []byte
+		var err error
+		if cfg.EncryptionKeyFile != "" {
+			key, err = codec.LoadKeyFile(cfg.EncryptionKeyFile)
+		} else {
+			key, err = codec.ParseKey(cfg.EncryptionKey)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		keyID := cfg.EncryptionKeyID
+		if keyID == "" {
+			keyID = defaultEncryptionKeyID
+		}
+		if aesCodec, err = codec.NewAESCodec(keyID, key); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if cfg.EncodeWorkflowInputs {
+		// Codecs encode in reverse order
+		var codecs []converter.PayloadCodec
+		if cfg.CodecEndpoint != "" {
+			codecs = append(codecs, converter.NewRemotePayloadCodec(converter.RemotePayloadCodecOptions{
+				Endpoint: strings.TrimSuffix(cfg.CodecEndpoint, "/"),
+				ModifyRequest: func(req *http.Request) error {
+					req.Header.Set("X-Namespace", workflowservice.Namespace)
+					return nil
+				},
+				Client: http.Client{Timeout: 30 * time.Second},
+			}))
+		}
+		if aesCodec != nil {
+			codecs = append(codecs, aesCodec)
+		}
+		dataConverter = converter.NewCodecDataConverter(dataConverter, codecs...)
+	}
+
+	if aesCodec == nil {
+		return nil, dataConverter, nil
+	}
+	return aesCodec, dataConverter, nil
+}
+
+// newBlobStore creates the store of artifact content. The database store is
+// used unless the filesystem store is configured.
+func newBlobStore(cfg ArtifactsConfig, dbStore blob.Store) (blob.Store, error) {
+	if cfg.Store == ArtifactStoreFilesystem {
+		return blob.NewFileStore(cfg.Dir)
+	}
+	return dbStore, nil
+}
+
+// artifactOpts returns the test service options that configure artifacts.
+func artifactOpts(cfg ArtifactsConfig, blobStore blob.Store) []testservice.ServiceOption {
+	opts := []testservice.ServiceOption{testservice.WithBlobStore(blobStore)}
+	if cfg.MaxSize > 0 {
+		opts = append(opts, testservice.WithMaxArtifactSize(cfg.MaxSize))
+	}
+	return opts
+}
+
+// postgresMigrationOption migrates the database at startup unless disabled,
+// in which case the schema version is only checked.
+func postgresMigrationOption(cfg PostgresConfig) postgres.OpenOption {
+	if cfg.SkipMigration {
+		return postgres.WithSchemaCheck()
+	}
+	return postgres.WithMigration()
+}
+
+// newLogRedactor creates the redactor of published logs. A nil redactor is
+// returned when redaction is disabled.
+func newLogRedactor(cfg LogRedactionConfig) (*redact.Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	var opts []redact.RedactorOption
+	if !cfg.DisableKnownFormats {
+		opts = append(opts, redact.WithKnownFormats())
+	}
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log redaction pattern: %w", err)
+		}
+		opts = append(opts, redact.WithPatterns(re))
+	}
+	return redact.NewRedactor(opts...), nil
+}
+
 func getHostPort(port int) string {
 	return fmt.Sprintf("127.0.0.1:%d", port)
 }
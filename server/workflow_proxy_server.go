@@ -2,15 +2,13 @@ package server
 
 import (
 	"context"
-	"net/http"
-	"time"
 
 	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
 	workflowservicev1 "go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
-	"google.golang.org/grpc/health"
-	grpchealthv1 "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/annexsh/annex/gen/annex/auth/v1/authv1connect"
+	"github.com/annexsh/annex/internal/health"
 	"github.com/annexsh/annex/internal/rpc"
 	"github.com/annexsh/annex/log"
 	"github.com/annexsh/annex/workflowservice"
@@ -18,26 +16,62 @@ import (
 
 func ServeWorkflowProxyService(ctx context.Context, cfg WorkflowProxyServiceConfig) error {
 	logger := log.NewLogger("service", "workflow_proxy_service")
-	srv := rpc.NewServer(getHostPort(cfg.Port))
+
+	shutdownTracing, err := setupTracing(ctx, cfg.Tracing, "annex-workflow-proxy-service", logger)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
+	srv, err := newRPCServer(ctx, cfg.Port, cfg.TLS, cfg.Health, logger)
+	if err != nil {
+		return err
+	}
+
+	temporalTLS, err := newClientTLS(ctx, cfg.Temporal.TLS, logger)
+	if err != nil {
+		return err
+	}
 
 	temporalClient, err := client.NewLazyClient(client.Options{
 		HostPort:  cfg.Temporal.HostPort,
 		Namespace: workflowservice.Namespace,
 		Logger:    logger.With("component", "temporal_client"),
+		ConnectionOptions: client.ConnectionOptions{
+			TLS:         temporalTLS,
+			DialOptions: rpc.WithGRPCClientInterceptors(),
+		},
 	})
 	if err != nil {
 		return err
 	}
 
-	httpClient := &http.Client{Timeout: 30 * time.Second}
-	testClient := testsv1connect.NewTestServiceClient(httpClient, cfg.TestServiceURL)
+	httpClient, err := newHTTPClient(ctx, cfg.TestServiceTLS, logger)
+	if err != nil {
+		return err
+	}
+	testClient := testsv1connect.NewTestServiceClient(httpClient, cfg.TestServiceURL, rpc.WithConnectClientInterceptors())
+	authClient := authv1connect.NewAuthServiceClient(httpClient, cfg.TestServiceURL, rpc.WithConnectClientInterceptors())
+
+	authenticator, err := newAuthenticator(ctx, cfg.Auth, nil, authClient, logger)
+	if err != nil {
+		return err
+	}
 
 	workflowSvc := workflowservice.NewProxyService(testClient, temporalClient.WorkflowService())
 	srv.RegisterGRPC(&workflowservicev1.WorkflowService_ServiceDesc, workflowSvc)
-	healthSvc := health.NewServer()
-	healthSvc.SetServingStatus(workflowservicev1.WorkflowService_ServiceDesc.ServiceName, grpchealthv1.HealthCheckResponse_SERVING)
-	srv.RegisterGRPC(&grpchealthv1.Health_ServiceDesc, healthSvc)
-	srv.WithGRPCOptions(rpc.WithGRPCInterceptors(logger)...)
+
+	healthSvc, err := health.NewGRPCService(ctx, health.Config{
+		ServiceNames:  []string{health.ServiceNameWorkflow},
+		Dependencies:  []health.DependencyChecker{health.WithTemporal(temporalClient, workflowservice.Namespace)},
+		CheckInterval: cfg.Health.CheckInterval,
+		Logger:        logger,
+	})
+	if err != nil {
+		return err
+	}
+	srv.RegisterHealth(healthSvc)
+	srv.WithGRPCOptions(rpc.WithGRPCInterceptors(logger, rpc.WithAuthenticator(authenticator))...)
 
 	return serve(ctx, srv, logger)
 }
@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/sqlite/sqlc"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+var (
+	_ auth.APIKeyReader = (*APIKeyReader)(nil)
+	_ auth.APIKeyWriter = (*APIKeyWriter)(nil)
+)
+
+type APIKeyReader struct {
+	db *DB
+}
+
+func NewAPIKeyReader(db *DB) *APIKeyReader {
+	return &APIKeyReader{db: db}
+}
+
+func (a *APIKeyReader) GetAPIKeyByHash(ctx context.Context, hash []byte) (*auth.APIKey, error) {
+	key, err := a.db.GetAPIKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, auth.ErrorAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return marshalAPIKey(key), nil
+}
+
+func (a *APIKeyReader) ListAPIKeys(ctx context.Context, filter test.PageFilter[uuid.V7]) (auth.APIKeyList, error) {
+	params := sqlc.ListAPIKeysParams{
+		PageSize: int64(filter.Size),
+	}
+	if filter.OffsetID != nil {
+		params.OffsetID = ptr.Get(filter.OffsetID.String())
+	}
+
+	keys, err := a.db.ListAPIKeys(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return marshalAPIKeys(keys), nil
+}
+
+type APIKeyWriter struct {
+	db *DB
+}
+
+func NewAPIKeyWriter(db *DB) *APIKeyWriter {
+	return &APIKeyWriter{db: db}
+}
+
+func (a *APIKeyWriter) CreateAPIKey(ctx context.Context, key *auth.APIKey, hash []byte) error {
+	return a.db.CreateAPIKey(ctx, sqlc.CreateAPIKeyParams{
+		ID:         key.ID,
+		Name:       key.Name,
+		Hash:       hash,
+		CreateTime: key.CreateTime.UTC(),
+	})
+}
+
+func (a *APIKeyWriter) RevokeAPIKey(ctx context.Context, id uuid.V7, revokeTime time.Time) error {
+	count, err := a.db.RevokeAPIKey(ctx, sqlc.RevokeAPIKeyParams{
+		ID:         id,
+		RevokeTime: ptr.Get(revokeTime.UTC()),
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return auth.ErrorAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+
+	"database/sql"
+
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/sqlite/sqlc"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+var (
+	_ test.ArtifactReader = (*ArtifactReader)(nil)
+	_ test.ArtifactWriter = (*ArtifactWriter)(nil)
+)
+
+type ArtifactReader struct {
+	db *DB
+}
+
+func NewArtifactReader(db *DB) *ArtifactReader {
+	return &ArtifactReader{db: db}
+}
+
+func (a *ArtifactReader) GetArtifact(ctx context.Context, id uuid.V7) (*test.Artifact, error) {
+	artifact, err := a.db.GetArtifact(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, test.ErrorArtifactNotFound
+		}
+		return nil, err
+	}
+	return marshalArtifact(artifact), nil
+}
+
+func (a *ArtifactReader) ListArtifacts(ctx context.Context, testExecID test.TestExecutionID, caseExecID *test.CaseExecutionID, filter test.PageFilter[uuid.V7]) (test.ArtifactList, error) {
+	params := sqlc.ListArtifactsParams{
+		TestExecutionID: testExecID,
+		PageSize:        int64(filter.Size),
+	}
+	if caseExecID != nil {
+		params.CaseExecutionID = ptr.Get(int64(*caseExecID))
+	}
+	if filter.OffsetID != nil {
+		params.OffsetID = ptr.Get(filter.OffsetID.String())
+	}
+
+	artifacts, err := a.db.ListArtifacts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return marshalArtifacts(artifacts), nil
+}
+
+func (a *ArtifactReader) ListPendingBlobDeletions(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+	return a.db.ListPendingBlobDeletions(ctx, sqlc.ListPendingBlobDeletionsParams{
+		OffsetKey: filter.OffsetID,
+		PageSize:  int64(filter.Size),
+	})
+}
+
+type ArtifactWriter struct {
+	db *DB
+}
+
+func NewArtifactWriter(db *DB) *ArtifactWriter {
+	return &ArtifactWriter{db: db}
+}
+
+func (a *ArtifactWriter) CreateArtifact(ctx context.Context, artifact *test.Artifact) error {
+	return a.db.CreateArtifact(ctx, sqlc.CreateArtifactParams{
+		ID:              artifact.ID,
+		TestExecutionID: artifact.TestExecutionID,
+		CaseExecutionID: artifact.CaseExecutionID,
+		Name:            artifact.Name,
+		ContentType:     artifact.ContentType,
+		Size:            artifact.Size,
+		Checksum:        artifact.Checksum,
+		CreateTime:      artifact.CreateTime.UTC(),
+	})
+}
+
+func (a *ArtifactWriter) DeletePendingBlobDeletion(ctx context.Context, blobKey string) error {
+	return a.db.DeletePendingBlobDeletion(ctx, blobKey)
+}
@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"github.com/annexsh/annex/auth"
+)
+
+type authRepository struct {
+	*APIKeyReader
+	*APIKeyWriter
+	*RoleBindingReader
+	*RoleBindingWriter
+}
+
+func NewAuthRepository(db *DB) auth.Repository {
+	return &authRepository{
+		APIKeyReader:      NewAPIKeyReader(db),
+		APIKeyWriter:      NewAPIKeyWriter(db),
+		RoleBindingReader: NewRoleBindingReader(db),
+		RoleBindingWriter: NewRoleBindingWriter(db),
+	}
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/blob"
+)
+
+func TestBlobStore(t *testing.T) {
+	ctx := context.Background()
+	db, closer := newTestDB(t)
+	defer closer()
+	s := NewBlobStore(db)
+
+	err := s.Put(ctx, "foo", strings.NewReader("bar"))
+	require.NoError(t, err)
+
+	err = s.Put(ctx, "foo", strings.NewReader("baz"))
+	require.NoError(t, err)
+
+	rc, err := s.Get(ctx, "foo")
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "baz", string(got))
+
+	err = s.Delete(ctx, "foo")
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, "foo")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+
+	err = s.Delete(ctx, "foo")
+	assert.NoError(t, err)
+}
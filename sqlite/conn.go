@@ -1,14 +1,19 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
+	"time"
 
 	_ "modernc.org/sqlite"
 
 	"github.com/annexsh/annex/sqlite/migrations"
 )
 
+const defaultBusyTimeout = 5 * time.Second
+
 type OpenOption func(opts *openOptions)
 
 func WithMigration() OpenOption {
@@ -17,30 +22,88 @@ func WithMigration() OpenOption {
 	}
 }
 
+// WithSchemaCheck fails to open the database if its schema is newer than the
+// latest migration, or a migration failed, without migrating. It's implied by
+// WithMigration.
+func WithSchemaCheck() OpenOption {
+	return func(opts *openOptions) {
+		opts.checkSchema = true
+	}
+}
+
+// WithPath persists the database to the file at the path. The database is
+// opened in WAL mode so that readers do not block the writer. An in-memory
+// database is used by default.
+func WithPath(path string) OpenOption {
+	return func(opts *openOptions) {
+		opts.path = path
+	}
+}
+
+// WithBusyTimeout sets how long a connection waits for a lock held by
+// another connection before failing with a busy error. Defaults to 5 seconds.
+func WithBusyTimeout(timeout time.Duration) OpenOption {
+	return func(opts *openOptions) {
+		opts.busyTimeout = timeout
+	}
+}
+
 type openOptions struct {
-	migrateUp bool
+	migrateUp   bool
+	checkSchema bool
+	path        string
+	busyTimeout time.Duration
 }
 
 func Open(opts ...OpenOption) (*sql.DB, error) {
-	var options openOptions
+	options := openOptions{
+		busyTimeout: defaultBusyTimeout,
+	}
 	for _, opt := range opts {
 		opt(&options)
 	}
 
-	db, err := sql.Open("sqlite", "file::memory:?cache=shared")
+	db, err := sql.Open("sqlite", dataSourceName(options))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open sqlite database connection: %w", err)
 	}
-	if _, err = db.Exec("PRAGMA foreign_keys = on;"); err != nil {
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to sqlite database: %w", err)
 	}
 
 	if options.migrateUp {
-		if err = migrations.MigrateDatabase(db); err != nil {
+		if err = migrations.MigrateDatabase(context.Background(), db); err != nil {
 			db.Close()
 			return nil, fmt.Errorf("failed to migrate database: %w", err)
 		}
+	} else if options.checkSchema {
+		if err = migrations.CheckDatabase(context.Background(), db); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to check database schema: %w", err)
+		}
 	}
 
 	return db, nil
 }
+
+// dataSourceName builds the connection string for the database. Pragmas are
+// set in the connection string so that they apply to every pooled connection.
+func dataSourceName(opts openOptions) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", opts.busyTimeout.Milliseconds()))
+	// Acquire the write lock when a transaction begins rather than on its
+	// first write so that concurrent transactions wait on the busy timeout
+	// instead of failing to upgrade their lock.
+	query.Set("_txlock", "immediate")
+
+	if opts.path == "" {
+		query.Set("cache", "shared")
+		return "file::memory:?" + query.Encode()
+	}
+
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_pragma", "synchronous(NORMAL)")
+	return "file:" + opts.path + "?" + query.Encode()
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/sqlite/sqlc"
+	"github.com/annexsh/annex/test"
+)
+
+func TestOpen_path(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "annex.db")
+
+	sqldb, err := Open(WithPath(path), WithMigration())
+	require.NoError(t, err)
+
+	var journalMode string
+	err = sqldb.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode)
+	require.NoError(t, err)
+	assert.Equal(t, "wal", journalMode)
+
+	var foreignKeys bool
+	err = sqldb.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys)
+	require.NoError(t, err)
+	assert.True(t, foreignKeys)
+
+	err = NewDB(sqldb).CreateContext(ctx, "foo")
+	require.NoError(t, err)
+	require.NoError(t, sqldb.Close())
+
+	// Data is persisted after the database is reopened
+	sqldb, err = Open(WithPath(path), WithMigration())
+	require.NoError(t, err)
+	defer sqldb.Close()
+
+	got, err := NewDB(sqldb).ListContexts(ctx, sqlc.ListContextsParams{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo"}, got)
+}
+
+func TestTestRepository_ExecuteTx_concurrent(t *testing.T) {
+	ctx := context.Background()
+
+	sqldb, err := Open(WithPath(filepath.Join(t.TempDir(), "annex.db")), WithMigration())
+	require.NoError(t, err)
+	defer sqldb.Close()
+	repo := NewTestRepository(NewDB(sqldb))
+
+	dummyTestExec := createDummyTestExec(ctx, t, NewDB(sqldb))
+
+	numTxs := 20
+	var wg sync.WaitGroup
+
+	for range numTxs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.ExecuteTx(ctx, func(repo test.Repository) error {
+				_, err := repo.NextExecutionEventSequence(ctx, dummyTestExec.ID)
+				return err
+			})
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	got, err := repo.NextExecutionEventSequence(ctx, dummyTestExec.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(numTxs+1), got)
+}
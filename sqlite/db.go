@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"sync"
 
 	"github.com/annexsh/annex/sqlite/sqlc"
 )
@@ -14,28 +15,66 @@ type DBTX interface {
 
 type DB struct {
 	*sqlc.Queries
+	// dbtx executes statements that sqlc can't generate for SQLite, such as
+	// multi-row inserts.
+	dbtx    sqlc.DBTX
 	beginTx func(ctx context.Context, txOptions *sql.TxOptions) (*sql.Tx, error)
+	// txSem serialises transactions since SQLite only supports a single
+	// writer at a time.
+	txSem chan struct{}
 }
 
 func NewDB(dbtx DBTX) *DB {
+	traced := &tracedDBTX{db: dbtx}
 	return &DB{
-		Queries: sqlc.New(dbtx),
+		Queries: sqlc.New(traced),
+		dbtx:    traced,
 		beginTx: dbtx.BeginTx,
+		txSem:   make(chan struct{}, 1),
 	}
 }
 
-func (d *DB) WithTx(ctx context.Context) (*DB, *sql.Tx, error) {
+// WithTx begins a transaction, waiting for any in-progress transaction to be
+// committed or rolled back first.
+func (d *DB) WithTx(ctx context.Context) (*DB, *Tx, error) {
+	select {
+	case d.txSem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+	release := sync.OnceFunc(func() { <-d.txSem })
+
 	tx, err := d.beginTx(ctx, &sql.TxOptions{})
 	if err != nil {
+		release()
 		return nil, nil, err
 	}
 
-	queries := d.Queries.WithTx(tx)
+	traced := &tracedDBTX{db: tx}
 
 	newDB := &DB{
-		Queries: queries,
+		Queries: sqlc.New(traced),
+		dbtx:    traced,
 		beginTx: d.beginTx,
+		txSem:   d.txSem,
 	}
 
-	return newDB, tx, nil
+	return newDB, &Tx{Tx: tx, release: release}, nil
+}
+
+// Tx is a transaction that allows the next transaction to begin once it is
+// committed or rolled back.
+type Tx struct {
+	*sql.Tx
+	release func()
+}
+
+func (t *Tx) Commit() error {
+	defer t.release()
+	return t.Tx.Commit()
+}
+
+func (t *Tx) Rollback() error {
+	defer t.release()
+	return t.Tx.Rollback()
 }
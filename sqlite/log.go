@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/sqlite/sqlc"
@@ -27,12 +29,21 @@ func (e *LogReader) GetLog(ctx context.Context, id uuid.V7) (*test.Log, error) {
 	if err != nil {
 		return nil, err
 	}
-	return marshalLog(execLog), nil
+	return marshalLog(execLog)
 }
 
-func (e *LogReader) ListLogs(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7]) (test.LogList, error) {
+func (e *LogReader) ListLogs(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7], logFilter test.LogFilter) (test.LogList, error) {
+	logParams, err := newLogFilterParams(logFilter)
+	if err != nil {
+		return nil, err
+	}
+
 	params := sqlc.ListLogsParams{
 		TestExecutionID: testExecID,
+		CaseExecutionID: logParams.caseExecutionID,
+		MinSeverity:     logParams.minSeverity,
+		Attributes:      logParams.attributes,
+		MessageContains: logParams.messageContains,
 		PageSize:        int64(filter.Size),
 	}
 	if filter.OffsetID != nil {
@@ -43,7 +54,7 @@ func (e *LogReader) ListLogs(ctx context.Context, testExecID test.TestExecutionI
 	if err != nil {
 		return nil, err
 	}
-	return marshalExecLogs(logs), nil
+	return marshalExecLogs(logs)
 }
 
 type LogWriter struct {
@@ -55,6 +66,10 @@ func NewLogWriter(db *DB) *LogWriter {
 }
 
 func (e *LogWriter) CreateLog(ctx context.Context, log *test.Log) error {
+	attrs, err := marshalStringMap(log.Attributes)
+	if err != nil {
+		return err
+	}
 	return e.db.CreateLog(ctx, sqlc.CreateLogParams{
 		ID:              log.ID,
 		TestExecutionID: log.TestExecutionID,
@@ -62,9 +77,83 @@ func (e *LogWriter) CreateLog(ctx context.Context, log *test.Log) error {
 		Level:           log.Level,
 		Message:         log.Message,
 		CreateTime:      log.CreateTime.UTC(),
+		RedactionCount:  int64(log.RedactionCount),
+		Attributes:      string(attrs),
+		Severity:        int64(test.LogSeverity(log.Level)),
 	})
 }
 
+// maxLogsPerInsert limits the rows of each batched insert so that the number
+// of bound parameters stays well below the SQLite limit of 32766.
+const maxLogsPerInsert = 1000
+
+// CreateLogs creates the logs using multi-row inserts of up to
+// maxLogsPerInsert logs each.
+func (e *LogWriter) CreateLogs(ctx context.Context, logs test.LogList) error {
+	for batch := range slices.Chunk(logs, maxLogsPerInsert) {
+		var query strings.Builder
+		query.WriteString("-- name: CreateLogs :exec\nINSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes, severity)\nVALUES ")
+		args := make([]any, 0, len(batch)*9)
+
+		for i, log := range batch {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			attrs, err := marshalStringMap(log.Attributes)
+			if err != nil {
+				return err
+			}
+
+			query.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args,
+				log.ID,
+				log.TestExecutionID,
+				log.CaseExecutionID,
+				log.Level,
+				log.Message,
+				log.CreateTime.UTC(),
+				int64(log.RedactionCount),
+				string(attrs),
+				int64(test.LogSeverity(log.Level)),
+			)
+		}
+
+		if _, err := e.db.dbtx.ExecContext(ctx, query.String(), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *LogWriter) DeleteLog(ctx context.Context, id uuid.V7) error {
 	return e.db.DeleteLog(ctx, id)
 }
+
+// logFilterParams are the query parameters of a log filter. Nil parameters
+// match all logs.
+type logFilterParams struct {
+	caseExecutionID *int64
+	minSeverity     *int64
+	attributes      *string
+	messageContains *string
+}
+
+func newLogFilterParams(filter test.LogFilter) (logFilterParams, error) {
+	params := logFilterParams{
+		messageContains: filter.MessageContains,
+	}
+	if filter.CaseExecutionID != nil {
+		params.caseExecutionID = ptr.Get(int64(*filter.CaseExecutionID))
+	}
+	if filter.MinLevel != nil {
+		params.minSeverity = ptr.Get(int64(test.LogSeverity(*filter.MinLevel)))
+	}
+	if len(filter.Attributes) > 0 {
+		attrs, err := marshalStringMap(filter.Attributes)
+		if err != nil {
+			return logFilterParams{}, err
+		}
+		params.attributes = ptr.Get(string(attrs))
+	}
+	return params, nil
+}
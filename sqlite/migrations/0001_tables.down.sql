@@ -0,0 +1,9 @@
+DROP TABLE logs;
+DROP TABLE case_executions;
+DROP TABLE test_execution_inputs;
+DROP TABLE test_executions;
+DROP TABLE test_default_inputs;
+DROP TABLE tests;
+DROP TABLE test_suite_registrations;
+DROP TABLE test_suites;
+DROP TABLE contexts;
@@ -0,0 +1,21 @@
+CREATE TABLE execution_event_sequences
+(
+    test_execution_id TEXT    NOT NULL PRIMARY KEY,
+    sequence          INTEGER NOT NULL,
+    FOREIGN KEY (test_execution_id) REFERENCES test_executions (id)
+);
+
+CREATE TABLE execution_events
+(
+    test_execution_id TEXT     NOT NULL,
+    sequence          INTEGER  NOT NULL,
+    type              TEXT     NOT NULL,
+    case_execution_id INTEGER,
+    log_id            TEXT,
+    data              BLOB     NOT NULL,
+    create_time       DATETIME NOT NULL,
+    PRIMARY KEY (test_execution_id, sequence),
+    FOREIGN KEY (test_execution_id) REFERENCES test_executions (id),
+    FOREIGN KEY (case_execution_id, test_execution_id) REFERENCES case_executions (id, test_execution_id) ON DELETE CASCADE,
+    FOREIGN KEY (log_id) REFERENCES logs (id) ON DELETE CASCADE
+);
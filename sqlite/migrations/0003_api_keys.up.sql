@@ -0,0 +1,8 @@
+CREATE TABLE api_keys
+(
+    id          TEXT     NOT NULL PRIMARY KEY,
+    name        TEXT     NOT NULL,
+    hash        BLOB     NOT NULL UNIQUE,
+    create_time DATETIME NOT NULL,
+    revoke_time DATETIME
+);
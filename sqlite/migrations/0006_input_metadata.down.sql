@@ -0,0 +1,5 @@
+ALTER TABLE test_execution_inputs
+    DROP COLUMN metadata;
+
+ALTER TABLE test_default_inputs
+    DROP COLUMN metadata;
@@ -0,0 +1,5 @@
+ALTER TABLE test_default_inputs
+    ADD COLUMN metadata BLOB;
+
+ALTER TABLE test_execution_inputs
+    ADD COLUMN metadata BLOB;
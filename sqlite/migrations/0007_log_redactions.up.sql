@@ -0,0 +1,2 @@
+ALTER TABLE logs
+    ADD COLUMN redaction_count INTEGER NOT NULL DEFAULT 0;
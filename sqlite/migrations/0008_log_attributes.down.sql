@@ -0,0 +1,5 @@
+ALTER TABLE logs
+    DROP COLUMN severity;
+
+ALTER TABLE logs
+    DROP COLUMN attributes;
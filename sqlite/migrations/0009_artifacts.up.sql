@@ -0,0 +1,21 @@
+CREATE TABLE artifacts
+(
+    id                TEXT PRIMARY KEY,
+    test_execution_id TEXT     NOT NULL,
+    case_execution_id INTEGER,
+    name              TEXT     NOT NULL,
+    content_type      TEXT     NOT NULL,
+    size              INTEGER  NOT NULL,
+    checksum          TEXT     NOT NULL,
+    create_time       DATETIME NOT NULL,
+    FOREIGN KEY (test_execution_id) REFERENCES test_executions (id)
+);
+
+CREATE INDEX artifacts_test_execution_id_idx ON artifacts (test_execution_id, id);
+
+CREATE TABLE blobs
+(
+    key         TEXT PRIMARY KEY,
+    data        BLOB     NOT NULL,
+    create_time DATETIME NOT NULL
+);
@@ -0,0 +1,17 @@
+ALTER TABLE test_executions
+    DROP COLUMN labels;
+
+ALTER TABLE test_executions
+    DROP COLUMN environment;
+
+ALTER TABLE test_executions
+    DROP COLUMN ci_job_url;
+
+ALTER TABLE test_executions
+    DROP COLUMN git_branch;
+
+ALTER TABLE test_executions
+    DROP COLUMN git_sha;
+
+ALTER TABLE test_executions
+    DROP COLUMN triggered_by;
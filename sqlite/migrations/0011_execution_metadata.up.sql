@@ -0,0 +1,17 @@
+ALTER TABLE test_executions
+    ADD COLUMN triggered_by TEXT;
+
+ALTER TABLE test_executions
+    ADD COLUMN git_sha TEXT;
+
+ALTER TABLE test_executions
+    ADD COLUMN git_branch TEXT;
+
+ALTER TABLE test_executions
+    ADD COLUMN ci_job_url TEXT;
+
+ALTER TABLE test_executions
+    ADD COLUMN environment TEXT;
+
+ALTER TABLE test_executions
+    ADD COLUMN labels TEXT NOT NULL DEFAULT '{}';
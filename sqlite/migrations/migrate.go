@@ -1,39 +1,57 @@
 package migrations
 
 import (
+	"context"
 	"database/sql"
 	"embed"
-	"errors"
 
-	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/annexsh/annex/internal/migration"
 )
 
 //go:embed *.sql
 var fs embed.FS
 
-func MigrateDatabase(db *sql.DB) error {
-	sd, err := iofs.New(fs, ".")
+// NewMigrator returns a Migrator of the database. Closing the Migrator
+// doesn't close the database.
+func NewMigrator(db *sql.DB) (*migration.Migrator, error) {
+	driver, err := sqlite.WithInstance(db, new(sqlite.Config))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer sd.Close()
+	return migration.New(fs, "sqlite", unclosableDriver{driver})
+}
 
-	driver, err := sqlite.WithInstance(db, new(sqlite.Config))
+// MigrateDatabase applies all pending migrations.
+func MigrateDatabase(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
 	if err != nil {
 		return err
 	}
+	defer m.Close()
+	return m.Up(ctx)
+}
 
-	m, err := migrate.NewWithInstance("iofs", sd, "sqlite", driver)
+// CheckDatabase returns an error if the schema of the database is newer than
+// the latest migration or dirty.
+func CheckDatabase(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
 	if err != nil {
 		return err
 	}
+	defer m.Close()
+	return m.Check(ctx)
+}
 
-	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return err
-	}
+// unclosableDriver prevents the sqlite driver from closing the database it
+// was created with, which is owned by the caller.
+type unclosableDriver struct {
+	database.Driver
+}
 
+func (unclosableDriver) Close() error {
 	return nil
 }
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/annexsh/annex/internal/migration"
+)
+
+func TestMigrator_upDown(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	m, err := NewMigrator(db)
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.NoError(t, m.Up(ctx))
+	status, err := m.Status()
+	require.NoError(t, err)
+	assert.Equal(t, status.Latest, status.Version)
+	assert.False(t, status.Pending())
+
+	require.NoError(t, m.Down(ctx, 1))
+	status, err = m.Status()
+	require.NoError(t, err)
+	assert.True(t, status.Pending())
+
+	err = m.Down(ctx, int(status.Latest))
+	require.ErrorContains(t, err, "can't revert")
+
+	// Reverting every migration leaves only the migrations table
+	require.NoError(t, m.Down(ctx, 0))
+	var tables []string
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		tables = append(tables, name)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"schema_migrations"}, tables)
+
+	require.NoError(t, m.Up(ctx))
+}
+
+func TestMigrator_newerSchema(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	require.NoError(t, MigrateDatabase(ctx, db))
+
+	// Simulate a migration applied by a newer version
+	_, err := db.ExecContext(ctx, "UPDATE schema_migrations SET version = version + 1")
+	require.NoError(t, err)
+
+	err = MigrateDatabase(ctx, db)
+	assert.ErrorIs(t, err, migration.ErrNewerSchema)
+	err = CheckDatabase(ctx, db)
+	assert.ErrorIs(t, err, migration.ErrNewerSchema)
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "annex.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
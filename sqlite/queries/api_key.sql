@@ -0,0 +1,22 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, name, hash, create_time)
+VALUES (?, ?, ?, ?);
+
+-- name: GetAPIKeyByHash :one
+SELECT *
+FROM api_keys
+WHERE hash = ?;
+
+-- name: ListAPIKeys :many
+SELECT *
+FROM api_keys
+-- Cast as text required below since sqlc.narg doesn't work with overridden column type
+WHERE (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR id < CAST(sqlc.narg('offset_id') AS TEXT))
+ORDER BY id DESC
+LIMIT @page_size;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoke_time = ?
+WHERE id = ?
+  AND revoke_time IS NULL;
@@ -0,0 +1,41 @@
+-- name: CreateArtifact :exec
+INSERT INTO artifacts (id, test_execution_id, case_execution_id, name, content_type, size, checksum, create_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetArtifact :one
+SELECT *
+FROM artifacts
+WHERE id = ?;
+
+-- name: ListArtifacts :many
+SELECT *
+FROM artifacts
+WHERE (test_execution_id = @test_execution_id)
+  -- Cast as text required below since sqlc.narg doesn't work with overridden column type
+  AND (CAST(sqlc.narg('case_execution_id') AS INTEGER) IS NULL OR case_execution_id = CAST(sqlc.narg('case_execution_id') AS INTEGER))
+  AND (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR id > CAST(sqlc.narg('offset_id') AS TEXT))
+ORDER BY id
+LIMIT @page_size;
+
+-- name: DeleteArtifacts :many
+DELETE
+FROM artifacts
+WHERE test_execution_id = ?
+RETURNING *;
+
+-- name: CreatePendingBlobDeletion :exec
+INSERT INTO pending_blob_deletions (blob_key, create_time)
+VALUES (?, ?)
+ON CONFLICT (blob_key) DO NOTHING;
+
+-- name: ListPendingBlobDeletions :many
+SELECT blob_key
+FROM pending_blob_deletions
+WHERE (blob_key > COALESCE(sqlc.narg('offset_key'), ''))
+ORDER BY blob_key
+LIMIT @page_size;
+
+-- name: DeletePendingBlobDeletion :exec
+DELETE
+FROM pending_blob_deletions
+WHERE blob_key = ?;
@@ -0,0 +1,18 @@
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_entries (id, context_id, action, subject, auth_method, test_suite_id, test_id, test_execution_id,
+                           summary, create_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListAuditEntries :many
+SELECT *
+FROM audit_entries
+WHERE (context_id = @context_id)
+  -- Cast as text required below since sqlc.narg doesn't work with overridden column type
+  AND (CAST(sqlc.narg('subject') AS TEXT) IS NULL OR subject = CAST(sqlc.narg('subject') AS TEXT))
+  AND (CAST(sqlc.narg('action') AS TEXT) IS NULL OR action = CAST(sqlc.narg('action') AS TEXT))
+  AND (CAST(sqlc.narg('test_id') AS TEXT) IS NULL OR test_id = CAST(sqlc.narg('test_id') AS TEXT))
+  AND (CAST(sqlc.narg('test_execution_id') AS TEXT) IS NULL OR
+       test_execution_id = CAST(sqlc.narg('test_execution_id') AS TEXT))
+  AND (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR id < CAST(sqlc.narg('offset_id') AS TEXT))
+ORDER BY id DESC
+LIMIT @page_size;
@@ -44,3 +44,8 @@ WHERE (test_execution_id = @test_execution_id)
   AND (CAST(sqlc.narg('offset_id') AS INTEGER) IS NULL OR id > CAST(sqlc.narg('offset_id') AS INTEGER))
 ORDER BY id
 LIMIT @page_size;
+
+-- name: DeleteCaseExecutions :execrows
+DELETE
+FROM case_executions
+WHERE test_execution_id = ?;
@@ -0,0 +1,51 @@
+-- name: NextExecutionEventSequence :one
+INSERT INTO execution_event_sequences (test_execution_id, sequence)
+VALUES (?, 1)
+ON CONFLICT (test_execution_id) DO UPDATE
+    SET sequence = execution_event_sequences.sequence + 1
+RETURNING sequence;
+
+-- name: CreateExecutionEvent :exec
+INSERT INTO execution_events (test_execution_id, sequence, type, case_execution_id, log_id, data, create_time)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListExecutionEvents :many
+SELECT execution_events.*
+FROM execution_events
+         LEFT JOIN logs ON logs.id = execution_events.log_id
+WHERE execution_events.test_execution_id = @test_execution_id
+  AND (CAST(sqlc.narg('offset_sequence') AS INTEGER) IS NULL OR sequence > CAST(sqlc.narg('offset_sequence') AS INTEGER))
+  -- Log filters only apply to log events
+  AND (execution_events.log_id IS NULL OR (
+    (CAST(sqlc.narg('case_execution_id') AS INTEGER) IS NULL OR logs.case_execution_id = CAST(sqlc.narg('case_execution_id') AS INTEGER))
+        AND (CAST(sqlc.narg('min_severity') AS INTEGER) IS NULL OR logs.severity >= CAST(sqlc.narg('min_severity') AS INTEGER))
+        AND (CAST(sqlc.narg('attributes') AS TEXT) IS NULL OR NOT EXISTS (
+            SELECT 1
+            FROM json_each(CAST(sqlc.narg('attributes') AS TEXT)) AS filter
+            WHERE NOT EXISTS (SELECT 1 FROM json_each(logs.attributes) AS attr WHERE attr.key = filter.key AND attr.value = filter.value)
+        ))
+        AND (CAST(sqlc.narg('message_contains') AS TEXT) IS NULL OR instr(logs.message, CAST(sqlc.narg('message_contains') AS TEXT)) > 0)
+    ))
+ORDER BY sequence
+LIMIT @page_size;
+
+-- name: GetExecutionEventSequence :one
+SELECT sequence
+FROM execution_event_sequences
+WHERE test_execution_id = ?;
+
+-- name: DeleteExecutionEvents :exec
+DELETE
+FROM execution_events
+WHERE test_execution_id = ?
+  AND type = ?;
+
+-- name: DeleteTestExecutionEvents :execrows
+DELETE
+FROM execution_events
+WHERE test_execution_id = ?;
+
+-- name: DeleteExecutionEventSequence :exec
+DELETE
+FROM execution_event_sequences
+WHERE test_execution_id = ?;
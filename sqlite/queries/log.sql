@@ -1,6 +1,7 @@
 -- name: CreateLog :exec
-INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time)
-VALUES (?, ?, ?, ?, ?, ?);
+INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes,
+                  severity)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetLog :one
 SELECT *
@@ -13,9 +14,24 @@ FROM logs
 WHERE (test_execution_id = @test_execution_id)
   -- Cast as text required below since sqlc.narg doesn't work with overridden column type
   AND (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR id < CAST(sqlc.narg('offset_id') AS TEXT))
+  AND (CAST(sqlc.narg('case_execution_id') AS INTEGER) IS NULL OR case_execution_id = CAST(sqlc.narg('case_execution_id') AS INTEGER))
+  AND (CAST(sqlc.narg('min_severity') AS INTEGER) IS NULL OR severity >= CAST(sqlc.narg('min_severity') AS INTEGER))
+  -- Matches if no filter attribute is missing from the log attributes
+  AND (CAST(sqlc.narg('attributes') AS TEXT) IS NULL OR NOT EXISTS (
+    SELECT 1
+    FROM json_each(CAST(sqlc.narg('attributes') AS TEXT)) AS filter
+    WHERE NOT EXISTS (SELECT 1 FROM json_each(logs.attributes) AS attr WHERE attr.key = filter.key AND attr.value = filter.value)
+  ))
+  AND (CAST(sqlc.narg('message_contains') AS TEXT) IS NULL OR instr(message, CAST(sqlc.narg('message_contains') AS TEXT)) > 0)
+ORDER BY id DESC
 LIMIT @page_size;
 
 -- name: DeleteLog :exec
 DELETE
 FROM logs
 WHERE id = ?;
+
+-- name: DeleteLogs :execrows
+DELETE
+FROM logs
+WHERE test_execution_id = ?;
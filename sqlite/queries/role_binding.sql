@@ -0,0 +1,28 @@
+-- name: CreateRoleBinding :one
+INSERT INTO role_bindings (id, context_id, subject, role, create_time)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (context_id, subject, role) DO UPDATE -- no-op update to return the existing row
+    SET create_time = role_bindings.create_time
+RETURNING *;
+
+-- name: ListRoleBindings :many
+SELECT *
+FROM role_bindings
+WHERE (context_id = @context_id)
+  -- Cast as text required below since sqlc.narg doesn't work with overridden column type
+  AND (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR id < CAST(sqlc.narg('offset_id') AS TEXT))
+ORDER BY id DESC
+LIMIT @page_size;
+
+-- name: ListSubjectRoles :many
+SELECT DISTINCT role
+FROM role_bindings
+WHERE subject = ?
+  AND (context_id = ? OR context_id = '*');
+
+-- name: DeleteRoleBinding :execrows
+DELETE
+FROM role_bindings
+WHERE context_id = ?
+  AND subject = ?
+  AND role = ?;
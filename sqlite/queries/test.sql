@@ -31,10 +31,11 @@ FROM tests
 WHERE id = ?;
 
 -- name: CreateTestDefaultInput :exec
-INSERT INTO test_default_inputs (test_id, data)
-VALUES (?, ?)
+INSERT INTO test_default_inputs (test_id, data, metadata)
+VALUES (?, ?, ?)
 ON CONFLICT(test_id) DO UPDATE
-    SET data = excluded.data;
+    SET data     = excluded.data,
+        metadata = excluded.metadata;
 
 -- name: GetTestDefaultInput :one
 SELECT *
@@ -1,20 +1,28 @@
 -- name: CreateTestExecutionScheduled :one
-INSERT INTO test_executions (id, test_id, has_input, schedule_time)
-VALUES (?, ?, ?, ?)
+INSERT INTO test_executions (id, test_id, has_input, schedule_time, triggered_by, git_sha, git_branch, ci_job_url,
+                             environment, labels)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE
     SET test_id       = excluded.test_id,
         has_input     = excluded.has_input,
         schedule_time = excluded.schedule_time,
         start_time    = NULL,
         finish_time   = NULL,
-        error         = NULL
+        error         = NULL,
+        triggered_by  = excluded.triggered_by,
+        git_sha       = excluded.git_sha,
+        git_branch    = excluded.git_branch,
+        ci_job_url    = excluded.ci_job_url,
+        environment   = excluded.environment,
+        labels        = excluded.labels
 RETURNING *;
 
 -- name: CreateTestExecutionInput :exec
-INSERT INTO test_execution_inputs (test_execution_id, data)
-VALUES (?, ?)
+INSERT INTO test_execution_inputs (test_execution_id, data, metadata)
+VALUES (?, ?, ?)
 ON CONFLICT(test_execution_id) DO UPDATE
-    SET data = excluded.data;
+    SET data     = excluded.data,
+        metadata = excluded.metadata;
 
 -- name: GetTestExecutionInput :one
 SELECT *
@@ -27,14 +35,18 @@ SET start_time  = ?,
     finish_time = NULL,
     error       = NULL
 WHERE id = ?
-RETURNING *;
+RETURNING *,
+    (SELECT context_id FROM tests WHERE tests.id = test_executions.test_id) AS context_id,
+    (SELECT test_suite_id FROM tests WHERE tests.id = test_executions.test_id) AS test_suite_id;
 
 -- name: UpdateTestExecutionFinished :one
 UPDATE test_executions
 SET finish_time = ?,
     error       = ?
 WHERE id = ?
-RETURNING *;
+RETURNING *,
+    (SELECT context_id FROM tests WHERE tests.id = test_executions.test_id) AS context_id,
+    (SELECT test_suite_id FROM tests WHERE tests.id = test_executions.test_id) AS test_suite_id;
 
 -- name: ResetTestExecution :one
 UPDATE test_executions
@@ -50,6 +62,12 @@ SELECT *
 FROM test_executions
 WHERE id = ?;
 
+-- name: GetTestExecutionContext :one
+SELECT t.context_id
+FROM test_executions te
+         JOIN tests t ON t.id = te.test_id
+WHERE te.id = ?;
+
 -- name: ListTestExecutions :many
 SELECT *
 FROM test_executions
@@ -58,3 +76,55 @@ WHERE (test_id = @test_id)
   AND (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR id < CAST(sqlc.narg('offset_id') AS TEXT))
 ORDER BY id DESC
 LIMIT @page_size;
+
+-- name: SearchTestExecutions :many
+SELECT te.*
+FROM test_executions te
+         JOIN tests t ON t.id = te.test_id
+WHERE t.context_id = @context_id
+  -- Cast as text required below since sqlc.narg doesn't work with overridden column type
+  AND (CAST(sqlc.narg('test_id') AS TEXT) IS NULL OR te.test_id = CAST(sqlc.narg('test_id') AS TEXT))
+  AND (CAST(sqlc.narg('offset_id') AS TEXT) IS NULL OR te.id < CAST(sqlc.narg('offset_id') AS TEXT))
+  AND (CAST(sqlc.narg('triggered_by') AS TEXT) IS NULL OR te.triggered_by = CAST(sqlc.narg('triggered_by') AS TEXT))
+  AND (CAST(sqlc.narg('git_sha') AS TEXT) IS NULL OR te.git_sha = CAST(sqlc.narg('git_sha') AS TEXT))
+  AND (CAST(sqlc.narg('git_branch') AS TEXT) IS NULL OR te.git_branch = CAST(sqlc.narg('git_branch') AS TEXT))
+  AND (CAST(sqlc.narg('ci_job_url') AS TEXT) IS NULL OR te.ci_job_url = CAST(sqlc.narg('ci_job_url') AS TEXT))
+  AND (CAST(sqlc.narg('environment') AS TEXT) IS NULL OR te.environment = CAST(sqlc.narg('environment') AS TEXT))
+  -- Matches if no filter label is missing from the execution labels
+  AND (CAST(sqlc.narg('labels') AS TEXT) IS NULL OR NOT EXISTS (
+    SELECT 1
+    FROM json_each(CAST(sqlc.narg('labels') AS TEXT)) AS filter
+    WHERE NOT EXISTS (SELECT 1 FROM json_each(te.labels) AS label WHERE label.key = filter.key AND label.value = filter.value)
+  ))
+ORDER BY te.id DESC
+LIMIT @page_size;
+
+-- name: ListExpiredTestExecutions :many
+SELECT id
+FROM (SELECT te.id,
+             te.finish_time,
+             te.error,
+             ROW_NUMBER() OVER (PARTITION BY te.test_id ORDER BY te.id DESC) AS position
+      FROM test_executions te
+               JOIN tests t ON t.id = te.test_id
+      WHERE t.context_id = @context_id) AS ranked
+WHERE finish_time IS NOT NULL
+  AND CASE
+          -- Failed executions are kept until they expire by the failed execution cutoff when set
+          WHEN error IS NOT NULL AND sqlc.narg('failed_finished_before') IS NOT NULL
+              THEN finish_time < sqlc.narg('failed_finished_before')
+          ELSE finish_time < sqlc.narg('finished_before')
+              OR (CAST(@max_per_test AS INTEGER) > 0 AND position > CAST(@max_per_test AS INTEGER))
+    END
+ORDER BY id
+LIMIT @page_size;
+
+-- name: DeleteTestExecutionInput :exec
+DELETE
+FROM test_execution_inputs
+WHERE test_execution_id = ?;
+
+-- name: DeleteTestExecution :execrows
+DELETE
+FROM test_executions
+WHERE id = ?;
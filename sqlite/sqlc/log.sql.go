@@ -14,8 +14,9 @@ import (
 )
 
 const createLog = `-- name: CreateLog :exec
-INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time)
-VALUES (?, ?, ?, ?, ?, ?)
+INSERT INTO logs (id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes,
+                  severity)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateLogParams struct {
@@ -25,6 +26,9 @@ type CreateLogParams struct {
 	Level           string                `json:"level"`
 	Message         string                `json:"message"`
 	CreateTime      time.Time             `json:"create_time"`
+	RedactionCount  int64                 `json:"redaction_count"`
+	Attributes      string                `json:"attributes"`
+	Severity        int64                 `json:"severity"`
 }
 
 func (q *Queries) CreateLog(ctx context.Context, arg CreateLogParams) error {
@@ -35,6 +39,9 @@ func (q *Queries) CreateLog(ctx context.Context, arg CreateLogParams) error {
 		arg.Level,
 		arg.Message,
 		arg.CreateTime,
+		arg.RedactionCount,
+		arg.Attributes,
+		arg.Severity,
 	)
 	return err
 }
@@ -50,8 +57,22 @@ func (q *Queries) DeleteLog(ctx context.Context, id uuid.V7) error {
 	return err
 }
 
+const deleteLogs = `-- name: DeleteLogs :execrows
+DELETE
+FROM logs
+WHERE test_execution_id = ?
+`
+
+func (q *Queries) DeleteLogs(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteLogs, testExecutionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getLog = `-- name: GetLog :one
-SELECT id, test_execution_id, case_execution_id, level, message, create_time
+SELECT id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes, severity
 FROM logs
 WHERE id = ?
 `
@@ -66,27 +87,52 @@ func (q *Queries) GetLog(ctx context.Context, id uuid.V7) (*Log, error) {
 		&i.Level,
 		&i.Message,
 		&i.CreateTime,
+		&i.RedactionCount,
+		&i.Attributes,
+		&i.Severity,
 	)
 	return &i, err
 }
 
 const listLogs = `-- name: ListLogs :many
-SELECT id, test_execution_id, case_execution_id, level, message, create_time
+SELECT id, test_execution_id, case_execution_id, level, message, create_time, redaction_count, attributes, severity
 FROM logs
 WHERE (test_execution_id = ?1)
   -- Cast as text required below since sqlc.narg doesn't work with overridden column type
   AND (CAST(?2 AS TEXT) IS NULL OR id < CAST(?2 AS TEXT))
-LIMIT ?3
+  AND (CAST(?3 AS INTEGER) IS NULL OR case_execution_id = CAST(?3 AS INTEGER))
+  AND (CAST(?4 AS INTEGER) IS NULL OR severity >= CAST(?4 AS INTEGER))
+  -- Matches if no filter attribute is missing from the log attributes
+  AND (CAST(?5 AS TEXT) IS NULL OR NOT EXISTS (
+    SELECT 1
+    FROM json_each(CAST(?5 AS TEXT)) AS filter
+    WHERE NOT EXISTS (SELECT 1 FROM json_each(logs.attributes) AS attr WHERE attr.key = filter.key AND attr.value = filter.value)
+  ))
+  AND (CAST(?6 AS TEXT) IS NULL OR instr(message, CAST(?6 AS TEXT)) > 0)
+ORDER BY id DESC
+LIMIT ?7
 `
 
 type ListLogsParams struct {
 	TestExecutionID test.TestExecutionID `json:"test_execution_id"`
 	OffsetID        *string              `json:"offset_id"`
+	CaseExecutionID *int64               `json:"case_execution_id"`
+	MinSeverity     *int64               `json:"min_severity"`
+	Attributes      *string              `json:"attributes"`
+	MessageContains *string              `json:"message_contains"`
 	PageSize        int64                `json:"page_size"`
 }
 
 func (q *Queries) ListLogs(ctx context.Context, arg ListLogsParams) ([]*Log, error) {
-	rows, err := q.db.QueryContext(ctx, listLogs, arg.TestExecutionID, arg.OffsetID, arg.PageSize)
+	rows, err := q.db.QueryContext(ctx, listLogs,
+		arg.TestExecutionID,
+		arg.OffsetID,
+		arg.CaseExecutionID,
+		arg.MinSeverity,
+		arg.Attributes,
+		arg.MessageContains,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +147,9 @@ func (q *Queries) ListLogs(ctx context.Context, arg ListLogsParams) ([]*Log, err
 			&i.Level,
 			&i.Message,
 			&i.CreateTime,
+			&i.RedactionCount,
+			&i.Attributes,
+			&i.Severity,
 		); err != nil {
 			return nil, err
 		}
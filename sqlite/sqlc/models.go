@@ -11,6 +11,44 @@ import (
 	"github.com/annexsh/annex/uuid"
 )
 
+type ApiKey struct {
+	ID         uuid.V7    `json:"id"`
+	Name       string     `json:"name"`
+	Hash       []byte     `json:"hash"`
+	CreateTime time.Time  `json:"create_time"`
+	RevokeTime *time.Time `json:"revoke_time"`
+}
+
+type Artifact struct {
+	ID              uuid.V7               `json:"id"`
+	TestExecutionID test.TestExecutionID  `json:"test_execution_id"`
+	CaseExecutionID *test.CaseExecutionID `json:"case_execution_id"`
+	Name            string                `json:"name"`
+	ContentType     string                `json:"content_type"`
+	Size            int64                 `json:"size"`
+	Checksum        string                `json:"checksum"`
+	CreateTime      time.Time             `json:"create_time"`
+}
+
+type AuditEntry struct {
+	ID              uuid.V7               `json:"id"`
+	ContextID       string                `json:"context_id"`
+	Action          string                `json:"action"`
+	Subject         string                `json:"subject"`
+	AuthMethod      string                `json:"auth_method"`
+	TestSuiteID     *uuid.V7              `json:"test_suite_id"`
+	TestID          *uuid.V7              `json:"test_id"`
+	TestExecutionID *test.TestExecutionID `json:"test_execution_id"`
+	Summary         string                `json:"summary"`
+	CreateTime      time.Time             `json:"create_time"`
+}
+
+type Blob struct {
+	Key        string    `json:"key"`
+	Data       []byte    `json:"data"`
+	CreateTime time.Time `json:"create_time"`
+}
+
 type CaseExecution struct {
 	ID              test.CaseExecutionID `json:"id"`
 	TestExecutionID test.TestExecutionID `json:"test_execution_id"`
@@ -25,6 +63,21 @@ type Context struct {
 	ID string `json:"id"`
 }
 
+type ExecutionEvent struct {
+	TestExecutionID test.TestExecutionID  `json:"test_execution_id"`
+	Sequence        int64                 `json:"sequence"`
+	Type            string                `json:"type"`
+	CaseExecutionID *test.CaseExecutionID `json:"case_execution_id"`
+	LogID           *uuid.V7              `json:"log_id"`
+	Data            []byte                `json:"data"`
+	CreateTime      time.Time             `json:"create_time"`
+}
+
+type ExecutionEventSequence struct {
+	TestExecutionID test.TestExecutionID `json:"test_execution_id"`
+	Sequence        int64                `json:"sequence"`
+}
+
 type Log struct {
 	ID              uuid.V7               `json:"id"`
 	TestExecutionID test.TestExecutionID  `json:"test_execution_id"`
@@ -32,6 +85,22 @@ type Log struct {
 	Level           string                `json:"level"`
 	Message         string                `json:"message"`
 	CreateTime      time.Time             `json:"create_time"`
+	RedactionCount  int64                 `json:"redaction_count"`
+	Attributes      string                `json:"attributes"`
+	Severity        int64                 `json:"severity"`
+}
+
+type PendingBlobDeletion struct {
+	BlobKey    string    `json:"blob_key"`
+	CreateTime time.Time `json:"create_time"`
+}
+
+type RoleBinding struct {
+	ID         uuid.V7   `json:"id"`
+	ContextID  string    `json:"context_id"`
+	Subject    string    `json:"subject"`
+	Role       string    `json:"role"`
+	CreateTime time.Time `json:"create_time"`
 }
 
 type Test struct {
@@ -44,8 +113,9 @@ type Test struct {
 }
 
 type TestDefaultInput struct {
-	TestID string `json:"test_id"`
-	Data   []byte `json:"data"`
+	TestID   string `json:"test_id"`
+	Data     []byte `json:"data"`
+	Metadata []byte `json:"metadata"`
 }
 
 type TestExecution struct {
@@ -56,11 +126,18 @@ type TestExecution struct {
 	StartTime    *time.Time           `json:"start_time"`
 	FinishTime   *time.Time           `json:"finish_time"`
 	Error        *string              `json:"error"`
+	TriggeredBy  *string              `json:"triggered_by"`
+	GitSha       *string              `json:"git_sha"`
+	GitBranch    *string              `json:"git_branch"`
+	CiJobUrl     *string              `json:"ci_job_url"`
+	Environment  *string              `json:"environment"`
+	Labels       string               `json:"labels"`
 }
 
 type TestExecutionInput struct {
 	TestExecutionID test.TestExecutionID `json:"test_execution_id"`
 	Data            []byte               `json:"data"`
+	Metadata        []byte               `json:"metadata"`
 }
 
 type TestSuite struct {
@@ -12,37 +12,71 @@ import (
 )
 
 type Querier interface {
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	CreateArtifact(ctx context.Context, arg CreateArtifactParams) error
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error
 	CreateCaseExecutionScheduled(ctx context.Context, arg CreateCaseExecutionScheduledParams) (*CaseExecution, error)
 	CreateContext(ctx context.Context, id string) error
+	CreateExecutionEvent(ctx context.Context, arg CreateExecutionEventParams) error
 	CreateLog(ctx context.Context, arg CreateLogParams) error
+	CreatePendingBlobDeletion(ctx context.Context, arg CreatePendingBlobDeletionParams) error
+	CreateRoleBinding(ctx context.Context, arg CreateRoleBindingParams) (*RoleBinding, error)
 	CreateTest(ctx context.Context, arg CreateTestParams) (*Test, error)
 	CreateTestDefaultInput(ctx context.Context, arg CreateTestDefaultInputParams) error
 	CreateTestExecutionInput(ctx context.Context, arg CreateTestExecutionInputParams) error
 	CreateTestExecutionScheduled(ctx context.Context, arg CreateTestExecutionScheduledParams) (*TestExecution, error)
 	CreateTestSuite(ctx context.Context, arg CreateTestSuiteParams) (uuid.V7, error)
+	DeleteArtifacts(ctx context.Context, testExecutionID test.TestExecutionID) ([]*Artifact, error)
+	DeleteBlob(ctx context.Context, key string) error
 	DeleteCaseExecution(ctx context.Context, arg DeleteCaseExecutionParams) error
+	DeleteCaseExecutions(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error)
+	DeleteExecutionEventSequence(ctx context.Context, testExecutionID test.TestExecutionID) error
+	DeleteExecutionEvents(ctx context.Context, arg DeleteExecutionEventsParams) error
 	DeleteLog(ctx context.Context, id uuid.V7) error
+	DeleteLogs(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error)
+	DeletePendingBlobDeletion(ctx context.Context, blobKey string) error
+	DeleteRoleBinding(ctx context.Context, arg DeleteRoleBindingParams) (int64, error)
 	DeleteTest(ctx context.Context, id uuid.V7) error
+	DeleteTestExecution(ctx context.Context, id test.TestExecutionID) (int64, error)
+	DeleteTestExecutionEvents(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error)
+	DeleteTestExecutionInput(ctx context.Context, testExecutionID test.TestExecutionID) error
+	GetAPIKeyByHash(ctx context.Context, hash []byte) (*ApiKey, error)
+	GetArtifact(ctx context.Context, id uuid.V7) (*Artifact, error)
+	GetBlob(ctx context.Context, key string) ([]byte, error)
 	GetCaseExecution(ctx context.Context, arg GetCaseExecutionParams) (*CaseExecution, error)
+	GetExecutionEventSequence(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error)
 	GetLog(ctx context.Context, id uuid.V7) (*Log, error)
 	GetTest(ctx context.Context, id uuid.V7) (*Test, error)
 	GetTestByName(ctx context.Context, arg GetTestByNameParams) (*Test, error)
 	GetTestDefaultInput(ctx context.Context, testID string) (*TestDefaultInput, error)
 	GetTestExecution(ctx context.Context, id test.TestExecutionID) (*TestExecution, error)
+	GetTestExecutionContext(ctx context.Context, id test.TestExecutionID) (string, error)
 	GetTestExecutionInput(ctx context.Context, testExecutionID test.TestExecutionID) (*TestExecutionInput, error)
 	GetTestSuiteVersion(ctx context.Context, arg GetTestSuiteVersionParams) (string, error)
+	ListAPIKeys(ctx context.Context, arg ListAPIKeysParams) ([]*ApiKey, error)
+	ListArtifacts(ctx context.Context, arg ListArtifactsParams) ([]*Artifact, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]*AuditEntry, error)
 	ListCaseExecutions(ctx context.Context, arg ListCaseExecutionsParams) ([]*CaseExecution, error)
 	ListContexts(ctx context.Context, arg ListContextsParams) ([]string, error)
+	ListExecutionEvents(ctx context.Context, arg ListExecutionEventsParams) ([]*ExecutionEvent, error)
+	ListExpiredTestExecutions(ctx context.Context, arg ListExpiredTestExecutionsParams) ([]test.TestExecutionID, error)
 	ListLogs(ctx context.Context, arg ListLogsParams) ([]*Log, error)
+	ListPendingBlobDeletions(ctx context.Context, arg ListPendingBlobDeletionsParams) ([]string, error)
+	ListRoleBindings(ctx context.Context, arg ListRoleBindingsParams) ([]*RoleBinding, error)
+	ListSubjectRoles(ctx context.Context, arg ListSubjectRolesParams) ([]string, error)
 	ListTestExecutions(ctx context.Context, arg ListTestExecutionsParams) ([]*TestExecution, error)
 	ListTestSuites(ctx context.Context, arg ListTestSuitesParams) ([]*TestSuite, error)
 	ListTests(ctx context.Context, arg ListTestsParams) ([]*Test, error)
+	NextExecutionEventSequence(ctx context.Context, testExecutionID test.TestExecutionID) (int64, error)
+	PutBlob(ctx context.Context, arg PutBlobParams) error
 	ResetTestExecution(ctx context.Context, arg ResetTestExecutionParams) (*TestExecution, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	SearchTestExecutions(ctx context.Context, arg SearchTestExecutionsParams) ([]*TestExecution, error)
 	SetTestSuiteVersion(ctx context.Context, arg SetTestSuiteVersionParams) error
 	UpdateCaseExecutionFinished(ctx context.Context, arg UpdateCaseExecutionFinishedParams) (*CaseExecution, error)
 	UpdateCaseExecutionStarted(ctx context.Context, arg UpdateCaseExecutionStartedParams) (*CaseExecution, error)
-	UpdateTestExecutionFinished(ctx context.Context, arg UpdateTestExecutionFinishedParams) (*TestExecution, error)
-	UpdateTestExecutionStarted(ctx context.Context, arg UpdateTestExecutionStartedParams) (*TestExecution, error)
+	UpdateTestExecutionFinished(ctx context.Context, arg UpdateTestExecutionFinishedParams) (*UpdateTestExecutionFinishedRow, error)
+	UpdateTestExecutionStarted(ctx context.Context, arg UpdateTestExecutionStartedParams) (*UpdateTestExecutionStartedRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -51,19 +51,21 @@ func (q *Queries) CreateTest(ctx context.Context, arg CreateTestParams) (*Test,
 }
 
 const createTestDefaultInput = `-- name: CreateTestDefaultInput :exec
-INSERT INTO test_default_inputs (test_id, data)
-VALUES (?, ?)
+INSERT INTO test_default_inputs (test_id, data, metadata)
+VALUES (?, ?, ?)
 ON CONFLICT(test_id) DO UPDATE
-    SET data = excluded.data
+    SET data     = excluded.data,
+        metadata = excluded.metadata
 `
 
 type CreateTestDefaultInputParams struct {
-	TestID string `json:"test_id"`
-	Data   []byte `json:"data"`
+	TestID   string `json:"test_id"`
+	Data     []byte `json:"data"`
+	Metadata []byte `json:"metadata"`
 }
 
 func (q *Queries) CreateTestDefaultInput(ctx context.Context, arg CreateTestDefaultInputParams) error {
-	_, err := q.db.ExecContext(ctx, createTestDefaultInput, arg.TestID, arg.Data)
+	_, err := q.db.ExecContext(ctx, createTestDefaultInput, arg.TestID, arg.Data, arg.Metadata)
 	return err
 }
 
@@ -125,7 +127,7 @@ func (q *Queries) GetTestByName(ctx context.Context, arg GetTestByNameParams) (*
 }
 
 const getTestDefaultInput = `-- name: GetTestDefaultInput :one
-SELECT test_id, data
+SELECT test_id, data, metadata
 FROM test_default_inputs
 WHERE test_id = ?
 `
@@ -133,7 +135,7 @@ WHERE test_id = ?
 func (q *Queries) GetTestDefaultInput(ctx context.Context, testID string) (*TestDefaultInput, error) {
 	row := q.db.QueryRowContext(ctx, getTestDefaultInput, testID)
 	var i TestDefaultInput
-	err := row.Scan(&i.TestID, &i.Data)
+	err := row.Scan(&i.TestID, &i.Data, &i.Metadata)
 	return &i, err
 }
 
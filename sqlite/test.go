@@ -27,6 +27,9 @@ func NewTestReader(db *DB) *TestReader {
 func (t *TestReader) GetTest(ctx context.Context, id uuid.V7) (*test.Test, error) {
 	tt, err := t.db.GetTest(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, test.ErrorTestNotFound
+		}
 		return nil, err
 	}
 	return marshalTest(tt), nil
@@ -58,7 +61,7 @@ func (t *TestReader) GetTestDefaultInput(ctx context.Context, testID uuid.V7) (*
 		}
 		return nil, err
 	}
-	return marshalTestDefaultInput(payload), nil
+	return marshalTestDefaultInput(payload)
 }
 
 type TestWriter struct {
@@ -89,8 +92,13 @@ func (t *TestWriter) DeleteTest(ctx context.Context, id uuid.V7) error {
 }
 
 func (t *TestWriter) CreateTestDefaultInput(ctx context.Context, testID uuid.V7, defaultInput *test.Payload) error {
+	metadata, err := marshalPayloadMetadata(defaultInput.Metadata)
+	if err != nil {
+		return err
+	}
 	return t.db.CreateTestDefaultInput(ctx, sqlc.CreateTestDefaultInputParams{
-		TestID: testID.String(),
-		Data:   defaultInput.Data,
+		TestID:   testID.String(),
+		Data:     defaultInput.Data,
+		Metadata: metadata,
 	})
 }
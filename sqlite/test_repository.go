@@ -2,7 +2,6 @@ package sqlite
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 
@@ -23,23 +22,31 @@ type testRepository struct {
 	*CaseExecutionWriter
 	*LogReader
 	*LogWriter
+	*ExecutionEventReader
+	*ExecutionEventWriter
+	*ArtifactReader
+	*ArtifactWriter
 }
 
 func NewTestRepository(db *DB) test.Repository {
 	return &testRepository{
-		db:                  db,
-		ContextReader:       NewContextReader(db),
-		ContextWriter:       NewContextWriter(db),
-		TestSuiteReader:     NewTestSuiteReader(db),
-		TestSuiteWriter:     NewTestSuiteWriter(db),
-		TestReader:          NewTestReader(db),
-		TestWriter:          NewTestWriter(db),
-		TestExecutionReader: NewTestExecutionReader(db),
-		TestExecutionWriter: NewTestExecutionWriter(db),
-		CaseExecutionReader: NewCaseExecutionReader(db),
-		CaseExecutionWriter: NewCaseExecutionWriter(db),
-		LogReader:           NewLogReader(db),
-		LogWriter:           NewLogWriter(db),
+		db:                   db,
+		ContextReader:        NewContextReader(db),
+		ContextWriter:        NewContextWriter(db),
+		TestSuiteReader:      NewTestSuiteReader(db),
+		TestSuiteWriter:      NewTestSuiteWriter(db),
+		TestReader:           NewTestReader(db),
+		TestWriter:           NewTestWriter(db),
+		TestExecutionReader:  NewTestExecutionReader(db),
+		TestExecutionWriter:  NewTestExecutionWriter(db),
+		CaseExecutionReader:  NewCaseExecutionReader(db),
+		CaseExecutionWriter:  NewCaseExecutionWriter(db),
+		LogReader:            NewLogReader(db),
+		LogWriter:            NewLogWriter(db),
+		ExecutionEventReader: NewExecutionEventReader(db),
+		ExecutionEventWriter: NewExecutionEventWriter(db),
+		ArtifactReader:       NewArtifactReader(db),
+		ArtifactWriter:       NewArtifactWriter(db),
 	}
 }
 
@@ -69,7 +76,7 @@ func (t *testRepository) ExecuteTx(ctx context.Context, query func(repo test.Rep
 }
 
 type txWrapper struct {
-	base *sql.Tx
+	base *Tx
 }
 
 func (t *txWrapper) Commit(_ context.Context) error {
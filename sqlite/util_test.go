@@ -51,6 +51,7 @@ func createDummyTestExec(ctx context.Context, t *testing.T, db *DB) *sqlc.TestEx
 		TestID:       dummyTest.ID,
 		HasInput:     false,
 		ScheduleTime: time.Now(),
+		Labels:       "{}",
 	})
 	require.NoError(t, err)
 	return dummyTestExec
@@ -9,6 +9,7 @@ const (
 	ErrorTestExecutionPayloadNotFound = testErr("test execution payload not found")
 	ErrorCaseExecutionNotFound        = testErr("case execution not found")
 	ErrorLogNotFound                  = testErr("execution log not found")
+	ErrorArtifactNotFound             = testErr("artifact not found")
 	ErrorNotTestExecution             = testErr("workflow is not a test execution")
 	ErrorNotCaseExecution             = testErr("activity is not a test execution")
 )
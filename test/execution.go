@@ -0,0 +1,16 @@
+package test
+
+import "github.com/annexsh/annex/uuid"
+
+// TestExecutionFilter selects test executions by their test and metadata.
+// Zero values match all test executions.
+type TestExecutionFilter struct {
+	TestID      *uuid.V7
+	TriggeredBy *string
+	GitSHA      *string
+	GitBranch   *string
+	CIJobURL    *string
+	Environment *string
+	// Labels matches test executions that have all the labels.
+	Labels map[string]string
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+)
+
+// Log severities in ascending order. Log levels are mapped to a severity so
+// that logs can be filtered by minimum level.
+const (
+	SeverityTrace = iota + 1
+	SeverityDebug
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+var logLevelSeverities = map[string]int{
+	"TRACE":    SeverityTrace,
+	"DEBUG":    SeverityDebug,
+	"INFO":     SeverityInfo,
+	"WARN":     SeverityWarn,
+	"WARNING":  SeverityWarn,
+	"ERROR":    SeverityError,
+	"FATAL":    SeverityFatal,
+	"CRITICAL": SeverityFatal,
+}
+
+// LogLevels are the log levels with a known severity.
+var LogLevels = slices.Sorted(maps.Keys(logLevelSeverities))
+
+// LogSeverity returns the severity of a case-insensitive log level. Unknown
+// levels have the severity of INFO so that they aren't hidden by filters on
+// the default level.
+func LogSeverity(level string) int {
+	if severity, ok := logLevelSeverities[strings.ToUpper(level)]; ok {
+		return severity
+	}
+	return SeverityInfo
+}
+
+// LogFilter selects logs. Zero values match all logs.
+type LogFilter struct {
+	// MinLevel matches logs with at least the severity of the level.
+	MinLevel *string
+	// CaseExecutionID matches logs of the case execution.
+	CaseExecutionID *CaseExecutionID
+	// Attributes matches logs that have all the attributes.
+	Attributes map[string]string
+	// MessageContains matches logs with messages containing the
+	// case-sensitive substring.
+	MessageContains *string
+}
+
+// Empty reports whether the filter matches all logs.
+func (f LogFilter) Empty() bool {
+	return f.MinLevel == nil && f.CaseExecutionID == nil && len(f.Attributes) == 0 && f.MessageContains == nil
+}
+
+// Matches reports whether the log matches the filter. Stored logs are
+// filtered by the repository, Matches is used for logs that haven't been
+// queried, such as logs received from the event bus.
+func (f LogFilter) Matches(log *executionsv1.Log) bool {
+	if f.MinLevel != nil && LogSeverity(log.Log.GetLevel()) < LogSeverity(*f.MinLevel) {
+		return false
+	}
+	if f.CaseExecutionID != nil && log.Log.GetCaseExecutionId() != f.CaseExecutionID.Int32() {
+		return false
+	}
+	for k, v := range f.Attributes {
+		if got, ok := log.Attributes[k]; !ok || got != v {
+			return false
+		}
+	}
+	if f.MessageContains != nil && !strings.Contains(log.Log.GetMessage(), *f.MessageContains) {
+		return false
+	}
+	return true
+}
@@ -4,7 +4,9 @@ import (
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/uuid"
 )
 
 func (t *TestSuite) Proto(available bool, runners ...*TestSuiteRunner) *testsv1.TestSuite {
@@ -77,6 +79,18 @@ func (t *TestExecution) Proto() *testsv1.TestExecution {
 	return exec
 }
 
+// ExecutionProto returns the test execution with its metadata, which
+// testsv1.TestExecution does not declare.
+func (t *TestExecution) ExecutionProto() *executionsv1.TestExecution {
+	exec := &executionsv1.TestExecution{
+		TestExecution: t.Proto(),
+	}
+	if !t.Metadata.Empty() {
+		exec.Metadata = t.Metadata.Proto()
+	}
+	return exec
+}
+
 func (t TestExecutionList) Proto() []*testsv1.TestExecution {
 	fe := make([]*testsv1.TestExecution, len(t))
 	for i, testExec := range t {
@@ -85,6 +99,64 @@ func (t TestExecutionList) Proto() []*testsv1.TestExecution {
 	return fe
 }
 
+func (t TestExecutionList) ExecutionProto() []*executionsv1.TestExecution {
+	fe := make([]*executionsv1.TestExecution, len(t))
+	for i, testExec := range t {
+		fe[i] = testExec.ExecutionProto()
+	}
+	return fe
+}
+
+func (m ExecutionMetadata) Proto() *executionsv1.ExecutionMetadata {
+	return &executionsv1.ExecutionMetadata{
+		TriggeredBy: m.TriggeredBy,
+		GitSha:      m.GitSHA,
+		GitBranch:   m.GitBranch,
+		CiJobUrl:    m.CIJobURL,
+		Environment: m.Environment,
+		Labels:      m.Labels,
+	}
+}
+
+func ExecutionMetadataFromProto(m *executionsv1.ExecutionMetadata) ExecutionMetadata {
+	if m == nil {
+		return ExecutionMetadata{}
+	}
+	metadata := ExecutionMetadata{
+		TriggeredBy: m.TriggeredBy,
+		GitSHA:      m.GitSha,
+		GitBranch:   m.GitBranch,
+		CIJobURL:    m.CiJobUrl,
+		Environment: m.Environment,
+	}
+	if len(m.Labels) > 0 {
+		metadata.Labels = m.Labels
+	}
+	return metadata
+}
+
+func TestExecutionFilterFromProto(f *executionsv1.TestExecutionFilter) (TestExecutionFilter, error) {
+	if f == nil {
+		return TestExecutionFilter{}, nil
+	}
+	filter := TestExecutionFilter{
+		TriggeredBy: f.TriggeredBy,
+		GitSHA:      f.GitSha,
+		GitBranch:   f.GitBranch,
+		CIJobURL:    f.CiJobUrl,
+		Environment: f.Environment,
+		Labels:      f.Labels,
+	}
+	if f.TestId != nil {
+		testID, err := uuid.Parse(*f.TestId)
+		if err != nil {
+			return TestExecutionFilter{}, err
+		}
+		filter.TestID = &testID
+	}
+	return filter, nil
+}
+
 func (c *CaseExecution) Proto() *testsv1.CaseExecution {
 	exec := &testsv1.CaseExecution{
 		Id:              c.ID.Int32(),
@@ -127,6 +199,56 @@ func (l *Log) Proto() *testsv1.Log {
 	}
 }
 
+// ExecutionProto returns the log with its attributes, which testsv1.Log does
+// not declare.
+func (l *Log) ExecutionProto() *executionsv1.Log {
+	return &executionsv1.Log{
+		Log:        l.Proto(),
+		Attributes: l.Attributes,
+	}
+}
+
+func (a *Artifact) Proto() *executionsv1.Artifact {
+	var caseExecID *int32
+	if a.CaseExecutionID != nil {
+		caseExecID = ptr.Get(a.CaseExecutionID.Int32())
+	}
+
+	return &executionsv1.Artifact{
+		Id:              a.ID.String(),
+		TestExecutionId: a.TestExecutionID.String(),
+		CaseExecutionId: caseExecID,
+		Name:            a.Name,
+		ContentType:     a.ContentType,
+		SizeBytes:       a.Size,
+		Checksum:        a.Checksum,
+		CreateTime:      timestamppb.New(a.CreateTime),
+	}
+}
+
+func (l ArtifactList) Proto() []*executionsv1.Artifact {
+	artifacts := make([]*executionsv1.Artifact, len(l))
+	for i, artifact := range l {
+		artifacts[i] = artifact.Proto()
+	}
+	return artifacts
+}
+
+func LogFilterFromProto(f *executionsv1.LogFilter) LogFilter {
+	if f == nil {
+		return LogFilter{}
+	}
+	filter := LogFilter{
+		MinLevel:        f.MinLevel,
+		Attributes:      f.Attributes,
+		MessageContains: f.MessageContains,
+	}
+	if f.CaseExecutionId != nil {
+		filter.CaseExecutionID = ptr.Get(CaseExecutionID(*f.CaseExecutionId))
+	}
+	return filter
+}
+
 func (l LogList) Proto() []*testsv1.Log {
 	execLogs := make([]*testsv1.Log, len(l))
 	for i, log := range l {
@@ -134,3 +256,11 @@ func (l LogList) Proto() []*testsv1.Log {
 	}
 	return execLogs
 }
+
+func (l LogList) ExecutionProto() []*executionsv1.Log {
+	execLogs := make([]*executionsv1.Log, len(l))
+	for i, log := range l {
+		execLogs[i] = log.ExecutionProto()
+	}
+	return execLogs
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+
 	"github.com/annexsh/annex/uuid"
 )
 
@@ -19,6 +21,8 @@ type Repository interface {
 	TestExecutionReadWriter
 	CaseExecutionReadWriter
 	LogReadWriter
+	ExecutionEventReadWriter
+	ArtifactReadWriter
 	WithTx(ctx context.Context) (Repository, Tx, error)
 	ExecuteTx(ctx context.Context, query func(repo Repository) error) error
 }
@@ -74,16 +78,29 @@ type TestExecutionReadWriter interface {
 
 type TestExecutionReader interface {
 	GetTestExecution(ctx context.Context, id TestExecutionID) (*TestExecution, error)
+	// GetTestExecutionContext returns the ID of the context of the test
+	// execution's test.
+	GetTestExecutionContext(ctx context.Context, id TestExecutionID) (string, error)
 	GetTestExecutionInput(ctx context.Context, id TestExecutionID) (*Payload, error)
 	ListTestExecutions(ctx context.Context, testID uuid.V7, filter PageFilter[TestExecutionID]) (TestExecutionList, error)
+	// SearchTestExecutions lists the test executions in a context that match
+	// the execution filter in descending order.
+	SearchTestExecutions(ctx context.Context, contextID string, filter PageFilter[TestExecutionID], execFilter TestExecutionFilter) (TestExecutionList, error)
+	// ListExpiredTestExecutions lists the IDs of finished test executions in
+	// a context that have expired under the filter, oldest first.
+	ListExpiredTestExecutions(ctx context.Context, contextID string, filter ExpiredFilter) ([]TestExecutionID, error)
 }
 
 type TestExecutionWriter interface {
 	CreateTestExecutionScheduled(ctx context.Context, scheduled *ScheduledTestExecution) (*TestExecution, error)
 	CreateTestExecutionInput(ctx context.Context, testExecID TestExecutionID, input *Payload) error
-	UpdateTestExecutionStarted(ctx context.Context, started *StartedTestExecution) (*TestExecution, error)
-	UpdateTestExecutionFinished(ctx context.Context, finished *FinishedTestExecution) (*TestExecution, error)
+	UpdateTestExecutionStarted(ctx context.Context, started *StartedTestExecution) (*UpdatedTestExecution, error)
+	UpdateTestExecutionFinished(ctx context.Context, finished *FinishedTestExecution) (*UpdatedTestExecution, error)
 	ResetTestExecution(ctx context.Context, testExecID TestExecutionID, resetTime time.Time) (*TestExecution, error)
+	// DeleteTestExecution deletes a test execution along with its input, case
+	// executions, logs, events and artifact metadata. The blob keys of the
+	// deleted artifacts are recorded as pending blob deletions.
+	DeleteTestExecution(ctx context.Context, id TestExecutionID) (*DeletedTestExecution, error)
 }
 
 type CaseExecutionReadWriter interface {
@@ -110,12 +127,61 @@ type LogReadWriter interface {
 
 type LogReader interface {
 	GetLog(ctx context.Context, id uuid.V7) (*Log, error)
-	ListLogs(ctx context.Context, testExecID TestExecutionID, filter PageFilter[uuid.V7]) (LogList, error)
+	// ListLogs lists the logs of a test execution that match the log filter
+	// in descending order.
+	ListLogs(ctx context.Context, testExecID TestExecutionID, filter PageFilter[uuid.V7], logFilter LogFilter) (LogList, error)
 }
 
 type LogWriter interface {
 	CreateLog(ctx context.Context, log *Log) error
+	// CreateLogs creates the logs in order with as few statements as possible.
+	CreateLogs(ctx context.Context, logs LogList) error
 	DeleteLog(ctx context.Context, id uuid.V7) error
 }
 
+type ExecutionEventReadWriter interface {
+	ExecutionEventReader
+	ExecutionEventWriter
+}
+
+type ExecutionEventReader interface {
+	// ListExecutionEvents lists the events of a test execution in ascending
+	// sequence order, starting after the filter offset sequence. Log events
+	// are only listed if their log matches the log filter.
+	ListExecutionEvents(ctx context.Context, testExecID TestExecutionID, filter PageFilter[uint64], logFilter LogFilter) (ExecutionEventList, error)
+	// GetExecutionEventSequence returns the sequence number of the last
+	// recorded event of a test execution, or zero if there are none.
+	GetExecutionEventSequence(ctx context.Context, testExecID TestExecutionID) (uint64, error)
+}
+
+type ExecutionEventWriter interface {
+	// NextExecutionEventSequence allocates the next event sequence number of a
+	// test execution. Sequence numbers start at 1.
+	NextExecutionEventSequence(ctx context.Context, testExecID TestExecutionID) (uint64, error)
+	CreateExecutionEvent(ctx context.Context, event *ExecutionEvent) error
+	DeleteExecutionEvents(ctx context.Context, testExecID TestExecutionID, eventType eventsv1.Event_Type) error
+}
+
+type ArtifactReadWriter interface {
+	ArtifactReader
+	ArtifactWriter
+}
+
+type ArtifactReader interface {
+	GetArtifact(ctx context.Context, id uuid.V7) (*Artifact, error)
+	// ListArtifacts lists the artifacts of a test execution in ascending
+	// order. Only the artifacts of the case execution are listed when set.
+	ListArtifacts(ctx context.Context, testExecID TestExecutionID, caseExecID *CaseExecutionID, filter PageFilter[uuid.V7]) (ArtifactList, error)
+	// ListPendingBlobDeletions lists the blob keys of deleted artifacts whose
+	// content is yet to be deleted from the blob store in ascending order.
+	ListPendingBlobDeletions(ctx context.Context, filter PageFilter[string]) ([]string, error)
+}
+
+type ArtifactWriter interface {
+	CreateArtifact(ctx context.Context, artifact *Artifact) error
+	// DeletePendingBlobDeletion removes a pending blob deletion once the
+	// content has been deleted from the blob store.
+	DeletePendingBlobDeletion(ctx context.Context, blobKey string) error
+}
+
 type ResetRollback func(ctx context.Context) error
@@ -3,6 +3,8 @@ package test
 import (
 	"time"
 
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+
 	"github.com/annexsh/annex/uuid"
 )
 
@@ -46,15 +48,35 @@ type TestExecution struct {
 	StartTime    *time.Time
 	FinishTime   *time.Time
 	Error        *string
+	Metadata     ExecutionMetadata
 }
 
 type TestExecutionList []*TestExecution
 
+// ExecutionMetadata describes who or what triggered a test execution and what
+// it ran against. Nil fields are unknown.
+type ExecutionMetadata struct {
+	TriggeredBy *string
+	GitSHA      *string
+	GitBranch   *string
+	CIJobURL    *string
+	Environment *string
+	// Labels are arbitrary key/value pairs.
+	Labels map[string]string
+}
+
+// Empty reports whether none of the metadata is known.
+func (m ExecutionMetadata) Empty() bool {
+	return m.TriggeredBy == nil && m.GitSHA == nil && m.GitBranch == nil && m.CIJobURL == nil &&
+		m.Environment == nil && len(m.Labels) == 0
+}
+
 type ScheduledTestExecution struct {
 	ID           TestExecutionID
 	TestID       uuid.V7
 	HasInput     bool
 	ScheduleTime time.Time
+	Metadata     ExecutionMetadata
 }
 
 type StartedTestExecution struct {
@@ -107,12 +129,84 @@ type Log struct {
 	Level           string
 	Message         string
 	CreateTime      time.Time
+	// Attributes are structured key/value pairs describing the log.
+	Attributes map[string]string
+	// RedactionCount is the number of secrets redacted from the message
+	// and attribute values.
+	RedactionCount int
 }
 
 type LogList []*Log
 
+// Artifact is a file attached to a test or case execution. Its content is
+// stored in a blob store under the artifact ID.
+type Artifact struct {
+	ID              uuid.V7
+	TestExecutionID TestExecutionID
+	CaseExecutionID *CaseExecutionID
+	Name            string
+	ContentType     string
+	Size            int64
+	// Checksum is the hex encoded SHA-256 checksum of the content.
+	Checksum   string
+	CreateTime time.Time
+}
+
+// BlobKey returns the key of the artifact content in the blob store.
+func (a *Artifact) BlobKey() string {
+	return a.ID.String()
+}
+
+type ArtifactList []*Artifact
+
+// ExecutionEvent is an event recorded for a test execution. Events are
+// sequenced per test execution so that they can be replayed in order.
+type ExecutionEvent struct {
+	TestExecutionID TestExecutionID
+	Sequence        uint64
+	CaseExecutionID *CaseExecutionID
+	LogID           *uuid.V7
+	Event           *eventsv1.Event
+}
+
+type ExecutionEventList []*ExecutionEvent
+
 type Identifier interface {
-	string | uuid.V7 | TestExecutionID | CaseExecutionID
+	string | uint64 | uuid.V7 | TestExecutionID | CaseExecutionID
+}
+
+// ExpiredFilter selects finished test executions that have expired under a
+// retention policy.
+type ExpiredFilter struct {
+	// FinishedBefore expires executions that finished before the time.
+	FinishedBefore *time.Time
+	// MaxPerTest expires executions beyond the most recent executions of each
+	// test. Zero keeps any number of executions.
+	MaxPerTest int
+	// FailedFinishedBefore expires failed executions only once they finished
+	// before the time, in place of FinishedBefore and MaxPerTest.
+	FailedFinishedBefore *time.Time
+	Size                 int
+}
+
+// UpdatedTestExecution is an updated test execution along with the context
+// and test suite of its test.
+type UpdatedTestExecution struct {
+	*TestExecution
+	ContextID   string
+	TestSuiteID uuid.V7
+}
+
+// DeletedTestExecution is the number of records removed by deleting a test
+// execution.
+type DeletedTestExecution struct {
+	ID             TestExecutionID
+	CaseExecutions int
+	Logs           int
+	Events         int
+	// Artifacts are the deleted artifacts, whose content must be deleted
+	// from the blob store once the deletion is committed.
+	Artifacts ArtifactList
 }
 
 type PageFilter[T Identifier] struct {
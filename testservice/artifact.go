@@ -0,0 +1,278 @@
+package testservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/blob"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/pagination"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+const (
+	defaultMaxArtifactSize     = 100 << 20 // 100 MiB
+	defaultArtifactContentType = "application/octet-stream"
+	artifactChunkSize          = 64 << 10 // 64 KiB
+)
+
+var errArtifactsDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("artifacts are not enabled"))
+
+func (s *Service) UploadArtifact(
+	ctx context.Context,
+	stream *connect.ClientStream[executionsv1.UploadArtifactRequest],
+) (*connect.Response[executionsv1.UploadArtifactResponse], error) {
+	if s.blobStore == nil {
+		return nil, errArtifactsDisabled
+	}
+
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, validateArtifactMetadata(nil)
+	}
+
+	metadata := stream.Msg().GetMetadata()
+	if err := validateArtifactMetadata(metadata); err != nil {
+		return nil, err
+	}
+
+	testExecID, err := test.ParseTestExecutionID(metadata.TestExecutionId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Artifacts are only deleted with their test execution so the test and
+	// case execution must exist before the content is stored
+	if err = s.authorizeTestExecution(ctx, metadata.Context, testExecID, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
+	var caseExecID *test.CaseExecutionID
+	if metadata.CaseExecutionId != nil {
+		caseExecID = ptr.Get(test.CaseExecutionID(*metadata.CaseExecutionId))
+		if _, err = s.repo.GetCaseExecution(ctx, testExecID, *caseExecID); err != nil {
+			return nil, err
+		}
+	}
+
+	artifact := &test.Artifact{
+		ID:              uuid.New(),
+		TestExecutionID: testExecID,
+		CaseExecutionID: caseExecID,
+		Name:            metadata.Name,
+		ContentType:     metadata.ContentType,
+		CreateTime:      time.Now().UTC(),
+	}
+	if artifact.ContentType == "" {
+		artifact.ContentType = defaultArtifactContentType
+	}
+
+	content := newArtifactReader(stream, s.maxArtifactSize)
+	if err = s.blobStore.Put(ctx, artifact.BlobKey(), content); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+	artifact.Size = content.size
+	artifact.Checksum = hex.EncodeToString(content.hash.Sum(nil))
+
+	if metadata.Checksum != nil && !strings.EqualFold(*metadata.Checksum, artifact.Checksum) {
+		s.deleteArtifactContent(ctx, artifact)
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("artifact checksum %s does not match uploaded content checksum %s", *metadata.Checksum, artifact.Checksum))
+	}
+
+	if err = s.repo.CreateArtifact(ctx, artifact); err != nil {
+		s.deleteArtifactContent(ctx, artifact)
+		return nil, err
+	}
+
+	return connect.NewResponse(&executionsv1.UploadArtifactResponse{
+		Artifact: artifact.Proto(),
+	}), nil
+}
+
+func (s *Service) ListTestExecutionArtifacts(
+	ctx context.Context,
+	req *connect.Request[executionsv1.ListTestExecutionArtifactsRequest],
+) (*connect.Response[executionsv1.ListTestExecutionArtifactsResponse], error) {
+	if err := validateListTestExecutionArtifactsRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	testExecID, err := test.ParseTestExecutionID(req.Msg.TestExecutionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	var caseExecID *test.CaseExecutionID
+	if req.Msg.CaseExecutionId != nil {
+		caseExecID = ptr.Get(test.CaseExecutionID(*req.Msg.CaseExecutionId))
+	}
+
+	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.repo.ListArtifacts(ctx, testExecID, caseExecID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(filter.Size, artifacts, func(artifact *test.Artifact) uuid.V7 {
+		return artifact.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&executionsv1.ListTestExecutionArtifactsResponse{
+		Artifacts:     artifacts.Proto(),
+		NextPageToken: nextPageTkn,
+	}), nil
+}
+
+func (s *Service) DownloadArtifact(
+	ctx context.Context,
+	req *connect.Request[executionsv1.DownloadArtifactRequest],
+	stream *connect.ServerStream[executionsv1.DownloadArtifactResponse],
+) error {
+	if s.blobStore == nil {
+		return errArtifactsDisabled
+	}
+
+	if err := validateDownloadArtifactRequest(req.Msg); err != nil {
+		return err
+	}
+
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleViewer); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(req.Msg.ArtifactId)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := s.repo.GetArtifact(ctx, id)
+	if err != nil {
+		if errors.Is(err, test.ErrorArtifactNotFound) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		return err
+	}
+
+	// An artifact of another context is not found so that a role in one
+	// context never grants access to the artifacts of another
+	execContextID, err := s.repo.GetTestExecutionContext(ctx, artifact.TestExecutionID)
+	if err != nil {
+		return err
+	}
+	if execContextID != req.Msg.Context {
+		return connect.NewError(connect.CodeNotFound, test.ErrorArtifactNotFound)
+	}
+
+	content, err := s.blobStore.Get(ctx, artifact.BlobKey())
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return connect.NewError(connect.CodeDataLoss, errors.New("artifact content not found"))
+		}
+		return fmt.Errorf("failed to get artifact content: %w", err)
+	}
+	defer content.Close()
+
+	if err = stream.Send(&executionsv1.DownloadArtifactResponse{
+		Data: &executionsv1.DownloadArtifactResponse_Artifact{Artifact: artifact.Proto()},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, artifactChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&executionsv1.DownloadArtifactResponse{
+				Data: &executionsv1.DownloadArtifactResponse_Chunk{Chunk: buf[:n]},
+			}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read artifact content: %w", err)
+		}
+	}
+}
+
+// deleteArtifactContent deletes the content of an artifact that could not be
+// created. Failures are logged since the upload has already failed.
+func (s *Service) deleteArtifactContent(ctx context.Context, artifact *test.Artifact) {
+	if err := s.blobStore.Delete(ctx, artifact.BlobKey()); err != nil {
+		s.logger.Error("failed to delete artifact content", "artifact_id", artifact.ID, "error", err)
+	}
+}
+
+// artifactReader reads the content chunks of an artifact upload stream
+// following the metadata request. The size and checksum of the content are
+// recorded as it's read.
+type artifactReader struct {
+	stream  *connect.ClientStream[executionsv1.UploadArtifactRequest]
+	maxSize int64
+	size    int64
+	hash    hash.Hash
+	chunk   []byte
+}
+
+func newArtifactReader(stream *connect.ClientStream[executionsv1.UploadArtifactRequest], maxSize int64) *artifactReader {
+	return &artifactReader{
+		stream:  stream,
+		maxSize: maxSize,
+		hash:    sha256.New(),
+	}
+}
+
+func (r *artifactReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if !r.stream.Receive() {
+			if err := r.stream.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+
+		data, ok := r.stream.Msg().Data.(*executionsv1.UploadArtifactRequest_Chunk)
+		if !ok {
+			return 0, connect.NewError(connect.CodeInvalidArgument, errors.New("only the first request may contain artifact metadata"))
+		}
+
+		r.size += int64(len(data.Chunk))
+		if r.maxSize > 0 && r.size > r.maxSize {
+			return 0, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("artifact exceeds the maximum size of %d bytes", r.maxSize))
+		}
+		r.hash.Write(data.Chunk)
+		r.chunk = data.Chunk
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
@@ -0,0 +1,273 @@
+package testservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/blob"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_UploadDownloadArtifact(t *testing.T) {
+	ctx := context.Background()
+	testExecID := test.NewTestExecutionID()
+	caseExecID := fake.GenCaseID()
+	content := bytes.Repeat([]byte("annex"), 20000) // spans multiple download chunks
+	sum := sha256.Sum256(content)
+
+	var created *test.Artifact
+
+	r := &RepositoryMock{
+		GetTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
+			assert.Equal(t, testExecID, id)
+			return &test.TestExecution{ID: id}, nil
+		},
+		GetCaseExecutionFunc: func(ctx context.Context, gotTestExecID test.TestExecutionID, gotCaseExecID test.CaseExecutionID) (*test.CaseExecution, error) {
+			assert.Equal(t, testExecID, gotTestExecID)
+			assert.Equal(t, caseExecID, gotCaseExecID)
+			return &test.CaseExecution{ID: gotCaseExecID, TestExecutionID: gotTestExecID}, nil
+		},
+		CreateArtifactFunc: func(ctx context.Context, artifact *test.Artifact) error {
+			created = artifact
+			return nil
+		},
+		GetArtifactFunc: func(ctx context.Context, id uuid.V7) (*test.Artifact, error) {
+			if created == nil || created.ID != id {
+				return nil, test.ErrorArtifactNotFound
+			}
+			return created, nil
+		},
+	}
+
+	store, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	mockTestExecutionContext(r, "foo")
+
+	s := New(r, nil, nil, WithBlobStore(store))
+	cli, closer := newExecutionServiceServer(s)
+	defer closer()
+
+	upload := cli.UploadArtifact(ctx)
+	require.NoError(t, upload.Send(&executionsv1.UploadArtifactRequest{
+		Data: &executionsv1.UploadArtifactRequest_Metadata{Metadata: &executionsv1.ArtifactMetadata{
+			Context:         "foo",
+			TestExecutionId: testExecID.String(),
+			CaseExecutionId: ptr.Get(int32(caseExecID)),
+			Name:            "report.txt",
+			ContentType:     "text/plain",
+			Checksum:        ptr.Get(hex.EncodeToString(sum[:])),
+		}},
+	}))
+	for chunk := range slices.Chunk(content, 1000) {
+		require.NoError(t, upload.Send(&executionsv1.UploadArtifactRequest{
+			Data: &executionsv1.UploadArtifactRequest_Chunk{Chunk: chunk},
+		}))
+	}
+	res, err := upload.CloseAndReceive()
+	require.NoError(t, err)
+
+	require.NotNil(t, created)
+	assert.Equal(t, testExecID, created.TestExecutionID)
+	assert.Equal(t, &caseExecID, created.CaseExecutionID)
+	assert.Equal(t, "report.txt", created.Name)
+	assert.Equal(t, "text/plain", created.ContentType)
+	assert.Equal(t, int64(len(content)), created.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), created.Checksum)
+	assert.Equal(t, created.Proto(), res.Msg.Artifact)
+
+	download, err := cli.DownloadArtifact(ctx, connect.NewRequest(&executionsv1.DownloadArtifactRequest{
+		Context:    "foo",
+		ArtifactId: created.ID.String(),
+	}))
+	require.NoError(t, err)
+
+	require.True(t, download.Receive())
+	assert.Equal(t, created.Proto(), download.Msg().GetArtifact())
+
+	var got []byte
+	for download.Receive() {
+		got = append(got, download.Msg().GetChunk()...)
+	}
+	require.NoError(t, download.Err())
+	assert.Equal(t, content, got)
+}
+
+func TestService_UploadArtifact_checksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	testExecID := test.NewTestExecutionID()
+
+	var putKey string
+	store := &BlobStoreMock{
+		PutFunc: func(ctx context.Context, key string, r io.Reader) error {
+			putKey = key
+			_, err := io.Copy(io.Discard, r)
+			return err
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			assert.Equal(t, putKey, key)
+			return nil
+		},
+	}
+
+	r := &RepositoryMock{
+		GetTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
+			return &test.TestExecution{ID: id}, nil
+		},
+	}
+
+	mockTestExecutionContext(r, "foo")
+
+	s := New(r, nil, nil, WithBlobStore(store))
+	cli, closer := newExecutionServiceServer(s)
+	defer closer()
+
+	upload := cli.UploadArtifact(ctx)
+	require.NoError(t, upload.Send(&executionsv1.UploadArtifactRequest{
+		Data: &executionsv1.UploadArtifactRequest_Metadata{Metadata: &executionsv1.ArtifactMetadata{
+			Context:         "foo",
+			TestExecutionId: testExecID.String(),
+			Name:            "report.txt",
+			Checksum:        ptr.Get(strings.Repeat("0", sha256.Size*2)),
+		}},
+	}))
+	require.NoError(t, upload.Send(&executionsv1.UploadArtifactRequest{
+		Data: &executionsv1.UploadArtifactRequest_Chunk{Chunk: []byte("foo")},
+	}))
+	_, err := upload.CloseAndReceive()
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Len(t, store.DeleteCalls(), 1)
+	assert.Empty(t, r.CreateArtifactCalls())
+}
+
+func TestService_UploadArtifact_maxSize(t *testing.T) {
+	ctx := context.Background()
+	testExecID := test.NewTestExecutionID()
+
+	r := &RepositoryMock{
+		GetTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
+			return &test.TestExecution{ID: id}, nil
+		},
+	}
+
+	store, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	mockTestExecutionContext(r, "foo")
+
+	s := New(r, nil, nil, WithBlobStore(store), WithMaxArtifactSize(4))
+	cli, closer := newExecutionServiceServer(s)
+	defer closer()
+
+	upload := cli.UploadArtifact(ctx)
+	require.NoError(t, upload.Send(&executionsv1.UploadArtifactRequest{
+		Data: &executionsv1.UploadArtifactRequest_Metadata{Metadata: &executionsv1.ArtifactMetadata{
+			Context:         "foo",
+			TestExecutionId: testExecID.String(),
+			Name:            "report.txt",
+		}},
+	}))
+	require.NoError(t, upload.Send(&executionsv1.UploadArtifactRequest{
+		Data: &executionsv1.UploadArtifactRequest_Chunk{Chunk: []byte("foobar")},
+	}))
+	_, err = upload.CloseAndReceive()
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	assert.Empty(t, r.CreateArtifactCalls())
+}
+
+func TestService_UploadArtifact_disabled(t *testing.T) {
+	s := New(new(RepositoryMock), nil, nil)
+	cli, closer := newExecutionServiceServer(s)
+	defer closer()
+
+	upload := cli.UploadArtifact(context.Background())
+	_, err := upload.CloseAndReceive()
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestService_DownloadArtifact_notFound(t *testing.T) {
+	r := &RepositoryMock{
+		GetArtifactFunc: func(ctx context.Context, id uuid.V7) (*test.Artifact, error) {
+			return nil, test.ErrorArtifactNotFound
+		},
+	}
+
+	store, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	mockTestExecutionContext(r, "foo")
+
+	s := New(r, nil, nil, WithBlobStore(store))
+	err = s.DownloadArtifact(context.Background(), connect.NewRequest(&executionsv1.DownloadArtifactRequest{
+		Context:    "foo",
+		ArtifactId: uuid.NewString(),
+	}), nil)
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	assert.True(t, errors.Is(err, test.ErrorArtifactNotFound))
+}
+
+func TestService_ListTestExecutionArtifacts(t *testing.T) {
+	pageSize := 2
+	testExecID := test.NewTestExecutionID()
+	caseExecID := fake.GenCaseID()
+
+	wantPage1 := test.ArtifactList{
+		fake.GenCaseExecArtifact(testExecID, caseExecID),
+		fake.GenCaseExecArtifact(testExecID, caseExecID),
+	}
+	wantPage2 := test.ArtifactList{fake.GenCaseExecArtifact(testExecID, caseExecID)}
+
+	r := new(RepositoryMock)
+	r.ListArtifactsFunc = func(ctx context.Context, gotTestExecID test.TestExecutionID, gotCaseExecID *test.CaseExecutionID, filter test.PageFilter[uuid.V7]) (test.ArtifactList, error) {
+		assert.Equal(t, testExecID, gotTestExecID)
+		assert.Equal(t, &caseExecID, gotCaseExecID)
+		assert.Equal(t, pageSize, filter.Size)
+
+		switch len(r.ListArtifactsCalls()) {
+		case 1:
+			assert.Nil(t, filter.OffsetID)
+			return wantPage1, nil
+		case 2:
+			assert.Equal(t, wantPage1[pageSize-1].ID, *filter.OffsetID)
+			return wantPage2, nil
+		default:
+			panic("unexpected list invocation")
+		}
+	}
+
+	mockTestExecutionContext(r, "foo")
+
+	s := Service{repo: r}
+
+	req := &executionsv1.ListTestExecutionArtifactsRequest{
+		Context:         "foo",
+		TestExecutionId: testExecID.String(),
+		CaseExecutionId: ptr.Get(int32(caseExecID)),
+		PageSize:        int32(pageSize),
+	}
+	res, err := s.ListTestExecutionArtifacts(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, wantPage1.Proto(), res.Msg.Artifacts)
+	assert.NotEmpty(t, res.Msg.NextPageToken)
+
+	req.NextPageToken = res.Msg.NextPageToken
+	res, err = s.ListTestExecutionArtifacts(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, wantPage2.Proto(), res.Msg.Artifacts)
+	assert.Empty(t, res.Msg.NextPageToken)
+}
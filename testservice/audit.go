@@ -0,0 +1,86 @@
+package testservice
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	auditv1 "github.com/annexsh/annex/gen/annex/audit/v1"
+	"github.com/annexsh/annex/internal/pagination"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func (s *Service) ListAuditEntries(
+	ctx context.Context,
+	req *connect.Request[auditv1.ListAuditEntriesRequest],
+) (*connect.Response[auditv1.ListAuditEntriesResponse], error) {
+	if err := validateListAuditEntriesRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if s.auditRepo == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("audit log is not enabled"))
+	}
+
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	page, err := pagination.FilterFromRequest(req.Msg, pagination.WithUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := audit.Filter{
+		PageFilter: page,
+		Subject:    req.Msg.Subject,
+	}
+	if req.Msg.Action != nil {
+		filter.Action = ptr.Get(audit.Action(*req.Msg.Action))
+	}
+	if req.Msg.TestId != nil {
+		testID, err := uuid.Parse(*req.Msg.TestId)
+		if err != nil {
+			return nil, err
+		}
+		filter.TestID = &testID
+	}
+	if req.Msg.TestExecutionId != nil {
+		testExecID, err := test.ParseTestExecutionID(*req.Msg.TestExecutionId)
+		if err != nil {
+			return nil, err
+		}
+		filter.TestExecutionID = &testExecID
+	}
+
+	entries, err := s.auditRepo.ListEntries(ctx, req.Msg.Context, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(page.Size, entries, func(e *audit.Entry) uuid.V7 {
+		return e.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&auditv1.ListAuditEntriesResponse{
+		Entries:       entries.Proto(),
+		NextPageToken: nextPageTkn,
+	}), nil
+}
+
+// recordAudit records a completed action in the audit log on behalf of the
+// caller, if the audit log is enabled.
+func (s *Service) recordAudit(ctx context.Context, entry *audit.Entry) {
+	if s.auditRepo == nil {
+		return
+	}
+	audit.Record(ctx, s.auditRepo, s.logger, entry)
+}
@@ -0,0 +1,104 @@
+package testservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	auditv1 "github.com/annexsh/annex/gen/annex/audit/v1"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_ListAuditEntries(t *testing.T) {
+	testExecID := test.NewTestExecutionID()
+	want := audit.EntryList{
+		{
+			ID:              uuid.New(),
+			Context:         "foo",
+			Action:          audit.ActionRetryTestExecution,
+			Subject:         "alice",
+			AuthMethod:      auth.MethodJWT,
+			TestExecutionID: &testExecID,
+			Summary:         "retried failed test execution",
+			CreateTime:      time.Now().UTC(),
+		},
+	}
+
+	a := &AuditRepositoryMock{
+		ListEntriesFunc: func(ctx context.Context, contextID string, filter audit.Filter) (audit.EntryList, error) {
+			assert.Equal(t, "foo", contextID)
+			assert.Equal(t, 10, filter.Size)
+			assert.Equal(t, ptr.Get("alice"), filter.Subject)
+			assert.Equal(t, ptr.Get(audit.ActionRetryTestExecution), filter.Action)
+			assert.Nil(t, filter.TestID)
+			assert.Equal(t, &testExecID, filter.TestExecutionID)
+			return want, nil
+		},
+	}
+
+	s := Service{auditRepo: a}
+
+	req := &auditv1.ListAuditEntriesRequest{
+		Context:         "foo",
+		PageSize:        10,
+		Subject:         ptr.Get("alice"),
+		Action:          ptr.Get("RetryTestExecution"),
+		TestExecutionId: ptr.Get(testExecID.String()),
+	}
+	res, err := s.ListAuditEntries(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, want.Proto(), res.Msg.Entries)
+	assert.Empty(t, res.Msg.NextPageToken)
+}
+
+func TestService_ListAuditEntries_validation(t *testing.T) {
+	s := Service{auditRepo: &AuditRepositoryMock{}}
+
+	req := &auditv1.ListAuditEntriesRequest{
+		Context: "foo",
+		Action:  ptr.Get("ListTests"),
+	}
+	res, err := s.ListAuditEntries(context.Background(), connect.NewRequest(req))
+	assert.Nil(t, res)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestService_recordAudit(t *testing.T) {
+	principal := &auth.Principal{Subject: "alice", Method: auth.MethodAPIKey}
+	ctx := auth.ContextWithPrincipal(context.Background(), principal)
+
+	r := &RepositoryMock{
+		CreateContextFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+	a := &AuditRepositoryMock{
+		CreateEntryFunc: func(ctx context.Context, entry *audit.Entry) error {
+			return nil
+		},
+	}
+
+	s := Service{repo: r, auditRepo: a}
+
+	_, err := s.RegisterContext(ctx, connect.NewRequest(&testsv1.RegisterContextRequest{Context: "foo"}))
+	require.NoError(t, err)
+
+	require.Len(t, a.CreateEntryCalls(), 1)
+	got := a.CreateEntryCalls()[0].Entry
+	assert.NotEmpty(t, got.ID)
+	assert.NotZero(t, got.CreateTime)
+	assert.Equal(t, "foo", got.Context)
+	assert.Equal(t, audit.ActionRegisterContext, got.Action)
+	assert.Equal(t, "alice", got.Subject)
+	assert.Equal(t, auth.MethodAPIKey, got.AuthMethod)
+	assert.Equal(t, "registered context 'foo'", got.Summary)
+}
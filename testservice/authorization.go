@@ -0,0 +1,69 @@
+package testservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+// authorize returns a permission denied error unless the caller has a role in
+// the context that includes the required role.
+func (s *Service) authorize(ctx context.Context, contextID string, required auth.Role) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	if err := s.authorizer.Authorize(ctx, contextID, required); err != nil {
+		if errors.Is(err, auth.ErrorPermissionDenied) {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s role required in context '%s'", required, contextID))
+		}
+		return err
+	}
+	return nil
+}
+
+// authorizeTest authorizes the caller in the context and returns the test. A
+// test of another context is not found so that a role in one context never
+// grants access to the tests of another.
+func (s *Service) authorizeTest(ctx context.Context, contextID string, testID uuid.V7, required auth.Role) (*test.Test, error) {
+	if err := s.authorize(ctx, contextID, required); err != nil {
+		return nil, err
+	}
+	t, err := s.repo.GetTest(ctx, testID)
+	if err != nil {
+		if errors.Is(err, test.ErrorTestNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+	if t.ContextID != contextID {
+		return nil, connect.NewError(connect.CodeNotFound, test.ErrorTestNotFound)
+	}
+	return t, nil
+}
+
+// authorizeTestExecution authorizes the caller in the context and checks that
+// the test execution belongs to the context. A test execution of another
+// context is not found so that a role in one context never grants access to
+// the test executions of another.
+func (s *Service) authorizeTestExecution(ctx context.Context, contextID string, testExecID test.TestExecutionID, required auth.Role) error {
+	if err := s.authorize(ctx, contextID, required); err != nil {
+		return err
+	}
+	execContextID, err := s.repo.GetTestExecutionContext(ctx, testExecID)
+	if err != nil {
+		if errors.Is(err, test.ErrorTestExecutionNotFound) {
+			return connect.NewError(connect.CodeNotFound, err)
+		}
+		return err
+	}
+	if execContextID != contextID {
+		return connect.NewError(connect.CodeNotFound, test.ErrorTestExecutionNotFound)
+	}
+	return nil
+}
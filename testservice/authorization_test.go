@@ -0,0 +1,250 @@
+package testservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/annexsh/annex/auth"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_authorization(t *testing.T) {
+	contextID := "foo"
+
+	tests := []struct {
+		name     string
+		call     func(s *Service) error
+		wantRole auth.Role
+	}{
+		{
+			name: "GetTestExecution",
+			call: func(s *Service) error {
+				_, err := s.GetTestExecution(context.Background(), connect.NewRequest(&testsv1.GetTestExecutionRequest{
+					Context:         contextID,
+					TestExecutionId: uuid.NewString(),
+				}))
+				return err
+			},
+			wantRole: auth.RoleViewer,
+		},
+		{
+			name: "ExecuteTest",
+			call: func(s *Service) error {
+				_, err := s.ExecuteTest(context.Background(), connect.NewRequest(&testsv1.ExecuteTestRequest{
+					Context: contextID,
+					TestId:  uuid.NewString(),
+				}))
+				return err
+			},
+			wantRole: auth.RoleExecutor,
+		},
+		{
+			name: "RetryTestExecution",
+			call: func(s *Service) error {
+				_, err := s.RetryTestExecution(context.Background(), connect.NewRequest(&testsv1.RetryTestExecutionRequest{
+					Context:         contextID,
+					TestExecutionId: uuid.NewString(),
+				}))
+				return err
+			},
+			wantRole: auth.RoleExecutor,
+		},
+		{
+			name: "AckTestExecutionStarted",
+			call: func(s *Service) error {
+				_, err := s.AckTestExecutionStarted(context.Background(), connect.NewRequest(&testsv1.AckTestExecutionStartedRequest{
+					Context:         contextID,
+					TestExecutionId: uuid.NewString(),
+					StartTime:       timestamppb.Now(),
+				}))
+				return err
+			},
+			wantRole: auth.RoleRunner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthorizerMock{
+				AuthorizeFunc: func(ctx context.Context, gotContextID string, required auth.Role) error {
+					assert.Equal(t, contextID, gotContextID)
+					assert.Equal(t, tt.wantRole, required)
+					return auth.ErrorPermissionDenied
+				},
+			}
+
+			// Repository calls panic since no functions are mocked
+			s := New(&RepositoryMock{}, &PublisherMock{}, &WorkflowerMock{}, WithAuthorizer(a))
+
+			err := tt.call(s)
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			assert.Len(t, a.AuthorizeCalls(), 1)
+		})
+	}
+
+	t.Run("authorizer error", func(t *testing.T) {
+		wantErr := errors.New("bang")
+		a := &AuthorizerMock{
+			AuthorizeFunc: func(ctx context.Context, contextID string, required auth.Role) error {
+				return wantErr
+			},
+		}
+		s := New(&RepositoryMock{}, &PublisherMock{}, &WorkflowerMock{}, WithAuthorizer(a))
+
+		err := tests[0].call(s)
+		assert.ErrorIs(t, err, wantErr)
+	})
+}
+
+func TestService_authorization_otherContext(t *testing.T) {
+	// The caller is authorized in the requested context but the resources
+	// belong to another context
+	contextID := "foo"
+	otherContextID := "bar"
+	testExecID := test.NewTestExecutionID()
+
+	r := &RepositoryMock{
+		GetTestFunc: func(ctx context.Context, id uuid.V7) (*test.Test, error) {
+			return fake.GenTest(fake.WithContextID(otherContextID)), nil
+		},
+		GetTestExecutionContextFunc: func(ctx context.Context, id test.TestExecutionID) (string, error) {
+			return otherContextID, nil
+		},
+		GetArtifactFunc: func(ctx context.Context, id uuid.V7) (*test.Artifact, error) {
+			return &test.Artifact{ID: id, TestExecutionID: testExecID}, nil
+		},
+		UpdateTestExecutionStartedFunc: func(ctx context.Context, started *test.StartedTestExecution) (*test.UpdatedTestExecution, error) {
+			return &test.UpdatedTestExecution{
+				TestExecution: &test.TestExecution{ID: started.ID},
+				ContextID:     otherContextID,
+			}, nil
+		},
+	}
+	r.ExecuteTxFunc = func(ctx context.Context, query func(repo test.Repository) error) error {
+		return query(r)
+	}
+
+	a := &AuthorizerMock{
+		AuthorizeFunc: func(ctx context.Context, gotContextID string, required auth.Role) error {
+			assert.Equal(t, contextID, gotContextID)
+			return nil
+		},
+	}
+
+	// Repository calls beyond resolving the context of the resources panic
+	// since no other functions are mocked
+	s := New(r, &PublisherMock{}, &WorkflowerMock{}, WithAuthorizer(a), WithBlobStore(&BlobStoreMock{}))
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "GetTestDefaultInput",
+			call: func() error {
+				_, err := s.GetTestDefaultInput(context.Background(), connect.NewRequest(&testsv1.GetTestDefaultInputRequest{
+					Context: contextID,
+					TestId:  uuid.NewString(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "ExecuteTest",
+			call: func() error {
+				_, err := s.ExecuteTest(context.Background(), connect.NewRequest(&testsv1.ExecuteTestRequest{
+					Context: contextID,
+					TestId:  uuid.NewString(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "GetTestExecution",
+			call: func() error {
+				_, err := s.GetTestExecution(context.Background(), connect.NewRequest(&testsv1.GetTestExecutionRequest{
+					Context:         contextID,
+					TestExecutionId: testExecID.String(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "RetryTestExecution",
+			call: func() error {
+				_, err := s.RetryTestExecution(context.Background(), connect.NewRequest(&testsv1.RetryTestExecutionRequest{
+					Context:         contextID,
+					TestExecutionId: testExecID.String(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "AckTestExecutionStarted",
+			call: func() error {
+				_, err := s.AckTestExecutionStarted(context.Background(), connect.NewRequest(&testsv1.AckTestExecutionStartedRequest{
+					Context:         contextID,
+					TestExecutionId: testExecID.String(),
+					StartTime:       timestamppb.Now(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "PublishLog",
+			call: func() error {
+				_, err := s.PublishLog(context.Background(), connect.NewRequest(&testsv1.PublishLogRequest{
+					Context:         contextID,
+					TestExecutionId: testExecID.String(),
+					Level:           "INFO",
+					Message:         "foo",
+					CreateTime:      timestamppb.Now(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "ListTestExecutionEvents",
+			call: func() error {
+				_, err := s.ListTestExecutionEvents(context.Background(), connect.NewRequest(&executionsv1.ListTestExecutionEventsRequest{
+					Context:         contextID,
+					TestExecutionId: testExecID.String(),
+				}))
+				return err
+			},
+		},
+		{
+			name: "DownloadArtifact",
+			call: func() error {
+				cli, closer := newExecutionServiceServer(s)
+				defer closer()
+				stream, err := cli.DownloadArtifact(context.Background(), connect.NewRequest(&executionsv1.DownloadArtifactRequest{
+					Context:    contextID,
+					ArtifactId: uuid.NewString(),
+				}))
+				if err != nil {
+					return err
+				}
+				for stream.Receive() {
+				}
+				return stream.Err()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 
+	"github.com/annexsh/annex/auth"
 	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/pagination"
 	"github.com/annexsh/annex/test"
 )
@@ -26,6 +28,10 @@ func (s *Service) ListCaseExecutions(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithCaseExecutionID())
 	if err != nil {
 		return nil, err
@@ -62,6 +68,10 @@ func (s *Service) AckCaseExecutionScheduled(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	scheduled := &test.ScheduledCaseExecution{
 		ID:              test.CaseExecutionID(req.Msg.CaseExecutionId),
 		TestExecutionID: testExecID,
@@ -69,13 +79,21 @@ func (s *Service) AckCaseExecutionScheduled(
 		ScheduleTime:    req.Msg.ScheduleTime.AsTime().UTC(),
 	}
 
-	caseExec, err := s.repo.CreateCaseExecutionScheduled(ctx, scheduled)
+	var execEvent *executionsv1.ExecutionEvent
+
+	err = s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		caseExec, err := repo.CreateCaseExecutionScheduled(ctx, scheduled)
+		if err != nil {
+			return fmt.Errorf("failed to create case execution: %w", err)
+		}
+		execEvent = &executionsv1.ExecutionEvent{Event: event.NewCaseExecutionEvent(eventsv1.Event_TYPE_CASE_EXECUTION_SCHEDULED, caseExec.Proto())}
+		return recordEvent(ctx, repo, testExecID, execEvent)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create case execution: %w", err)
+		return nil, err
 	}
 
-	execEvent := event.NewCaseExecutionEvent(eventsv1.Event_TYPE_CASE_EXECUTION_SCHEDULED, caseExec.Proto())
-	if err = s.eventPub.Publish(caseExec.TestExecutionID.String(), execEvent); err != nil {
+	if err = publishEvent(ctx, s.eventPub, testExecID.String(), execEvent); err != nil {
 		return nil, fmt.Errorf("failed to publish case execution event: %w", err)
 	}
 
@@ -95,19 +113,31 @@ func (s *Service) AckCaseExecutionStarted(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	started := &test.StartedCaseExecution{
 		ID:              test.CaseExecutionID(req.Msg.CaseExecutionId),
 		TestExecutionID: testExecID,
 		StartTime:       req.Msg.StartTime.AsTime().UTC(),
 	}
 
-	caseExec, err := s.repo.UpdateCaseExecutionStarted(ctx, started)
+	var execEvent *executionsv1.ExecutionEvent
+
+	err = s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		caseExec, err := repo.UpdateCaseExecutionStarted(ctx, started)
+		if err != nil {
+			return fmt.Errorf("failed to create case execution: %w", err)
+		}
+		execEvent = &executionsv1.ExecutionEvent{Event: event.NewCaseExecutionEvent(eventsv1.Event_TYPE_CASE_EXECUTION_STARTED, caseExec.Proto())}
+		return recordEvent(ctx, repo, testExecID, execEvent)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create case execution: %w", err)
+		return nil, err
 	}
 
-	execEvent := event.NewCaseExecutionEvent(eventsv1.Event_TYPE_CASE_EXECUTION_STARTED, caseExec.Proto())
-	if err = s.eventPub.Publish(caseExec.TestExecutionID.String(), execEvent); err != nil {
+	if err = publishEvent(ctx, s.eventPub, testExecID.String(), execEvent); err != nil {
 		return nil, fmt.Errorf("failed to publish case execution event: %w", err)
 	}
 
@@ -127,6 +157,10 @@ func (s *Service) AckCaseExecutionFinished(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	finished := &test.FinishedCaseExecution{
 		ID:              test.CaseExecutionID(req.Msg.CaseExecutionId),
 		TestExecutionID: testExecID,
@@ -134,13 +168,25 @@ func (s *Service) AckCaseExecutionFinished(
 		Error:           req.Msg.Error,
 	}
 
-	caseExec, err := s.repo.UpdateCaseExecutionFinished(ctx, finished)
+	var execEvent *executionsv1.ExecutionEvent
+	var caseExec *test.CaseExecution
+
+	err = s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		var err error
+		caseExec, err = repo.UpdateCaseExecutionFinished(ctx, finished)
+		if err != nil {
+			return fmt.Errorf("failed to update case execution: %w", err)
+		}
+		execEvent = &executionsv1.ExecutionEvent{Event: event.NewCaseExecutionEvent(eventsv1.Event_TYPE_CASE_EXECUTION_FINISHED, caseExec.Proto())}
+		return recordEvent(ctx, repo, testExecID, execEvent)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update case execution: %w", err)
+		return nil, err
 	}
 
-	execEvent := event.NewCaseExecutionEvent(eventsv1.Event_TYPE_CASE_EXECUTION_FINISHED, caseExec.Proto())
-	if err = s.eventPub.Publish(caseExec.TestExecutionID.String(), execEvent); err != nil {
+	observeCaseExecutionFinished(req.Msg.Context, caseExec)
+
+	if err = publishEvent(ctx, s.eventPub, testExecID.String(), execEvent); err != nil {
 		return nil, fmt.Errorf("failed to publish case execution event: %w", err)
 	}
 
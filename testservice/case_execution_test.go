@@ -13,6 +13,7 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/fake"
 	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/test"
@@ -43,6 +44,8 @@ func TestService_ListCaseExecutions(t *testing.T) {
 		}
 	}
 
+	mockTestExecutionContext(r, "foo")
+
 	s := Service{repo: r}
 
 	req := &testsv1.ListCaseExecutionsRequest{
@@ -142,9 +145,13 @@ func TestService_AckCaseExecutionScheduled(t *testing.T) {
 			return wantCaseExec, nil
 		},
 	}
+	mockEventRecording(t, r)
+	mockTestExecutionContext(r, "foo")
 
 	p := &PublisherMock{
-		PublishFunc: func(testExecID string, e *eventsv1.Event) error {
+		PublishFunc: func(testExecID string, execEvent *executionsv1.ExecutionEvent) error {
+			e := execEvent.Event
+			assertEventSequence(t, execEvent)
 			assert.Equal(t, wantCaseExec.TestExecutionID.String(), testExecID)
 			assert.Equal(t, wantCaseExec.TestExecutionID.String(), e.TestExecutionId)
 			assert.Equal(t, eventsv1.Event_TYPE_CASE_EXECUTION_SCHEDULED, e.Type)
@@ -301,9 +308,13 @@ func TestService_AckCaseExecutionStarted(t *testing.T) {
 			return wantCaseExec, nil
 		},
 	}
+	mockEventRecording(t, r)
+	mockTestExecutionContext(r, "foo")
 
 	p := &PublisherMock{
-		PublishFunc: func(testExecID string, e *eventsv1.Event) error {
+		PublishFunc: func(testExecID string, execEvent *executionsv1.ExecutionEvent) error {
+			e := execEvent.Event
+			assertEventSequence(t, execEvent)
 			assert.Equal(t, wantCaseExec.TestExecutionID.String(), testExecID)
 			assert.Equal(t, wantCaseExec.TestExecutionID.String(), e.TestExecutionId)
 			assert.Equal(t, eventsv1.Event_TYPE_CASE_EXECUTION_STARTED, e.Type)
@@ -448,9 +459,13 @@ func TestService_AckCaseExecutionFinished(t *testing.T) {
 			return wantCaseExec, nil
 		},
 	}
+	mockEventRecording(t, r)
+	mockTestExecutionContext(r, "foo")
 
 	p := &PublisherMock{
-		PublishFunc: func(testExecID string, e *eventsv1.Event) error {
+		PublishFunc: func(testExecID string, execEvent *executionsv1.ExecutionEvent) error {
+			e := execEvent.Event
+			assertEventSequence(t, execEvent)
 			assert.Equal(t, wantCaseExec.TestExecutionID.String(), testExecID)
 			assert.Equal(t, wantCaseExec.TestExecutionID.String(), e.TestExecutionId)
 			assert.Equal(t, eventsv1.Event_TYPE_CASE_EXECUTION_FINISHED, e.Type)
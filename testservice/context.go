@@ -2,10 +2,13 @@ package testservice
 
 import (
 	"context"
+	"fmt"
 
 	"connectrpc.com/connect"
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
 	"github.com/annexsh/annex/internal/pagination"
 )
 
@@ -17,9 +20,20 @@ func (s *Service) RegisterContext(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.CreateContext(ctx, req.Msg.Context); err != nil {
 		return nil, err
 	}
+
+	s.recordAudit(ctx, &audit.Entry{
+		Context: req.Msg.Context,
+		Action:  audit.ActionRegisterContext,
+		Summary: fmt.Sprintf("registered context '%s'", req.Msg.Context),
+	})
+
 	return connect.NewResponse(&testsv1.RegisterContextResponse{}), nil
 }
 
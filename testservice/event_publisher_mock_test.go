@@ -4,8 +4,8 @@
 package testservice
 
 import (
-	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"sync"
 )
 
@@ -19,9 +19,12 @@ var _ event.Publisher = &PublisherMock{}
 //
 //		// make and configure a mocked event.Publisher
 //		mockedPublisher := &PublisherMock{
-//			PublishFunc: func(testExecID string, event *eventsv1.Event) error {
+//			PublishFunc: func(testExecID string, event *executionsv1.ExecutionEvent) error {
 //				panic("mock out the Publish method")
 //			},
+//			PublishBatchFunc: func(testExecID string, events []*executionsv1.ExecutionEvent) error {
+//				panic("mock out the PublishBatch method")
+//			},
 //		}
 //
 //		// use mockedPublisher in code that requires event.Publisher
@@ -30,7 +33,10 @@ var _ event.Publisher = &PublisherMock{}
 //	}
 type PublisherMock struct {
 	// PublishFunc mocks the Publish method.
-	PublishFunc func(testExecID string, event *eventsv1.Event) error
+	PublishFunc func(testExecID string, event *executionsv1.ExecutionEvent) error
+
+	// PublishBatchFunc mocks the PublishBatch method.
+	PublishBatchFunc func(testExecID string, events []*executionsv1.ExecutionEvent) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -39,20 +45,28 @@ type PublisherMock struct {
 			// TestExecID is the testExecID argument value.
 			TestExecID string
 			// Event is the event argument value.
-			Event *eventsv1.Event
+			Event *executionsv1.ExecutionEvent
+		}
+		// PublishBatch holds details about calls to the PublishBatch method.
+		PublishBatch []struct {
+			// TestExecID is the testExecID argument value.
+			TestExecID string
+			// Events is the events argument value.
+			Events []*executionsv1.ExecutionEvent
 		}
 	}
-	lockPublish sync.RWMutex
+	lockPublish      sync.RWMutex
+	lockPublishBatch sync.RWMutex
 }
 
 // Publish calls PublishFunc.
-func (mock *PublisherMock) Publish(testExecID string, event *eventsv1.Event) error {
+func (mock *PublisherMock) Publish(testExecID string, event *executionsv1.ExecutionEvent) error {
 	if mock.PublishFunc == nil {
 		panic("PublisherMock.PublishFunc: method is nil but Publisher.Publish was just called")
 	}
 	callInfo := struct {
 		TestExecID string
-		Event      *eventsv1.Event
+		Event      *executionsv1.ExecutionEvent
 	}{
 		TestExecID: testExecID,
 		Event:      event,
@@ -69,14 +83,50 @@ func (mock *PublisherMock) Publish(testExecID string, event *eventsv1.Event) err
 //	len(mockedPublisher.PublishCalls())
 func (mock *PublisherMock) PublishCalls() []struct {
 	TestExecID string
-	Event      *eventsv1.Event
+	Event      *executionsv1.ExecutionEvent
 } {
 	var calls []struct {
 		TestExecID string
-		Event      *eventsv1.Event
+		Event      *executionsv1.ExecutionEvent
 	}
 	mock.lockPublish.RLock()
 	calls = mock.calls.Publish
 	mock.lockPublish.RUnlock()
 	return calls
 }
+
+// PublishBatch calls PublishBatchFunc.
+func (mock *PublisherMock) PublishBatch(testExecID string, events []*executionsv1.ExecutionEvent) error {
+	if mock.PublishBatchFunc == nil {
+		panic("PublisherMock.PublishBatchFunc: method is nil but Publisher.PublishBatch was just called")
+	}
+	callInfo := struct {
+		TestExecID string
+		Events     []*executionsv1.ExecutionEvent
+	}{
+		TestExecID: testExecID,
+		Events:     events,
+	}
+	mock.lockPublishBatch.Lock()
+	mock.calls.PublishBatch = append(mock.calls.PublishBatch, callInfo)
+	mock.lockPublishBatch.Unlock()
+	return mock.PublishBatchFunc(testExecID, events)
+}
+
+// PublishBatchCalls gets all the calls that were made to PublishBatch.
+// Check the length with:
+//
+//	len(mockedPublisher.PublishBatchCalls())
+func (mock *PublisherMock) PublishBatchCalls() []struct {
+	TestExecID string
+	Events     []*executionsv1.ExecutionEvent
+} {
+	var calls []struct {
+		TestExecID string
+		Events     []*executionsv1.ExecutionEvent
+	}
+	mock.lockPublishBatch.RLock()
+	calls = mock.calls.PublishBatch
+	mock.lockPublishBatch.RUnlock()
+	return calls
+}
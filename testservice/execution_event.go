@@ -0,0 +1,158 @@
+package testservice
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/pagination"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+const tracerName = "github.com/annexsh/annex/testservice"
+
+func (s *Service) ListTestExecutionEvents(
+	ctx context.Context,
+	req *connect.Request[executionsv1.ListTestExecutionEventsRequest],
+) (*connect.Response[executionsv1.ListTestExecutionEventsResponse], error) {
+	if err := validateListTestExecutionEventsRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	testExecID, err := test.ParseTestExecutionID(req.Msg.TestExecutionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithSequence())
+	if err != nil {
+		return nil, err
+	}
+	if filter.OffsetID == nil && req.Msg.AfterSequence != nil {
+		filter.OffsetID = req.Msg.AfterSequence
+	}
+
+	// The last sequence is read before listing so that events recorded in
+	// between are never skipped by clients resuming from it
+	lastSeq, err := s.repo.GetExecutionEventSequence(ctx, testExecID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListExecutionEvents(ctx, testExecID, filter, test.LogFilterFromProto(req.Msg.LogFilter))
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(filter.Size, events, func(e *test.ExecutionEvent) uint64 {
+		return e.Sequence
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eventspb := make([]*executionsv1.ExecutionEvent, len(events))
+	for i, e := range events {
+		eventspb[i] = &executionsv1.ExecutionEvent{
+			Event:    e.Event,
+			Sequence: e.Sequence,
+		}
+	}
+
+	return connect.NewResponse(&executionsv1.ListTestExecutionEventsResponse{
+		Events:        eventspb,
+		NextPageToken: nextPageTkn,
+		LastSequence:  lastSeq,
+	}), nil
+}
+
+// recordEvent assigns the next sequence number of the test execution to the
+// event and stores it so that it can be replayed in order. It must be called
+// in the same transaction as the state change described by the event.
+func recordEvent(ctx context.Context, repo test.Repository, testExecID test.TestExecutionID, e *executionsv1.ExecutionEvent) error {
+	seq, err := repo.NextExecutionEventSequence(ctx, testExecID)
+	if err != nil {
+		return fmt.Errorf("failed to allocate event sequence: %w", err)
+	}
+	e.Sequence = seq
+
+	recorded := &test.ExecutionEvent{
+		TestExecutionID: testExecID,
+		Sequence:        seq,
+		Event:           e.Event,
+	}
+
+	switch data := e.Event.GetData().GetData().(type) {
+	case *eventsv1.Event_Data_CaseExecution:
+		recorded.CaseExecutionID = ptr.Get(test.CaseExecutionID(data.CaseExecution.Id))
+	case *eventsv1.Event_Data_Log:
+		logID, err := uuid.Parse(data.Log.Id)
+		if err != nil {
+			return fmt.Errorf("failed to parse log id: %w", err)
+		}
+		recorded.LogID = &logID
+	}
+
+	if err = repo.CreateExecutionEvent(ctx, recorded); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
+}
+
+// publishEvent publishes a test execution event to subscribers.
+func publishEvent(ctx context.Context, pub event.Publisher, testExecID string, e *executionsv1.ExecutionEvent) error {
+	_, span := otel.Tracer(tracerName).Start(ctx, "publish "+e.Event.Type.String(),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(testExecID),
+		),
+	)
+	defer span.End()
+
+	if err := pub.Publish(testExecID, e); err != nil {
+		eventPublishFailures.WithLabelValues(e.Event.Type.String()).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+// publishEvents publishes the events of a test execution as a single batch.
+func publishEvents(ctx context.Context, pub event.Publisher, testExecID string, events []*executionsv1.ExecutionEvent) error {
+	_, span := otel.Tracer(tracerName).Start(ctx, "publish batch",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(testExecID),
+			semconv.MessagingBatchMessageCount(len(events)),
+		),
+	)
+	defer span.End()
+
+	if err := pub.PublishBatch(testExecID, events); err != nil {
+		for _, e := range events {
+			eventPublishFailures.WithLabelValues(e.Event.Type.String()).Inc()
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package testservice
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_ListTestExecutionEvents(t *testing.T) {
+	pageSize := 2
+	testExecID := test.NewTestExecutionID()
+
+	wantPage1 := fake.GenExecutionEvents(testExecID, 0, 2)
+	wantPage2 := fake.GenExecutionEvents(testExecID, 2, 1)
+
+	r := new(RepositoryMock)
+	r.GetExecutionEventSequenceFunc = func(ctx context.Context, gotTestExecID test.TestExecutionID) (uint64, error) {
+		assert.Equal(t, testExecID, gotTestExecID)
+		return 4, nil
+	}
+	r.ListExecutionEventsFunc = func(ctx context.Context, gotTestExecID test.TestExecutionID, filter test.PageFilter[uint64], logFilter test.LogFilter) (test.ExecutionEventList, error) {
+		assert.Equal(t, testExecID, gotTestExecID)
+		assert.Equal(t, pageSize, filter.Size)
+		assert.Equal(t, "WARN", *logFilter.MinLevel)
+
+		switch len(r.ListExecutionEventsCalls()) {
+		case 1:
+			assert.Nil(t, filter.OffsetID)
+			return wantPage1, nil
+		case 2:
+			assert.Equal(t, wantPage1[pageSize-1].Sequence, *filter.OffsetID)
+			return wantPage2, nil
+		default:
+			panic("unexpected list invocation")
+		}
+	}
+
+	mockTestExecutionContext(r, "foo")
+
+	s := Service{repo: r}
+
+	req := &executionsv1.ListTestExecutionEventsRequest{
+		Context:         "foo",
+		TestExecutionId: testExecID.String(),
+		PageSize:        int32(pageSize),
+		LogFilter: &executionsv1.LogFilter{
+			MinLevel: ptr.Get("WARN"),
+		},
+	}
+	res, err := s.ListTestExecutionEvents(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assertEvents(t, wantPage1, res.Msg.Events)
+	assert.NotEmpty(t, res.Msg.NextPageToken)
+	assert.Equal(t, uint64(4), res.Msg.LastSequence)
+
+	req.NextPageToken = res.Msg.NextPageToken
+	res, err = s.ListTestExecutionEvents(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assertEvents(t, wantPage2, res.Msg.Events)
+	assert.Empty(t, res.Msg.NextPageToken)
+}
+
+func TestService_ListTestExecutionEvents_afterSequence(t *testing.T) {
+	testExecID := test.NewTestExecutionID()
+	want := fake.GenExecutionEvents(testExecID, 3, 1)
+
+	r := new(RepositoryMock)
+	r.GetExecutionEventSequenceFunc = func(ctx context.Context, gotTestExecID test.TestExecutionID) (uint64, error) {
+		return 4, nil
+	}
+	r.ListExecutionEventsFunc = func(ctx context.Context, gotTestExecID test.TestExecutionID, filter test.PageFilter[uint64], logFilter test.LogFilter) (test.ExecutionEventList, error) {
+		require.NotNil(t, filter.OffsetID)
+		assert.Equal(t, uint64(3), *filter.OffsetID)
+		return want, nil
+	}
+	mockTestExecutionContext(r, "foo")
+
+	s := Service{repo: r}
+
+	res, err := s.ListTestExecutionEvents(context.Background(), connect.NewRequest(&executionsv1.ListTestExecutionEventsRequest{
+		Context:         "foo",
+		TestExecutionId: testExecID.String(),
+		AfterSequence:   ptr.Get(uint64(3)),
+	}))
+	require.NoError(t, err)
+	assertEvents(t, want, res.Msg.Events)
+}
+
+func TestService_ListTestExecutionEvents_validation(t *testing.T) {
+	tests := []struct {
+		name               string
+		req                *executionsv1.ListTestExecutionEventsRequest
+		wantFieldViolation *errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name: "blank context",
+			req: &executionsv1.ListTestExecutionEventsRequest{
+				Context:         "",
+				TestExecutionId: uuid.NewString(),
+				PageSize:        1,
+			},
+			wantFieldViolation: wantBlankContextFieldViolation(),
+		},
+		{
+			name: "blank test execution id",
+			req: &executionsv1.ListTestExecutionEventsRequest{
+				Context:         "foo",
+				TestExecutionId: "",
+				PageSize:        1,
+			},
+			wantFieldViolation: wantBlankTestExecIDFieldViolation(),
+		},
+		{
+			name: "test execution id not a uuid",
+			req: &executionsv1.ListTestExecutionEventsRequest{
+				Context:         "foo",
+				TestExecutionId: "bar",
+				PageSize:        1,
+			},
+			wantFieldViolation: wantTestExecIDNotUUIDFieldViolation(),
+		},
+		{
+			name: "page size greater than max",
+			req: &executionsv1.ListTestExecutionEventsRequest{
+				Context:         "foo",
+				TestExecutionId: uuid.NewString(),
+				PageSize:        maxPageSize + 1,
+			},
+			wantFieldViolation: wantPageSizeFieldViolation(),
+		},
+		{
+			name: "unknown log filter min level",
+			req: &executionsv1.ListTestExecutionEventsRequest{
+				Context:         "foo",
+				TestExecutionId: uuid.NewString(),
+				PageSize:        1,
+				LogFilter: &executionsv1.LogFilter{
+					MinLevel: ptr.Get("LOUD"),
+				},
+			},
+			wantFieldViolation: &errdetails.BadRequest_FieldViolation{
+				Field:       "log_filter.min_level",
+				Description: "Min level is not valid",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Service{}
+			res, err := s.ListTestExecutionEvents(context.Background(), connect.NewRequest(tt.req))
+			require.Nil(t, res)
+			assertInvalidRequest(t, err, tt.wantFieldViolation)
+		})
+	}
+}
+
+func assertEvents(t *testing.T, want test.ExecutionEventList, got []*executionsv1.ExecutionEvent) {
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.True(t, proto.Equal(want[i].Event, got[i].Event))
+		assert.Equal(t, want[i].Sequence, got[i].Sequence)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"go.temporal.io/sdk/temporal"
 
 	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/log"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -25,23 +26,35 @@ import (
 const retryReason = "retry failed test execution"
 
 type executor struct {
-	repo     test.Repository
-	eventPub event.Publisher
-	temporal Workflower
-	logger   log.Logger
+	repo          test.Repository
+	eventPub      event.Publisher
+	temporal      Workflower
+	payloadCodec  converter.PayloadCodec
+	dataConverter converter.DataConverter
+	logger        log.Logger
 }
 
-func newExecutor(repo test.Repository, eventPub event.Publisher, workflower Workflower, logger log.Logger) *executor {
+func newExecutor(
+	repo test.Repository,
+	eventPub event.Publisher,
+	workflower Workflower,
+	payloadCodec converter.PayloadCodec,
+	dataConverter converter.DataConverter,
+	logger log.Logger,
+) *executor {
 	return &executor{
-		repo:     repo,
-		eventPub: eventPub,
-		temporal: workflower,
-		logger:   logger,
+		repo:          repo,
+		eventPub:      eventPub,
+		temporal:      workflower,
+		payloadCodec:  payloadCodec,
+		dataConverter: dataConverter,
+		logger:        logger,
 	}
 }
 
 type executeOptions struct {
-	payload *testsv1.Payload
+	payload  *testsv1.Payload
+	metadata test.ExecutionMetadata
 }
 
 type executeOption func(opts *executeOptions)
@@ -52,6 +65,12 @@ func withInput(input *testsv1.Payload) executeOption {
 	}
 }
 
+func withMetadata(metadata test.ExecutionMetadata) executeOption {
+	return func(opts *executeOptions) {
+		opts.metadata = metadata
+	}
+}
+
 func (e *executor) execute(ctx context.Context, t *test.Test, opts ...executeOption) (*test.TestExecution, error) {
 	var options executeOptions
 	for _, opt := range opts {
@@ -62,6 +81,7 @@ func (e *executor) execute(ctx context.Context, t *test.Test, opts ...executeOpt
 	workflowID := execID.WorkflowID()
 
 	var testExec *test.TestExecution
+	var execEvent *executionsv1.ExecutionEvent
 
 	err := e.repo.ExecuteTx(ctx, func(repo test.Repository) error {
 		var err error
@@ -70,6 +90,7 @@ func (e *executor) execute(ctx context.Context, t *test.Test, opts ...executeOpt
 			TestID:       t.ID,
 			HasInput:     t.HasInput,
 			ScheduleTime: time.Now().UTC(),
+			Metadata:     options.metadata,
 		})
 		if err != nil {
 			return err
@@ -81,20 +102,27 @@ func (e *executor) execute(ctx context.Context, t *test.Test, opts ...executeOpt
 			if options.payload.Metadata == nil {
 				return errors.New("test execution input metadata required")
 			}
-			input := &test.Payload{
+			input, err := encodePayload(e.payloadCodec, &test.Payload{
 				Metadata: options.payload.Metadata,
 				Data:     options.payload.Data,
+			})
+			if err != nil {
+				return err
+			}
+			if err = repo.CreateTestExecutionInput(ctx, testExec.ID, input); err != nil {
+				return err
 			}
-			return repo.CreateTestExecutionInput(ctx, testExec.ID, input)
 		}
-		return nil
+		execEvent = &executionsv1.ExecutionEvent{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_SCHEDULED, testExec.Proto())}
+		return recordEvent(ctx, repo, testExec.ID, execEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	execEvent := event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_SCHEDULED, testExec.Proto())
-	if err = e.eventPub.Publish(testExec.ID.String(), execEvent); err != nil {
+	testExecutionsScheduled.WithLabelValues(t.ContextID, t.TestSuiteID.String()).Inc()
+
+	if err = publishEvent(ctx, e.eventPub, testExec.ID.String(), execEvent); err != nil {
 		return nil, fmt.Errorf("failed to publish test execution event: %w", err)
 	}
 
@@ -216,8 +244,7 @@ func (e *executor) retry(ctx context.Context, execID test.TestExecutionID) (*tes
 					// Local activity should just have a single payload
 					if len(data.Payloads) == 1 {
 						var logResult struct{ LogID uuid.V7 }
-						dc := converter.GetDefaultDataConverter()
-						if err = dc.FromPayload(data.Payloads[0], &logResult); err != nil {
+						if err = e.dataConverter.FromPayload(data.Payloads[0], &logResult); err != nil {
 							return nil, err
 						}
 						testLogsToDelete.Remove(logResult.LogID)
@@ -275,7 +302,7 @@ func (e *executor) getAllLogs(ctx context.Context, testExecID test.TestExecution
 		page, err := e.repo.ListLogs(ctx, testExecID, test.PageFilter[uuid.V7]{
 			Size:     pageSize,
 			OffsetID: offsetID,
-		})
+		}, test.LogFilter{})
 		if err != nil {
 			return nil, err
 		}
@@ -310,6 +337,17 @@ func (e *executor) resetTestExecution(ctx context.Context, testExec *test.TestEx
 			return err
 		}
 
+		// The reset test execution is started and finished again so the
+		// recorded events of the previous run no longer apply
+		for _, eventType := range []eventsv1.Event_Type{
+			eventsv1.Event_TYPE_TEST_EXECUTION_STARTED,
+			eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED,
+		} {
+			if err = repo.DeleteExecutionEvents(ctx, testExec.ID, eventType); err != nil {
+				return err
+			}
+		}
+
 		_, err = e.temporal.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
 			Namespace: "default", // TODO: allow custom
 			WorkflowExecution: &common.WorkflowExecution{
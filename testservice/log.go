@@ -2,13 +2,16 @@ package testservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
 	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 
+	"github.com/annexsh/annex/auth"
 	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/pagination"
 	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/test"
@@ -28,6 +31,10 @@ func (s *Service) PublishLog(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	var caseExecID *test.CaseExecutionID
 	if req.Msg.CaseExecutionId != nil {
 		caseExecID = ptr.Get(test.CaseExecutionID(*req.Msg.CaseExecutionId))
@@ -42,25 +49,193 @@ func (s *Service) PublishLog(
 		CreateTime:      req.Msg.CreateTime.AsTime().UTC(),
 	}
 
+	if s.logRedactor != nil {
+		secrets, err := s.getInputSecrets(ctx, testExecID)
+		if err != nil {
+			return nil, err
+		}
+		s.redactLog(execLog, secrets)
+	}
+
+	var execEvent *executionsv1.ExecutionEvent
+
 	err = s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
-		if err = s.repo.CreateLog(ctx, execLog); err != nil {
+		if err := repo.CreateLog(ctx, execLog); err != nil {
 			return err
 		}
+		execEvent = newLogPublishedEvent(execLog)
+		return recordEvent(ctx, repo, testExecID, execEvent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = publishEvent(ctx, s.eventPub, testExecID.String(), execEvent); err != nil {
+		return nil, fmt.Errorf("failed to publish log event: %w", err)
+	}
+
+	return connect.NewResponse(&testsv1.PublishLogResponse{
+		LogId: execLog.ID.String(),
+	}), nil
+}
+
+func (s *Service) PublishLogs(
+	ctx context.Context,
+	stream *connect.ClientStream[executionsv1.PublishLogsRequest],
+) (_ *connect.Response[executionsv1.PublishLogsResponse], err error) {
+	var contextID string
+	var testExecID test.TestExecutionID
+	var secrets []string
+	logIDs := []string{}
+
+	// The logs of each message are committed as they're received, so the logs
+	// published before a failure are reported to the caller
+	defer func() {
+		if err != nil {
+			err = partialPublishLogsError(err, logIDs)
+		}
+	}()
+
+	for i := 0; stream.Receive(); i++ {
+		msg := stream.Msg()
 
-		execEvent := event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, execLog.Proto())
-		if err = s.eventPub.Publish(execLog.TestExecutionID.String(), execEvent); err != nil {
-			return fmt.Errorf("failed to publish log event: %w", err)
+		if err := validatePublishLogsMessage(i, msg); err != nil {
+			return nil, err
 		}
 
+		if i == 0 {
+			// Subsequent messages must have the same context and test
+			// execution as the first message
+			var err error
+			contextID = msg.Context
+			if testExecID, err = test.ParseTestExecutionID(msg.TestExecutionId); err != nil {
+				return nil, err
+			}
+
+			if err = s.authorizeTestExecution(ctx, contextID, testExecID, auth.RoleRunner); err != nil {
+				return nil, err
+			}
+
+			if s.logRedactor != nil {
+				if secrets, err = s.getInputSecrets(ctx, testExecID); err != nil {
+					return nil, err
+				}
+			}
+		} else if err := validatePublishLogsMessageMismatch(i, msg, contextID, testExecID); err != nil {
+			return nil, err
+		}
+
+		logs, err := s.publishLogs(ctx, testExecID, msg.Logs, secrets)
+		if err != nil {
+			return nil, err
+		}
+		for _, execLog := range logs {
+			logIDs = append(logIDs, execLog.ID.String())
+		}
+	}
+
+	if err = stream.Err(); err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&executionsv1.PublishLogsResponse{
+		LogIds: logIDs,
+	}), nil
+}
+
+// partialPublishLogsError adds the IDs of the logs that were published before
+// a batch log stream failed to the error as a PublishLogsResponse error
+// detail.
+func partialPublishLogsError(err error, logIDs []string) error {
+	if len(logIDs) == 0 {
+		return err
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		connectErr = connect.NewError(connect.CodeOf(err), err)
+	}
+	if detail, detailErr := connect.NewErrorDetail(&executionsv1.PublishLogsResponse{LogIds: logIDs}); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// publishLogs stores the log entries in a single transaction and publishes
+// their events as a single batch, preserving the order of the entries.
+func (s *Service) publishLogs(ctx context.Context, testExecID test.TestExecutionID, entries []*executionsv1.LogEntry, secrets []string) (test.LogList, error) {
+	logs := make(test.LogList, len(entries))
+	for i, entry := range entries {
+		var caseExecID *test.CaseExecutionID
+		if entry.CaseExecutionId != nil {
+			caseExecID = ptr.Get(test.CaseExecutionID(*entry.CaseExecutionId))
+		}
+
+		// UUIDv7 IDs are generated in ascending order so logs are listed in
+		// the order they were sent
+		logs[i] = &test.Log{
+			ID:              uuid.New(),
+			TestExecutionID: testExecID,
+			CaseExecutionID: caseExecID,
+			Level:           entry.Level,
+			Message:         entry.Message,
+			Attributes:      entry.Attributes,
+			CreateTime:      entry.CreateTime.AsTime().UTC(),
+		}
+
+		if s.logRedactor != nil {
+			s.redactLog(logs[i], secrets)
+		}
+	}
+
+	execEvents := make([]*executionsv1.ExecutionEvent, len(logs))
+
+	err := s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		if err := repo.CreateLogs(ctx, logs); err != nil {
+			return err
+		}
+		for i, execLog := range logs {
+			execEvents[i] = newLogPublishedEvent(execLog)
+			if err := recordEvent(ctx, repo, testExecID, execEvents[i]); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return connect.NewResponse(&testsv1.PublishLogResponse{
-		LogId: execLog.ID.String(),
-	}), nil
+	if err = publishEvents(ctx, s.eventPub, testExecID.String(), execEvents); err != nil {
+		return nil, fmt.Errorf("failed to publish log events: %w", err)
+	}
+
+	return logs, nil
+}
+
+// newLogPublishedEvent creates the event of a published log. The attributes
+// of the log are carried by the event since testsv1.Log does not declare them.
+func newLogPublishedEvent(execLog *test.Log) *executionsv1.ExecutionEvent {
+	return &executionsv1.ExecutionEvent{
+		Event:         event.NewLogEvent(eventsv1.Event_TYPE_LOG_PUBLISHED, execLog.Proto()),
+		LogAttributes: execLog.Attributes,
+	}
+}
+
+// redactLog redacts secrets from the message and attribute values of the log.
+func (s *Service) redactLog(execLog *test.Log, secrets []string) {
+	var count int
+	execLog.Message, count = s.logRedactor.Redact(execLog.Message, secrets...)
+	execLog.RedactionCount = count
+
+	if len(execLog.Attributes) == 0 {
+		return
+	}
+	attrs := make(map[string]string, len(execLog.Attributes))
+	for key, value := range execLog.Attributes {
+		attrs[key], count = s.logRedactor.Redact(value, secrets...)
+		execLog.RedactionCount += count
+	}
+	execLog.Attributes = attrs
 }
 
 func (s *Service) ListTestExecutionLogs(
@@ -76,12 +251,16 @@ func (s *Service) ListTestExecutionLogs(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithUUID())
 	if err != nil {
 		return nil, err
 	}
 
-	logs, err := s.repo.ListLogs(ctx, testExecID, filter)
+	logs, err := s.repo.ListLogs(ctx, testExecID, filter, test.LogFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -98,3 +277,43 @@ func (s *Service) ListTestExecutionLogs(
 		NextPageToken: nextPageTkn,
 	}), nil
 }
+
+func (s *Service) SearchTestExecutionLogs(
+	ctx context.Context,
+	req *connect.Request[executionsv1.SearchTestExecutionLogsRequest],
+) (*connect.Response[executionsv1.SearchTestExecutionLogsResponse], error) {
+	if err := validateSearchTestExecutionLogsRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	testExecID, err := test.ParseTestExecutionID(req.Msg.TestExecutionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.ListLogs(ctx, testExecID, filter, test.LogFilterFromProto(req.Msg.Filter))
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(filter.Size, logs, func(log *test.Log) uuid.V7 {
+		return log.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&executionsv1.SearchTestExecutionLogsResponse{
+		Logs:          logs.ExecutionProto(),
+		NextPageToken: nextPageTkn,
+	}), nil
+}
@@ -0,0 +1,67 @@
+package testservice
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/annexsh/annex/internal/metrics"
+	"github.com/annexsh/annex/test"
+)
+
+const (
+	outcomePassed = "passed"
+	outcomeFailed = "failed"
+)
+
+var (
+	testExecutionsScheduled = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "test_executions_scheduled_total",
+		Help:      "Total number of scheduled test executions by context and test suite.",
+	}, []string{"context", "suite_id"})
+
+	testExecutionsStarted = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "test_executions_started_total",
+		Help:      "Total number of started test executions by context and test suite.",
+	}, []string{"context", "suite_id"})
+
+	testExecutionsFinished = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "test_executions_finished_total",
+		Help:      "Total number of finished test executions by context, test suite and outcome.",
+	}, []string{"context", "suite_id", "outcome"})
+
+	testExecutionRetries = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "test_execution_retries_total",
+		Help:      "Total number of retried test executions by context.",
+	}, []string{"context"})
+
+	caseExecutionDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Name:      "case_execution_duration_seconds",
+		Help:      "Duration of finished case executions by context and outcome.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"context", "outcome"})
+
+	eventPublishFailures = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "event_publish_failures_total",
+		Help:      "Total number of test execution events that failed to publish by event type.",
+	}, []string{"event_type"})
+)
+
+func observeCaseExecutionFinished(contextID string, caseExec *test.CaseExecution) {
+	if caseExec.StartTime == nil || caseExec.FinishTime == nil {
+		return
+	}
+	duration := caseExec.FinishTime.Sub(*caseExec.StartTime)
+	caseExecutionDuration.WithLabelValues(contextID, outcome(caseExec.Error)).Observe(duration.Seconds())
+}
+
+func outcome(execErr *string) string {
+	if execErr != nil {
+		return outcomeFailed
+	}
+	return outcomePassed
+}
@@ -0,0 +1,205 @@
+package testservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/test"
+)
+
+const (
+	redactedValue        = "[REDACTED]"
+	inputSecretCacheSize = 1000
+)
+
+// encodePayload encodes a payload to be stored using the codec. The payload
+// is returned unchanged if the codec is nil.
+func encodePayload(codec converter.PayloadCodec, p *test.Payload) (*test.Payload, error) {
+	if codec == nil {
+		return p, nil
+	}
+	encoded, err := codec.Encode([]*commonpb.Payload{{Metadata: p.Metadata, Data: p.Data}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return &test.Payload{Metadata: encoded[0].Metadata, Data: encoded[0].Data}, nil
+}
+
+// decodePayload decodes a stored payload using the codec. The payload is
+// returned unchanged if the codec is nil.
+func decodePayload(codec converter.PayloadCodec, p *test.Payload) (*test.Payload, error) {
+	if codec == nil {
+		return p, nil
+	}
+	decoded, err := codec.Decode([]*commonpb.Payload{{Metadata: p.Metadata, Data: p.Data}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	return &test.Payload{Metadata: decoded[0].Metadata, Data: decoded[0].Data}, nil
+}
+
+// canViewSecrets reports whether the caller can view the secret fields of
+// payloads in the context. Secrets can be viewed by callers that can execute
+// tests, and therefore provide the inputs themselves.
+func (s *Service) canViewSecrets(ctx context.Context, contextID string) (bool, error) {
+	if len(s.secretFields) == 0 || s.authorizer == nil {
+		return true, nil
+	}
+	if err := s.authorizer.Authorize(ctx, contextID, auth.RoleExecutor); err != nil {
+		if errors.Is(err, auth.ErrorPermissionDenied) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// redactPayload replaces the values of secret fields in the JSON data of the
+// payload. The entire data is redacted if it is not JSON since secret fields
+// cannot be located.
+func redactPayload(p *testsv1.Payload, secretFields map[string]bool) *testsv1.Payload {
+	redacted := &testsv1.Payload{Metadata: p.Metadata}
+
+	dec := json.NewDecoder(bytes.NewReader(p.Data))
+	dec.UseNumber()
+	var data any
+	if err := dec.Decode(&data); err != nil {
+		redacted.Data = []byte(redactedValue)
+		return redacted
+	}
+
+	data = redactValue(data, secretFields)
+	redactedData, err := json.Marshal(data)
+	if err != nil {
+		redacted.Data = []byte(redactedValue)
+		return redacted
+	}
+	redacted.Data = redactedData
+	return redacted
+}
+
+func redactValue(v any, secretFields map[string]bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, fieldVal := range val {
+			if secretFields[strings.ToLower(key)] {
+				val[key] = redactedValue
+			} else {
+				val[key] = redactValue(fieldVal, secretFields)
+			}
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = redactValue(item, secretFields)
+		}
+	}
+	return v
+}
+
+// getInputSecrets returns the values of the secret fields in the input of the
+// test execution. Inputs can't change once created so secrets are cached.
+func (s *Service) getInputSecrets(ctx context.Context, id test.TestExecutionID) ([]string, error) {
+	if len(s.secretFields) == 0 {
+		return nil, nil
+	}
+	if secrets, ok := s.inputSecrets.get(id); ok {
+		return secrets, nil
+	}
+
+	var secrets []string
+	input, err := s.repo.GetTestExecutionInput(ctx, id)
+	if err != nil && !errors.Is(err, test.ErrorTestExecutionPayloadNotFound) {
+		return nil, err
+	}
+	if input != nil {
+		if input, err = decodePayload(s.payloadCodec, input); err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(input.Data))
+		dec.UseNumber()
+		var data any
+		if err = dec.Decode(&data); err == nil {
+			secrets = collectSecrets(data, s.secretFields, false, nil)
+		}
+	}
+
+	s.inputSecrets.add(id, secrets)
+	return secrets, nil
+}
+
+// collectSecrets collects the string and number values of secret fields,
+// including all values nested within secret fields.
+func collectSecrets(v any, secretFields map[string]bool, secret bool, secrets []string) []string {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, fieldVal := range val {
+			secrets = collectSecrets(fieldVal, secretFields, secret || secretFields[strings.ToLower(key)], secrets)
+		}
+	case []any:
+		for _, item := range val {
+			secrets = collectSecrets(item, secretFields, secret, secrets)
+		}
+	case string:
+		if secret {
+			secrets = append(secrets, val)
+		}
+	case json.Number:
+		if secret {
+			secrets = append(secrets, val.String())
+		}
+	}
+	return secrets
+}
+
+// secretCache holds the input secrets of recent test executions. The oldest
+// entry is evicted once the cache is full.
+type secretCache struct {
+	mu      sync.Mutex
+	entries map[test.TestExecutionID][]string
+	order   []test.TestExecutionID
+	size    int
+}
+
+func newSecretCache(size int) *secretCache {
+	return &secretCache{
+		entries: map[test.TestExecutionID][]string{},
+		size:    size,
+	}
+}
+
+func (c *secretCache) get(id test.TestExecutionID) ([]string, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	secrets, ok := c.entries[id]
+	return secrets, ok
+}
+
+func (c *secretCache) add(id test.TestExecutionID, secrets []string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[id]; ok {
+		return
+	}
+	if len(c.order) >= c.size {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[id] = secrets
+	c.order = append(c.order, id)
+}
@@ -0,0 +1,144 @@
+package testservice
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/converter"
+
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/codec"
+	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/test"
+	"github.com/annexsh/annex/uuid"
+)
+
+func TestService_GetTestExecution_encryptedInput(t *testing.T) {
+	aesCodec, err := codec.NewAESCodec("test", bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	input := &test.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON)},
+		Data:     []byte(`{"user":"alice","password":"hunter2","nested":[{"Token":"abc"}]}`),
+	}
+	stored, err := encodePayload(aesCodec, input)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored.Data), "hunter2")
+
+	testExec := fake.GenTestExec(uuid.New())
+	testExec.HasInput = true
+
+	r := &RepositoryMock{
+		GetTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
+			return testExec, nil
+		},
+		GetTestExecutionInputFunc: func(ctx context.Context, id test.TestExecutionID) (*test.Payload, error) {
+			return stored, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		allowed   bool
+		wantInput string
+	}{
+		{
+			name:      "executor sees secrets",
+			allowed:   true,
+			wantInput: `{"user":"alice","password":"hunter2","nested":[{"Token":"abc"}]}`,
+		},
+		{
+			name:      "viewer sees redacted secrets",
+			allowed:   false,
+			wantInput: `{"nested":[{"Token":"[REDACTED]"}],"password":"[REDACTED]","user":"alice"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authorizer := &AuthorizerMock{
+				AuthorizeFunc: func(ctx context.Context, contextID string, required auth.Role) error {
+					if required == auth.RoleExecutor && !tt.allowed {
+						return auth.ErrorPermissionDenied
+					}
+					return nil
+				},
+			}
+
+			mockTestExecutionContext(r, "foo")
+
+			s := New(r, nil, nil,
+				WithAuthorizer(authorizer),
+				WithPayloadCodec(aesCodec),
+				WithSecretFields("password", "token"),
+			)
+
+			req := &testsv1.GetTestExecutionRequest{
+				Context:         "foo",
+				TestExecutionId: testExec.ID.String(),
+			}
+			res, err := s.GetTestExecution(context.Background(), connect.NewRequest(req))
+			require.NoError(t, err)
+
+			assert.Equal(t, input.Metadata, res.Msg.Input.Metadata)
+			assert.Equal(t, tt.wantInput, string(res.Msg.Input.Data))
+		})
+	}
+}
+
+func TestService_GetTestDefaultInput_redacted(t *testing.T) {
+	aesCodec, err := codec.NewAESCodec("test", bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	stored, err := encodePayload(aesCodec, &test.Payload{
+		Metadata: map[string][]byte{converter.MetadataEncoding: []byte(converter.MetadataEncodingJSON)},
+		Data:     []byte(`{"user":"alice","password":"hunter2"}`),
+	})
+	require.NoError(t, err)
+
+	wantTest := fake.GenTest(fake.WithContextID("foo"))
+
+	r := &RepositoryMock{
+		GetTestFunc: func(ctx context.Context, id uuid.V7) (*test.Test, error) {
+			return wantTest, nil
+		},
+		GetTestDefaultInputFunc: func(ctx context.Context, testID uuid.V7) (*test.Payload, error) {
+			return stored, nil
+		},
+	}
+
+	// Viewers can't see secrets
+	authorizer := &AuthorizerMock{
+		AuthorizeFunc: func(ctx context.Context, contextID string, required auth.Role) error {
+			if required == auth.RoleExecutor {
+				return auth.ErrorPermissionDenied
+			}
+			return nil
+		},
+	}
+
+	s := New(r, nil, nil,
+		WithAuthorizer(authorizer),
+		WithPayloadCodec(aesCodec),
+		WithSecretFields("password"),
+	)
+
+	req := &testsv1.GetTestDefaultInputRequest{
+		Context: "foo",
+		TestId:  wantTest.ID.String(),
+	}
+	res, err := s.GetTestDefaultInput(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, `{"password":"[REDACTED]","user":"alice"}`, res.Msg.DefaultInput)
+}
+
+func TestRedactPayload_notJSON(t *testing.T) {
+	p := &testsv1.Payload{Data: []byte("password=hunter2")}
+	got := redactPayload(p, map[string]bool{"password": true})
+	assert.Equal(t, redactedValue, string(got.Data))
+}
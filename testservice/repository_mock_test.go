@@ -5,6 +5,7 @@ package testservice
 
 import (
 	"context"
+	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
 	"sync"
@@ -21,15 +22,24 @@ var _ test.Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked test.Repository
 //		mockedRepository := &RepositoryMock{
+//			CreateArtifactFunc: func(ctx context.Context, artifact *test.Artifact) error {
+//				panic("mock out the CreateArtifact method")
+//			},
 //			CreateCaseExecutionScheduledFunc: func(ctx context.Context, scheduled *test.ScheduledCaseExecution) (*test.CaseExecution, error) {
 //				panic("mock out the CreateCaseExecutionScheduled method")
 //			},
 //			CreateContextFunc: func(ctx context.Context, id string) error {
 //				panic("mock out the CreateContext method")
 //			},
+//			CreateExecutionEventFunc: func(ctx context.Context, event *test.ExecutionEvent) error {
+//				panic("mock out the CreateExecutionEvent method")
+//			},
 //			CreateLogFunc: func(ctx context.Context, log *test.Log) error {
 //				panic("mock out the CreateLog method")
 //			},
+//			CreateLogsFunc: func(ctx context.Context, logs test.LogList) error {
+//				panic("mock out the CreateLogs method")
+//			},
 //			CreateTestFunc: func(ctx context.Context, testMoqParam *test.Test) (*test.Test, error) {
 //				panic("mock out the CreateTest method")
 //			},
@@ -48,18 +58,33 @@ var _ test.Repository = &RepositoryMock{}
 //			DeleteCaseExecutionFunc: func(ctx context.Context, testExecID test.TestExecutionID, id test.CaseExecutionID) error {
 //				panic("mock out the DeleteCaseExecution method")
 //			},
+//			DeleteExecutionEventsFunc: func(ctx context.Context, testExecID test.TestExecutionID, eventType eventsv1.Event_Type) error {
+//				panic("mock out the DeleteExecutionEvents method")
+//			},
 //			DeleteLogFunc: func(ctx context.Context, id uuid.V7) error {
 //				panic("mock out the DeleteLog method")
 //			},
+//			DeletePendingBlobDeletionFunc: func(ctx context.Context, blobKey string) error {
+//				panic("mock out the DeletePendingBlobDeletion method")
+//			},
 //			DeleteTestFunc: func(ctx context.Context, id uuid.V7) error {
 //				panic("mock out the DeleteTest method")
 //			},
+//			DeleteTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.DeletedTestExecution, error) {
+//				panic("mock out the DeleteTestExecution method")
+//			},
 //			ExecuteTxFunc: func(ctx context.Context, query func(repo test.Repository) error) error {
 //				panic("mock out the ExecuteTx method")
 //			},
+//			GetArtifactFunc: func(ctx context.Context, id uuid.V7) (*test.Artifact, error) {
+//				panic("mock out the GetArtifact method")
+//			},
 //			GetCaseExecutionFunc: func(ctx context.Context, testExecID test.TestExecutionID, caseExecID test.CaseExecutionID) (*test.CaseExecution, error) {
 //				panic("mock out the GetCaseExecution method")
 //			},
+//			GetExecutionEventSequenceFunc: func(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+//				panic("mock out the GetExecutionEventSequence method")
+//			},
 //			GetLogFunc: func(ctx context.Context, id uuid.V7) (*test.Log, error) {
 //				panic("mock out the GetLog method")
 //			},
@@ -72,21 +97,36 @@ var _ test.Repository = &RepositoryMock{}
 //			GetTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
 //				panic("mock out the GetTestExecution method")
 //			},
+//			GetTestExecutionContextFunc: func(ctx context.Context, id test.TestExecutionID) (string, error) {
+//				panic("mock out the GetTestExecutionContext method")
+//			},
 //			GetTestExecutionInputFunc: func(ctx context.Context, id test.TestExecutionID) (*test.Payload, error) {
 //				panic("mock out the GetTestExecutionInput method")
 //			},
 //			GetTestSuiteVersionFunc: func(ctx context.Context, contextID string, id uuid.V7) (string, error) {
 //				panic("mock out the GetTestSuiteVersion method")
 //			},
+//			ListArtifactsFunc: func(ctx context.Context, testExecID test.TestExecutionID, caseExecID *test.CaseExecutionID, filter test.PageFilter[uuid.V7]) (test.ArtifactList, error) {
+//				panic("mock out the ListArtifacts method")
+//			},
 //			ListCaseExecutionsFunc: func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[test.CaseExecutionID]) (test.CaseExecutionList, error) {
 //				panic("mock out the ListCaseExecutions method")
 //			},
 //			ListContextsFunc: func(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
 //				panic("mock out the ListContexts method")
 //			},
-//			ListLogsFunc: func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7]) (test.LogList, error) {
+//			ListExecutionEventsFunc: func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uint64], logFilter test.LogFilter) (test.ExecutionEventList, error) {
+//				panic("mock out the ListExecutionEvents method")
+//			},
+//			ListExpiredTestExecutionsFunc: func(ctx context.Context, contextID string, filter test.ExpiredFilter) ([]test.TestExecutionID, error) {
+//				panic("mock out the ListExpiredTestExecutions method")
+//			},
+//			ListLogsFunc: func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7], logFilter test.LogFilter) (test.LogList, error) {
 //				panic("mock out the ListLogs method")
 //			},
+//			ListPendingBlobDeletionsFunc: func(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+//				panic("mock out the ListPendingBlobDeletions method")
+//			},
 //			ListTestExecutionsFunc: func(ctx context.Context, testID uuid.V7, filter test.PageFilter[test.TestExecutionID]) (test.TestExecutionList, error) {
 //				panic("mock out the ListTestExecutions method")
 //			},
@@ -96,19 +136,25 @@ var _ test.Repository = &RepositoryMock{}
 //			ListTestsFunc: func(ctx context.Context, contextID string, testSuiteID uuid.V7, filter test.PageFilter[uuid.V7]) (test.TestList, error) {
 //				panic("mock out the ListTests method")
 //			},
+//			NextExecutionEventSequenceFunc: func(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+//				panic("mock out the NextExecutionEventSequence method")
+//			},
 //			ResetTestExecutionFunc: func(ctx context.Context, testExecID test.TestExecutionID, resetTime time.Time) (*test.TestExecution, error) {
 //				panic("mock out the ResetTestExecution method")
 //			},
+//			SearchTestExecutionsFunc: func(ctx context.Context, contextID string, filter test.PageFilter[test.TestExecutionID], execFilter test.TestExecutionFilter) (test.TestExecutionList, error) {
+//				panic("mock out the SearchTestExecutions method")
+//			},
 //			UpdateCaseExecutionFinishedFunc: func(ctx context.Context, finished *test.FinishedCaseExecution) (*test.CaseExecution, error) {
 //				panic("mock out the UpdateCaseExecutionFinished method")
 //			},
 //			UpdateCaseExecutionStartedFunc: func(ctx context.Context, started *test.StartedCaseExecution) (*test.CaseExecution, error) {
 //				panic("mock out the UpdateCaseExecutionStarted method")
 //			},
-//			UpdateTestExecutionFinishedFunc: func(ctx context.Context, finished *test.FinishedTestExecution) (*test.TestExecution, error) {
+//			UpdateTestExecutionFinishedFunc: func(ctx context.Context, finished *test.FinishedTestExecution) (*test.UpdatedTestExecution, error) {
 //				panic("mock out the UpdateTestExecutionFinished method")
 //			},
-//			UpdateTestExecutionStartedFunc: func(ctx context.Context, started *test.StartedTestExecution) (*test.TestExecution, error) {
+//			UpdateTestExecutionStartedFunc: func(ctx context.Context, started *test.StartedTestExecution) (*test.UpdatedTestExecution, error) {
 //				panic("mock out the UpdateTestExecutionStarted method")
 //			},
 //			WithTxFunc: func(ctx context.Context) (test.Repository, test.Tx, error) {
@@ -121,15 +167,24 @@ var _ test.Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// CreateArtifactFunc mocks the CreateArtifact method.
+	CreateArtifactFunc func(ctx context.Context, artifact *test.Artifact) error
+
 	// CreateCaseExecutionScheduledFunc mocks the CreateCaseExecutionScheduled method.
 	CreateCaseExecutionScheduledFunc func(ctx context.Context, scheduled *test.ScheduledCaseExecution) (*test.CaseExecution, error)
 
 	// CreateContextFunc mocks the CreateContext method.
 	CreateContextFunc func(ctx context.Context, id string) error
 
+	// CreateExecutionEventFunc mocks the CreateExecutionEvent method.
+	CreateExecutionEventFunc func(ctx context.Context, event *test.ExecutionEvent) error
+
 	// CreateLogFunc mocks the CreateLog method.
 	CreateLogFunc func(ctx context.Context, log *test.Log) error
 
+	// CreateLogsFunc mocks the CreateLogs method.
+	CreateLogsFunc func(ctx context.Context, logs test.LogList) error
+
 	// CreateTestFunc mocks the CreateTest method.
 	CreateTestFunc func(ctx context.Context, testMoqParam *test.Test) (*test.Test, error)
 
@@ -148,18 +203,33 @@ type RepositoryMock struct {
 	// DeleteCaseExecutionFunc mocks the DeleteCaseExecution method.
 	DeleteCaseExecutionFunc func(ctx context.Context, testExecID test.TestExecutionID, id test.CaseExecutionID) error
 
+	// DeleteExecutionEventsFunc mocks the DeleteExecutionEvents method.
+	DeleteExecutionEventsFunc func(ctx context.Context, testExecID test.TestExecutionID, eventType eventsv1.Event_Type) error
+
 	// DeleteLogFunc mocks the DeleteLog method.
 	DeleteLogFunc func(ctx context.Context, id uuid.V7) error
 
+	// DeletePendingBlobDeletionFunc mocks the DeletePendingBlobDeletion method.
+	DeletePendingBlobDeletionFunc func(ctx context.Context, blobKey string) error
+
 	// DeleteTestFunc mocks the DeleteTest method.
 	DeleteTestFunc func(ctx context.Context, id uuid.V7) error
 
+	// DeleteTestExecutionFunc mocks the DeleteTestExecution method.
+	DeleteTestExecutionFunc func(ctx context.Context, id test.TestExecutionID) (*test.DeletedTestExecution, error)
+
 	// ExecuteTxFunc mocks the ExecuteTx method.
 	ExecuteTxFunc func(ctx context.Context, query func(repo test.Repository) error) error
 
+	// GetArtifactFunc mocks the GetArtifact method.
+	GetArtifactFunc func(ctx context.Context, id uuid.V7) (*test.Artifact, error)
+
 	// GetCaseExecutionFunc mocks the GetCaseExecution method.
 	GetCaseExecutionFunc func(ctx context.Context, testExecID test.TestExecutionID, caseExecID test.CaseExecutionID) (*test.CaseExecution, error)
 
+	// GetExecutionEventSequenceFunc mocks the GetExecutionEventSequence method.
+	GetExecutionEventSequenceFunc func(ctx context.Context, testExecID test.TestExecutionID) (uint64, error)
+
 	// GetLogFunc mocks the GetLog method.
 	GetLogFunc func(ctx context.Context, id uuid.V7) (*test.Log, error)
 
@@ -172,20 +242,35 @@ type RepositoryMock struct {
 	// GetTestExecutionFunc mocks the GetTestExecution method.
 	GetTestExecutionFunc func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error)
 
+	// GetTestExecutionContextFunc mocks the GetTestExecutionContext method.
+	GetTestExecutionContextFunc func(ctx context.Context, id test.TestExecutionID) (string, error)
+
 	// GetTestExecutionInputFunc mocks the GetTestExecutionInput method.
 	GetTestExecutionInputFunc func(ctx context.Context, id test.TestExecutionID) (*test.Payload, error)
 
 	// GetTestSuiteVersionFunc mocks the GetTestSuiteVersion method.
 	GetTestSuiteVersionFunc func(ctx context.Context, contextID string, id uuid.V7) (string, error)
 
+	// ListArtifactsFunc mocks the ListArtifacts method.
+	ListArtifactsFunc func(ctx context.Context, testExecID test.TestExecutionID, caseExecID *test.CaseExecutionID, filter test.PageFilter[uuid.V7]) (test.ArtifactList, error)
+
 	// ListCaseExecutionsFunc mocks the ListCaseExecutions method.
 	ListCaseExecutionsFunc func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[test.CaseExecutionID]) (test.CaseExecutionList, error)
 
 	// ListContextsFunc mocks the ListContexts method.
 	ListContextsFunc func(ctx context.Context, filter test.PageFilter[string]) ([]string, error)
 
+	// ListExecutionEventsFunc mocks the ListExecutionEvents method.
+	ListExecutionEventsFunc func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uint64], logFilter test.LogFilter) (test.ExecutionEventList, error)
+
+	// ListExpiredTestExecutionsFunc mocks the ListExpiredTestExecutions method.
+	ListExpiredTestExecutionsFunc func(ctx context.Context, contextID string, filter test.ExpiredFilter) ([]test.TestExecutionID, error)
+
 	// ListLogsFunc mocks the ListLogs method.
-	ListLogsFunc func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7]) (test.LogList, error)
+	ListLogsFunc func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7], logFilter test.LogFilter) (test.LogList, error)
+
+	// ListPendingBlobDeletionsFunc mocks the ListPendingBlobDeletions method.
+	ListPendingBlobDeletionsFunc func(ctx context.Context, filter test.PageFilter[string]) ([]string, error)
 
 	// ListTestExecutionsFunc mocks the ListTestExecutions method.
 	ListTestExecutionsFunc func(ctx context.Context, testID uuid.V7, filter test.PageFilter[test.TestExecutionID]) (test.TestExecutionList, error)
@@ -196,9 +281,15 @@ type RepositoryMock struct {
 	// ListTestsFunc mocks the ListTests method.
 	ListTestsFunc func(ctx context.Context, contextID string, testSuiteID uuid.V7, filter test.PageFilter[uuid.V7]) (test.TestList, error)
 
+	// NextExecutionEventSequenceFunc mocks the NextExecutionEventSequence method.
+	NextExecutionEventSequenceFunc func(ctx context.Context, testExecID test.TestExecutionID) (uint64, error)
+
 	// ResetTestExecutionFunc mocks the ResetTestExecution method.
 	ResetTestExecutionFunc func(ctx context.Context, testExecID test.TestExecutionID, resetTime time.Time) (*test.TestExecution, error)
 
+	// SearchTestExecutionsFunc mocks the SearchTestExecutions method.
+	SearchTestExecutionsFunc func(ctx context.Context, contextID string, filter test.PageFilter[test.TestExecutionID], execFilter test.TestExecutionFilter) (test.TestExecutionList, error)
+
 	// UpdateCaseExecutionFinishedFunc mocks the UpdateCaseExecutionFinished method.
 	UpdateCaseExecutionFinishedFunc func(ctx context.Context, finished *test.FinishedCaseExecution) (*test.CaseExecution, error)
 
@@ -206,16 +297,23 @@ type RepositoryMock struct {
 	UpdateCaseExecutionStartedFunc func(ctx context.Context, started *test.StartedCaseExecution) (*test.CaseExecution, error)
 
 	// UpdateTestExecutionFinishedFunc mocks the UpdateTestExecutionFinished method.
-	UpdateTestExecutionFinishedFunc func(ctx context.Context, finished *test.FinishedTestExecution) (*test.TestExecution, error)
+	UpdateTestExecutionFinishedFunc func(ctx context.Context, finished *test.FinishedTestExecution) (*test.UpdatedTestExecution, error)
 
 	// UpdateTestExecutionStartedFunc mocks the UpdateTestExecutionStarted method.
-	UpdateTestExecutionStartedFunc func(ctx context.Context, started *test.StartedTestExecution) (*test.TestExecution, error)
+	UpdateTestExecutionStartedFunc func(ctx context.Context, started *test.StartedTestExecution) (*test.UpdatedTestExecution, error)
 
 	// WithTxFunc mocks the WithTx method.
 	WithTxFunc func(ctx context.Context) (test.Repository, test.Tx, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// CreateArtifact holds details about calls to the CreateArtifact method.
+		CreateArtifact []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Artifact is the artifact argument value.
+			Artifact *test.Artifact
+		}
 		// CreateCaseExecutionScheduled holds details about calls to the CreateCaseExecutionScheduled method.
 		CreateCaseExecutionScheduled []struct {
 			// Ctx is the ctx argument value.
@@ -230,6 +328,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// CreateExecutionEvent holds details about calls to the CreateExecutionEvent method.
+		CreateExecutionEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Event is the event argument value.
+			Event *test.ExecutionEvent
+		}
 		// CreateLog holds details about calls to the CreateLog method.
 		CreateLog []struct {
 			// Ctx is the ctx argument value.
@@ -237,6 +342,13 @@ type RepositoryMock struct {
 			// Log is the log argument value.
 			Log *test.Log
 		}
+		// CreateLogs holds details about calls to the CreateLogs method.
+		CreateLogs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Logs is the logs argument value.
+			Logs test.LogList
+		}
 		// CreateTest holds details about calls to the CreateTest method.
 		CreateTest []struct {
 			// Ctx is the ctx argument value.
@@ -285,6 +397,15 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID test.CaseExecutionID
 		}
+		// DeleteExecutionEvents holds details about calls to the DeleteExecutionEvents method.
+		DeleteExecutionEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TestExecID is the testExecID argument value.
+			TestExecID test.TestExecutionID
+			// EventType is the eventType argument value.
+			EventType eventsv1.Event_Type
+		}
 		// DeleteLog holds details about calls to the DeleteLog method.
 		DeleteLog []struct {
 			// Ctx is the ctx argument value.
@@ -292,6 +413,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.V7
 		}
+		// DeletePendingBlobDeletion holds details about calls to the DeletePendingBlobDeletion method.
+		DeletePendingBlobDeletion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlobKey is the blobKey argument value.
+			BlobKey string
+		}
 		// DeleteTest holds details about calls to the DeleteTest method.
 		DeleteTest []struct {
 			// Ctx is the ctx argument value.
@@ -299,6 +427,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.V7
 		}
+		// DeleteTestExecution holds details about calls to the DeleteTestExecution method.
+		DeleteTestExecution []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID test.TestExecutionID
+		}
 		// ExecuteTx holds details about calls to the ExecuteTx method.
 		ExecuteTx []struct {
 			// Ctx is the ctx argument value.
@@ -306,6 +441,13 @@ type RepositoryMock struct {
 			// Query is the query argument value.
 			Query func(repo test.Repository) error
 		}
+		// GetArtifact holds details about calls to the GetArtifact method.
+		GetArtifact []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.V7
+		}
 		// GetCaseExecution holds details about calls to the GetCaseExecution method.
 		GetCaseExecution []struct {
 			// Ctx is the ctx argument value.
@@ -315,6 +457,13 @@ type RepositoryMock struct {
 			// CaseExecID is the caseExecID argument value.
 			CaseExecID test.CaseExecutionID
 		}
+		// GetExecutionEventSequence holds details about calls to the GetExecutionEventSequence method.
+		GetExecutionEventSequence []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TestExecID is the testExecID argument value.
+			TestExecID test.TestExecutionID
+		}
 		// GetLog holds details about calls to the GetLog method.
 		GetLog []struct {
 			// Ctx is the ctx argument value.
@@ -343,6 +492,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID test.TestExecutionID
 		}
+		// GetTestExecutionContext holds details about calls to the GetTestExecutionContext method.
+		GetTestExecutionContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID test.TestExecutionID
+		}
 		// GetTestExecutionInput holds details about calls to the GetTestExecutionInput method.
 		GetTestExecutionInput []struct {
 			// Ctx is the ctx argument value.
@@ -359,6 +515,17 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID uuid.V7
 		}
+		// ListArtifacts holds details about calls to the ListArtifacts method.
+		ListArtifacts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TestExecID is the testExecID argument value.
+			TestExecID test.TestExecutionID
+			// CaseExecID is the caseExecID argument value.
+			CaseExecID *test.CaseExecutionID
+			// Filter is the filter argument value.
+			Filter test.PageFilter[uuid.V7]
+		}
 		// ListCaseExecutions holds details about calls to the ListCaseExecutions method.
 		ListCaseExecutions []struct {
 			// Ctx is the ctx argument value.
@@ -375,6 +542,26 @@ type RepositoryMock struct {
 			// Filter is the filter argument value.
 			Filter test.PageFilter[string]
 		}
+		// ListExecutionEvents holds details about calls to the ListExecutionEvents method.
+		ListExecutionEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TestExecID is the testExecID argument value.
+			TestExecID test.TestExecutionID
+			// Filter is the filter argument value.
+			Filter test.PageFilter[uint64]
+			// LogFilter is the logFilter argument value.
+			LogFilter test.LogFilter
+		}
+		// ListExpiredTestExecutions holds details about calls to the ListExpiredTestExecutions method.
+		ListExpiredTestExecutions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ContextID is the contextID argument value.
+			ContextID string
+			// Filter is the filter argument value.
+			Filter test.ExpiredFilter
+		}
 		// ListLogs holds details about calls to the ListLogs method.
 		ListLogs []struct {
 			// Ctx is the ctx argument value.
@@ -383,6 +570,15 @@ type RepositoryMock struct {
 			TestExecID test.TestExecutionID
 			// Filter is the filter argument value.
 			Filter test.PageFilter[uuid.V7]
+			// LogFilter is the logFilter argument value.
+			LogFilter test.LogFilter
+		}
+		// ListPendingBlobDeletions holds details about calls to the ListPendingBlobDeletions method.
+		ListPendingBlobDeletions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter test.PageFilter[string]
 		}
 		// ListTestExecutions holds details about calls to the ListTestExecutions method.
 		ListTestExecutions []struct {
@@ -413,6 +609,13 @@ type RepositoryMock struct {
 			// Filter is the filter argument value.
 			Filter test.PageFilter[uuid.V7]
 		}
+		// NextExecutionEventSequence holds details about calls to the NextExecutionEventSequence method.
+		NextExecutionEventSequence []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TestExecID is the testExecID argument value.
+			TestExecID test.TestExecutionID
+		}
 		// ResetTestExecution holds details about calls to the ResetTestExecution method.
 		ResetTestExecution []struct {
 			// Ctx is the ctx argument value.
@@ -422,6 +625,17 @@ type RepositoryMock struct {
 			// ResetTime is the resetTime argument value.
 			ResetTime time.Time
 		}
+		// SearchTestExecutions holds details about calls to the SearchTestExecutions method.
+		SearchTestExecutions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ContextID is the contextID argument value.
+			ContextID string
+			// Filter is the filter argument value.
+			Filter test.PageFilter[test.TestExecutionID]
+			// ExecFilter is the execFilter argument value.
+			ExecFilter test.TestExecutionFilter
+		}
 		// UpdateCaseExecutionFinished holds details about calls to the UpdateCaseExecutionFinished method.
 		UpdateCaseExecutionFinished []struct {
 			// Ctx is the ctx argument value.
@@ -456,32 +670,47 @@ type RepositoryMock struct {
 			Ctx context.Context
 		}
 	}
+	lockCreateArtifact               sync.RWMutex
 	lockCreateCaseExecutionScheduled sync.RWMutex
 	lockCreateContext                sync.RWMutex
+	lockCreateExecutionEvent         sync.RWMutex
 	lockCreateLog                    sync.RWMutex
+	lockCreateLogs                   sync.RWMutex
 	lockCreateTest                   sync.RWMutex
 	lockCreateTestDefaultInput       sync.RWMutex
 	lockCreateTestExecutionInput     sync.RWMutex
 	lockCreateTestExecutionScheduled sync.RWMutex
 	lockCreateTestSuite              sync.RWMutex
 	lockDeleteCaseExecution          sync.RWMutex
+	lockDeleteExecutionEvents        sync.RWMutex
 	lockDeleteLog                    sync.RWMutex
+	lockDeletePendingBlobDeletion    sync.RWMutex
 	lockDeleteTest                   sync.RWMutex
+	lockDeleteTestExecution          sync.RWMutex
 	lockExecuteTx                    sync.RWMutex
+	lockGetArtifact                  sync.RWMutex
 	lockGetCaseExecution             sync.RWMutex
+	lockGetExecutionEventSequence    sync.RWMutex
 	lockGetLog                       sync.RWMutex
 	lockGetTest                      sync.RWMutex
 	lockGetTestDefaultInput          sync.RWMutex
 	lockGetTestExecution             sync.RWMutex
+	lockGetTestExecutionContext      sync.RWMutex
 	lockGetTestExecutionInput        sync.RWMutex
 	lockGetTestSuiteVersion          sync.RWMutex
+	lockListArtifacts                sync.RWMutex
 	lockListCaseExecutions           sync.RWMutex
 	lockListContexts                 sync.RWMutex
+	lockListExecutionEvents          sync.RWMutex
+	lockListExpiredTestExecutions    sync.RWMutex
 	lockListLogs                     sync.RWMutex
+	lockListPendingBlobDeletions     sync.RWMutex
 	lockListTestExecutions           sync.RWMutex
 	lockListTestSuites               sync.RWMutex
 	lockListTests                    sync.RWMutex
+	lockNextExecutionEventSequence   sync.RWMutex
 	lockResetTestExecution           sync.RWMutex
+	lockSearchTestExecutions         sync.RWMutex
 	lockUpdateCaseExecutionFinished  sync.RWMutex
 	lockUpdateCaseExecutionStarted   sync.RWMutex
 	lockUpdateTestExecutionFinished  sync.RWMutex
@@ -489,6 +718,42 @@ type RepositoryMock struct {
 	lockWithTx                       sync.RWMutex
 }
 
+// CreateArtifact calls CreateArtifactFunc.
+func (mock *RepositoryMock) CreateArtifact(ctx context.Context, artifact *test.Artifact) error {
+	if mock.CreateArtifactFunc == nil {
+		panic("RepositoryMock.CreateArtifactFunc: method is nil but Repository.CreateArtifact was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Artifact *test.Artifact
+	}{
+		Ctx:      ctx,
+		Artifact: artifact,
+	}
+	mock.lockCreateArtifact.Lock()
+	mock.calls.CreateArtifact = append(mock.calls.CreateArtifact, callInfo)
+	mock.lockCreateArtifact.Unlock()
+	return mock.CreateArtifactFunc(ctx, artifact)
+}
+
+// CreateArtifactCalls gets all the calls that were made to CreateArtifact.
+// Check the length with:
+//
+//	len(mockedRepository.CreateArtifactCalls())
+func (mock *RepositoryMock) CreateArtifactCalls() []struct {
+	Ctx      context.Context
+	Artifact *test.Artifact
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Artifact *test.Artifact
+	}
+	mock.lockCreateArtifact.RLock()
+	calls = mock.calls.CreateArtifact
+	mock.lockCreateArtifact.RUnlock()
+	return calls
+}
+
 // CreateCaseExecutionScheduled calls CreateCaseExecutionScheduledFunc.
 func (mock *RepositoryMock) CreateCaseExecutionScheduled(ctx context.Context, scheduled *test.ScheduledCaseExecution) (*test.CaseExecution, error) {
 	if mock.CreateCaseExecutionScheduledFunc == nil {
@@ -561,6 +826,42 @@ func (mock *RepositoryMock) CreateContextCalls() []struct {
 	return calls
 }
 
+// CreateExecutionEvent calls CreateExecutionEventFunc.
+func (mock *RepositoryMock) CreateExecutionEvent(ctx context.Context, event *test.ExecutionEvent) error {
+	if mock.CreateExecutionEventFunc == nil {
+		panic("RepositoryMock.CreateExecutionEventFunc: method is nil but Repository.CreateExecutionEvent was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Event *test.ExecutionEvent
+	}{
+		Ctx:   ctx,
+		Event: event,
+	}
+	mock.lockCreateExecutionEvent.Lock()
+	mock.calls.CreateExecutionEvent = append(mock.calls.CreateExecutionEvent, callInfo)
+	mock.lockCreateExecutionEvent.Unlock()
+	return mock.CreateExecutionEventFunc(ctx, event)
+}
+
+// CreateExecutionEventCalls gets all the calls that were made to CreateExecutionEvent.
+// Check the length with:
+//
+//	len(mockedRepository.CreateExecutionEventCalls())
+func (mock *RepositoryMock) CreateExecutionEventCalls() []struct {
+	Ctx   context.Context
+	Event *test.ExecutionEvent
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Event *test.ExecutionEvent
+	}
+	mock.lockCreateExecutionEvent.RLock()
+	calls = mock.calls.CreateExecutionEvent
+	mock.lockCreateExecutionEvent.RUnlock()
+	return calls
+}
+
 // CreateLog calls CreateLogFunc.
 func (mock *RepositoryMock) CreateLog(ctx context.Context, log *test.Log) error {
 	if mock.CreateLogFunc == nil {
@@ -597,6 +898,42 @@ func (mock *RepositoryMock) CreateLogCalls() []struct {
 	return calls
 }
 
+// CreateLogs calls CreateLogsFunc.
+func (mock *RepositoryMock) CreateLogs(ctx context.Context, logs test.LogList) error {
+	if mock.CreateLogsFunc == nil {
+		panic("RepositoryMock.CreateLogsFunc: method is nil but Repository.CreateLogs was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Logs test.LogList
+	}{
+		Ctx:  ctx,
+		Logs: logs,
+	}
+	mock.lockCreateLogs.Lock()
+	mock.calls.CreateLogs = append(mock.calls.CreateLogs, callInfo)
+	mock.lockCreateLogs.Unlock()
+	return mock.CreateLogsFunc(ctx, logs)
+}
+
+// CreateLogsCalls gets all the calls that were made to CreateLogs.
+// Check the length with:
+//
+//	len(mockedRepository.CreateLogsCalls())
+func (mock *RepositoryMock) CreateLogsCalls() []struct {
+	Ctx  context.Context
+	Logs test.LogList
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Logs test.LogList
+	}
+	mock.lockCreateLogs.RLock()
+	calls = mock.calls.CreateLogs
+	mock.lockCreateLogs.RUnlock()
+	return calls
+}
+
 // CreateTest calls CreateTestFunc.
 func (mock *RepositoryMock) CreateTest(ctx context.Context, testMoqParam *test.Test) (*test.Test, error) {
 	if mock.CreateTestFunc == nil {
@@ -825,6 +1162,46 @@ func (mock *RepositoryMock) DeleteCaseExecutionCalls() []struct {
 	return calls
 }
 
+// DeleteExecutionEvents calls DeleteExecutionEventsFunc.
+func (mock *RepositoryMock) DeleteExecutionEvents(ctx context.Context, testExecID test.TestExecutionID, eventType eventsv1.Event_Type) error {
+	if mock.DeleteExecutionEventsFunc == nil {
+		panic("RepositoryMock.DeleteExecutionEventsFunc: method is nil but Repository.DeleteExecutionEvents was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+		EventType  eventsv1.Event_Type
+	}{
+		Ctx:        ctx,
+		TestExecID: testExecID,
+		EventType:  eventType,
+	}
+	mock.lockDeleteExecutionEvents.Lock()
+	mock.calls.DeleteExecutionEvents = append(mock.calls.DeleteExecutionEvents, callInfo)
+	mock.lockDeleteExecutionEvents.Unlock()
+	return mock.DeleteExecutionEventsFunc(ctx, testExecID, eventType)
+}
+
+// DeleteExecutionEventsCalls gets all the calls that were made to DeleteExecutionEvents.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteExecutionEventsCalls())
+func (mock *RepositoryMock) DeleteExecutionEventsCalls() []struct {
+	Ctx        context.Context
+	TestExecID test.TestExecutionID
+	EventType  eventsv1.Event_Type
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+		EventType  eventsv1.Event_Type
+	}
+	mock.lockDeleteExecutionEvents.RLock()
+	calls = mock.calls.DeleteExecutionEvents
+	mock.lockDeleteExecutionEvents.RUnlock()
+	return calls
+}
+
 // DeleteLog calls DeleteLogFunc.
 func (mock *RepositoryMock) DeleteLog(ctx context.Context, id uuid.V7) error {
 	if mock.DeleteLogFunc == nil {
@@ -861,6 +1238,42 @@ func (mock *RepositoryMock) DeleteLogCalls() []struct {
 	return calls
 }
 
+// DeletePendingBlobDeletion calls DeletePendingBlobDeletionFunc.
+func (mock *RepositoryMock) DeletePendingBlobDeletion(ctx context.Context, blobKey string) error {
+	if mock.DeletePendingBlobDeletionFunc == nil {
+		panic("RepositoryMock.DeletePendingBlobDeletionFunc: method is nil but Repository.DeletePendingBlobDeletion was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		BlobKey string
+	}{
+		Ctx:     ctx,
+		BlobKey: blobKey,
+	}
+	mock.lockDeletePendingBlobDeletion.Lock()
+	mock.calls.DeletePendingBlobDeletion = append(mock.calls.DeletePendingBlobDeletion, callInfo)
+	mock.lockDeletePendingBlobDeletion.Unlock()
+	return mock.DeletePendingBlobDeletionFunc(ctx, blobKey)
+}
+
+// DeletePendingBlobDeletionCalls gets all the calls that were made to DeletePendingBlobDeletion.
+// Check the length with:
+//
+//	len(mockedRepository.DeletePendingBlobDeletionCalls())
+func (mock *RepositoryMock) DeletePendingBlobDeletionCalls() []struct {
+	Ctx     context.Context
+	BlobKey string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		BlobKey string
+	}
+	mock.lockDeletePendingBlobDeletion.RLock()
+	calls = mock.calls.DeletePendingBlobDeletion
+	mock.lockDeletePendingBlobDeletion.RUnlock()
+	return calls
+}
+
 // DeleteTest calls DeleteTestFunc.
 func (mock *RepositoryMock) DeleteTest(ctx context.Context, id uuid.V7) error {
 	if mock.DeleteTestFunc == nil {
@@ -897,6 +1310,42 @@ func (mock *RepositoryMock) DeleteTestCalls() []struct {
 	return calls
 }
 
+// DeleteTestExecution calls DeleteTestExecutionFunc.
+func (mock *RepositoryMock) DeleteTestExecution(ctx context.Context, id test.TestExecutionID) (*test.DeletedTestExecution, error) {
+	if mock.DeleteTestExecutionFunc == nil {
+		panic("RepositoryMock.DeleteTestExecutionFunc: method is nil but Repository.DeleteTestExecution was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  test.TestExecutionID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteTestExecution.Lock()
+	mock.calls.DeleteTestExecution = append(mock.calls.DeleteTestExecution, callInfo)
+	mock.lockDeleteTestExecution.Unlock()
+	return mock.DeleteTestExecutionFunc(ctx, id)
+}
+
+// DeleteTestExecutionCalls gets all the calls that were made to DeleteTestExecution.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteTestExecutionCalls())
+func (mock *RepositoryMock) DeleteTestExecutionCalls() []struct {
+	Ctx context.Context
+	ID  test.TestExecutionID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  test.TestExecutionID
+	}
+	mock.lockDeleteTestExecution.RLock()
+	calls = mock.calls.DeleteTestExecution
+	mock.lockDeleteTestExecution.RUnlock()
+	return calls
+}
+
 // ExecuteTx calls ExecuteTxFunc.
 func (mock *RepositoryMock) ExecuteTx(ctx context.Context, query func(repo test.Repository) error) error {
 	if mock.ExecuteTxFunc == nil {
@@ -933,6 +1382,42 @@ func (mock *RepositoryMock) ExecuteTxCalls() []struct {
 	return calls
 }
 
+// GetArtifact calls GetArtifactFunc.
+func (mock *RepositoryMock) GetArtifact(ctx context.Context, id uuid.V7) (*test.Artifact, error) {
+	if mock.GetArtifactFunc == nil {
+		panic("RepositoryMock.GetArtifactFunc: method is nil but Repository.GetArtifact was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.V7
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetArtifact.Lock()
+	mock.calls.GetArtifact = append(mock.calls.GetArtifact, callInfo)
+	mock.lockGetArtifact.Unlock()
+	return mock.GetArtifactFunc(ctx, id)
+}
+
+// GetArtifactCalls gets all the calls that were made to GetArtifact.
+// Check the length with:
+//
+//	len(mockedRepository.GetArtifactCalls())
+func (mock *RepositoryMock) GetArtifactCalls() []struct {
+	Ctx context.Context
+	ID  uuid.V7
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.V7
+	}
+	mock.lockGetArtifact.RLock()
+	calls = mock.calls.GetArtifact
+	mock.lockGetArtifact.RUnlock()
+	return calls
+}
+
 // GetCaseExecution calls GetCaseExecutionFunc.
 func (mock *RepositoryMock) GetCaseExecution(ctx context.Context, testExecID test.TestExecutionID, caseExecID test.CaseExecutionID) (*test.CaseExecution, error) {
 	if mock.GetCaseExecutionFunc == nil {
@@ -973,6 +1458,42 @@ func (mock *RepositoryMock) GetCaseExecutionCalls() []struct {
 	return calls
 }
 
+// GetExecutionEventSequence calls GetExecutionEventSequenceFunc.
+func (mock *RepositoryMock) GetExecutionEventSequence(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+	if mock.GetExecutionEventSequenceFunc == nil {
+		panic("RepositoryMock.GetExecutionEventSequenceFunc: method is nil but Repository.GetExecutionEventSequence was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+	}{
+		Ctx:        ctx,
+		TestExecID: testExecID,
+	}
+	mock.lockGetExecutionEventSequence.Lock()
+	mock.calls.GetExecutionEventSequence = append(mock.calls.GetExecutionEventSequence, callInfo)
+	mock.lockGetExecutionEventSequence.Unlock()
+	return mock.GetExecutionEventSequenceFunc(ctx, testExecID)
+}
+
+// GetExecutionEventSequenceCalls gets all the calls that were made to GetExecutionEventSequence.
+// Check the length with:
+//
+//	len(mockedRepository.GetExecutionEventSequenceCalls())
+func (mock *RepositoryMock) GetExecutionEventSequenceCalls() []struct {
+	Ctx        context.Context
+	TestExecID test.TestExecutionID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+	}
+	mock.lockGetExecutionEventSequence.RLock()
+	calls = mock.calls.GetExecutionEventSequence
+	mock.lockGetExecutionEventSequence.RUnlock()
+	return calls
+}
+
 // GetLog calls GetLogFunc.
 func (mock *RepositoryMock) GetLog(ctx context.Context, id uuid.V7) (*test.Log, error) {
 	if mock.GetLogFunc == nil {
@@ -1117,6 +1638,42 @@ func (mock *RepositoryMock) GetTestExecutionCalls() []struct {
 	return calls
 }
 
+// GetTestExecutionContext calls GetTestExecutionContextFunc.
+func (mock *RepositoryMock) GetTestExecutionContext(ctx context.Context, id test.TestExecutionID) (string, error) {
+	if mock.GetTestExecutionContextFunc == nil {
+		panic("RepositoryMock.GetTestExecutionContextFunc: method is nil but Repository.GetTestExecutionContext was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  test.TestExecutionID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetTestExecutionContext.Lock()
+	mock.calls.GetTestExecutionContext = append(mock.calls.GetTestExecutionContext, callInfo)
+	mock.lockGetTestExecutionContext.Unlock()
+	return mock.GetTestExecutionContextFunc(ctx, id)
+}
+
+// GetTestExecutionContextCalls gets all the calls that were made to GetTestExecutionContext.
+// Check the length with:
+//
+//	len(mockedRepository.GetTestExecutionContextCalls())
+func (mock *RepositoryMock) GetTestExecutionContextCalls() []struct {
+	Ctx context.Context
+	ID  test.TestExecutionID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  test.TestExecutionID
+	}
+	mock.lockGetTestExecutionContext.RLock()
+	calls = mock.calls.GetTestExecutionContext
+	mock.lockGetTestExecutionContext.RUnlock()
+	return calls
+}
+
 // GetTestExecutionInput calls GetTestExecutionInputFunc.
 func (mock *RepositoryMock) GetTestExecutionInput(ctx context.Context, id test.TestExecutionID) (*test.Payload, error) {
 	if mock.GetTestExecutionInputFunc == nil {
@@ -1193,6 +1750,50 @@ func (mock *RepositoryMock) GetTestSuiteVersionCalls() []struct {
 	return calls
 }
 
+// ListArtifacts calls ListArtifactsFunc.
+func (mock *RepositoryMock) ListArtifacts(ctx context.Context, testExecID test.TestExecutionID, caseExecID *test.CaseExecutionID, filter test.PageFilter[uuid.V7]) (test.ArtifactList, error) {
+	if mock.ListArtifactsFunc == nil {
+		panic("RepositoryMock.ListArtifactsFunc: method is nil but Repository.ListArtifacts was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+		CaseExecID *test.CaseExecutionID
+		Filter     test.PageFilter[uuid.V7]
+	}{
+		Ctx:        ctx,
+		TestExecID: testExecID,
+		CaseExecID: caseExecID,
+		Filter:     filter,
+	}
+	mock.lockListArtifacts.Lock()
+	mock.calls.ListArtifacts = append(mock.calls.ListArtifacts, callInfo)
+	mock.lockListArtifacts.Unlock()
+	return mock.ListArtifactsFunc(ctx, testExecID, caseExecID, filter)
+}
+
+// ListArtifactsCalls gets all the calls that were made to ListArtifacts.
+// Check the length with:
+//
+//	len(mockedRepository.ListArtifactsCalls())
+func (mock *RepositoryMock) ListArtifactsCalls() []struct {
+	Ctx        context.Context
+	TestExecID test.TestExecutionID
+	CaseExecID *test.CaseExecutionID
+	Filter     test.PageFilter[uuid.V7]
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+		CaseExecID *test.CaseExecutionID
+		Filter     test.PageFilter[uuid.V7]
+	}
+	mock.lockListArtifacts.RLock()
+	calls = mock.calls.ListArtifacts
+	mock.lockListArtifacts.RUnlock()
+	return calls
+}
+
 // ListCaseExecutions calls ListCaseExecutionsFunc.
 func (mock *RepositoryMock) ListCaseExecutions(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[test.CaseExecutionID]) (test.CaseExecutionList, error) {
 	if mock.ListCaseExecutionsFunc == nil {
@@ -1269,8 +1870,92 @@ func (mock *RepositoryMock) ListContextsCalls() []struct {
 	return calls
 }
 
+// ListExecutionEvents calls ListExecutionEventsFunc.
+func (mock *RepositoryMock) ListExecutionEvents(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uint64], logFilter test.LogFilter) (test.ExecutionEventList, error) {
+	if mock.ListExecutionEventsFunc == nil {
+		panic("RepositoryMock.ListExecutionEventsFunc: method is nil but Repository.ListExecutionEvents was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+		Filter     test.PageFilter[uint64]
+		LogFilter  test.LogFilter
+	}{
+		Ctx:        ctx,
+		TestExecID: testExecID,
+		Filter:     filter,
+		LogFilter:  logFilter,
+	}
+	mock.lockListExecutionEvents.Lock()
+	mock.calls.ListExecutionEvents = append(mock.calls.ListExecutionEvents, callInfo)
+	mock.lockListExecutionEvents.Unlock()
+	return mock.ListExecutionEventsFunc(ctx, testExecID, filter, logFilter)
+}
+
+// ListExecutionEventsCalls gets all the calls that were made to ListExecutionEvents.
+// Check the length with:
+//
+//	len(mockedRepository.ListExecutionEventsCalls())
+func (mock *RepositoryMock) ListExecutionEventsCalls() []struct {
+	Ctx        context.Context
+	TestExecID test.TestExecutionID
+	Filter     test.PageFilter[uint64]
+	LogFilter  test.LogFilter
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+		Filter     test.PageFilter[uint64]
+		LogFilter  test.LogFilter
+	}
+	mock.lockListExecutionEvents.RLock()
+	calls = mock.calls.ListExecutionEvents
+	mock.lockListExecutionEvents.RUnlock()
+	return calls
+}
+
+// ListExpiredTestExecutions calls ListExpiredTestExecutionsFunc.
+func (mock *RepositoryMock) ListExpiredTestExecutions(ctx context.Context, contextID string, filter test.ExpiredFilter) ([]test.TestExecutionID, error) {
+	if mock.ListExpiredTestExecutionsFunc == nil {
+		panic("RepositoryMock.ListExpiredTestExecutionsFunc: method is nil but Repository.ListExpiredTestExecutions was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ContextID string
+		Filter    test.ExpiredFilter
+	}{
+		Ctx:       ctx,
+		ContextID: contextID,
+		Filter:    filter,
+	}
+	mock.lockListExpiredTestExecutions.Lock()
+	mock.calls.ListExpiredTestExecutions = append(mock.calls.ListExpiredTestExecutions, callInfo)
+	mock.lockListExpiredTestExecutions.Unlock()
+	return mock.ListExpiredTestExecutionsFunc(ctx, contextID, filter)
+}
+
+// ListExpiredTestExecutionsCalls gets all the calls that were made to ListExpiredTestExecutions.
+// Check the length with:
+//
+//	len(mockedRepository.ListExpiredTestExecutionsCalls())
+func (mock *RepositoryMock) ListExpiredTestExecutionsCalls() []struct {
+	Ctx       context.Context
+	ContextID string
+	Filter    test.ExpiredFilter
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ContextID string
+		Filter    test.ExpiredFilter
+	}
+	mock.lockListExpiredTestExecutions.RLock()
+	calls = mock.calls.ListExpiredTestExecutions
+	mock.lockListExpiredTestExecutions.RUnlock()
+	return calls
+}
+
 // ListLogs calls ListLogsFunc.
-func (mock *RepositoryMock) ListLogs(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7]) (test.LogList, error) {
+func (mock *RepositoryMock) ListLogs(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7], logFilter test.LogFilter) (test.LogList, error) {
 	if mock.ListLogsFunc == nil {
 		panic("RepositoryMock.ListLogsFunc: method is nil but Repository.ListLogs was just called")
 	}
@@ -1278,15 +1963,17 @@ func (mock *RepositoryMock) ListLogs(ctx context.Context, testExecID test.TestEx
 		Ctx        context.Context
 		TestExecID test.TestExecutionID
 		Filter     test.PageFilter[uuid.V7]
+		LogFilter  test.LogFilter
 	}{
 		Ctx:        ctx,
 		TestExecID: testExecID,
 		Filter:     filter,
+		LogFilter:  logFilter,
 	}
 	mock.lockListLogs.Lock()
 	mock.calls.ListLogs = append(mock.calls.ListLogs, callInfo)
 	mock.lockListLogs.Unlock()
-	return mock.ListLogsFunc(ctx, testExecID, filter)
+	return mock.ListLogsFunc(ctx, testExecID, filter, logFilter)
 }
 
 // ListLogsCalls gets all the calls that were made to ListLogs.
@@ -1297,11 +1984,13 @@ func (mock *RepositoryMock) ListLogsCalls() []struct {
 	Ctx        context.Context
 	TestExecID test.TestExecutionID
 	Filter     test.PageFilter[uuid.V7]
+	LogFilter  test.LogFilter
 } {
 	var calls []struct {
 		Ctx        context.Context
 		TestExecID test.TestExecutionID
 		Filter     test.PageFilter[uuid.V7]
+		LogFilter  test.LogFilter
 	}
 	mock.lockListLogs.RLock()
 	calls = mock.calls.ListLogs
@@ -1309,6 +1998,42 @@ func (mock *RepositoryMock) ListLogsCalls() []struct {
 	return calls
 }
 
+// ListPendingBlobDeletions calls ListPendingBlobDeletionsFunc.
+func (mock *RepositoryMock) ListPendingBlobDeletions(ctx context.Context, filter test.PageFilter[string]) ([]string, error) {
+	if mock.ListPendingBlobDeletionsFunc == nil {
+		panic("RepositoryMock.ListPendingBlobDeletionsFunc: method is nil but Repository.ListPendingBlobDeletions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter test.PageFilter[string]
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+	}
+	mock.lockListPendingBlobDeletions.Lock()
+	mock.calls.ListPendingBlobDeletions = append(mock.calls.ListPendingBlobDeletions, callInfo)
+	mock.lockListPendingBlobDeletions.Unlock()
+	return mock.ListPendingBlobDeletionsFunc(ctx, filter)
+}
+
+// ListPendingBlobDeletionsCalls gets all the calls that were made to ListPendingBlobDeletions.
+// Check the length with:
+//
+//	len(mockedRepository.ListPendingBlobDeletionsCalls())
+func (mock *RepositoryMock) ListPendingBlobDeletionsCalls() []struct {
+	Ctx    context.Context
+	Filter test.PageFilter[string]
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter test.PageFilter[string]
+	}
+	mock.lockListPendingBlobDeletions.RLock()
+	calls = mock.calls.ListPendingBlobDeletions
+	mock.lockListPendingBlobDeletions.RUnlock()
+	return calls
+}
+
 // ListTestExecutions calls ListTestExecutionsFunc.
 func (mock *RepositoryMock) ListTestExecutions(ctx context.Context, testID uuid.V7, filter test.PageFilter[test.TestExecutionID]) (test.TestExecutionList, error) {
 	if mock.ListTestExecutionsFunc == nil {
@@ -1433,6 +2158,42 @@ func (mock *RepositoryMock) ListTestsCalls() []struct {
 	return calls
 }
 
+// NextExecutionEventSequence calls NextExecutionEventSequenceFunc.
+func (mock *RepositoryMock) NextExecutionEventSequence(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+	if mock.NextExecutionEventSequenceFunc == nil {
+		panic("RepositoryMock.NextExecutionEventSequenceFunc: method is nil but Repository.NextExecutionEventSequence was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+	}{
+		Ctx:        ctx,
+		TestExecID: testExecID,
+	}
+	mock.lockNextExecutionEventSequence.Lock()
+	mock.calls.NextExecutionEventSequence = append(mock.calls.NextExecutionEventSequence, callInfo)
+	mock.lockNextExecutionEventSequence.Unlock()
+	return mock.NextExecutionEventSequenceFunc(ctx, testExecID)
+}
+
+// NextExecutionEventSequenceCalls gets all the calls that were made to NextExecutionEventSequence.
+// Check the length with:
+//
+//	len(mockedRepository.NextExecutionEventSequenceCalls())
+func (mock *RepositoryMock) NextExecutionEventSequenceCalls() []struct {
+	Ctx        context.Context
+	TestExecID test.TestExecutionID
+} {
+	var calls []struct {
+		Ctx        context.Context
+		TestExecID test.TestExecutionID
+	}
+	mock.lockNextExecutionEventSequence.RLock()
+	calls = mock.calls.NextExecutionEventSequence
+	mock.lockNextExecutionEventSequence.RUnlock()
+	return calls
+}
+
 // ResetTestExecution calls ResetTestExecutionFunc.
 func (mock *RepositoryMock) ResetTestExecution(ctx context.Context, testExecID test.TestExecutionID, resetTime time.Time) (*test.TestExecution, error) {
 	if mock.ResetTestExecutionFunc == nil {
@@ -1473,6 +2234,50 @@ func (mock *RepositoryMock) ResetTestExecutionCalls() []struct {
 	return calls
 }
 
+// SearchTestExecutions calls SearchTestExecutionsFunc.
+func (mock *RepositoryMock) SearchTestExecutions(ctx context.Context, contextID string, filter test.PageFilter[test.TestExecutionID], execFilter test.TestExecutionFilter) (test.TestExecutionList, error) {
+	if mock.SearchTestExecutionsFunc == nil {
+		panic("RepositoryMock.SearchTestExecutionsFunc: method is nil but Repository.SearchTestExecutions was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ContextID  string
+		Filter     test.PageFilter[test.TestExecutionID]
+		ExecFilter test.TestExecutionFilter
+	}{
+		Ctx:        ctx,
+		ContextID:  contextID,
+		Filter:     filter,
+		ExecFilter: execFilter,
+	}
+	mock.lockSearchTestExecutions.Lock()
+	mock.calls.SearchTestExecutions = append(mock.calls.SearchTestExecutions, callInfo)
+	mock.lockSearchTestExecutions.Unlock()
+	return mock.SearchTestExecutionsFunc(ctx, contextID, filter, execFilter)
+}
+
+// SearchTestExecutionsCalls gets all the calls that were made to SearchTestExecutions.
+// Check the length with:
+//
+//	len(mockedRepository.SearchTestExecutionsCalls())
+func (mock *RepositoryMock) SearchTestExecutionsCalls() []struct {
+	Ctx        context.Context
+	ContextID  string
+	Filter     test.PageFilter[test.TestExecutionID]
+	ExecFilter test.TestExecutionFilter
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ContextID  string
+		Filter     test.PageFilter[test.TestExecutionID]
+		ExecFilter test.TestExecutionFilter
+	}
+	mock.lockSearchTestExecutions.RLock()
+	calls = mock.calls.SearchTestExecutions
+	mock.lockSearchTestExecutions.RUnlock()
+	return calls
+}
+
 // UpdateCaseExecutionFinished calls UpdateCaseExecutionFinishedFunc.
 func (mock *RepositoryMock) UpdateCaseExecutionFinished(ctx context.Context, finished *test.FinishedCaseExecution) (*test.CaseExecution, error) {
 	if mock.UpdateCaseExecutionFinishedFunc == nil {
@@ -1546,7 +2351,7 @@ func (mock *RepositoryMock) UpdateCaseExecutionStartedCalls() []struct {
 }
 
 // UpdateTestExecutionFinished calls UpdateTestExecutionFinishedFunc.
-func (mock *RepositoryMock) UpdateTestExecutionFinished(ctx context.Context, finished *test.FinishedTestExecution) (*test.TestExecution, error) {
+func (mock *RepositoryMock) UpdateTestExecutionFinished(ctx context.Context, finished *test.FinishedTestExecution) (*test.UpdatedTestExecution, error) {
 	if mock.UpdateTestExecutionFinishedFunc == nil {
 		panic("RepositoryMock.UpdateTestExecutionFinishedFunc: method is nil but Repository.UpdateTestExecutionFinished was just called")
 	}
@@ -1582,7 +2387,7 @@ func (mock *RepositoryMock) UpdateTestExecutionFinishedCalls() []struct {
 }
 
 // UpdateTestExecutionStarted calls UpdateTestExecutionStartedFunc.
-func (mock *RepositoryMock) UpdateTestExecutionStarted(ctx context.Context, started *test.StartedTestExecution) (*test.TestExecution, error) {
+func (mock *RepositoryMock) UpdateTestExecutionStarted(ctx context.Context, started *test.StartedTestExecution) (*test.UpdatedTestExecution, error) {
 	if mock.UpdateTestExecutionStartedFunc == nil {
 		panic("RepositoryMock.UpdateTestExecutionStartedFunc: method is nil but Repository.UpdateTestExecutionStarted was just called")
 	}
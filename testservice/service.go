@@ -3,21 +3,36 @@ package testservice
 //go:generate go run github.com/matryer/moq@latest -out workflower_mock_test.go . Workflower
 //go:generate go run github.com/matryer/moq@latest -out repository_mock_test.go -pkg testservice ../test Repository
 //go:generate go run github.com/matryer/moq@latest -out event_publisher_mock_test.go -pkg testservice ../event Publisher
+//go:generate go run github.com/matryer/moq@latest -out authorizer_mock_test.go -pkg testservice ../auth Authorizer
+//go:generate go run github.com/matryer/moq@latest -out audit_repository_mock_test.go -pkg testservice ../audit Repository:AuditRepositoryMock
+//go:generate go run github.com/matryer/moq@latest -out blob_store_mock_test.go -pkg testservice ../blob Store:BlobStoreMock
 
 import (
 	"context"
+	"strings"
 
 	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	"github.com/annexsh/annex/blob"
 	"github.com/annexsh/annex/event"
+	"github.com/annexsh/annex/gen/annex/audit/v1/auditv1connect"
+	"github.com/annexsh/annex/gen/annex/executions/v1/executionsv1connect"
 	"github.com/annexsh/annex/log"
+	"github.com/annexsh/annex/redact"
 	"github.com/annexsh/annex/test"
 )
 
-var _ testsv1connect.TestServiceHandler = (*Service)(nil)
+var (
+	_ testsv1connect.TestServiceHandler           = (*Service)(nil)
+	_ executionsv1connect.ExecutionServiceHandler = executionService{}
+	_ auditv1connect.AuditServiceHandler          = (*Service)(nil)
+)
 
 type Workflower interface {
 	ExecuteWorkflow(ctx context.Context, options client.StartWorkflowOptions, workflow interface{}, args ...interface{}) (client.WorkflowRun, error)
@@ -36,24 +51,122 @@ func WithLogger(logger log.Logger) ServiceOption {
 	}
 }
 
+// WithAuditRepository records user-initiated actions in the audit log of the
+// repository. Actions are not recorded by default.
+func WithAuditRepository(repo audit.Repository) ServiceOption {
+	return func(s *Service) {
+		s.auditRepo = repo
+	}
+}
+
+// WithAuthorizer authorizes requests using the authorizer. Requests are not
+// authorized by default.
+func WithAuthorizer(authorizer auth.Authorizer) ServiceOption {
+	return func(s *Service) {
+		s.authorizer = authorizer
+	}
+}
+
+// WithPayloadCodec encodes test inputs with the codec before they are stored,
+// for example to encrypt them at rest. Inputs are stored as provided by
+// default.
+func WithPayloadCodec(codec converter.PayloadCodec) ServiceOption {
+	return func(s *Service) {
+		s.payloadCodec = codec
+	}
+}
+
+// WithDataConverter decodes workflow histories using the data converter,
+// which must match the data converter of the Workflower. Defaults to the
+// Temporal default data converter.
+func WithDataConverter(dataConverter converter.DataConverter) ServiceOption {
+	return func(s *Service) {
+		s.dataConverter = dataConverter
+	}
+}
+
+// WithSecretFields redacts the values of the fields in the JSON inputs of
+// test executions unless the caller can execute tests in the context. Field
+// names are case-insensitive and matched at any depth.
+func WithSecretFields(fields ...string) ServiceOption {
+	return func(s *Service) {
+		for _, field := range fields {
+			s.secretFields[strings.ToLower(field)] = true
+		}
+	}
+}
+
+// WithLogRedactor redacts secrets from published logs before they are stored
+// and published. The values of secret input fields (see WithSecretFields) of
+// the test execution are redacted in addition to the redactor patterns. Logs
+// are stored as published by default.
+func WithLogRedactor(redactor *redact.Redactor) ServiceOption {
+	return func(s *Service) {
+		s.logRedactor = redactor
+	}
+}
+
+// WithBlobStore stores the content of artifacts in the blob store. Artifacts
+// can't be uploaded or downloaded without a blob store.
+func WithBlobStore(store blob.Store) ServiceOption {
+	return func(s *Service) {
+		s.blobStore = store
+	}
+}
+
+// WithMaxArtifactSize sets the maximum size in bytes of uploaded artifacts.
+// Defaults to 100 MiB. Zero disables the limit.
+func WithMaxArtifactSize(size int64) ServiceOption {
+	return func(s *Service) {
+		s.maxArtifactSize = size
+	}
+}
+
 type Service struct {
-	repo       test.Repository
-	eventPub   event.Publisher
-	workflower Workflower
-	executor   *executor
-	logger     log.Logger
+	repo            test.Repository
+	eventPub        event.Publisher
+	workflower      Workflower
+	executor        *executor
+	authorizer      auth.Authorizer
+	auditRepo       audit.Repository
+	payloadCodec    converter.PayloadCodec
+	dataConverter   converter.DataConverter
+	secretFields    map[string]bool
+	logRedactor     *redact.Redactor
+	inputSecrets    *secretCache
+	blobStore       blob.Store
+	maxArtifactSize int64
+	logger          log.Logger
 }
 
 func New(repo test.Repository, eventPub event.Publisher, workflower Workflower, opts ...ServiceOption) *Service {
 	s := &Service{
-		repo:       repo,
-		eventPub:   eventPub,
-		workflower: workflower,
-		logger:     log.NewNopLogger(),
+		repo:            repo,
+		eventPub:        eventPub,
+		workflower:      workflower,
+		dataConverter:   converter.GetDefaultDataConverter(),
+		secretFields:    map[string]bool{},
+		inputSecrets:    newSecretCache(inputSecretCacheSize),
+		maxArtifactSize: defaultMaxArtifactSize,
+		logger:          log.NewNopLogger(),
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
-	s.executor = newExecutor(repo, eventPub, workflower, s.logger)
+	s.executor = newExecutor(repo, s.eventPub, workflower, s.payloadCodec, s.dataConverter, s.logger)
 	return s
 }
+
+// executionService serves the execution service. It is a separate handler
+// because some methods of the test and execution services have the same
+// name.
+type executionService struct {
+	*Service
+}
+
+// ExecutionHandler returns the handler of the execution service, which serves
+// the test execution data recorded by the service in addition to the test
+// service.
+func (s *Service) ExecutionHandler() executionsv1connect.ExecutionServiceHandler {
+	return executionService{Service: s}
+}
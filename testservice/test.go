@@ -9,6 +9,9 @@ import (
 	"connectrpc.com/connect"
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/pagination"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -27,6 +30,13 @@ func (s *Service) RegisterTests(
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no tests received in stream"))
 	}
 
+	// Subsequent messages must have the same context as the first message
+	if err := s.authorize(ctx, stream.Msg().Context, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
+	var registered *audit.Entry
+
 	// Only start transaction once first message has been received
 	err := s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
 		var contextID string
@@ -93,9 +103,12 @@ func (s *Service) RegisterTests(
 			tests[t.Name] = t
 
 			if t.HasInput {
-				inputs[t.Name] = &test.Payload{
+				inputs[t.Name], err = encodePayload(s.payloadCodec, &test.Payload{
 					Metadata: msg.Definition.DefaultInput.Metadata,
 					Data:     msg.Definition.DefaultInput.Data,
+				})
+				if err != nil {
+					return err
 				}
 			}
 		}
@@ -122,12 +135,24 @@ func (s *Service) RegisterTests(
 			return err
 		}
 
+		registered = &audit.Entry{
+			Context:     contextID,
+			Action:      audit.ActionRegisterTests,
+			TestSuiteID: &testSuiteID,
+			Summary:     fmt.Sprintf("registered %d tests for test suite version '%s'", len(tests), version),
+		}
+
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Re-registering the current version is a no-op and isn't audited
+	if registered != nil {
+		s.recordAudit(ctx, registered)
+	}
+
 	return &connect.Response[testsv1.RegisterTestsResponse]{}, nil
 }
 
@@ -144,7 +169,7 @@ func (s *Service) GetTest(
 		return nil, err
 	}
 
-	t, err := s.repo.GetTest(ctx, testID)
+	t, err := s.authorizeTest(ctx, req.Msg.Context, testID, auth.RoleViewer)
 	if err != nil {
 		return nil, err
 	}
@@ -167,13 +192,31 @@ func (s *Service) GetTestDefaultInput(
 		return nil, err
 	}
 
+	if _, err = s.authorizeTest(ctx, req.Msg.Context, testID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	payload, err := s.repo.GetTestDefaultInput(ctx, testID)
 	if err != nil {
 		return nil, err
 	}
 
+	payload, err = decodePayload(s.payloadCodec, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	canViewSecrets, err := s.canViewSecrets(ctx, req.Msg.Context)
+	if err != nil {
+		return nil, err
+	}
+	data := payload.Data
+	if !canViewSecrets {
+		data = redactPayload(payload.Proto(), s.secretFields).Data
+	}
+
 	return connect.NewResponse(&testsv1.GetTestDefaultInputResponse{
-		DefaultInput: string(payload.Data),
+		DefaultInput: string(data),
 	}), nil
 }
 
@@ -185,6 +228,10 @@ func (s *Service) ListTests(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	testSuiteID, err := uuid.Parse(req.Msg.TestSuiteId)
 	if err != nil {
 		return nil, err
@@ -217,27 +264,65 @@ func (s *Service) ExecuteTest(
 	ctx context.Context,
 	req *connect.Request[testsv1.ExecuteTestRequest],
 ) (*connect.Response[testsv1.ExecuteTestResponse], error) {
-	if err := validateExecuteTestRequest(req.Msg); err != nil {
+	testExec, err := s.executeTest(ctx, req.Msg, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	testID, err := uuid.Parse(req.Msg.TestId)
+	return connect.NewResponse(&testsv1.ExecuteTestResponse{
+		TestExecution: testExec.Proto(),
+	}), nil
+}
+
+func (s executionService) ExecuteTest(
+	ctx context.Context,
+	req *connect.Request[executionsv1.ExecuteTestRequest],
+) (*connect.Response[executionsv1.ExecuteTestResponse], error) {
+	testExec, err := s.executeTest(ctx, &testsv1.ExecuteTestRequest{
+		Context: req.Msg.Context,
+		TestId:  req.Msg.TestId,
+		Input:   req.Msg.Input,
+	}, req.Msg.Metadata)
 	if err != nil {
 		return nil, err
 	}
 
-	t, err := s.repo.GetTest(ctx, testID)
+	return connect.NewResponse(&executionsv1.ExecuteTestResponse{
+		TestExecution: testExec.ExecutionProto(),
+	}), nil
+}
+
+// executeTest executes the test of the request with the execution metadata,
+// which is nil if the request has none.
+func (s *Service) executeTest(ctx context.Context, req *testsv1.ExecuteTestRequest, metadata *executionsv1.ExecutionMetadata) (*test.TestExecution, error) {
+	if err := validateExecuteTestRequest(req, metadata); err != nil {
+		return nil, err
+	}
+
+	testID, err := uuid.Parse(req.TestId)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = validateExecuteTestRequestInputRequired(t.HasInput, req.Msg); err != nil {
+	t, err := s.authorizeTest(ctx, req.Context, testID, auth.RoleExecutor)
+	if err != nil {
 		return nil, err
 	}
 
-	var opts []executeOption
-	if req.Msg.Input != nil {
-		opts = append(opts, withInput(req.Msg.Input))
+	if err = validateExecuteTestRequestInputRequired(t.HasInput, req); err != nil {
+		return nil, err
+	}
+
+	execMetadata := test.ExecutionMetadataFromProto(metadata)
+	if execMetadata.TriggeredBy == nil {
+		if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.Subject != "" {
+			execMetadata.TriggeredBy = &principal.Subject
+		}
+	}
+
+	opts := []executeOption{withMetadata(execMetadata)}
+	if req.Input != nil {
+		opts = append(opts, withInput(req.Input))
 	}
 
 	testExec, err := s.executor.execute(ctx, t, opts...)
@@ -245,9 +330,20 @@ func (s *Service) ExecuteTest(
 		return nil, fmt.Errorf("failed to execute test: %w", err)
 	}
 
-	return connect.NewResponse(&testsv1.ExecuteTestResponse{
-		TestExecution: testExec.Proto(),
-	}), nil
+	summary := fmt.Sprintf("executed test '%s'", t.Name)
+	if req.Input != nil {
+		summary += " with input"
+	}
+	s.recordAudit(ctx, &audit.Entry{
+		Context:         req.Context,
+		Action:          audit.ActionExecuteTest,
+		TestSuiteID:     &t.TestSuiteID,
+		TestID:          &t.ID,
+		TestExecutionID: &testExec.ID,
+		Summary:         summary,
+	})
+
+	return testExec, nil
 }
 
 type testsResult struct {
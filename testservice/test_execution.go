@@ -8,7 +8,10 @@ import (
 	eventsv1 "github.com/annexsh/annex-proto/go/gen/annex/events/v1"
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
 	"github.com/annexsh/annex/event"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/pagination"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -18,33 +21,79 @@ func (s *Service) GetTestExecution(
 	ctx context.Context,
 	req *connect.Request[testsv1.GetTestExecutionRequest],
 ) (*connect.Response[testsv1.GetTestExecutionResponse], error) {
-	if err := validateGetTestExecutionRequest(req.Msg); err != nil {
+	exec, input, err := s.getTestExecution(ctx, req.Msg)
+	if err != nil {
 		return nil, err
 	}
 
-	execID, err := test.ParseTestExecutionID(req.Msg.TestExecutionId)
+	return connect.NewResponse(&testsv1.GetTestExecutionResponse{
+		TestExecution: exec.Proto(),
+		Input:         input,
+	}), nil
+}
+
+func (s executionService) GetTestExecution(
+	ctx context.Context,
+	req *connect.Request[executionsv1.GetTestExecutionRequest],
+) (*connect.Response[executionsv1.GetTestExecutionResponse], error) {
+	exec, input, err := s.getTestExecution(ctx, &testsv1.GetTestExecutionRequest{
+		Context:         req.Msg.Context,
+		TestExecutionId: req.Msg.TestExecutionId,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return connect.NewResponse(&executionsv1.GetTestExecutionResponse{
+		TestExecution: exec.ExecutionProto(),
+		Input:         input,
+	}), nil
+}
+
+// getTestExecution returns the test execution of the request and its input,
+// which is nil if the test execution has no input.
+func (s *Service) getTestExecution(ctx context.Context, req *testsv1.GetTestExecutionRequest) (*test.TestExecution, *testsv1.Payload, error) {
+	if err := validateGetTestExecutionRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	execID, err := test.ParseTestExecutionID(req.TestExecutionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = s.authorizeTestExecution(ctx, req.Context, execID, auth.RoleViewer); err != nil {
+		return nil, nil, err
+	}
+
 	exec, err := s.repo.GetTestExecution(ctx, execID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	res := &testsv1.GetTestExecutionResponse{
-		TestExecution: exec.Proto(),
+	if !exec.HasInput {
+		return exec, nil, nil
 	}
 
-	if exec.HasInput {
-		input, err := s.repo.GetTestExecutionInput(ctx, execID)
-		if err != nil {
-			return nil, err
-		}
-		res.Input = input.Proto()
+	input, err := s.repo.GetTestExecutionInput(ctx, execID)
+	if err != nil {
+		return nil, nil, err
+	}
+	input, err = decodePayload(s.payloadCodec, input)
+	if err != nil {
+		return nil, nil, err
 	}
+	inputpb := input.Proto()
 
-	return connect.NewResponse(res), nil
+	canViewSecrets, err := s.canViewSecrets(ctx, req.Context)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canViewSecrets {
+		inputpb = redactPayload(inputpb, s.secretFields)
+	}
+
+	return exec, inputpb, nil
 }
 
 func (s *Service) ListTestExecutions(
@@ -60,6 +109,10 @@ func (s *Service) ListTestExecutions(
 		return nil, err
 	}
 
+	if _, err = s.authorizeTest(ctx, req.Msg.Context, testID, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithTestExecutionID())
 	if err != nil {
 		return nil, err
@@ -83,6 +136,46 @@ func (s *Service) ListTestExecutions(
 	}), nil
 }
 
+func (s *Service) SearchTestExecutions(
+	ctx context.Context,
+	req *connect.Request[executionsv1.SearchTestExecutionsRequest],
+) (*connect.Response[executionsv1.SearchTestExecutionsResponse], error) {
+	if err := validateSearchTestExecutionsRequest(req.Msg); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	execFilter, err := test.TestExecutionFilterFromProto(req.Msg.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithTestExecutionID())
+	if err != nil {
+		return nil, err
+	}
+
+	testExecs, err := s.repo.SearchTestExecutions(ctx, req.Msg.Context, filter, execFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	nextPageTkn, err := pagination.NextPageTokenFromItems(filter.Size, testExecs, func(testExec *test.TestExecution) test.TestExecutionID {
+		return testExec.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&executionsv1.SearchTestExecutionsResponse{
+		TestExecutions: testExecs.ExecutionProto(),
+		NextPageToken:  nextPageTkn,
+	}), nil
+}
+
 func (s *Service) AckTestExecutionStarted(
 	ctx context.Context,
 	req *connect.Request[testsv1.AckTestExecutionStartedRequest],
@@ -91,6 +184,10 @@ func (s *Service) AckTestExecutionStarted(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	execID, err := test.ParseTestExecutionID(req.Msg.TestExecutionId)
 	if err != nil {
 		return nil, err
@@ -101,13 +198,29 @@ func (s *Service) AckTestExecutionStarted(
 		StartTime: req.Msg.StartTime.AsTime(),
 	}
 
-	testExec, err := s.repo.UpdateTestExecutionStarted(ctx, started)
+	var execEvent *executionsv1.ExecutionEvent
+	var testExec *test.UpdatedTestExecution
+
+	err = s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		testExec, err = repo.UpdateTestExecutionStarted(ctx, started)
+		if err != nil {
+			return err
+		}
+		// The update is rolled back unless the test execution belongs to the
+		// context, which saves querying its context beforehand
+		if testExec.ContextID != req.Msg.Context {
+			return connect.NewError(connect.CodeNotFound, test.ErrorTestExecutionNotFound)
+		}
+		execEvent = &executionsv1.ExecutionEvent{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, testExec.Proto())}
+		return recordEvent(ctx, repo, testExec.ID, execEvent)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	execEvent := event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, testExec.Proto())
-	if err = s.eventPub.Publish(testExec.ID.String(), execEvent); err != nil {
+	testExecutionsStarted.WithLabelValues(testExec.ContextID, testExec.TestSuiteID.String()).Inc()
+
+	if err = publishEvent(ctx, s.eventPub, execID.String(), execEvent); err != nil {
 		return nil, fmt.Errorf("failed to publish test execution event: %w", err)
 	}
 
@@ -122,6 +235,10 @@ func (s *Service) AckTestExecutionFinished(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	execID, err := test.ParseTestExecutionID(req.Msg.TestExecutionId)
 	if err != nil {
 		return nil, err
@@ -133,13 +250,29 @@ func (s *Service) AckTestExecutionFinished(
 		Error:      req.Msg.Error,
 	}
 
-	testExec, err := s.repo.UpdateTestExecutionFinished(ctx, finished)
+	var execEvent *executionsv1.ExecutionEvent
+	var testExec *test.UpdatedTestExecution
+
+	err = s.repo.ExecuteTx(ctx, func(repo test.Repository) error {
+		testExec, err = repo.UpdateTestExecutionFinished(ctx, finished)
+		if err != nil {
+			return fmt.Errorf("failed to update test execution: %w", err)
+		}
+		// The update is rolled back unless the test execution belongs to the
+		// context, which saves querying its context beforehand
+		if testExec.ContextID != req.Msg.Context {
+			return connect.NewError(connect.CodeNotFound, test.ErrorTestExecutionNotFound)
+		}
+		execEvent = &executionsv1.ExecutionEvent{Event: event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, testExec.Proto())}
+		return recordEvent(ctx, repo, testExec.ID, execEvent)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update test execution: %w", err)
+		return nil, err
 	}
 
-	execEvent := event.NewTestExecutionEvent(eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, testExec.Proto())
-	if err = s.eventPub.Publish(testExec.ID.String(), execEvent); err != nil {
+	testExecutionsFinished.WithLabelValues(testExec.ContextID, testExec.TestSuiteID.String(), outcome(finished.Error)).Inc()
+
+	if err = publishEvent(ctx, s.eventPub, execID.String(), execEvent); err != nil {
 		return nil, fmt.Errorf("failed to publish test execution event: %w", err)
 	}
 
@@ -159,11 +292,25 @@ func (s *Service) RetryTestExecution(
 		return nil, err
 	}
 
+	if err = s.authorizeTestExecution(ctx, req.Msg.Context, testExecID, auth.RoleExecutor); err != nil {
+		return nil, err
+	}
+
 	testExec, err := s.executor.retry(ctx, testExecID)
 	if err != nil {
 		return nil, err
 	}
 
+	testExecutionRetries.WithLabelValues(req.Msg.Context).Inc()
+
+	s.recordAudit(ctx, &audit.Entry{
+		Context:         req.Msg.Context,
+		Action:          audit.ActionRetryTestExecution,
+		TestID:          &testExec.TestID,
+		TestExecutionID: &testExec.ID,
+		Summary:         "retried failed test execution",
+	})
+
 	return connect.NewResponse(&testsv1.RetryTestExecutionResponse{
 		TestExecution: testExec.Proto(),
 	}), nil
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/fake"
 	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/test"
@@ -56,6 +57,8 @@ func TestService_GetTestExecution(t *testing.T) {
 				}
 			}
 
+			mockTestExecutionContext(r, "foo")
+
 			s := Service{repo: r}
 
 			req := &testsv1.GetTestExecutionRequest{
@@ -77,6 +80,29 @@ func TestService_GetTestExecution(t *testing.T) {
 	}
 }
 
+func TestService_GetTestExecution_metadata(t *testing.T) {
+	testExec := fake.GenTestExec(uuid.New())
+	testExec.Metadata = fake.GenExecutionMetadata()
+
+	r := &RepositoryMock{
+		GetTestExecutionFunc: func(ctx context.Context, id test.TestExecutionID) (*test.TestExecution, error) {
+			return testExec, nil
+		},
+	}
+	mockTestExecutionContext(r, "foo")
+
+	s := &Service{repo: r}
+
+	res, err := s.ExecutionHandler().GetTestExecution(context.Background(), connect.NewRequest(&executionsv1.GetTestExecutionRequest{
+		Context:         "foo",
+		TestExecutionId: testExec.ID.String(),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, testExec.Proto(), res.Msg.TestExecution.TestExecution)
+	assert.Equal(t, testExec.Metadata.Proto(), res.Msg.TestExecution.Metadata)
+	assert.Nil(t, res.Msg.Input)
+}
+
 func TestService_GetTestExecution_validation(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -133,6 +159,9 @@ func TestService_ListTestExecutions(t *testing.T) {
 	wantPage2 := test.TestExecutionList{fake.GenTestExec(testID)}
 
 	r := new(RepositoryMock)
+	r.GetTestFunc = func(ctx context.Context, id uuid.V7) (*test.Test, error) {
+		return &test.Test{ContextID: "foo", ID: id}, nil
+	}
 	r.ListTestExecutionsFunc = func(ctx context.Context, gotTestID uuid.V7, filter test.PageFilter[test.TestExecutionID]) (test.TestExecutionList, error) {
 		assert.Equal(t, testID, gotTestID)
 		assert.Equal(t, pageSize, filter.Size)
@@ -231,25 +260,148 @@ func TestService_ListTestExecutions_validation(t *testing.T) {
 	}
 }
 
+func TestService_SearchTestExecutions(t *testing.T) {
+	pageSize := 2
+	testID := uuid.New()
+
+	wantPage1 := test.TestExecutionList{fake.GenTestExec(testID), fake.GenTestExec(testID)}
+	wantPage2 := test.TestExecutionList{fake.GenTestExec(testID)}
+	for _, testExec := range append(wantPage1, wantPage2...) {
+		testExec.Metadata = fake.GenExecutionMetadata()
+	}
+
+	wantFilter := test.TestExecutionFilter{
+		TestID:      &testID,
+		Environment: ptr.Get("staging"),
+		Labels:      map[string]string{"team": "payments"},
+	}
+
+	r := new(RepositoryMock)
+	r.SearchTestExecutionsFunc = func(ctx context.Context, contextID string, filter test.PageFilter[test.TestExecutionID], execFilter test.TestExecutionFilter) (test.TestExecutionList, error) {
+		assert.Equal(t, "foo", contextID)
+		assert.Equal(t, pageSize, filter.Size)
+		assert.Equal(t, wantFilter, execFilter)
+
+		switch len(r.SearchTestExecutionsCalls()) {
+		case 1:
+			assert.Nil(t, filter.OffsetID)
+			return wantPage1, nil
+		case 2:
+			assert.Equal(t, wantPage1[pageSize-1].ID, *filter.OffsetID)
+			return wantPage2, nil
+		default:
+			panic("unexpected search invocation")
+		}
+	}
+
+	s := Service{repo: r}
+
+	req := &executionsv1.SearchTestExecutionsRequest{
+		Context:  "foo",
+		PageSize: int32(pageSize),
+		Filter: &executionsv1.TestExecutionFilter{
+			TestId:      ptr.Get(testID.String()),
+			Environment: ptr.Get("staging"),
+			Labels:      map[string]string{"team": "payments"},
+		},
+	}
+	res, err := s.SearchTestExecutions(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, wantPage1.ExecutionProto(), res.Msg.TestExecutions)
+	assert.NotEmpty(t, res.Msg.NextPageToken)
+
+	req.NextPageToken = res.Msg.NextPageToken
+	res, err = s.SearchTestExecutions(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	assert.Equal(t, wantPage2.ExecutionProto(), res.Msg.TestExecutions)
+	assert.Empty(t, res.Msg.NextPageToken)
+}
+
+func TestService_SearchTestExecutions_validation(t *testing.T) {
+	tests := []struct {
+		name               string
+		req                *executionsv1.SearchTestExecutionsRequest
+		wantFieldViolation *errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name: "blank context",
+			req: &executionsv1.SearchTestExecutionsRequest{
+				Context:  "",
+				PageSize: 1,
+			},
+			wantFieldViolation: wantBlankContextFieldViolation(),
+		},
+		{
+			name: "page size greater than max",
+			req: &executionsv1.SearchTestExecutionsRequest{
+				Context:  "foo",
+				PageSize: maxPageSize + 1,
+			},
+			wantFieldViolation: wantPageSizeFieldViolation(),
+		},
+		{
+			name: "filter test id not a uuid",
+			req: &executionsv1.SearchTestExecutionsRequest{
+				Context:  "foo",
+				PageSize: 1,
+				Filter:   &executionsv1.TestExecutionFilter{TestId: ptr.Get("bar")},
+			},
+			wantFieldViolation: &errdetails.BadRequest_FieldViolation{
+				Field:       "filter.test_id",
+				Description: wantTestIDNotUUIDFieldViolation().Description,
+			},
+		},
+		{
+			name: "filter blank git branch",
+			req: &executionsv1.SearchTestExecutionsRequest{
+				Context:  "foo",
+				PageSize: 1,
+				Filter:   &executionsv1.TestExecutionFilter{GitBranch: ptr.Get("")},
+			},
+			wantFieldViolation: &errdetails.BadRequest_FieldViolation{
+				Field:       "filter.git_branch",
+				Description: "Git branch can't be blank",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Service{}
+			res, err := s.SearchTestExecutions(context.Background(), connect.NewRequest(tt.req))
+			require.Nil(t, res)
+			assertInvalidRequest(t, err, tt.wantFieldViolation)
+		})
+	}
+}
+
 func TestService_AckTestExecutionStarted(t *testing.T) {
+	wantTest := fake.GenTest()
 	wantTestExec := &test.TestExecution{
 		ID:           test.NewTestExecutionID(),
-		TestID:       uuid.New(),
+		TestID:       wantTest.ID,
 		HasInput:     true,
 		ScheduleTime: time.Now().UTC(),
 		StartTime:    ptr.Get(time.Now().UTC()),
 	}
 
 	r := &RepositoryMock{
-		UpdateTestExecutionStartedFunc: func(ctx context.Context, started *test.StartedTestExecution) (*test.TestExecution, error) {
+		UpdateTestExecutionStartedFunc: func(ctx context.Context, started *test.StartedTestExecution) (*test.UpdatedTestExecution, error) {
 			assert.Equal(t, wantTestExec.ID, started.ID)
 			assert.Equal(t, *wantTestExec.StartTime, started.StartTime)
-			return wantTestExec, nil
+			return &test.UpdatedTestExecution{
+				TestExecution: wantTestExec,
+				ContextID:     wantTest.ContextID,
+				TestSuiteID:   wantTest.TestSuiteID,
+			}, nil
 		},
 	}
+	mockEventRecording(t, r)
 
 	p := &PublisherMock{
-		PublishFunc: func(testExecID string, e *eventsv1.Event) error {
+		PublishFunc: func(testExecID string, execEvent *executionsv1.ExecutionEvent) error {
+			e := execEvent.Event
+			assertEventSequence(t, execEvent)
 			assert.Equal(t, wantTestExec.ID.String(), testExecID)
 			assert.Equal(t, wantTestExec.ID.String(), e.TestExecutionId)
 			assert.Equal(t, eventsv1.Event_TYPE_TEST_EXECUTION_STARTED, e.Type)
@@ -264,7 +416,7 @@ func TestService_AckTestExecutionStarted(t *testing.T) {
 	s := Service{repo: r, eventPub: p}
 
 	req := &testsv1.AckTestExecutionStartedRequest{
-		Context:         "foo",
+		Context:         wantTest.ContextID,
 		TestExecutionId: wantTestExec.ID.String(),
 		StartTime:       timestamppb.New(*wantTestExec.StartTime),
 	}
@@ -350,9 +502,10 @@ func TestService_AckTestExecutionStarted_validation(t *testing.T) {
 }
 
 func TestService_AckTestExecutionFinished(t *testing.T) {
+	wantTest := fake.GenTest()
 	wantTestExec := &test.TestExecution{
 		ID:           test.NewTestExecutionID(),
-		TestID:       uuid.New(),
+		TestID:       wantTest.ID,
 		HasInput:     true,
 		ScheduleTime: time.Now().UTC(),
 		StartTime:    ptr.Get(time.Now().UTC()),
@@ -361,18 +514,25 @@ func TestService_AckTestExecutionFinished(t *testing.T) {
 	}
 
 	r := &RepositoryMock{
-		UpdateTestExecutionFinishedFunc: func(ctx context.Context, finished *test.FinishedTestExecution) (*test.TestExecution, error) {
+		UpdateTestExecutionFinishedFunc: func(ctx context.Context, finished *test.FinishedTestExecution) (*test.UpdatedTestExecution, error) {
 			assert.Equal(t, wantTestExec.ID, finished.ID)
 			assert.Equal(t, *wantTestExec.FinishTime, finished.FinishTime)
 			assert.Equal(t, wantTestExec.Error, finished.Error)
 			wantTestExec.FinishTime = &finished.FinishTime
 			wantTestExec.Error = finished.Error
-			return wantTestExec, nil
+			return &test.UpdatedTestExecution{
+				TestExecution: wantTestExec,
+				ContextID:     wantTest.ContextID,
+				TestSuiteID:   wantTest.TestSuiteID,
+			}, nil
 		},
 	}
+	mockEventRecording(t, r)
 
 	p := &PublisherMock{
-		PublishFunc: func(testExecID string, e *eventsv1.Event) error {
+		PublishFunc: func(testExecID string, execEvent *executionsv1.ExecutionEvent) error {
+			e := execEvent.Event
+			assertEventSequence(t, execEvent)
 			assert.Equal(t, wantTestExec.ID.String(), testExecID)
 			assert.Equal(t, wantTestExec.ID.String(), e.TestExecutionId)
 			assert.Equal(t, eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED, e.Type)
@@ -387,7 +547,7 @@ func TestService_AckTestExecutionFinished(t *testing.T) {
 	s := Service{repo: r, eventPub: p}
 
 	req := &testsv1.AckTestExecutionFinishedRequest{
-		Context:         "foo",
+		Context:         wantTest.ContextID,
 		TestExecutionId: wantTestExec.ID.String(),
 		FinishTime:      timestamppb.New(*wantTestExec.FinishTime),
 		Error:           wantTestExec.Error,
@@ -517,7 +677,7 @@ func TestService_RetryTestExecution(t *testing.T) {
 			assert.Equal(t, testExec.ID, testExecID)
 			return test.CaseExecutionList{successCaseExec, failureCaseExec}, nil
 		},
-		ListLogsFunc: func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7]) (test.LogList, error) {
+		ListLogsFunc: func(ctx context.Context, testExecID test.TestExecutionID, filter test.PageFilter[uuid.V7], logFilter test.LogFilter) (test.LogList, error) {
 			assert.Equal(t, testExec.ID, testExecID)
 			return append(successCaseLogs, failureCaseLogs...), nil
 		},
@@ -535,6 +695,14 @@ func TestService_RetryTestExecution(t *testing.T) {
 			assert.False(t, resetTime.IsZero())
 			return wantResetTestExec, nil
 		},
+		DeleteExecutionEventsFunc: func(ctx context.Context, testExecID test.TestExecutionID, eventType eventsv1.Event_Type) error {
+			assert.Equal(t, testExec.ID, testExecID)
+			assert.Contains(t, []eventsv1.Event_Type{
+				eventsv1.Event_TYPE_TEST_EXECUTION_STARTED,
+				eventsv1.Event_TYPE_TEST_EXECUTION_FINISHED,
+			}, eventType)
+			return nil
+		},
 	}
 	r.ExecuteTxFunc = func(ctx context.Context, query func(repo test.Repository) error) error {
 		return query(r)
@@ -559,6 +727,8 @@ func TestService_RetryTestExecution(t *testing.T) {
 
 	// Setup logs
 
+	mockTestExecutionContext(r, "foo")
+
 	svc := New(r, fake.NewPubSub(), w)
 
 	// Retry test execution
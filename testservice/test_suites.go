@@ -10,6 +10,8 @@ import (
 	"go.temporal.io/api/enums/v1"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/annexsh/annex/audit"
+	"github.com/annexsh/annex/auth"
 	"github.com/annexsh/annex/internal/pagination"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -25,6 +27,10 @@ func (s *Service) RegisterTestSuite(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleRunner); err != nil {
+		return nil, err
+	}
+
 	testSuite := &test.TestSuite{
 		ID:          uuid.New(),
 		ContextID:   req.Msg.Context,
@@ -37,6 +43,13 @@ func (s *Service) RegisterTestSuite(
 		return nil, err
 	}
 
+	s.recordAudit(ctx, &audit.Entry{
+		Context:     req.Msg.Context,
+		Action:      audit.ActionRegisterTestSuite,
+		TestSuiteID: &id,
+		Summary:     fmt.Sprintf("registered test suite '%s'", req.Msg.Name),
+	})
+
 	return connect.NewResponse(&testsv1.RegisterTestSuiteResponse{
 		Id: id.String(),
 	}), nil
@@ -50,6 +63,10 @@ func (s *Service) ListTestSuites(
 		return nil, err
 	}
 
+	if err := s.authorize(ctx, req.Msg.Context, auth.RoleViewer); err != nil {
+		return nil, err
+	}
+
 	contextID := req.Msg.Context
 
 	filter, err := pagination.FilterFromRequest(req.Msg, pagination.WithString())
@@ -17,7 +17,10 @@ import (
 	"go.temporal.io/sdk/converter"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 
+	"github.com/annexsh/annex/auth"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/fake"
+	"github.com/annexsh/annex/internal/ptr"
 	"github.com/annexsh/annex/log"
 	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
@@ -291,7 +294,7 @@ func TestService_RegisterTests_validation(t *testing.T) {
 
 func TestService_GetTest(t *testing.T) {
 	testID := uuid.New()
-	tt := fake.GenTest()
+	tt := fake.GenTest(fake.WithContextID("foo"))
 
 	r := &RepositoryMock{
 		GetTestFunc: func(ctx context.Context, id uuid.V7) (*test.Test, error) {
@@ -358,6 +361,9 @@ func TestService_GetDefaultInput(t *testing.T) {
 	input := fake.GenInput()
 
 	r := &RepositoryMock{
+		GetTestFunc: func(ctx context.Context, id uuid.V7) (*test.Test, error) {
+			return &test.Test{ContextID: "foo", ID: id}, nil
+		},
 		GetTestDefaultInputFunc: func(ctx context.Context, gotTestID uuid.V7) (*test.Payload, error) {
 			assert.Equal(t, testID, gotTestID)
 			return input, nil
@@ -525,6 +531,8 @@ func TestService_ListTests_validation(t *testing.T) {
 func TestService_ExecuteTest(t *testing.T) {
 	input := fake.GenInput()
 	tt := fake.GenTest(fake.WithHasInput(true))
+	metadata := fake.GenExecutionMetadata()
+	metadata.TriggeredBy = nil // defaults to the principal subject
 
 	var gotTestExec *test.TestExecution
 
@@ -538,11 +546,15 @@ func TestService_ExecuteTest(t *testing.T) {
 			assert.False(t, scheduled.ID.Empty())
 			assert.Equal(t, tt.HasInput, scheduled.HasInput)
 			assert.False(t, scheduled.ScheduleTime.IsZero())
+			wantMetadata := metadata
+			wantMetadata.TriggeredBy = ptr.Get("alice")
+			assert.Equal(t, wantMetadata, scheduled.Metadata)
 			gotTestExec = &test.TestExecution{
 				ID:           scheduled.ID,
 				TestID:       scheduled.TestID,
 				HasInput:     scheduled.HasInput,
 				ScheduleTime: scheduled.ScheduleTime,
+				Metadata:     scheduled.Metadata,
 			}
 			return gotTestExec, nil
 		},
@@ -553,12 +565,12 @@ func TestService_ExecuteTest(t *testing.T) {
 		},
 	}
 
-	r.ExecuteTxFunc = func(ctx context.Context, query func(repo test.Repository) error) error {
-		return query(r)
-	}
+	mockEventRecording(t, r)
 
 	p := &PublisherMock{
-		PublishFunc: func(testExecID string, e *eventsv1.Event) error {
+		PublishFunc: func(testExecID string, execEvent *executionsv1.ExecutionEvent) error {
+			e := execEvent.Event
+			assertEventSequence(t, execEvent)
 			assert.Equal(t, gotTestExec.ID.String(), testExecID)
 			assert.Equal(t, gotTestExec.ID.String(), e.TestExecutionId)
 			assert.Equal(t, eventsv1.Event_TYPE_TEST_EXECUTION_SCHEDULED, e.Type)
@@ -583,24 +595,28 @@ func TestService_ExecuteTest(t *testing.T) {
 
 	s := Service{
 		repo:     r,
-		executor: newExecutor(r, p, w, log.NewNopLogger()),
+		executor: newExecutor(r, p, w, nil, converter.GetDefaultDataConverter(), log.NewNopLogger()),
 	}
 
-	req := &testsv1.ExecuteTestRequest{
-		Context: tt.ContextID,
-		TestId:  tt.ID.String(),
-		Input:   input.Proto(),
+	req := &executionsv1.ExecuteTestRequest{
+		Context:  tt.ContextID,
+		TestId:   tt.ID.String(),
+		Input:    input.Proto(),
+		Metadata: metadata.Proto(),
 	}
 
-	res, err := s.ExecuteTest(context.Background(), connect.NewRequest(req))
+	ctx := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "alice"})
+	res, err := s.ExecutionHandler().ExecuteTest(ctx, connect.NewRequest(req))
 	require.NoError(t, err)
-	assert.Equal(t, gotTestExec.Proto(), res.Msg.TestExecution)
+	assert.Equal(t, gotTestExec.ExecutionProto(), res.Msg.TestExecution)
+	assert.Equal(t, gotTestExec.Metadata, test.ExecutionMetadataFromProto(res.Msg.TestExecution.Metadata))
 }
 
 func TestService_ExecuteTest_validation(t *testing.T) {
 	tests := []struct {
 		name               string
 		req                *testsv1.ExecuteTestRequest
+		metadata           *executionsv1.ExecutionMetadata
 		wantFieldViolation *errdetails.BadRequest_FieldViolation
 	}{
 		{
@@ -656,12 +672,48 @@ func TestService_ExecuteTest_validation(t *testing.T) {
 				Description: "Metadata encoding must be json/plain",
 			},
 		},
+		{
+			name: "metadata ci job url not a url",
+			req: &testsv1.ExecuteTestRequest{
+				Context: "foo",
+				TestId:  uuid.NewString(),
+			},
+			metadata: &executionsv1.ExecutionMetadata{CiJobUrl: ptr.Get("not a url")},
+			wantFieldViolation: &errdetails.BadRequest_FieldViolation{
+				Field:       "metadata.ci_job_url",
+				Description: "Ci job url must be an absolute http or https URL",
+			},
+		},
+		{
+			name: "metadata blank environment",
+			req: &testsv1.ExecuteTestRequest{
+				Context: "foo",
+				TestId:  uuid.NewString(),
+			},
+			metadata: &executionsv1.ExecutionMetadata{Environment: ptr.Get(" ")},
+			wantFieldViolation: &errdetails.BadRequest_FieldViolation{
+				Field:       "metadata.environment",
+				Description: "Environment can't be blank",
+			},
+		},
+		{
+			name: "metadata blank label key",
+			req: &testsv1.ExecuteTestRequest{
+				Context: "foo",
+				TestId:  uuid.NewString(),
+			},
+			metadata: &executionsv1.ExecutionMetadata{Labels: map[string]string{"": "bar"}},
+			wantFieldViolation: &errdetails.BadRequest_FieldViolation{
+				Field:       "metadata.labels",
+				Description: "Labels keys can't be blank",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := Service{}
-			res, err := s.ExecuteTest(context.Background(), connect.NewRequest(tt.req))
+			res, err := s.executeTest(context.Background(), tt.req, tt.metadata)
 			require.Nil(t, res)
 			assertInvalidRequest(t, err, tt.wantFieldViolation)
 		})
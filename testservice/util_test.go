@@ -1,6 +1,7 @@
 package testservice
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -8,8 +9,40 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
+	"github.com/annexsh/annex/test"
 )
 
+const wantEventSequence = uint64(7)
+
+// mockEventRecording configures the repository mock to execute transactions
+// in place and to record events with wantEventSequence.
+func mockEventRecording(t *testing.T, r *RepositoryMock) {
+	r.ExecuteTxFunc = func(ctx context.Context, query func(repo test.Repository) error) error {
+		return query(r)
+	}
+	r.NextExecutionEventSequenceFunc = func(ctx context.Context, testExecID test.TestExecutionID) (uint64, error) {
+		return wantEventSequence, nil
+	}
+	r.CreateExecutionEventFunc = func(ctx context.Context, e *test.ExecutionEvent) error {
+		assert.Equal(t, e.TestExecutionID.String(), e.Event.TestExecutionId)
+		assert.Equal(t, wantEventSequence, e.Sequence)
+		return nil
+	}
+}
+
+// mockTestExecutionContext places all test executions in the context.
+func mockTestExecutionContext(r *RepositoryMock, contextID string) {
+	r.GetTestExecutionContextFunc = func(ctx context.Context, id test.TestExecutionID) (string, error) {
+		return contextID, nil
+	}
+}
+
+func assertEventSequence(t *testing.T, e *executionsv1.ExecutionEvent) {
+	assert.Equal(t, wantEventSequence, e.Sequence)
+}
+
 func wantBlankContextFieldViolation(streamIndex ...int) *errdetails.BadRequest_FieldViolation {
 	return &errdetails.BadRequest_FieldViolation{
 		Field:       fieldViolationName("context", streamIndex...),
@@ -1,13 +1,23 @@
 package testservice
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
 
 	testsv1 "github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
 	"github.com/cohesivestack/valgo"
 	"go.temporal.io/sdk/converter"
 
+	"github.com/annexsh/annex/audit"
+	auditv1 "github.com/annexsh/annex/gen/annex/audit/v1"
+	executionsv1 "github.com/annexsh/annex/gen/annex/executions/v1"
 	"github.com/annexsh/annex/internal/validator"
+	"github.com/annexsh/annex/test"
 	"github.com/annexsh/annex/uuid"
 )
 
@@ -15,6 +25,10 @@ const (
 	reqValidationBaseErrMsg       = "invalid request"
 	streamReqValidationBaseErrMsg = "invalid stream request"
 	maxPageSize                   = 1000
+	maxLogsPerMessage             = 1000
+	maxLogAttributes              = 32
+	maxExecutionLabels            = 32
+	maxArtifactNameLength         = 255
 )
 
 func validateRegisterContextRequest(req *testsv1.RegisterContextRequest) error {
@@ -117,7 +131,7 @@ func validateListTestsRequest(req *testsv1.ListTestsRequest) error {
 	return v.ConnectError()
 }
 
-func validateExecuteTestRequest(req *testsv1.ExecuteTestRequest) error {
+func validateExecuteTestRequest(req *testsv1.ExecuteTestRequest, metadata *executionsv1.ExecutionMetadata) error {
 	v := newValidator()
 
 	v.Is(
@@ -129,9 +143,69 @@ func validateExecuteTestRequest(req *testsv1.ExecuteTestRequest) error {
 		validatePayload(v.Validation, "input", req.Input)
 	}
 
+	if metadata != nil {
+		validateExecutionMetadata(v.Validation, "metadata", metadata)
+	}
+
 	return v.ConnectError()
 }
 
+func validateExecutionMetadata(v *valgo.Validation, fieldName string, metadata *executionsv1.ExecutionMetadata) {
+	metadataValidation := valgo.Is(
+		valgo.Int(len(metadata.Labels), "labels").LessOrEqualTo(maxExecutionLabels, "{{title}} must not contain more than {{value}} labels"),
+	)
+	validateOptionalStrings(metadataValidation, map[string]*string{
+		"triggered_by": metadata.TriggeredBy,
+		"git_sha":      metadata.GitSha,
+		"git_branch":   metadata.GitBranch,
+		"environment":  metadata.Environment,
+	})
+	if metadata.CiJobUrl != nil {
+		metadataValidation.Is(valgo.StringP(metadata.CiJobUrl, "ci_job_url").Passing(isHTTPURL, "{{title}} must be an absolute http or https URL"))
+	}
+	validateLabels(metadataValidation, metadata.Labels)
+	v.In(fieldName, metadataValidation)
+}
+
+func validateTestExecutionFilter(v *valgo.Validation, fieldName string, filter *executionsv1.TestExecutionFilter) {
+	if filter == nil {
+		return
+	}
+	filterValidation := valgo.New()
+	if filter.TestId != nil {
+		filterValidation.Is(validator.TestID(*filter.TestId))
+	}
+	validateOptionalStrings(filterValidation, map[string]*string{
+		"triggered_by": filter.TriggeredBy,
+		"git_sha":      filter.GitSha,
+		"git_branch":   filter.GitBranch,
+		"ci_job_url":   filter.CiJobUrl,
+		"environment":  filter.Environment,
+	})
+	validateLabels(filterValidation, filter.Labels)
+	v.In(fieldName, filterValidation)
+}
+
+// validateOptionalStrings validates that the set fields aren't blank.
+func validateOptionalStrings(v *valgo.Validation, fields map[string]*string) {
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		if fields[name] != nil {
+			v.Is(valgo.StringP(fields[name], name).Not().Blank())
+		}
+	}
+}
+
+func validateLabels(v *valgo.Validation, labels map[string]string) {
+	for key := range labels {
+		v.Is(valgo.String(key, "labels").Not().Blank("{{title}} keys can't be blank"))
+	}
+}
+
+func isHTTPURL(s *string) bool {
+	u, err := url.Parse(*s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 func validateExecuteTestRequestInputRequired(requiresInput bool, req *testsv1.ExecuteTestRequest) error {
 	v := newValidator()
 
@@ -257,6 +331,90 @@ func validatePublishLogRequest(req *testsv1.PublishLogRequest) error {
 	return v.ConnectError()
 }
 
+func validatePublishLogsMessage(i int, msg *executionsv1.PublishLogsRequest) error {
+	v := newStreamValidator()
+	validation := valgo.Is(
+		validator.Context(msg.Context),
+		validator.TestExecID(msg.TestExecutionId),
+		valgo.Int(len(msg.Logs), "logs").Between(1, maxLogsPerMessage, "{{title}} must contain between {{min}} and {{max}} logs"),
+	)
+	for j, entry := range msg.Logs {
+		entryValidation := valgo.Is(
+			valgo.String(entry.Level, "level").Not().Blank(),
+			valgo.String(entry.Message, "message").Not().Blank(),
+			validator.Timestamppb(entry.CreateTime, "create_time"),
+			valgo.Int(len(entry.Attributes), "attributes").LessOrEqualTo(maxLogAttributes, "{{title}} must not contain more than {{value}} attributes"),
+		)
+		validateLogAttributes(entryValidation, entry.Attributes)
+		if entry.CaseExecutionId != nil {
+			entryValidation.Is(valgo.Int32P(entry.CaseExecutionId, "case_execution_id").GreaterThan(0))
+		}
+		validation.InRow("logs", j, entryValidation)
+	}
+	v.InRow("stream", i, validation)
+	return v.ConnectError()
+}
+
+func validatePublishLogsMessageMismatch(i int, msg *executionsv1.PublishLogsRequest, contextID string, testExecID test.TestExecutionID) error {
+	v := newStreamValidator()
+	v.InRow("stream", i, valgo.Is(
+		valgo.String(msg.Context, "context").EqualTo(
+			contextID,
+			fmt.Sprintf(`Only one {{name}} permitted in stream, found "%s" and "{{value}}"`, contextID),
+		),
+		valgo.String(msg.TestExecutionId, "test_execution_id").EqualTo(
+			testExecID.String(),
+			fmt.Sprintf(`Only one {{name}} permitted in stream, found "%s" and "{{value}}"`, testExecID),
+		),
+	))
+	return v.ConnectError()
+}
+
+func validateSearchTestExecutionsRequest(req *executionsv1.SearchTestExecutionsRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.PageSize(req.PageSize, maxPageSize),
+	)
+	validateTestExecutionFilter(v.Validation, "filter", req.Filter)
+	return v.ConnectError()
+}
+
+func validateSearchTestExecutionLogsRequest(req *executionsv1.SearchTestExecutionLogsRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.TestExecID(req.TestExecutionId),
+		validator.PageSize(req.PageSize, maxPageSize),
+	)
+	validateLogFilter(v.Validation, "filter", req.Filter)
+	return v.ConnectError()
+}
+
+func validateLogFilter(v *valgo.Validation, fieldName string, filter *executionsv1.LogFilter) {
+	if filter == nil {
+		return
+	}
+	filterValidation := valgo.New()
+	if filter.MinLevel != nil {
+		filterValidation.Is(valgo.String(strings.ToUpper(*filter.MinLevel), "min_level").InSlice(test.LogLevels))
+	}
+	if filter.CaseExecutionId != nil {
+		filterValidation.Is(valgo.Int32P(filter.CaseExecutionId, "case_execution_id").GreaterThan(0))
+	}
+	if filter.MessageContains != nil {
+		filterValidation.Is(valgo.StringP(filter.MessageContains, "message_contains").Not().Empty())
+	}
+	validateLogAttributes(filterValidation, filter.Attributes)
+	v.In(fieldName, filterValidation)
+}
+
+func validateLogAttributes(v *valgo.Validation, attrs map[string]string) {
+	for key := range attrs {
+		v.Is(valgo.String(key, "attributes").Not().Blank("{{title}} keys can't be blank"))
+	}
+}
+
 func validateListTestExecutionLogsRequest(req *testsv1.ListTestExecutionLogsRequest) error {
 	v := newValidator()
 	v.Is(
@@ -267,6 +425,84 @@ func validateListTestExecutionLogsRequest(req *testsv1.ListTestExecutionLogsRequ
 	return v.ConnectError()
 }
 
+func validateListTestExecutionEventsRequest(req *executionsv1.ListTestExecutionEventsRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.TestExecID(req.TestExecutionId),
+		validator.PageSize(req.PageSize, maxPageSize),
+	)
+	validateLogFilter(v.Validation, "log_filter", req.LogFilter)
+	return v.ConnectError()
+}
+
+func validateArtifactMetadata(metadata *executionsv1.ArtifactMetadata) error {
+	v := newStreamValidator()
+	if metadata == nil {
+		v.AddErrorMessage("metadata", "Metadata is required in the first request")
+		return v.ConnectError()
+	}
+	validation := valgo.Is(
+		validator.Context(metadata.Context),
+		validator.TestExecID(metadata.TestExecutionId),
+		valgo.String(metadata.Name, "name").Not().Blank().MaxLength(maxArtifactNameLength),
+	)
+	if metadata.CaseExecutionId != nil {
+		validation.Is(validator.CaseExecID(*metadata.CaseExecutionId))
+	}
+	if metadata.Checksum != nil {
+		validation.Is(valgo.StringP(metadata.Checksum, "checksum").Passing(func(checksum *string) bool {
+			decoded, err := hex.DecodeString(*checksum)
+			return err == nil && len(decoded) == sha256.Size
+		}, "{{title}} must be a hex encoded SHA-256 checksum"))
+	}
+	v.In("metadata", validation)
+	return v.ConnectError()
+}
+
+func validateListTestExecutionArtifactsRequest(req *executionsv1.ListTestExecutionArtifactsRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.TestExecID(req.TestExecutionId),
+		validator.PageSize(req.PageSize, maxPageSize),
+	)
+	if req.CaseExecutionId != nil {
+		v.Is(validator.CaseExecID(*req.CaseExecutionId))
+	}
+	return v.ConnectError()
+}
+
+func validateDownloadArtifactRequest(req *executionsv1.DownloadArtifactRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.UUIDv7(req.ArtifactId, "artifact_id"),
+	)
+	return v.ConnectError()
+}
+
+func validateListAuditEntriesRequest(req *auditv1.ListAuditEntriesRequest) error {
+	v := newValidator()
+	v.Is(
+		validator.Context(req.Context),
+		validator.PageSize(req.PageSize, maxPageSize),
+	)
+	if req.Subject != nil {
+		v.Is(valgo.StringP(req.Subject, "subject").Not().Blank())
+	}
+	if req.Action != nil {
+		v.Is(valgo.String(audit.Action(*req.Action), "action").InSlice(audit.Actions, "{{title}} must be the name of an audited RPC"))
+	}
+	if req.TestId != nil {
+		v.Is(validator.TestID(*req.TestId))
+	}
+	if req.TestExecutionId != nil {
+		v.Is(validator.TestExecID(*req.TestExecutionId))
+	}
+	return v.ConnectError()
+}
+
 func validatePayload(v *valgo.Validation, fieldName string, payload *testsv1.Payload) {
 	inputValidator := valgo.Is(
 		valgo.String(string(payload.Data), "data").Not().Empty(),
@@ -0,0 +1,62 @@
+package workflowservice
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1"
+	"github.com/annexsh/annex-proto/go/gen/annex/tests/v1/testsv1connect"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/annexsh/annex/internal/metrics"
+)
+
+var ackDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "workflow_proxy",
+	Name:      "ack_duration_seconds",
+	Help:      "Duration of acknowledgements sent to the test service by acknowledgement type and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"ack", "code"})
+
+// ackTimer records the latency of acknowledgements sent to the test service.
+type ackTimer struct {
+	testsv1connect.TestServiceClient
+}
+
+func (a *ackTimer) AckTestExecutionStarted(ctx context.Context, req *connect.Request[testsv1.AckTestExecutionStartedRequest]) (*connect.Response[testsv1.AckTestExecutionStartedResponse], error) {
+	return timeAck(ctx, "test_execution_started", req, a.TestServiceClient.AckTestExecutionStarted)
+}
+
+func (a *ackTimer) AckTestExecutionFinished(ctx context.Context, req *connect.Request[testsv1.AckTestExecutionFinishedRequest]) (*connect.Response[testsv1.AckTestExecutionFinishedResponse], error) {
+	return timeAck(ctx, "test_execution_finished", req, a.TestServiceClient.AckTestExecutionFinished)
+}
+
+func (a *ackTimer) AckCaseExecutionScheduled(ctx context.Context, req *connect.Request[testsv1.AckCaseExecutionScheduledRequest]) (*connect.Response[testsv1.AckCaseExecutionScheduledResponse], error) {
+	return timeAck(ctx, "case_execution_scheduled", req, a.TestServiceClient.AckCaseExecutionScheduled)
+}
+
+func (a *ackTimer) AckCaseExecutionStarted(ctx context.Context, req *connect.Request[testsv1.AckCaseExecutionStartedRequest]) (*connect.Response[testsv1.AckCaseExecutionStartedResponse], error) {
+	return timeAck(ctx, "case_execution_started", req, a.TestServiceClient.AckCaseExecutionStarted)
+}
+
+func (a *ackTimer) AckCaseExecutionFinished(ctx context.Context, req *connect.Request[testsv1.AckCaseExecutionFinishedRequest]) (*connect.Response[testsv1.AckCaseExecutionFinishedResponse], error) {
+	return timeAck(ctx, "case_execution_finished", req, a.TestServiceClient.AckCaseExecutionFinished)
+}
+
+func timeAck[Req, Res any](
+	ctx context.Context,
+	ack string,
+	req *connect.Request[Req],
+	call func(context.Context, *connect.Request[Req]) (*connect.Response[Res], error),
+) (*connect.Response[Res], error) {
+	start := time.Now()
+	res, err := call(ctx, req)
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	ackDuration.WithLabelValues(ack, code).Observe(time.Since(start).Seconds())
+	return res, err
+}
@@ -20,7 +20,7 @@ func NewProxyService(
 	workflowClient workflowservice.WorkflowServiceClient,
 ) *ProxyService {
 	return &ProxyService{
-		test:     testClient,
+		test:     &ackTimer{TestServiceClient: testClient},
 		workflow: workflowClient,
 	}
 }